@@ -0,0 +1,165 @@
+package temporalis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// abbreviationZone is a zone that uses an abbreviation, with the offset the
+// abbreviation stands for there. The zone is empty for an abbreviation that
+// only stands for an offset, such as "UTC".
+type abbreviationZone struct {
+	zone   string
+	region string
+	offset int
+}
+
+// registered reports whether c was added by RegisterAbbreviation, which
+// gives it no region. The built-in entries for "UTC" and "Z" have none
+// either, but mean the same as the zone or offset they would shadow.
+func (c abbreviationZone) registered() bool {
+	return c.region == ""
+}
+
+// named reports whether c was registered with RegisterAbbreviation by IANA
+// name, which gives it no offset of its own.
+func (c abbreviationZone) named() bool {
+	return c.zone != "" && c.registered()
+}
+
+// abbreviations lists, for upper-case abbreviations that are commonly written
+// in timestamps, the zones that use them, with the zone most people mean
+// first. Many abbreviations are ambiguous: "CST" is Central Standard Time in
+// North America, China Standard Time and Cuba Standard Time, and "IST" is
+// India, Irish and Israel Standard Time. The defaults can be replaced with
+// RegisterAbbreviation.
+var abbreviations = map[string][]abbreviationZone{
+	"ACST": {{"Australia/Adelaide", "AU", 34200}, {"Australia/Darwin", "AU", 34200}},
+	"AEST": {{"Australia/Sydney", "AU", 36000}, {"Australia/Brisbane", "AU", 36000}},
+	"AEDT": {{"Australia/Sydney", "AU", 39600}},
+	"AWST": {{"Australia/Perth", "AU", 28800}},
+	"AKST": {{"America/Anchorage", "US", -32400}},
+	"AKDT": {{"America/Anchorage", "US", -28800}},
+	"AST":  {{"America/Halifax", "CA", -14400}, {"America/Puerto_Rico", "PR", -14400}, {"Asia/Riyadh", "SA", 10800}},
+	"ADT":  {{"America/Halifax", "CA", -10800}},
+	"BRT":  {{"America/Sao_Paulo", "BR", -10800}},
+	"BST":  {{"Europe/London", "GB", 3600}, {"Asia/Dhaka", "BD", 21600}},
+	"CET":  {{"Europe/Paris", "FR", 3600}, {"Europe/Berlin", "DE", 3600}},
+	"CEST": {{"Europe/Paris", "FR", 7200}, {"Europe/Berlin", "DE", 7200}},
+	"CST": {
+		{"America/Chicago", "US", -21600}, {"America/Winnipeg", "CA", -21600}, {"America/Mexico_City", "MX", -21600},
+		{"Asia/Shanghai", "CN", 28800}, {"Asia/Taipei", "TW", 28800}, {"America/Havana", "CU", -18000},
+	},
+	"CDT":  {{"America/Chicago", "US", -18000}, {"America/Winnipeg", "CA", -18000}, {"America/Havana", "CU", -14400}},
+	"EET":  {{"Europe/Athens", "GR", 7200}, {"Africa/Cairo", "EG", 7200}},
+	"EEST": {{"Europe/Athens", "GR", 10800}},
+	"EST":  {{"America/New_York", "US", -18000}, {"America/Toronto", "CA", -18000}},
+	"EDT":  {{"America/New_York", "US", -14400}, {"America/Toronto", "CA", -14400}},
+	"GMT":  {{"Europe/London", "GB", 0}, {"Africa/Accra", "GH", 0}},
+	"HKT":  {{"Asia/Hong_Kong", "HK", 28800}},
+	"HST":  {{"Pacific/Honolulu", "US", -36000}},
+	"ICT":  {{"Asia/Bangkok", "TH", 25200}, {"Asia/Ho_Chi_Minh", "VN", 25200}},
+	"IST":  {{"Asia/Kolkata", "IN", 19800}, {"Europe/Dublin", "IE", 3600}, {"Asia/Jerusalem", "IL", 7200}},
+	"JST":  {{"Asia/Tokyo", "JP", 32400}},
+	"KST":  {{"Asia/Seoul", "KR", 32400}},
+	"MSK":  {{"Europe/Moscow", "RU", 10800}},
+	"MST":  {{"America/Denver", "US", -25200}, {"America/Phoenix", "US", -25200}, {"America/Edmonton", "CA", -25200}},
+	"MDT":  {{"America/Denver", "US", -21600}, {"America/Edmonton", "CA", -21600}},
+	"NST":  {{"America/St_Johns", "CA", -12600}},
+	"NZST": {{"Pacific/Auckland", "NZ", 43200}},
+	"NZDT": {{"Pacific/Auckland", "NZ", 46800}},
+	"PKT":  {{"Asia/Karachi", "PK", 18000}},
+	"PST":  {{"America/Los_Angeles", "US", -28800}, {"America/Vancouver", "CA", -28800}, {"Asia/Manila", "PH", 28800}},
+	"PDT":  {{"America/Los_Angeles", "US", -25200}, {"America/Vancouver", "CA", -25200}},
+	"SAST": {{"Africa/Johannesburg", "ZA", 7200}},
+	"SGT":  {{"Asia/Singapore", "SG", 28800}},
+	"SST":  {{"Pacific/Pago_Pago", "AS", -39600}, {"Asia/Singapore", "SG", 28800}},
+	"WET":  {{"Europe/Lisbon", "PT", 0}},
+	"WEST": {{"Europe/Lisbon", "PT", 3600}},
+	"WIB":  {{"Asia/Jakarta", "ID", 25200}},
+	"UTC":  {{offset: 0}},
+	"Z":    {{offset: 0}},
+}
+
+// abbreviationCandidates returns the zones registered for the abbreviation.
+// The slice must not be modified.
+func abbreviationCandidates(abbr string) []abbreviationZone {
+	abbreviationsMu.RLock()
+	defer abbreviationsMu.RUnlock()
+
+	return abbreviations[strings.ToUpper(strings.TrimSpace(abbr))]
+}
+
+// LocationsForAbbreviation returns the IANA zones that use the time zone
+// abbreviation, such as "America/Chicago", "Asia/Shanghai" and others for
+// "CST", with the zone most people mean first. Abbreviations are matched
+// case-insensitively. It returns nil for abbreviations that are not in the
+// package's table or that only stand for an offset.
+func LocationsForAbbreviation(abbr string) []string {
+	candidates := abbreviationCandidates(abbr)
+
+	zones := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if c.zone != "" {
+			zones = append(zones, c.zone)
+		}
+	}
+	if len(zones) == 0 {
+		return nil
+	}
+
+	return zones
+}
+
+// ResolveAbbreviation returns a fixed location named after the time zone
+// abbreviation, with the offset it stands for, for parsing timestamps such
+// as "10:00 IST". Ambiguous abbreviations are resolved in favour of the
+// zones of region, an ISO 3166-1 alpha-2 code such as "IE", or of the zone
+// most people mean if region is empty or uses none of them. Among those,
+// the first zone that uses the abbreviation at hint according to the time
+// zone data decides the offset, which accounts for zones that changed
+// their offset over the years; if none does, as for "CST" in summer, the
+// usual offset of the first zone is used. A zone registered by IANA name
+// with RegisterAbbreviation decides the offset at hint whatever
+// abbreviation it uses then. Abbreviations missing from the table are
+// resolved like LoadLocation, and an error wrapping ErrInvalidZone is
+// returned if that fails too.
+func ResolveAbbreviation(abbr string, hint time.Time, region string) (*time.Location, error) {
+	name := strings.ToUpper(strings.TrimSpace(abbr))
+
+	candidates := abbreviationCandidates(name)
+	if len(candidates) == 0 {
+		loc, err := LoadLocation(strings.TrimSpace(abbr))
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: unknown abbreviation", ErrInvalidZone, abbr)
+		}
+
+		return loc, nil
+	}
+
+	var inRegion []abbreviationZone
+	for _, c := range candidates {
+		if strings.EqualFold(c.region, region) {
+			inRegion = append(inRegion, c)
+		}
+	}
+	if len(inRegion) > 0 {
+		candidates = inRegion
+	}
+
+	for _, c := range candidates {
+		if c.zone == "" {
+			continue
+		}
+		loc, err := loadZone(c.zone)
+		if err != nil {
+			continue
+		}
+		if zone, offset := hint.In(loc).Zone(); zone == name || c.named() {
+			return time.FixedZone(name, offset), nil
+		}
+	}
+
+	return time.FixedZone(name, candidates[0].offset), nil
+}
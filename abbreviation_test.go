@@ -0,0 +1,108 @@
+package temporalis
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestLocationsForAbbreviation tests the zones listed for ambiguous and
+// unknown abbreviations.
+func TestLocationsForAbbreviation(t *testing.T) {
+	if zones := LocationsForAbbreviation("cst"); len(zones) < 2 || zones[0] != "America/Chicago" || !slices.Contains(zones, "Asia/Shanghai") {
+		t.Errorf("LocationsForAbbreviation(cst) = %v, expected America/Chicago first and Asia/Shanghai", zones)
+	}
+	if zones := LocationsForAbbreviation("XYZ"); zones != nil {
+		t.Errorf("LocationsForAbbreviation(XYZ) = %v, expected nil", zones)
+	}
+}
+
+// TestResolveAbbreviation tests that regions and the hint select the
+// offset of ambiguous abbreviations.
+func TestResolveAbbreviation(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Moscow"); err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	winter := time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2024, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		abbr     string
+		hint     time.Time
+		region   string
+		expected int
+	}{
+		{"IST", winter, "", 19800},
+		{"IST", winter, "IL", 7200},
+		{"IST", summer, "ie", 3600},
+		{"IST", winter, "IE", 3600},
+		{"CST", winter, "", -21600},
+		{"CST", summer, "", -21600},
+		{"CST", winter, "CN", 28800},
+		{"CST", winter, "CU", -18000},
+		{"CST", winter, "DE", -21600},
+		{"MSK", time.Date(2012, time.June, 1, 0, 0, 0, 0, time.UTC), "", 14400},
+		{"MSK", winter, "", 10800},
+		{"WEST", winter, "", 3600},
+		{"UTC+02:00", winter, "", 7200},
+	}
+
+	for _, test := range tests {
+		loc, err := ResolveAbbreviation(test.abbr, test.hint, test.region)
+		if err != nil {
+			t.Errorf("ResolveAbbreviation(%s, %s) error = %v", test.abbr, test.region, err)
+			continue
+		}
+		if _, offset := test.hint.In(loc).Zone(); offset != test.expected {
+			t.Errorf("ResolveAbbreviation(%s, %v, %s) offset = %d, expected %d", test.abbr, test.hint, test.region, offset, test.expected)
+		}
+	}
+
+	if _, err := ResolveAbbreviation("XYZ", winter, ""); !errors.Is(err, ErrInvalidZone) {
+		t.Errorf("ResolveAbbreviation(XYZ) error = %v, expected ErrInvalidZone", err)
+	}
+}
+
+// TestRegisterAbbreviation tests that registrations replace the built-in
+// zones of an abbreviation everywhere it is resolved.
+func TestRegisterAbbreviation(t *testing.T) {
+	if _, err := time.LoadLocation("America/Havana"); err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	defer func(cst, ist []abbreviationZone) {
+		abbreviations["CST"], abbreviations["IST"] = cst, ist
+	}(abbreviations["CST"], abbreviations["IST"])
+
+	winter := time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	RegisterAbbreviation("cst", "America/Havana")
+	if zones := LocationsForAbbreviation("CST"); !slices.Equal(zones, []string{"America/Havana"}) {
+		t.Errorf("LocationsForAbbreviation(CST) = %v, expected [America/Havana]", zones)
+	}
+	if loc, err := LoadLocation("CST"); err != nil || loc.String() != "America/Havana" {
+		t.Errorf("LoadLocation(CST) = %v, %v, expected America/Havana", loc, err)
+	}
+	if loc, err := ResolveAbbreviation("CST", winter, "US"); err != nil || offsetAt(winter, loc) != -18000 {
+		t.Errorf("ResolveAbbreviation(CST) = %v, %v, expected offset -18000", loc, err)
+	}
+
+	RegisterAbbreviation("IST", "+01:00")
+	if zones := LocationsForAbbreviation("IST"); zones != nil {
+		t.Errorf("LocationsForAbbreviation(IST) = %v, expected nil", zones)
+	}
+	if loc, err := LoadLocation("IST"); err != nil || offsetAt(winter, loc) != 3600 {
+		t.Errorf("LoadLocation(IST) = %v, %v, expected offset 3600", loc, err)
+	}
+	if loc, err := ResolveAbbreviation("IST", winter, "IN"); err != nil || offsetAt(winter, loc) != 3600 {
+		t.Errorf("ResolveAbbreviation(IST) = %v, %v, expected offset 3600", loc, err)
+	}
+}
+
+// offsetAt returns the offset of loc at t in seconds.
+func offsetAt(t time.Time, loc *time.Location) int {
+	_, offset := t.In(loc).Zone()
+	return offset
+}
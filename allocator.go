@@ -0,0 +1,127 @@
+package temporalis
+
+import (
+	"sync"
+	"time"
+)
+
+// TimestampAllocator hands out wall-clock timestamps that are strictly
+// increasing, for systems that use timestamps as ordering keys. When the wall
+// clock stalls, returns the same reading twice, or steps backwards, the
+// allocator returns the previous timestamp plus one nanosecond instead and
+// records how far ahead of the wall clock it has drifted. The drift, called
+// skew debt, is paid back automatically once the wall clock catches up.
+// A TimestampAllocator is safe for concurrent use.
+type TimestampAllocator struct {
+	mu   sync.Mutex
+	last time.Time
+	debt time.Duration
+	max  time.Duration
+	now  func() time.Time
+}
+
+// NewTimestampAllocator returns an allocator reading the system wall clock.
+func NewTimestampAllocator() *TimestampAllocator {
+	return &TimestampAllocator{now: time.Now}
+}
+
+// Next returns a timestamp strictly after every timestamp previously returned
+// by the allocator. The result carries no monotonic clock reading.
+func (a *TimestampAllocator) Next() time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now().Round(0)
+
+	if now.After(a.last) {
+		a.last = now
+		a.debt = 0
+		return now
+	}
+
+	a.last = a.last.Add(time.Nanosecond)
+	a.debt = a.last.Sub(now)
+	if a.debt > a.max {
+		a.max = a.debt
+	}
+
+	return a.last
+}
+
+// SkewDebt returns how far the last allocated timestamp is ahead of the wall
+// clock reading it was allocated at. It is zero while the clock moves forward
+// normally.
+func (a *TimestampAllocator) SkewDebt() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.debt
+}
+
+// MaxSkewDebt returns the largest skew debt observed since the allocator was
+// created, which indicates the size of the largest backwards clock step.
+func (a *TimestampAllocator) MaxSkewDebt() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.max
+}
+
+// LogClock is a Clock whose Now never goes backwards, for stamping log lines
+// and events that must stay in order. When the underlying clock is stepped
+// back, for example by an NTP correction, Now keeps returning the last time
+// it returned until the underlying clock catches up, and then follows it
+// again. Unlike TimestampAllocator, successive readings may be equal. Timers
+// are delegated to the underlying clock. A LogClock is safe for concurrent
+// use.
+type LogClock struct {
+	clock Clock
+
+	mu   sync.Mutex
+	last time.Time
+	lag  time.Duration
+}
+
+// NewLogClock returns a LogClock reading c, or the system clock if c is nil.
+func NewLogClock(c Clock) *LogClock {
+	return &LogClock{clock: clockOrSystem(c)}
+}
+
+// Now returns the current time of the underlying clock, or the last time
+// returned if that is later. The result carries no monotonic clock reading,
+// since the comparison has to use the wall clock to notice it stepping back.
+func (c *LogClock) Now() time.Time {
+	now := c.clock.Now().Round(0)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Before(c.last) {
+		c.lag = c.last.Sub(now)
+		return c.last
+	}
+
+	c.last, c.lag = now, 0
+
+	return now
+}
+
+// Lag returns how far the underlying clock was behind the time returned by
+// the last call to Now, which is zero unless the clock was stepped back and
+// has not caught up yet.
+func (c *LogClock) Lag() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lag
+}
+
+// After implements Clock using the underlying clock.
+func (c *LogClock) After(d time.Duration) <-chan time.Time {
+	return c.clock.After(d)
+}
+
+// AfterFunc implements Clock using the underlying clock.
+func (c *LogClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.clock.AfterFunc(d, f)
+}
@@ -0,0 +1,69 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTimestampAllocator drives the allocator with a clock that stalls and
+// then steps backwards, and checks that timestamps keep increasing while the
+// skew debt is tracked and later paid back.
+func TestTimestampAllocator(t *testing.T) {
+	base := time.Unix(1000, 0)
+	readings := []time.Time{base, base, base.Add(-time.Second), base.Add(time.Second)}
+
+	a := NewTimestampAllocator()
+	a.now = func() time.Time {
+		r := readings[0]
+		readings = readings[1:]
+		return r
+	}
+
+	var last time.Time
+	for i := 0; i < 3; i++ {
+		ts := a.Next()
+		if !ts.After(last) {
+			t.Fatalf("Next() = %v, not after %v", ts, last)
+		}
+		last = ts
+	}
+
+	if debt := a.SkewDebt(); debt != time.Second+2*time.Nanosecond {
+		t.Errorf("SkewDebt() = %v, expected 1.000000002s", debt)
+	}
+
+	if ts := a.Next(); !ts.Equal(base.Add(time.Second)) || a.SkewDebt() != 0 {
+		t.Errorf("Next() = %v with debt %v, expected the wall clock and no debt", ts, a.SkewDebt())
+	}
+
+	if a.MaxSkewDebt() != time.Second+2*time.Nanosecond {
+		t.Errorf("MaxSkewDebt() = %v", a.MaxSkewDebt())
+	}
+}
+
+// TestLogClock steps a fake clock back and checks that Now holds its last
+// value until the clock catches up.
+func TestLogClock(t *testing.T) {
+	base := time.Unix(1000, 0)
+	fake := NewFakeClock(base)
+	c := NewLogClock(fake)
+
+	if now := c.Now(); !now.Equal(base) {
+		t.Errorf("Now() = %v, expected %v", now, base)
+	}
+
+	fake.Set(base.Add(-time.Minute))
+	if now := c.Now(); !now.Equal(base) || c.Lag() != time.Minute {
+		t.Errorf("Now() after stepping back = %v with lag %v, expected %v with lag 1m", now, c.Lag(), base)
+	}
+
+	fake.Advance(30 * time.Second)
+	if now := c.Now(); !now.Equal(base) || c.Lag() != 30*time.Second {
+		t.Errorf("Now() while catching up = %v with lag %v, expected %v with lag 30s", now, c.Lag(), base)
+	}
+
+	fake.Advance(time.Minute)
+	if now := c.Now(); !now.Equal(base.Add(30*time.Second)) || c.Lag() != 0 {
+		t.Errorf("Now() after catching up = %v with lag %v, expected %v with no lag", now, c.Lag(), base.Add(30*time.Second))
+	}
+}
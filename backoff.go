@@ -0,0 +1,155 @@
+package temporalis
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// JitterMode selects how a Backoff randomizes its delays.
+type JitterMode int
+
+const (
+	// NoJitter uses the exponential delay as is.
+	NoJitter JitterMode = iota
+	// FullJitter draws the delay uniformly from [0, delay].
+	FullJitter
+	// EqualJitter keeps half of the delay and draws the other half
+	// uniformly, giving delays in [delay/2, delay].
+	EqualJitter
+)
+
+// Backoff computes exponentially growing delays between retries: Initial,
+// Initial·Multiplier, Initial·Multiplier², and so on, capped at Max and then
+// randomized according to Jitter. It is safe for concurrent use.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     JitterMode
+	// MaxAttempts limits the number of calls Retry makes. Zero means no
+	// limit, so only the context ends the retries.
+	MaxAttempts int
+	// Clock is used by Retry to sleep between attempts. If nil, SystemClock
+	// is used.
+	Clock Clock
+
+	mu      sync.Mutex
+	attempt int
+}
+
+// NewBackoff returns a backoff starting at initial and doubling up to max,
+// with full jitter.
+func NewBackoff(initial, max time.Duration) *Backoff {
+	return &Backoff{
+		Initial:    initial,
+		Max:        max,
+		Multiplier: 2,
+		Jitter:     FullJitter,
+	}
+}
+
+// Next returns the delay before the next retry and advances the backoff.
+func (b *Backoff) Next() time.Duration {
+	b.mu.Lock()
+	attempt := b.attempt
+	b.attempt++
+	b.mu.Unlock()
+
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(attempt))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, which does not convert back
+	// to a Duration, so clamp to 2^63 here, which also keeps an infinite
+	// delay out of the jitter, and saturate after it.
+	const limit = float64(1 << 63)
+	if delay >= limit {
+		delay = limit
+	}
+
+	switch b.Jitter {
+	case FullJitter:
+		delay = rand.Float64() * delay
+	case EqualJitter:
+		delay = delay/2 + rand.Float64()*delay/2
+	}
+
+	if delay >= limit {
+		return math.MaxInt64
+	}
+
+	return time.Duration(delay)
+}
+
+// Reset returns the backoff to its initial delay.
+func (b *Backoff) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.attempt = 0
+}
+
+// Attempt returns the number of delays handed out since the last Reset.
+func (b *Backoff) Attempt() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.attempt
+}
+
+// permanentError marks an error that Retry must not retry.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that Retry returns it immediately instead of
+// retrying. Retry returns err itself, without the wrapper.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+// Retry calls fn until it returns nil, returns an error wrapped with
+// Permanent, MaxAttempts calls have been made, or ctx is cancelled. Between
+// attempts it sleeps for b.Next() on b.Clock. The backoff is reset before the
+// first attempt. If the context ends the retries, the returned error wraps
+// both the context error and the last error from fn.
+func Retry(ctx context.Context, b *Backoff, fn func(ctx context.Context) error) error {
+	clock := clockOrSystem(b.Clock)
+	b.Reset()
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		if b.MaxAttempts > 0 && attempt >= b.MaxAttempts {
+			return err
+		}
+
+		if sleepErr := sleepClock(ctx, clock, b.Next()); sleepErr != nil {
+			return errors.Join(sleepErr, err)
+		}
+	}
+}
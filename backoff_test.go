@@ -0,0 +1,96 @@
+package temporalis
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestBackoffNext checks the exponential sequence, the cap and the bounds of
+// the jittered delays.
+func TestBackoffNext(t *testing.T) {
+	b := &Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+	expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, e := range expected {
+		if actual := b.Next(); actual != e*time.Millisecond {
+			t.Errorf("Next() #%d = %v, expected %v", i, actual, e*time.Millisecond)
+		}
+	}
+
+	b.Reset()
+	b.Jitter = EqualJitter
+	for i := 0; i < 100; i++ {
+		b.Reset()
+		if d := b.Next(); d < 50*time.Millisecond || d > 100*time.Millisecond {
+			t.Fatalf("Next() with equal jitter = %v, expected within [50ms, 100ms]", d)
+		}
+	}
+}
+
+// TestBackoffUncapped checks that delays without a cap saturate instead of
+// overflowing, for attempts far beyond the range of a Duration.
+func TestBackoffUncapped(t *testing.T) {
+	for _, jitter := range []JitterMode{NoJitter, FullJitter, EqualJitter} {
+		b := &Backoff{Initial: time.Second, Multiplier: 2, Jitter: jitter}
+
+		for i := 0; i < 2000; i++ {
+			if d := b.Next(); d < 0 {
+				t.Fatalf("Next() #%d with jitter %v = %v, expected a non-negative delay", i, jitter, d)
+			}
+		}
+
+		b.Jitter = NoJitter
+		if d := b.Next(); d != math.MaxInt64 {
+			t.Errorf("Next() after 2000 attempts = %v, expected the maximum duration", d)
+		}
+	}
+}
+
+// TestRetry checks that Retry sleeps on the backoff clock between attempts
+// and stops on success, on permanent errors and at MaxAttempts.
+func TestRetry(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	b := &Backoff{Initial: time.Second, Max: time.Minute, Multiplier: 2, Clock: clock}
+	failure := errors.New("unavailable")
+
+	calls := 0
+	done := make(chan error)
+	go func() {
+		done <- Retry(context.Background(), b, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return failure
+			}
+			return nil
+		})
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Second)
+
+	if err := <-done; err != nil || calls != 3 {
+		t.Errorf("Retry() = %v after %d calls, expected nil after 3", err, calls)
+	}
+
+	b.MaxAttempts = 1
+	if err := Retry(context.Background(), b, func(context.Context) error { return failure }); err != failure {
+		t.Errorf("Retry() with MaxAttempts = %v, expected %v", err, failure)
+	}
+
+	b.MaxAttempts = 0
+	if err := Retry(context.Background(), b, func(context.Context) error { return Permanent(failure) }); err != failure {
+		t.Errorf("Retry() with permanent error = %v, expected %v", err, failure)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Retry(ctx, b, func(context.Context) error { return failure })
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, failure) {
+		t.Errorf("Retry() with cancelled context = %v, expected both errors", err)
+	}
+}
@@ -0,0 +1,79 @@
+package temporalis
+
+import (
+	"iter"
+	"time"
+)
+
+// PartialPeriods selects which incomplete periods at the ends of a range are
+// yielded by MonthsBetween, QuartersBetween and YearsBetween.
+type PartialPeriods int
+
+const (
+	// FullPeriodsOnly yields only periods that lie entirely in the range.
+	FullPeriodsOnly PartialPeriods = 0
+	// PartialFirst also yields the period containing the start of the
+	// range, clipped to begin at the start.
+	PartialFirst PartialPeriods = 1
+	// PartialLast also yields the period containing the end of the range,
+	// clipped to end at the end.
+	PartialLast PartialPeriods = 2
+	// PartialBoth yields the partial periods at both ends.
+	PartialBoth = PartialFirst | PartialLast
+)
+
+// MonthsBetween yields the calendar months within [start, end) as intervals
+// from midnight on the first of the month to midnight on the first of the
+// next, in start's location. Months cut by start or end are yielded clipped
+// to the range only if partial asks for them.
+func MonthsBetween(start, end time.Time, partial PartialPeriods) iter.Seq[Interval] {
+	return periodsBetween(start, end, Monthly, partial)
+}
+
+// QuartersBetween is like MonthsBetween for calendar quarters starting in
+// January, April, July and October.
+func QuartersBetween(start, end time.Time, partial PartialPeriods) iter.Seq[Interval] {
+	return periodsBetween(start, end, Quarterly, partial)
+}
+
+// YearsBetween is like MonthsBetween for calendar years.
+func YearsBetween(start, end time.Time, partial PartialPeriods) iter.Seq[Interval] {
+	return periodsBetween(start, end, Yearly, partial)
+}
+
+// periodsBetween yields the periods of granularity g within [start, end).
+func periodsBetween(start, end time.Time, g Granularity, partial PartialPeriods) iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		if !end.After(start) {
+			return
+		}
+
+		end := end.In(start.Location())
+
+		for from := StartOfPeriod(start, g); from.Before(end); {
+			to := NextPeriod(from, g)
+			period := Interval{Start: from, End: to}
+
+			if from.Before(start) {
+				if partial&PartialFirst == 0 {
+					from = to
+					continue
+				}
+				period.Start = start
+			}
+
+			if to.After(end) {
+				if partial&PartialLast == 0 {
+					return
+				}
+				period.End = end
+			}
+
+			if !yield(period) {
+				return
+			}
+
+			from = to
+		}
+	}
+}
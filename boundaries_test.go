@@ -0,0 +1,39 @@
+package temporalis
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestMonthsBetween checks full and partial months at both ends of a range.
+func TestMonthsBetween(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.April, 10, 0, 0, 0, 0, time.UTC)
+	month := func(m time.Month) time.Time {
+		return time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	full := slices.Collect(MonthsBetween(start, end, FullPeriodsOnly))
+	expected := []Interval{
+		{month(time.February), month(time.March)},
+		{month(time.March), month(time.April)},
+	}
+	if !slices.Equal(full, expected) {
+		t.Errorf("MonthsBetween(full) = %v, expected %v", full, expected)
+	}
+
+	both := slices.Collect(MonthsBetween(start, end, PartialBoth))
+	if len(both) != 4 || !both[0].Start.Equal(start) || !both[3].End.Equal(end) {
+		t.Errorf("MonthsBetween(partial) = %v, expected 4 periods clipped to the range", both)
+	}
+
+	quarters := slices.Collect(QuartersBetween(start, end, PartialLast))
+	if len(quarters) != 1 || !quarters[0].Start.Equal(month(time.April)) {
+		t.Errorf("QuartersBetween() = %v, expected only the partial second quarter", quarters)
+	}
+
+	if years := slices.Collect(YearsBetween(start, end, FullPeriodsOnly)); len(years) != 0 {
+		t.Errorf("YearsBetween() = %v, expected none", years)
+	}
+}
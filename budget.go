@@ -0,0 +1,88 @@
+package temporalis
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Budget tracks a total time budget spent across several sequential calls,
+// such as the downstream RPCs made to answer one request, so that each call
+// gets a consistent share of what is left instead of its own fixed timeout:
+//
+//	b := NewBudget(2 * time.Second)
+//	ctx1, cancel := b.Context(ctx, 0.5) // half of the budget for the first call
+//	...
+//	ctx2, cancel := b.Context(ctx, 1)   // whatever is left for the last one
+//
+// Time passing on the clock is charged to the budget automatically. A
+// Budget is safe for concurrent use.
+type Budget struct {
+	clock Clock
+
+	mu       sync.Mutex
+	deadline time.Time
+}
+
+// NewBudget returns a budget of total starting now. The WithClock option
+// selects the clock that measures it.
+func NewBudget(total time.Duration, opts ...Option) *Budget {
+	o := NewOptions(opts...)
+	c := clockOrSystem(o.Clock)
+
+	return &Budget{clock: c, deadline: c.Now().Add(total)}
+}
+
+// Deadline returns when the budget runs out.
+func (b *Budget) Deadline() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.deadline
+}
+
+// Remaining returns how much of the budget is left, or zero if it has run
+// out.
+func (b *Budget) Remaining() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.remainingLocked()
+}
+
+// Expired reports whether the budget has run out.
+func (b *Budget) Expired() bool {
+	return b.Remaining() == 0
+}
+
+// Consume charges d to the budget on top of the time that passes, for time
+// spent that the clock does not see, such as the queueing time reported by
+// a server, or to hold back a reserve. It returns what is left.
+func (b *Budget) Consume(d time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.deadline = b.deadline.Add(-d)
+
+	return b.remainingLocked()
+}
+
+// Context returns a context derived from ctx that expires once the given
+// fraction of the remaining budget has passed, or when ctx does if that is
+// sooner. A fraction of 1 gives the call everything that is left. Like
+// every context deadline, the timeout runs on the system clock even if the
+// budget uses another. Context panics if fraction is not in (0, 1].
+func (b *Budget) Context(ctx context.Context, fraction float64) (context.Context, context.CancelFunc) {
+	if !(fraction > 0 && fraction <= 1) {
+		panic("temporalis: Budget fraction out of range")
+	}
+
+	share := time.Duration(float64(b.Remaining()) * fraction)
+
+	return context.WithTimeout(ctx, share)
+}
+
+// remainingLocked returns what is left of the budget. b.mu must be held.
+func (b *Budget) remainingLocked() time.Duration {
+	return max(b.deadline.Sub(b.clock.Now()), 0)
+}
@@ -0,0 +1,58 @@
+package temporalis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestBudget tests that elapsed and consumed time are both charged.
+func TestBudget(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	b := NewBudget(2*time.Second, WithClock(clock))
+
+	clock.Advance(500 * time.Millisecond)
+	if actual := b.Remaining(); actual != 1500*time.Millisecond {
+		t.Errorf("Remaining() = %v, expected 1.5s", actual)
+	}
+
+	if actual := b.Consume(time.Second); actual != 500*time.Millisecond {
+		t.Errorf("Consume(1s) = %v, expected 500ms", actual)
+	}
+	if expected := clock.Now().Add(500 * time.Millisecond); !b.Deadline().Equal(expected) {
+		t.Errorf("Deadline() = %v, expected %v", b.Deadline(), expected)
+	}
+
+	clock.Advance(time.Second)
+	if b.Remaining() != 0 || !b.Expired() {
+		t.Errorf("Remaining() after running out = %v, expected 0", b.Remaining())
+	}
+}
+
+// TestBudgetContext tests the deadline of contexts given a share of the
+// budget, and that a parent deadline is kept if it is sooner.
+func TestBudgetContext(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	b := NewBudget(time.Hour, WithClock(clock))
+
+	ctx, cancel := b.Context(context.Background(), 0.25)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > 15*time.Minute || time.Until(deadline) < 14*time.Minute {
+		t.Errorf("Context(0.25) deadline in %v, expected 15m", time.Until(deadline))
+	}
+
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelParent()
+	ctx, cancel = b.Context(parent, 1)
+	defer cancel()
+	if deadline, _ := ctx.Deadline(); time.Until(deadline) > time.Minute {
+		t.Errorf("Context(1) deadline in %v, expected the parent's 1m", time.Until(deadline))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Context(0) did not panic")
+		}
+	}()
+	b.Context(context.Background(), 0)
+}
@@ -0,0 +1,136 @@
+package temporalis
+
+import (
+	"iter"
+	"time"
+)
+
+// AddBusinessHours returns the instant d of business time after t, counting
+// only the business hours of the business days of cal on the wall clock of
+// t's location, or before t if d is negative. Adding four hours to 16:00 on
+// a Friday, with hours from 09:00 to 17:00, gives 12:00 on Monday. A result
+// that falls exactly at the end of a day's hours is reported at the close
+// rather than at the next opening, and when subtracting, at the opening
+// rather than at the previous close. If cal has no business days, the zero
+// time is returned.
+func AddBusinessHours(t time.Time, d time.Duration, cal *BusinessCalendar) time.Time {
+	if d == 0 {
+		return t
+	}
+
+	if d > 0 {
+		for _, period := range cal.businessPeriods(t, 1) {
+			from := Max(period.Start, t)
+			available := period.End.Sub(from)
+			if d <= available {
+				return from.Add(d)
+			}
+			d -= available
+		}
+	} else {
+		d = -d
+		for _, period := range cal.businessPeriods(t, -1) {
+			to := Min(period.End, t)
+			available := to.Sub(period.Start)
+			if d <= available {
+				return to.Add(-d)
+			}
+			d -= available
+		}
+	}
+
+	return time.Time{}
+}
+
+// BusinessTimeBetween returns the business time from start to end, counted
+// like AddBusinessHours on the wall clock of start's location, so that
+// adding it to start gives end if end is within business hours. It is
+// negative if end is before start.
+func BusinessTimeBetween(start, end time.Time, cal *BusinessCalendar) time.Duration {
+	if end.Before(start) {
+		return -BusinessTimeBetween(end.In(start.Location()), start, cal)
+	}
+
+	var total time.Duration
+	for _, period := range cal.businessPeriods(start, 1) {
+		if !period.Start.Before(end) {
+			break
+		}
+		if i, ok := period.Intersect(Interval{Start: start, End: end}); ok {
+			total += i.Duration()
+		}
+	}
+
+	return total
+}
+
+// AddBusinessDays returns the time n business days of cal after t, or
+// before it if n is negative, at the same wall-clock time in t's location,
+// so that one business day after 16:00 on a Friday is 16:00 on Monday even
+// across a DST change. If cal has business hours and t is outside them, t
+// first moves to the next opening, or to the previous close when
+// subtracting, so that one business day after 20:00 on a Monday is 09:00 on
+// Wednesday. The result is kept within the business hours of its day. If
+// cal has no business days, the zero time is returned.
+func AddBusinessDays(t time.Time, n int, cal *BusinessCalendar) time.Time {
+	if n == 0 {
+		return t
+	}
+
+	step := 1
+	if n < 0 {
+		step = -1
+	}
+
+	for d, period := range cal.businessPeriods(t, step) {
+		switch {
+		case t.Before(period.Start):
+			t = period.Start
+		case t.After(period.End):
+			t = period.End
+		}
+
+		// Hours that wrap around midnight may put t on the day after d.
+		offset := DateOf(t).DaysSince(d)
+		target := cal.AddBusinessDays(d, n)
+		hours := cal.BusinessHours().shiftsOn(target, t.Location())[0]
+		result := TimeOfDayOf(t).OnDate(target.AddDays(offset), t.Location())
+
+		return Min(Max(result, hours.Start), hours.End)
+	}
+
+	return time.Time{}
+}
+
+// businessPeriods returns the business hours of each business day of the
+// calendar, together with the day, on the wall clock of from's location.
+// With step 1 they run forwards from the first that ends at or after from;
+// with step -1 they run backwards from the last that starts at or before
+// from. The sequence ends if the calendar has no business days.
+func (c *BusinessCalendar) businessPeriods(from time.Time, step int) iter.Seq2[CivilDate, Interval] {
+	hours := c.BusinessHours()
+	loc := from.Location()
+
+	return func(yield func(CivilDate, Interval) bool) {
+		// Hours that wrap around midnight run into the date of from from
+		// the day before.
+		d, ok := DateOf(from), true
+		if step > 0 {
+			d = d.AddDays(-1)
+		}
+		if !c.IsBusinessDay(d) {
+			d, ok = c.stepBusinessDay(d, step)
+		}
+
+		for ok {
+			period := hours.shiftsOn(d, loc)[0]
+			if (step > 0 && !period.End.Before(from)) || (step < 0 && !period.Start.After(from)) {
+				if !yield(d, period) {
+					return
+				}
+			}
+
+			d, ok = c.stepBusinessDay(d, step)
+		}
+	}
+}
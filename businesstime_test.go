@@ -0,0 +1,111 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// nineToFive returns a calendar with business hours from 09:00 to 17:00 and
+// the given holidays.
+func nineToFive(holidays ...CivilDate) *BusinessCalendar {
+	cal := NewBusinessCalendar(holidays...)
+	cal.SetBusinessHours(ClockRange{Start: NewTimeOfDay(9, 0, 0, 0), End: NewTimeOfDay(17, 0, 0, 0)})
+
+	return cal
+}
+
+// TestAddBusinessHours tests adding and subtracting business time and that
+// BusinessTimeBetween inverts it.
+func TestAddBusinessHours(t *testing.T) {
+	// Monday 18 March 2024 is a holiday.
+	cal := nineToFive(NewCivilDate(2024, 3, 18))
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		t        time.Time
+		d        time.Duration
+		expected time.Time
+	}{
+		{at(15, 16, 0), 4 * time.Hour, at(19, 12, 0)},
+		{at(19, 12, 0), -4 * time.Hour, at(15, 16, 0)},
+		{at(19, 10, 0), -time.Hour, at(19, 9, 0)},
+		{at(19, 9, 0), -time.Hour, at(15, 16, 0)},
+		{at(16, 12, 0), -30 * time.Minute, at(15, 16, 30)},
+		{at(15, 13, 0), 4 * time.Hour, at(15, 17, 0)},
+		{at(20, 3, 0), 0, at(20, 3, 0)},
+	}
+
+	for _, test := range tests {
+		actual := AddBusinessHours(test.t, test.d, cal)
+		if !actual.Equal(test.expected) {
+			t.Errorf("AddBusinessHours(%v, %v) = %v, expected %v", test.t, test.d, actual, test.expected)
+		}
+		if test.d != 0 {
+			if back := BusinessTimeBetween(test.t, actual, cal); back != test.d {
+				t.Errorf("BusinessTimeBetween(%v, %v) = %v, expected %v", test.t, actual, back, test.d)
+			}
+		}
+	}
+}
+
+// TestAddBusinessDays tests moving by whole business days, from outside
+// business hours and across a DST change.
+func TestAddBusinessDays(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	// Good Friday and Easter Monday 2024, around the change to summer time
+	// on Sunday 31 March.
+	cal := nineToFive(NewCivilDate(2024, 3, 29), NewCivilDate(2024, 4, 1))
+
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		t        time.Time
+		n        int
+		expected time.Time
+	}{
+		{at(3, 28, 16, 0), 1, at(4, 2, 16, 0)},
+		{at(4, 2, 16, 0), -1, at(3, 28, 16, 0)},
+		{at(3, 28, 16, 0).UTC(), 1, time.Date(2024, 4, 2, 15, 0, 0, 0, time.UTC)},
+		{at(3, 25, 20, 0), 1, at(3, 27, 9, 0)},
+		{at(3, 30, 11, 0), 1, at(4, 3, 9, 0)},
+		{at(3, 30, 11, 0), -1, at(3, 27, 17, 0)},
+		{at(3, 28, 17, 0), 1, at(4, 2, 17, 0)},
+		{at(3, 28, 16, 0), 0, at(3, 28, 16, 0)},
+	}
+
+	for _, test := range tests {
+		actual := AddBusinessDays(test.t, test.n, cal)
+		if !actual.Equal(test.expected) || actual.Location() != test.t.Location() {
+			t.Errorf("AddBusinessDays(%v, %d) = %v, expected %v", test.t, test.n, actual, test.expected.In(test.t.Location()))
+		}
+	}
+
+	// A whole-day calendar keeps the wall clock across the DST change.
+	if actual, expected := AddBusinessDays(at(3, 29, 12, 0), 1, NewBusinessCalendar()), at(4, 1, 12, 0); !actual.Equal(expected) {
+		t.Errorf("AddBusinessDays() without hours = %v, expected %v", actual, expected)
+	}
+}
+
+// TestAddBusinessDaysNightShift tests hours that wrap around midnight.
+func TestAddBusinessDaysNightShift(t *testing.T) {
+	cal := NewBusinessCalendar()
+	cal.SetBusinessHours(ClockRange{Start: NewTimeOfDay(22, 0, 0, 0), End: NewTimeOfDay(6, 0, 0, 0)})
+
+	// 02:00 on Saturday 16 March 2024 is in Friday's shift.
+	start := time.Date(2024, 3, 16, 2, 0, 0, 0, time.UTC)
+	if actual, expected := AddBusinessDays(start, 1, cal), time.Date(2024, 3, 19, 2, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("AddBusinessDays() = %v, expected %v", actual, expected)
+	}
+	if actual, expected := AddBusinessHours(start, 5*time.Hour, cal), time.Date(2024, 3, 18, 23, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("AddBusinessHours() = %v, expected %v", actual, expected)
+	}
+}
@@ -0,0 +1,199 @@
+package temporalis
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// BusinessCalendar describes which days are working days: every day that is
+// neither a weekend day nor a holiday. It may also have business hours,
+// which functions that count business time, such as SLADeadline, use. The
+// zero value and a nil calendar both treat Saturday and Sunday as the
+// weekend, have no holidays and count the whole of every business day.
+// A BusinessCalendar is safe for concurrent use.
+type BusinessCalendar struct {
+	mu       sync.RWMutex
+	weekend  map[time.Weekday]bool
+	holidays map[CivilDate]string
+	hours    ClockRange
+}
+
+// NewBusinessCalendar returns a calendar with a Saturday/Sunday weekend and
+// the given holidays.
+func NewBusinessCalendar(holidays ...CivilDate) *BusinessCalendar {
+	cal := &BusinessCalendar{}
+	for _, h := range holidays {
+		cal.AddHoliday(h, "")
+	}
+
+	return cal
+}
+
+// SetWeekend replaces the weekend days, for example Friday and Saturday for
+// much of the Middle East. Calling it without arguments makes every weekday
+// a working day.
+func (c *BusinessCalendar) SetWeekend(days ...time.Weekday) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.weekend = make(map[time.Weekday]bool, len(days))
+	for _, d := range days {
+		c.weekend[d] = true
+	}
+}
+
+// SetBusinessHours sets the hours of each business day, such as 09:00 to
+// 17:00, on the wall clock of the times they are applied to. A range that
+// wraps around midnight starts on the business day and runs into the next
+// one. The zero range, which is the default, is the whole day.
+func (c *BusinessCalendar) SetBusinessHours(r ClockRange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hours = r
+}
+
+// BusinessHours returns the hours of each business day.
+func (c *BusinessCalendar) BusinessHours() ClockRange {
+	if c == nil {
+		return ClockRange{}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.hours
+}
+
+// AddHoliday marks d as a non-working day with an optional name.
+func (c *BusinessCalendar) AddHoliday(d CivilDate, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.holidays == nil {
+		c.holidays = map[CivilDate]string{}
+	}
+	c.holidays[d] = name
+}
+
+// Holidays returns the holidays of the calendar in chronological order.
+func (c *BusinessCalendar) Holidays() []CivilDate {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	dates := make([]CivilDate, 0, len(c.holidays))
+	for d := range c.holidays {
+		dates = append(dates, d)
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	return dates
+}
+
+// IsWeekend reports whether d falls on a weekend day of the calendar.
+func (c *BusinessCalendar) IsWeekend(d CivilDate) bool {
+	wd := d.Weekday()
+
+	if c == nil {
+		return wd == time.Saturday || wd == time.Sunday
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.weekend == nil {
+		return wd == time.Saturday || wd == time.Sunday
+	}
+
+	return c.weekend[wd]
+}
+
+// IsHoliday reports whether d is a holiday and returns its name.
+func (c *BusinessCalendar) IsHoliday(d CivilDate) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	name, ok := c.holidays[d]
+
+	return name, ok
+}
+
+// IsBusinessDay reports whether d is neither a weekend day nor a holiday.
+func (c *BusinessCalendar) IsBusinessDay(d CivilDate) bool {
+	if c.IsWeekend(d) {
+		return false
+	}
+
+	_, holiday := c.IsHoliday(d)
+
+	return !holiday
+}
+
+// NextBusinessDay returns the first business day strictly after d.
+func (c *BusinessCalendar) NextBusinessDay(d CivilDate) CivilDate {
+	return c.AddBusinessDays(d, 1)
+}
+
+// PreviousBusinessDay returns the last business day strictly before d.
+func (c *BusinessCalendar) PreviousBusinessDay(d CivilDate) CivilDate {
+	return c.AddBusinessDays(d, -1)
+}
+
+// AddBusinessDays returns the date n business days after d, or before it if
+// n is negative. The starting date itself is never counted, so adding one
+// business day to a Friday yields the following Monday. With n equal to zero
+// d is returned unchanged, even if it is not a business day. If the calendar
+// has no business days at all, d is returned.
+func (c *BusinessCalendar) AddBusinessDays(d CivilDate, n int) CivilDate {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	for n > 0 {
+		next, ok := c.stepBusinessDay(d, step)
+		if !ok {
+			return d
+		}
+		d = next
+		n--
+	}
+
+	return d
+}
+
+// CountBusinessDays returns the number of business days from start to end,
+// both inclusive, or zero if end is before start.
+func (c *BusinessCalendar) CountBusinessDays(start, end CivilDate) int {
+	total := 0
+	for d := start; !d.After(end); d = d.AddDays(1) {
+		if c.IsBusinessDay(d) {
+			total++
+		}
+	}
+
+	return total
+}
+
+// stepBusinessDay moves to the next business day in the given direction. It
+// gives up after a year without finding one.
+func (c *BusinessCalendar) stepBusinessDay(d CivilDate, step int) (CivilDate, bool) {
+	for i := 0; i < 366; i++ {
+		d = d.AddDays(step)
+		if c.IsBusinessDay(d) {
+			return d, true
+		}
+	}
+
+	return d, false
+}
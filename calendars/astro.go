@@ -0,0 +1,110 @@
+package calendars
+
+import (
+	"math"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+const rad = math.Pi / 180
+
+// tropicalYear is the mean length of the tropical year in days.
+const tropicalYear = 365.242189
+
+// synodicMonth is the mean length of a lunation in days.
+const synodicMonth = 29.530588861
+
+// solarLongitude returns the apparent ecliptic longitude of the sun at t, in
+// degrees from 0 up to 360, after Meeus, Astronomical Algorithms, chapter
+// 25. It is good to about 0.01 degrees, or a quarter of an hour of the sun's
+// motion.
+func solarLongitude(t time.Time) float64 {
+	T := (temporalis.ToJulianDay(t) - 2451545) / 36525
+
+	l0 := 280.46646 + 36000.76983*T + 0.0003032*T*T
+	m := (357.52911 + 35999.05029*T - 0.0001537*T*T) * rad
+	c := (1.914602-0.004817*T-0.000014*T*T)*math.Sin(m) +
+		(0.019993-0.000101*T)*math.Sin(2*m) +
+		0.000289*math.Sin(3*m)
+	omega := (125.04 - 1934.136*T) * rad
+
+	return mod360(l0 + c - 0.00569 - 0.00478*math.Sin(omega))
+}
+
+// solarLongitudeAfter returns the first instant after t at which the solar
+// longitude is lambda degrees, such as 270 for the December solstice.
+func solarLongitudeAfter(lambda float64, t time.Time) time.Time {
+	perDegree := tropicalYear / 360 * 24 * float64(time.Hour)
+
+	x := t.Add(time.Duration(mod360(lambda-solarLongitude(t)) * perDegree))
+	for range 8 {
+		diff := mod360(lambda-solarLongitude(x)+180) - 180
+		x = x.Add(time.Duration(diff * perDegree))
+		if math.Abs(diff) < 1e-6 {
+			break
+		}
+	}
+
+	return x
+}
+
+// moonAltitude returns the geocentric altitude of the moon above the horizon
+// at t for an observer at the given latitude and longitude, and its
+// horizontal parallax, both in degrees. It uses the low-precision series of
+// the Astronomical Almanac, which are good to a few tenths of a degree.
+func moonAltitude(t time.Time, lat, lon float64) (altitude, parallax float64) {
+	T := (temporalis.ToJulianDay(t) - 2451545) / 36525
+
+	sin := func(deg float64) float64 { return math.Sin(deg * rad) }
+	cos := func(deg float64) float64 { return math.Cos(deg * rad) }
+
+	lambda := 218.32 + 481267.881*T +
+		6.29*sin(135.0+477198.87*T) -
+		1.27*sin(259.3-413335.36*T) +
+		0.66*sin(235.7+890534.22*T) +
+		0.21*sin(269.9+954397.74*T) -
+		0.19*sin(357.5+35999.05*T) -
+		0.11*sin(186.5+966404.03*T)
+	beta := 5.13*sin(93.3+483202.02*T) +
+		0.28*sin(228.2+960400.89*T) -
+		0.28*sin(318.3+6003.15*T) -
+		0.17*sin(217.6-407332.21*T)
+	parallax = 0.9508 +
+		0.0518*cos(135.0+477198.87*T) +
+		0.0095*cos(259.3-413335.36*T) +
+		0.0078*cos(235.7+890534.22*T) +
+		0.0028*cos(269.9+954397.74*T)
+
+	// Equatorial coordinates.
+	eps := 23.439 - 0.013*T
+	ra := math.Atan2(sin(lambda)*cos(eps)-math.Tan(beta*rad)*sin(eps), cos(lambda)) / rad
+	decl := math.Asin(sin(beta)*cos(eps)+cos(beta)*sin(eps)*sin(lambda)) / rad
+
+	hourAngle := temporalis.LocalSiderealTime(t, lon).Hours()*15 - ra
+	altitude = math.Asin(sin(lat)*sin(decl)+cos(lat)*cos(decl)*cos(hourAngle)) / rad
+
+	return altitude, parallax
+}
+
+// mod360 normalises an angle in degrees to [0, 360).
+func mod360(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+
+	return deg
+}
+
+// zoneDay returns the day number of t in the zone with the given fixed UTC
+// offset.
+func zoneDay(t time.Time, offset time.Duration) int {
+	return floorDiv(int(t.Add(offset).Unix()), 24*60*60)
+}
+
+// zoneMidnight returns the instant that day number n begins in the zone with
+// the given fixed UTC offset.
+func zoneMidnight(n int, offset time.Duration) time.Time {
+	return time.Unix(int64(n)*24*60*60, 0).Add(-offset).UTC()
+}
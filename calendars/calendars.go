@@ -0,0 +1,156 @@
+// Package calendars converts dates between the Gregorian calendar and the
+// Islamic, Hebrew, Chinese and Persian calendars, so that business calendars
+// can include holidays that are fixed in one of them:
+//
+//	cal := temporalis.NewBusinessCalendar()
+//	for _, d := range calendars.InGregorianYear(calendars.UmmAlQura, 10, 1, 2025) {
+//		cal.AddHoliday(d, "Eid al-Fitr")
+//	}
+//
+// The Islamic and Hebrew calendars are arithmetic and exact. Umm al-Qura,
+// the Chinese and the Persian calendar follow astronomical events, which are
+// computed here to within minutes; a date very close to a deciding instant
+// may therefore come out a day off from the published calendar. For
+// religious observance, where the start of a month depends on a sighting of
+// the moon, treat the results as predictions.
+package calendars
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// Date is a date in one of the calendars of this package. Months are
+// numbered from 1 in the order of the calendar, except that the Hebrew
+// calendar numbers Nisan as 1 although the year begins with Tishrei, month 7.
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+	// Leap marks the leap month of the Chinese calendar, which follows the
+	// month of the same number.
+	Leap bool
+}
+
+// String returns the date in "YYYY-MM-DD" format, with an "L" before the
+// month of a leap month, as in "2023-L02-01".
+func (d Date) String() string {
+	if d.Leap {
+		return fmt.Sprintf("%04d-L%02d-%02d", d.Year, d.Month, d.Day)
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Calendar is a calendar system that can be converted to and from the
+// Gregorian calendar. The calendars in this package are the variables
+// Islamic, UmmAlQura, Hebrew, Chinese and Persian.
+type Calendar interface {
+	// Name returns the name of the calendar, such as "Hebrew".
+	Name() string
+	// FromGregorian returns the date in this calendar of the Gregorian
+	// date d. Where days traditionally begin at sunset, they are taken to
+	// begin at the following midnight, so both dates share their daylight.
+	FromGregorian(d temporalis.CivilDate) Date
+	// ToGregorian returns the Gregorian date of d. It returns an error
+	// wrapping temporalis.ErrOutOfRange if d does not exist, such as the
+	// 30th of a month of 29 days.
+	ToGregorian(d Date) (temporalis.CivilDate, error)
+	// MonthName returns the name of the month of d, which in some
+	// calendars depends on the year.
+	MonthName(d Date) string
+	// Format returns d written out in the usual way for the calendar, such
+	// as "15 Nisan 5784".
+	Format(d Date) string
+}
+
+// arithmetic is implemented by the calendars on top of day numbers, which
+// count days from 1970-01-01.
+type arithmetic interface {
+	Name() string
+	fromDays(n int) Date
+	toDays(d Date) int
+}
+
+// unixEpoch is day number 0.
+var unixEpoch = temporalis.CivilDate{Year: 1970, Month: time.January, Day: 1}
+
+// days returns the day number of d.
+func days(d temporalis.CivilDate) int {
+	return d.DaysSince(unixEpoch)
+}
+
+// civil returns the Gregorian date of day number n.
+func civil(n int) temporalis.CivilDate {
+	return unixEpoch.AddDays(n)
+}
+
+// toGregorian implements Calendar.ToGregorian for c, rejecting dates that
+// do not survive the round trip.
+func toGregorian(c arithmetic, d Date, months int) (temporalis.CivilDate, error) {
+	if d.Month >= 1 && d.Month <= months && d.Day >= 1 && d.Day <= 31 {
+		if n := c.toDays(d); c.fromDays(n) == d {
+			return civil(n), nil
+		}
+	}
+
+	return temporalis.CivilDate{}, fmt.Errorf("calendars: %s date %v does not exist: %w", c.Name(), d, temporalis.ErrOutOfRange)
+}
+
+// format implements Calendar.Format for calendars written as day, month name
+// and year.
+func format(c Calendar, d Date, era string) string {
+	s := fmt.Sprintf("%d %s %d", d.Day, c.MonthName(d), d.Year)
+	if era != "" {
+		s += " " + era
+	}
+
+	return s
+}
+
+// monthName returns names[month-1], or a numbered placeholder for a month
+// outside the calendar.
+func monthName(names []string, month int) string {
+	if month < 1 || month > len(names) {
+		return fmt.Sprintf("Month(%d)", month)
+	}
+
+	return names[month-1]
+}
+
+// InGregorianYear returns the Gregorian dates in the given year on which the
+// given day of the given month of cal falls, in order. There can be none,
+// one or, for the Islamic calendars, whose year is 11 days shorter, two. A
+// month that has a leap month after it in the Chinese calendar counts only
+// once, and Hebrew month 13 (Adar II) is found only in leap years.
+func InGregorianYear(cal Calendar, month, day, year int) []temporalis.CivilDate {
+	first := cal.FromGregorian(temporalis.CivilDate{Year: year, Month: time.January, Day: 1})
+	last := cal.FromGregorian(temporalis.CivilDate{Year: year, Month: time.December, Day: 31})
+
+	var dates []temporalis.CivilDate
+	for y := first.Year; y <= last.Year; y++ {
+		d, err := cal.ToGregorian(Date{Year: y, Month: month, Day: day})
+		if err == nil && d.Year == year {
+			dates = append(dates, d)
+		}
+	}
+
+	return dates
+}
+
+// floorDiv returns a/b rounded towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
+
+// mod returns a modulo b in [0, b).
+func mod(a, b int) int {
+	return a - b*floorDiv(a, b)
+}
@@ -0,0 +1,106 @@
+package calendars
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+var allCalendars = []Calendar{Islamic, UmmAlQura, Hebrew, Chinese, Persian}
+
+// TestRoundTrip tests that every day of several years converts to a date
+// that converts back to it, and that consecutive days are consecutive dates.
+func TestRoundTrip(t *testing.T) {
+	start := temporalis.CivilDate{Year: 2022, Month: time.November, Day: 1}
+
+	for _, cal := range allCalendars {
+		prev := cal.FromGregorian(start.AddDays(-1))
+		for d := start; d.Year < 2026; d = d.AddDays(1) {
+			date := cal.FromGregorian(d)
+
+			back, err := cal.ToGregorian(date)
+			if err != nil || back != d {
+				t.Fatalf("%s: ToGregorian(FromGregorian(%v) = %v) = %v, %v", cal.Name(), d, date, back, err)
+			}
+
+			if date.Day != prev.Day+1 && (date.Day != 1 || prev.Day < 29) {
+				t.Fatalf("%s: %v follows %v", cal.Name(), date, prev)
+			}
+			prev = date
+		}
+	}
+}
+
+// TestToGregorianInvalid tests that dates that do not exist are rejected.
+func TestToGregorianInvalid(t *testing.T) {
+	tests := []struct {
+		cal  Calendar
+		date Date
+	}{
+		{Islamic, Date{Year: 1445, Month: 2, Day: 30}},
+		{Islamic, Date{Year: 1445, Month: 13, Day: 1}},
+		{UmmAlQura, Date{Year: 1445, Month: 0, Day: 1}},
+		{Hebrew, Date{Year: 5785, Month: 13, Day: 1}},
+		{Hebrew, Date{Year: 5785, Month: 10, Day: 30}},
+		{Chinese, Date{Year: 2024, Month: 2, Day: 1, Leap: true}},
+		{Chinese, Date{Year: 2024, Month: 1, Day: 31}},
+		{Persian, Date{Year: 1402, Month: 12, Day: 30}},
+		{Persian, Date{Year: 1403, Month: 1, Day: 0}},
+		{Islamic, Date{Year: 1445, Month: 1, Day: 1, Leap: true}},
+	}
+
+	for _, test := range tests {
+		if d, err := test.cal.ToGregorian(test.date); !errors.Is(err, temporalis.ErrOutOfRange) {
+			t.Errorf("%s: ToGregorian(%v) = %v, %v, expected ErrOutOfRange", test.cal.Name(), test.date, d, err)
+		}
+	}
+}
+
+// TestInGregorianYear tests finding holidays in a Gregorian year.
+func TestInGregorianYear(t *testing.T) {
+	tests := []struct {
+		cal        Calendar
+		month, day int
+		year       int
+		expected   []temporalis.CivilDate
+	}{
+		// Eid al-Fitr.
+		{UmmAlQura, 10, 1, 2025, []temporalis.CivilDate{{Year: 2025, Month: time.March, Day: 30}}},
+		// Islamic New Year, twice in 2008.
+		{Islamic, 1, 1, 2008, []temporalis.CivilDate{{Year: 2008, Month: time.January, Day: 10}, {Year: 2008, Month: time.December, Day: 29}}},
+		// Rosh Hashanah.
+		{Hebrew, 7, 1, 2024, []temporalis.CivilDate{{Year: 2024, Month: time.October, Day: 3}}},
+		// Adar II, which 5785 does not have.
+		{Hebrew, 13, 14, 2025, nil},
+		// Chinese New Year.
+		{Chinese, 1, 1, 2025, []temporalis.CivilDate{{Year: 2025, Month: time.January, Day: 29}}},
+		// Nowruz.
+		{Persian, 1, 1, 2025, []temporalis.CivilDate{{Year: 2025, Month: time.March, Day: 21}}},
+	}
+
+	for _, test := range tests {
+		if actual := InGregorianYear(test.cal, test.month, test.day, test.year); !slices.Equal(actual, test.expected) {
+			t.Errorf("InGregorianYear(%s, %d, %d, %d) = %v, expected %v", test.cal.Name(), test.month, test.day, test.year, actual, test.expected)
+		}
+	}
+}
+
+// TestDateString tests the numeric format of dates.
+func TestDateString(t *testing.T) {
+	tests := []struct {
+		date     Date
+		expected string
+	}{
+		{Date{Year: 1445, Month: 9, Day: 1}, "1445-09-01"},
+		{Date{Year: 2023, Month: 2, Day: 1, Leap: true}, "2023-L02-01"},
+	}
+
+	for _, test := range tests {
+		if actual := test.date.String(); actual != test.expected {
+			t.Errorf("String() = %q, expected %q", actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package calendars
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+var chineseMonths = []string{
+	"正月", "二月", "三月", "四月", "五月", "六月",
+	"七月", "八月", "九月", "十月", "冬月", "腊月",
+}
+
+// Chinese is the Chinese lunisolar calendar as reformed in 1645. Months
+// begin on the day of the new moon in China, month 11 contains the December
+// solstice, and a year with 13 months repeats, as a leap month, the first
+// month in which the sun enters no new zodiac sign. Dates are computed for
+// UTC+8, which China has used since 1929. The year of a Date is the
+// Gregorian year in which the Chinese year begins, so Chinese New Year of
+// 2024, on 10 February, is Date{Year: 2024, Month: 1, Day: 1} and the last
+// days of that Chinese year fall in 2025.
+var Chinese Calendar = chinese{}
+
+type chinese struct{}
+
+// chinaOffset is the offset of China Standard Time.
+const chinaOffset = 8 * time.Hour
+
+func (chinese) Name() string { return "Chinese" }
+
+func (c chinese) FromGregorian(d temporalis.CivilDate) Date { return c.fromDays(days(d)) }
+
+func (c chinese) ToGregorian(d Date) (temporalis.CivilDate, error) { return toGregorian(c, d, 12) }
+
+// MonthName returns the traditional name of the month, such as "正月" for
+// the first month or "闰二月" for a leap second month.
+func (chinese) MonthName(d Date) string {
+	name := monthName(chineseMonths, d.Month)
+	if d.Leap {
+		name = "闰" + name
+	}
+
+	return name
+}
+
+// Format returns the date with the sexagenary name of its year, as in
+// "甲辰年正月初一".
+func (c chinese) Format(d Date) string {
+	return sexagenaryYear(d.Year) + "年" + c.MonthName(d) + chineseDay(d.Day)
+}
+
+func (c chinese) toDays(d Date) int {
+	midYear := days(temporalis.CivilDate{Year: d.Year, Month: time.July, Day: 1})
+	newYear := chineseNewYearOnOrBefore(midYear)
+
+	p := newMoonOnOrAfter(newYear + (d.Month-1)*29)
+	if found := c.fromDays(p); found.Month != d.Month || found.Leap != d.Leap {
+		p = newMoonOnOrAfter(p + 1)
+	}
+
+	return p + d.Day - 1
+}
+
+func (chinese) fromDays(n int) Date {
+	s1 := solsticeOnOrBefore(n)
+	s2 := solsticeOnOrBefore(s1 + 370)
+	m12 := newMoonOnOrAfter(s1 + 1)
+	leapYear := lunations(m12, newMoonBefore(s2+1)) == 12
+
+	m := newMoonBefore(n + 1)
+	month := lunations(m12, m)
+	if leapYear && priorLeapMonth(m12, m) {
+		month--
+	}
+	month = mod(month-1, 12) + 1
+
+	leap := leapYear && noMajorSolarTerm(m) && !priorLeapMonth(m12, newMoonBefore(m))
+
+	g := civil(n)
+	year := g.Year
+	if month >= 11 && g.Month <= time.March {
+		year--
+	}
+
+	return Date{Year: year, Month: month, Day: n - m + 1, Leap: leap}
+}
+
+// chineseNewYearOnOrBefore returns the day number of the Chinese New Year on
+// or before day n.
+func chineseNewYearOnOrBefore(n int) int {
+	if ny := chineseNewYearInSui(n); n >= ny {
+		return ny
+	}
+
+	return chineseNewYearInSui(n - 180)
+}
+
+// chineseNewYearInSui returns the day number of the Chinese New Year in the
+// solar year from the December solstice on or before day n.
+func chineseNewYearInSui(n int) int {
+	s1 := solsticeOnOrBefore(n)
+	s2 := solsticeOnOrBefore(s1 + 370)
+	m12 := newMoonOnOrAfter(s1 + 1)
+	m13 := newMoonOnOrAfter(m12 + 1)
+
+	if lunations(m12, newMoonBefore(s2+1)) == 12 && (noMajorSolarTerm(m12) || noMajorSolarTerm(m13)) {
+		return newMoonOnOrAfter(m13 + 1)
+	}
+
+	return m13
+}
+
+// priorLeapMonth reports whether the month that begins on day m, or one
+// before it but on or after day start, is a leap month.
+func priorLeapMonth(start, m int) bool {
+	for ; m >= start; m = newMoonBefore(m) {
+		if noMajorSolarTerm(m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// noMajorSolarTerm reports whether the sun enters no new sign of the zodiac
+// during the month that begins on day m.
+func noMajorSolarTerm(m int) bool {
+	return majorSolarTerm(m) == majorSolarTerm(newMoonOnOrAfter(m+1))
+}
+
+// majorSolarTerm returns the sign of the zodiac the sun is in at the start
+// of day n, as a number from 0 to 11.
+func majorSolarTerm(n int) int {
+	return int(solarLongitude(zoneMidnight(n, chinaOffset)) / 30)
+}
+
+// lunations returns the number of months from the new moon of day a to that
+// of day b.
+func lunations(a, b int) int {
+	return int(math.Round(float64(b-a) / synodicMonth))
+}
+
+// newMoonOnOrAfter returns the day number of the first new moon in China on
+// or after day n.
+func newMoonOnOrAfter(n int) int {
+	return zoneDay(temporalis.NextNewMoon(zoneMidnight(n, chinaOffset).Add(-time.Nanosecond)), chinaOffset)
+}
+
+// newMoonBefore returns the day number of the last new moon in China before
+// day n.
+func newMoonBefore(n int) int {
+	start := zoneMidnight(n, chinaOffset)
+
+	m := temporalis.NextNewMoon(start.AddDate(0, 0, -35))
+	for next := temporalis.NextNewMoon(m); next.Before(start); next = temporalis.NextNewMoon(m) {
+		m = next
+	}
+
+	return zoneDay(m, chinaOffset)
+}
+
+// solsticeOnOrBefore returns the day number of the December solstice in
+// China on or before day n.
+func solsticeOnOrBefore(n int) int {
+	end := zoneMidnight(n+1, chinaOffset)
+
+	s := solarLongitudeAfter(270, end.AddDate(-1, 0, -7))
+	for next := solarLongitudeAfter(270, s.AddDate(0, 0, 1)); next.Before(end); next = solarLongitudeAfter(270, s.AddDate(0, 0, 1)) {
+		s = next
+	}
+
+	return zoneDay(s, chinaOffset)
+}
+
+// sexagenaryYear returns the name of the year in the sixty-year cycle of
+// heavenly stems and earthly branches, such as "甲辰" for 2024.
+func sexagenaryYear(year int) string {
+	const stems, branches = "甲乙丙丁戊己庚辛壬癸", "子丑寅卯辰巳午未申酉戌亥"
+
+	stem := []rune(stems)[mod(year-4, 10)]
+	branch := []rune(branches)[mod(year-4, 12)]
+
+	return string([]rune{stem, branch})
+}
+
+// chineseDay returns the traditional name of a day of the month, such as
+// "初一" or "廿三".
+func chineseDay(day int) string {
+	digits := []rune("十一二三四五六七八九")
+
+	var b strings.Builder
+	switch {
+	case day <= 10:
+		b.WriteString("初")
+	case day < 20:
+		b.WriteString("十")
+	case day == 20:
+		b.WriteString("二")
+	case day < 30:
+		b.WriteString("廿")
+	default:
+		b.WriteString("三")
+	}
+	b.WriteRune(digits[day%10])
+
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package calendars
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// TestChinese tests the Chinese calendar against new years, leap months and
+// festivals.
+func TestChinese(t *testing.T) {
+	tests := []struct {
+		gregorian temporalis.CivilDate
+		expected  Date
+		formatted string
+	}{
+		{temporalis.CivilDate{Year: 2024, Month: time.February, Day: 10}, Date{Year: 2024, Month: 1, Day: 1}, "甲辰年正月初一"},
+		{temporalis.CivilDate{Year: 2024, Month: time.February, Day: 9}, Date{Year: 2023, Month: 12, Day: 30}, "癸卯年腊月三十"},
+		{temporalis.CivilDate{Year: 2023, Month: time.January, Day: 22}, Date{Year: 2023, Month: 1, Day: 1}, "癸卯年正月初一"},
+		{temporalis.CivilDate{Year: 2023, Month: time.March, Day: 22}, Date{Year: 2023, Month: 2, Day: 1, Leap: true}, "癸卯年闰二月初一"},
+		{temporalis.CivilDate{Year: 2025, Month: time.July, Day: 25}, Date{Year: 2025, Month: 6, Day: 1, Leap: true}, "乙巳年闰六月初一"},
+		// Mid-Autumn Festival.
+		{temporalis.CivilDate{Year: 2024, Month: time.September, Day: 17}, Date{Year: 2024, Month: 8, Day: 15}, "甲辰年八月十五"},
+		{temporalis.CivilDate{Year: 2024, Month: time.December, Day: 21}, Date{Year: 2024, Month: 11, Day: 21}, "甲辰年冬月廿一"},
+	}
+
+	for _, test := range tests {
+		actual := Chinese.FromGregorian(test.gregorian)
+		if actual != test.expected {
+			t.Errorf("FromGregorian(%v) = %v, expected %v", test.gregorian, actual, test.expected)
+		}
+		if formatted := Chinese.Format(actual); formatted != test.formatted {
+			t.Errorf("Format(%v) = %q, expected %q", actual, formatted, test.formatted)
+		}
+	}
+}
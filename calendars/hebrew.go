@@ -0,0 +1,139 @@
+package calendars
+
+import (
+	"slices"
+
+	"github.com/goify/temporalis"
+)
+
+var hebrewMonths = []string{
+	"Nisan", "Iyar", "Sivan", "Tammuz", "Av", "Elul",
+	"Tishrei", "Cheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II",
+}
+
+// Hebrew is the arithmetic Hebrew calendar, with 7 leap years of 13 months
+// in every 19 and the year beginning on 1 Tishrei. Month 12 is Adar in
+// common years and Adar I in leap years, when month 13 is Adar II. Days are
+// taken to begin at midnight rather than at sunset the evening before.
+var Hebrew Calendar = hebrew{}
+
+type hebrew struct{}
+
+// hebrewEpoch is the day number of 1 Tishrei 1 AM, 7 October 3761 BC in the
+// Julian calendar.
+const hebrewEpoch = -1373427 - 719163
+
+func (hebrew) Name() string { return "Hebrew" }
+
+func (c hebrew) FromGregorian(d temporalis.CivilDate) Date { return c.fromDays(days(d)) }
+
+func (c hebrew) ToGregorian(d Date) (temporalis.CivilDate, error) { return toGregorian(c, d, 13) }
+
+func (hebrew) MonthName(d Date) string {
+	if d.Month == 12 && hebrewLeapYear(d.Year) {
+		return "Adar I"
+	}
+
+	return monthName(hebrewMonths, d.Month)
+}
+
+func (c hebrew) Format(d Date) string { return format(c, d, "") }
+
+func (hebrew) toDays(d Date) int {
+	n := hebrewNewYear(d.Year) + d.Day - 1
+
+	if d.Month < 7 {
+		for m := 7; m <= hebrewLastMonth(d.Year); m++ {
+			n += hebrewMonthLength(d.Year, m)
+		}
+		for m := 1; m < d.Month; m++ {
+			n += hebrewMonthLength(d.Year, m)
+		}
+	} else {
+		for m := 7; m < d.Month; m++ {
+			n += hebrewMonthLength(d.Year, m)
+		}
+	}
+
+	return n
+}
+
+func (c hebrew) fromDays(n int) Date {
+	year := int(float64(n-hebrewEpoch) / (35975351.0 / 98496))
+	for hebrewNewYear(year+1) <= n {
+		year++
+	}
+	for hebrewNewYear(year) > n {
+		year--
+	}
+
+	month := 1
+	if n < c.toDays(Date{Year: year, Month: 1, Day: 1}) {
+		month = 7
+	}
+	for n > c.toDays(Date{Year: year, Month: month, Day: hebrewMonthLength(year, month)}) {
+		month++
+	}
+
+	return Date{Year: year, Month: month, Day: n - c.toDays(Date{Year: year, Month: month, Day: 1}) + 1}
+}
+
+// hebrewLeapYear reports whether the year has 13 months.
+func hebrewLeapYear(year int) bool {
+	return mod(7*year+1, 19) < 7
+}
+
+// hebrewLastMonth returns the number of the last month of the year, Adar or
+// Adar II.
+func hebrewLastMonth(year int) int {
+	if hebrewLeapYear(year) {
+		return 13
+	}
+
+	return 12
+}
+
+// hebrewElapsedDays returns the number of days from the epoch to the molad
+// of Tishrei of the year, moved to avoid Rosh Hashanah on a Sunday,
+// Wednesday or Friday.
+func hebrewElapsedDays(year int) int {
+	months := floorDiv(235*year-234, 19)
+	parts := 12084 + 13753*months
+	d := 29*months + floorDiv(parts, 25920)
+	if mod(3*(d+1), 7) < 3 {
+		d++
+	}
+
+	return d
+}
+
+// hebrewNewYear returns the day number of 1 Tishrei of the year, after the
+// postponements that keep the lengths of years within their allowed values.
+func hebrewNewYear(year int) int {
+	ny0, ny1, ny2 := hebrewElapsedDays(year-1), hebrewElapsedDays(year), hebrewElapsedDays(year+1)
+
+	correction := 0
+	switch {
+	case ny2-ny1 == 356:
+		correction = 2
+	case ny1-ny0 == 382:
+		correction = 1
+	}
+
+	return hebrewEpoch + ny1 + correction
+}
+
+// hebrewMonthLength returns the number of days in the month.
+func hebrewMonthLength(year, month int) int {
+	length := hebrewNewYear(year+1) - hebrewNewYear(year)
+
+	switch {
+	case slices.Contains([]int{2, 4, 6, 10, 13}, month),
+		month == 12 && !hebrewLeapYear(year),
+		month == 8 && length != 355 && length != 385,
+		month == 9 && (length == 353 || length == 383):
+		return 29
+	}
+
+	return 30
+}
@@ -0,0 +1,39 @@
+package calendars
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// TestHebrew tests the Hebrew calendar against known holidays.
+func TestHebrew(t *testing.T) {
+	tests := []struct {
+		gregorian temporalis.CivilDate
+		expected  Date
+		formatted string
+	}{
+		// Rosh Hashanah.
+		{temporalis.CivilDate{Year: 2024, Month: time.October, Day: 3}, Date{Year: 5785, Month: 7, Day: 1}, "1 Tishrei 5785"},
+		// Passover.
+		{temporalis.CivilDate{Year: 2024, Month: time.April, Day: 23}, Date{Year: 5784, Month: 1, Day: 15}, "15 Nisan 5784"},
+		// Purim in a leap year.
+		{temporalis.CivilDate{Year: 2024, Month: time.March, Day: 24}, Date{Year: 5784, Month: 13, Day: 14}, "14 Adar II 5784"},
+		{temporalis.CivilDate{Year: 2024, Month: time.February, Day: 10}, Date{Year: 5784, Month: 12, Day: 1}, "1 Adar I 5784"},
+		// Purim in a common year.
+		{temporalis.CivilDate{Year: 2025, Month: time.March, Day: 14}, Date{Year: 5785, Month: 12, Day: 14}, "14 Adar 5785"},
+		// Yom Kippur.
+		{temporalis.CivilDate{Year: 2025, Month: time.October, Day: 2}, Date{Year: 5786, Month: 7, Day: 10}, "10 Tishrei 5786"},
+	}
+
+	for _, test := range tests {
+		actual := Hebrew.FromGregorian(test.gregorian)
+		if actual != test.expected {
+			t.Errorf("FromGregorian(%v) = %v, expected %v", test.gregorian, actual, test.expected)
+		}
+		if formatted := Hebrew.Format(actual); formatted != test.formatted {
+			t.Errorf("Format(%v) = %q, expected %q", actual, formatted, test.formatted)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package calendars
+
+import (
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+var islamicMonths = []string{
+	"Muharram", "Safar", "Rabi' al-Awwal", "Rabi' al-Thani", "Jumada al-Ula", "Jumada al-Akhirah",
+	"Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu al-Qa'dah", "Dhu al-Hijjah",
+}
+
+// Islamic is the tabular Islamic calendar, in which months alternate between
+// 30 and 29 days and 11 years in every 30 have a 30th day of Dhu al-Hijjah.
+// It counts from 16 July 622 in the Julian calendar and never differs from
+// the observed calendar by more than a day or two. Days are taken to begin
+// at midnight rather than at sunset.
+var Islamic Calendar = islamic{}
+
+type islamic struct{}
+
+// islamicEpoch is the day number of 1 Muharram 1 AH.
+var islamicEpoch = days(temporalis.CivilDate{Year: 622, Month: time.July, Day: 19})
+
+func (islamic) Name() string { return "Islamic" }
+
+func (c islamic) FromGregorian(d temporalis.CivilDate) Date { return c.fromDays(days(d)) }
+
+func (c islamic) ToGregorian(d Date) (temporalis.CivilDate, error) { return toGregorian(c, d, 12) }
+
+func (islamic) MonthName(d Date) string { return monthName(islamicMonths, d.Month) }
+
+func (c islamic) Format(d Date) string { return format(c, d, "AH") }
+
+func (islamic) toDays(d Date) int {
+	return islamicEpoch - 1 + (d.Year-1)*354 + floorDiv(3+11*d.Year, 30) + 29*(d.Month-1) + d.Month/2 + d.Day
+}
+
+func (c islamic) fromDays(n int) Date {
+	year := floorDiv(30*(n-islamicEpoch)+10646, 10631)
+	month := min(floorDiv(11*(n-c.toDays(Date{Year: year, Month: 1, Day: 1}))+330, 325), 12)
+	day := n - c.toDays(Date{Year: year, Month: month, Day: 1}) + 1
+
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// UmmAlQura is the Umm al-Qura calendar of Saudi Arabia. A month begins on
+// the day after the 29th of the previous one if, seen from Mecca, the moon
+// was new before sunset that day and set after the sun; otherwise the month
+// before has 30 days. That has been the rule since 1423 AH (2002), so
+// earlier dates may differ from the calendar published at the time. The
+// moon is computed to within a few minutes, so the start of a month may be
+// off by a day when the moon sets almost together with the sun.
+var UmmAlQura Calendar = ummAlQura{}
+
+type ummAlQura struct{}
+
+// Mecca, at UTC+3.
+const (
+	meccaLatitude  = 21.4225
+	meccaLongitude = 39.8262
+	meccaOffset    = 3 * time.Hour
+)
+
+func (ummAlQura) Name() string { return "Umm al-Qura" }
+
+func (c ummAlQura) FromGregorian(d temporalis.CivilDate) Date { return c.fromDays(days(d)) }
+
+func (c ummAlQura) ToGregorian(d Date) (temporalis.CivilDate, error) { return toGregorian(c, d, 12) }
+
+func (ummAlQura) MonthName(d Date) string { return monthName(islamicMonths, d.Month) }
+
+func (c ummAlQura) Format(d Date) string { return format(c, d, "AH") }
+
+func (c ummAlQura) toDays(d Date) int {
+	return c.monthStart(d.Year, d.Month) + d.Day - 1
+}
+
+func (c ummAlQura) fromDays(n int) Date {
+	// The tabular calendar is never more than a couple of days out, so the
+	// month is the tabular one or a neighbour.
+	guess := islamic{}.fromDays(n)
+	year, month := guess.Year, guess.Month
+
+	for n < c.monthStart(year, month) {
+		year, month = previousMonth(year, month)
+	}
+	for {
+		y, m := nextMonth(year, month)
+		if n < c.monthStart(y, m) {
+			break
+		}
+		year, month = y, m
+	}
+
+	return Date{Year: year, Month: month, Day: n - c.monthStart(year, month) + 1}
+}
+
+// monthStart returns the day number of the first day of the month.
+func (ummAlQura) monthStart(year, month int) int {
+	tabular := islamic{}.toDays(Date{Year: year, Month: month, Day: 1})
+	conjunction := temporalis.NextNewMoon(zoneMidnight(tabular-15, meccaOffset))
+
+	// The day of the conjunction stands for the 29th of the previous month.
+	day := zoneDay(conjunction, meccaOffset)
+	sunset, ok := temporalis.Sunset.On(civil(day), meccaLatitude, meccaLongitude)
+	if ok && conjunction.Before(sunset) {
+		// The moon sets after the sun if its centre is above the altitude
+		// at which it sets, allowing for parallax and refraction.
+		altitude, parallax := moonAltitude(sunset, meccaLatitude, meccaLongitude)
+		if altitude > 0.7275*parallax-0.5667 {
+			return day + 1
+		}
+	}
+
+	return day + 2
+}
+
+// previousMonth returns the year and month before the given ones.
+func previousMonth(year, month int) (int, int) {
+	if month == 1 {
+		return year - 1, 12
+	}
+
+	return year, month - 1
+}
+
+// nextMonth returns the year and month after the given ones.
+func nextMonth(year, month int) (int, int) {
+	if month == 12 {
+		return year + 1, 1
+	}
+
+	return year, month + 1
+}
@@ -0,0 +1,53 @@
+package calendars
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// TestIslamic tests the tabular Islamic calendar against its epoch and known
+// dates.
+func TestIslamic(t *testing.T) {
+	tests := []struct {
+		gregorian temporalis.CivilDate
+		expected  Date
+		formatted string
+	}{
+		{temporalis.CivilDate{Year: 622, Month: time.July, Day: 19}, Date{Year: 1, Month: 1, Day: 1}, "1 Muharram 1 AH"},
+		{temporalis.CivilDate{Year: 2024, Month: time.March, Day: 11}, Date{Year: 1445, Month: 9, Day: 1}, "1 Ramadan 1445 AH"},
+		{temporalis.CivilDate{Year: 2024, Month: time.July, Day: 7}, Date{Year: 1445, Month: 12, Day: 30}, "30 Dhu al-Hijjah 1445 AH"},
+	}
+
+	for _, test := range tests {
+		actual := Islamic.FromGregorian(test.gregorian)
+		if actual != test.expected {
+			t.Errorf("FromGregorian(%v) = %v, expected %v", test.gregorian, actual, test.expected)
+		}
+		if formatted := Islamic.Format(actual); formatted != test.formatted {
+			t.Errorf("Format(%v) = %q, expected %q", actual, formatted, test.formatted)
+		}
+	}
+}
+
+// TestUmmAlQura tests month starts against the published Umm al-Qura
+// calendar.
+func TestUmmAlQura(t *testing.T) {
+	tests := []struct {
+		date     Date
+		expected temporalis.CivilDate
+	}{
+		{Date{Year: 1445, Month: 9, Day: 1}, temporalis.CivilDate{Year: 2024, Month: time.March, Day: 11}},
+		{Date{Year: 1446, Month: 1, Day: 1}, temporalis.CivilDate{Year: 2024, Month: time.July, Day: 7}},
+		{Date{Year: 1446, Month: 9, Day: 1}, temporalis.CivilDate{Year: 2025, Month: time.March, Day: 1}},
+		{Date{Year: 1446, Month: 10, Day: 1}, temporalis.CivilDate{Year: 2025, Month: time.March, Day: 30}},
+		{Date{Year: 1446, Month: 12, Day: 10}, temporalis.CivilDate{Year: 2025, Month: time.June, Day: 6}},
+	}
+
+	for _, test := range tests {
+		if actual, err := UmmAlQura.ToGregorian(test.date); err != nil || actual != test.expected {
+			t.Errorf("ToGregorian(%v) = %v, %v, expected %v", test.date, actual, err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package calendars
+
+import (
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+var persianMonths = []string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+
+// Persian is the Solar Hijri calendar of Iran and Afghanistan. The year
+// begins at Nowruz, the day of the March equinox if it falls before noon in
+// Tehran and the day after if it does not. The first six months have 31
+// days, the next five 30 and Esfand 29, or 30 in leap years. Noon is taken
+// at 12:00 Iran Standard Time (UTC+3:30) rather than at apparent noon, which
+// matters only when the equinox is within minutes of it.
+var Persian Calendar = persian{}
+
+type persian struct{}
+
+// tehranOffset is the offset of Iran Standard Time.
+const tehranOffset = 3*time.Hour + 30*time.Minute
+
+func (persian) Name() string { return "Persian" }
+
+func (c persian) FromGregorian(d temporalis.CivilDate) Date { return c.fromDays(days(d)) }
+
+func (c persian) ToGregorian(d Date) (temporalis.CivilDate, error) { return toGregorian(c, d, 12) }
+
+func (persian) MonthName(d Date) string { return monthName(persianMonths, d.Month) }
+
+func (c persian) Format(d Date) string { return format(c, d, "") }
+
+func (persian) toDays(d Date) int {
+	n := nowruz(d.Year) + d.Day - 1
+	if d.Month <= 6 {
+		return n + (d.Month-1)*31
+	}
+
+	return n + 6*31 + (d.Month-7)*30
+}
+
+func (persian) fromDays(n int) Date {
+	// Nowruz is in March, so the year is 621 less than the Gregorian year
+	// from then on.
+	year := civil(n).Year - 621
+	if n < nowruz(year) {
+		year--
+	}
+
+	day := n - nowruz(year)
+	if day < 6*31 {
+		return Date{Year: year, Month: day/31 + 1, Day: day%31 + 1}
+	}
+	day -= 6 * 31
+
+	return Date{Year: year, Month: day/30 + 7, Day: day%30 + 1}
+}
+
+// nowruz returns the day number of 1 Farvardin of the year.
+func nowruz(year int) int {
+	start := temporalis.CivilDate{Year: year + 621, Month: time.January, Day: 1}
+	equinox := solarLongitudeAfter(0, start.In(time.UTC))
+
+	n := zoneDay(equinox, tehranOffset)
+	if equinox.Sub(zoneMidnight(n, tehranOffset)) >= 12*time.Hour {
+		n++
+	}
+
+	return n
+}
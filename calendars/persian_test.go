@@ -0,0 +1,35 @@
+package calendars
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// TestPersian tests the Persian calendar around Nowruz, including years in
+// which the equinox falls after noon in Tehran.
+func TestPersian(t *testing.T) {
+	tests := []struct {
+		gregorian temporalis.CivilDate
+		expected  Date
+		formatted string
+	}{
+		{temporalis.CivilDate{Year: 2023, Month: time.March, Day: 21}, Date{Year: 1402, Month: 1, Day: 1}, "1 Farvardin 1402"},
+		{temporalis.CivilDate{Year: 2024, Month: time.March, Day: 20}, Date{Year: 1403, Month: 1, Day: 1}, "1 Farvardin 1403"},
+		{temporalis.CivilDate{Year: 2025, Month: time.March, Day: 20}, Date{Year: 1403, Month: 12, Day: 30}, "30 Esfand 1403"},
+		{temporalis.CivilDate{Year: 2025, Month: time.March, Day: 21}, Date{Year: 1404, Month: 1, Day: 1}, "1 Farvardin 1404"},
+		{temporalis.CivilDate{Year: 2024, Month: time.September, Day: 21}, Date{Year: 1403, Month: 6, Day: 31}, "31 Shahrivar 1403"},
+		{temporalis.CivilDate{Year: 2024, Month: time.September, Day: 22}, Date{Year: 1403, Month: 7, Day: 1}, "1 Mehr 1403"},
+	}
+
+	for _, test := range tests {
+		actual := Persian.FromGregorian(test.gregorian)
+		if actual != test.expected {
+			t.Errorf("FromGregorian(%v) = %v, expected %v", test.gregorian, actual, test.expected)
+		}
+		if formatted := Persian.Format(actual); formatted != test.formatted {
+			t.Errorf("Format(%v) = %q, expected %q", actual, formatted, test.formatted)
+		}
+	}
+}
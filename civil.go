@@ -0,0 +1,205 @@
+package temporalis
+
+import (
+	"fmt"
+	"time"
+)
+
+// CivilDate is a calendar date without a time of day or time zone, such as a
+// birthday or a holiday. It is named CivilDate because Date is the package's
+// time.Date wrapper. The zero value is not a valid date; use IsZero to detect
+// it. CivilDate values can be compared with == and used as map keys.
+type CivilDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewCivilDate returns the date with the given fields, normalising
+// out-of-range values the way time.Date does, so month 13 is January of the
+// following year and day 0 is the last day of the previous month.
+func NewCivilDate(year int, month time.Month, day int) CivilDate {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the calendar date of t in t's location.
+func DateOf(t time.Time) CivilDate {
+	y, m, d := t.Date()
+
+	return CivilDate{Year: y, Month: m, Day: d}
+}
+
+// Today returns the current date in loc, or in the local time zone if loc is
+// nil.
+func Today(loc *time.Location) CivilDate {
+	if loc == nil {
+		loc = LocalLocation()
+	}
+
+	return DateOf(time.Now().In(loc))
+}
+
+// ParseCivilDate parses a date in "YYYY-MM-DD" format.
+func ParseCivilDate(s string) (CivilDate, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return CivilDate{}, timeParseError("ParseCivilDate", s, err)
+	}
+
+	return DateOf(t), nil
+}
+
+// String returns the date in "YYYY-MM-DD" format.
+func (d CivilDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether d is the zero value.
+func (d CivilDate) IsZero() bool {
+	return d == CivilDate{}
+}
+
+// IsValid reports whether d names an existing day, so February 30 is not
+// valid.
+func (d CivilDate) IsValid() bool {
+	return NewCivilDate(d.Year, d.Month, d.Day) == d
+}
+
+// In returns the instant at which the date begins in loc. On the rare days
+// that start with a DST gap, this is the first instant that exists.
+func (d CivilDate) In(loc *time.Location) time.Time {
+	return d.At(0, 0, 0, loc)
+}
+
+// At returns the instant at the given wall-clock time on the date in loc, or
+// in UTC if loc is nil. A time that falls into a DST gap is shifted forward
+// by the length of the gap, and one that occurs twice resolves to the
+// earlier instant, as with ResolveAmbiguous.
+func (d CivilDate) At(hour, min, sec int, loc *time.Location) time.Time {
+	wall := time.Date(d.Year, d.Month, d.Day, hour, min, sec, 0, time.UTC)
+	if loc == nil || loc == time.UTC {
+		return wall
+	}
+
+	// ResolveAmbiguous only fails for the rejecting policies.
+	t, _ := ResolveAmbiguous(wall, loc, PreferEarlier)
+
+	return t
+}
+
+// Weekday returns the day of the week of the date.
+func (d CivilDate) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// YearDay returns the day of the year, in the range [1, 365] for non-leap
+// years and [1, 366] for leap years.
+func (d CivilDate) YearDay() int {
+	return d.In(time.UTC).YearDay()
+}
+
+// AddDays returns the date n days after d. Negative values move backwards.
+func (d CivilDate) AddDays(n int) CivilDate {
+	return NewCivilDate(d.Year, d.Month, d.Day+n)
+}
+
+// AddMonths returns the date n months after d. If the day does not exist in
+// the target month it is clamped to the last day of that month, so January
+// 31 plus one month is February 28 or 29.
+func (d CivilDate) AddMonths(n int) CivilDate {
+	first := NewCivilDate(d.Year, d.Month+time.Month(n), 1)
+
+	day := d.Day
+	if last := daysIn(first.Year, first.Month); day > last {
+		day = last
+	}
+
+	return CivilDate{Year: first.Year, Month: first.Month, Day: day}
+}
+
+// AddYears returns the date n years after d, clamping February 29 to
+// February 28 in non-leap years.
+func (d CivilDate) AddYears(n int) CivilDate {
+	return d.AddMonths(12 * n)
+}
+
+// DaysSince returns the number of days from other to d, which is negative
+// if d is before other.
+func (d CivilDate) DaysSince(other CivilDate) int {
+	return daysFromCivil(d.Year, d.Month, d.Day) - daysFromCivil(other.Year, other.Month, other.Day)
+}
+
+// Compare returns -1 if d is before other, +1 if it is after, and 0 if they
+// are the same date.
+func (d CivilDate) Compare(other CivilDate) int {
+	switch {
+	case d.Year != other.Year:
+		return sign(d.Year - other.Year)
+	case d.Month != other.Month:
+		return sign(int(d.Month - other.Month))
+	default:
+		return sign(d.Day - other.Day)
+	}
+}
+
+// Before reports whether d is before other.
+func (d CivilDate) Before(other CivilDate) bool {
+	return d.Compare(other) < 0
+}
+
+// After reports whether d is after other.
+func (d CivilDate) After(other CivilDate) bool {
+	return d.Compare(other) > 0
+}
+
+// MarshalText implements encoding.TextMarshaler using "YYYY-MM-DD".
+func (d CivilDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using "YYYY-MM-DD".
+func (d *CivilDate) UnmarshalText(data []byte) error {
+	parsed, err := ParseCivilDate(string(data))
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// CivilDateRange returns every date from start to end, both inclusive. It
+// returns nil if end is before start.
+func CivilDateRange(start, end CivilDate) []CivilDate {
+	var dates []CivilDate
+
+	for d := start; !d.After(end); d = d.AddDays(1) {
+		dates = append(dates, d)
+	}
+
+	return dates
+}
+
+// BusinessDaysBetween counts the dates from start to end, both inclusive, that
+// fall on a weekday and are not in holidays. Because dates carry no time of
+// day, the result does not depend on the hour at which a range starts. With
+// WithCalendar, the weekend and holidays of the calendar are used, and
+// holidays lists additional closures.
+func BusinessDaysBetween(start, end CivilDate, holidays []CivilDate, opts ...Option) int {
+	o := NewOptions(opts...)
+
+	closed := make(map[CivilDate]bool, len(holidays))
+	for _, h := range holidays {
+		closed[h] = true
+	}
+
+	total := 0
+	for d := start; !d.After(end); d = d.AddDays(1) {
+		if o.Calendar.IsBusinessDay(d) && !closed[d] {
+			total++
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,90 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCivilDateArithmetic checks day and month arithmetic, including month-end
+// clamping and day counts across years.
+func TestCivilDateArithmetic(t *testing.T) {
+	d := CivilDate{2024, time.January, 31}
+
+	if got := d.AddDays(30); got != (CivilDate{2024, time.March, 1}) {
+		t.Errorf("AddDays(30) = %v, expected 2024-03-01", got)
+	}
+
+	if got := d.AddMonths(1); got != (CivilDate{2024, time.February, 29}) {
+		t.Errorf("AddMonths(1) = %v, expected 2024-02-29", got)
+	}
+
+	if got := (CivilDate{2024, time.February, 29}).AddYears(1); got != (CivilDate{2025, time.February, 28}) {
+		t.Errorf("AddYears(1) = %v, expected 2025-02-28", got)
+	}
+
+	if got := (CivilDate{2025, time.January, 1}).DaysSince(d); got != 336 {
+		t.Errorf("DaysSince() = %d, expected 336", got)
+	}
+
+	if got := (CivilDate{1, time.January, 1}).DaysSince(CivilDate{9999, time.December, 31}); got != -3652058 {
+		t.Errorf("DaysSince() across the full range = %d, expected -3652058", got)
+	}
+
+	if (CivilDate{2023, time.February, 29}).IsValid() {
+		t.Errorf("2023-02-29 reported as valid")
+	}
+}
+
+// TestCivilDateInGap checks that a day whose midnight falls into a DST gap
+// starts at the first instant that exists, not on the previous day.
+func TestCivilDateInGap(t *testing.T) {
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skip("America/Santiago not available")
+	}
+
+	// Clocks in Santiago jump from 00:00 to 01:00 on 8 September 2024.
+	d := CivilDate{2024, time.September, 8}
+	expected := time.Date(2024, time.September, 8, 4, 0, 0, 0, time.UTC)
+
+	if got := d.In(santiago); !got.Equal(expected) || DateOf(got) != d || got.Hour() != 1 {
+		t.Errorf("In() = %v, expected 2024-09-08 01:00 -03", got)
+	}
+	if got := d.At(0, 30, 0, santiago); !got.Equal(expected.Add(30 * time.Minute)) {
+		t.Errorf("At(0, 30, 0) = %v, expected 2024-09-08 01:30 -03", got)
+	}
+	if got := d.At(12, 0, 0, santiago); got.Hour() != 12 || DateOf(got) != d {
+		t.Errorf("At(12, 0, 0) = %v, expected 2024-09-08 12:00", got)
+	}
+}
+
+// TestParseCivilDate checks parsing, formatting and rejection of bad input.
+func TestParseCivilDate(t *testing.T) {
+	d, err := ParseCivilDate("2024-05-06")
+	if err != nil || d != (CivilDate{2024, time.May, 6}) || d.String() != "2024-05-06" {
+		t.Errorf("ParseCivilDate() = %v, %v", d, err)
+	}
+
+	if _, err := ParseCivilDate("2024-13-01"); err == nil {
+		t.Errorf("ParseCivilDate() with month 13 returned no error")
+	}
+}
+
+// TestDateRangeIgnoresTimeOfDay checks that a range starting late in the day
+// still includes its end date.
+func TestDateRangeIgnoresTimeOfDay(t *testing.T) {
+	start := time.Date(2024, time.May, 6, 18, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.May, 8, 9, 0, 0, 0, time.UTC)
+
+	if got := len(DateRange(start, end)); got != 3 {
+		t.Errorf("len(DateRange()) = %d, expected 3", got)
+	}
+
+	if got := BusinessDays(start, end, nil); got != 3 {
+		t.Errorf("BusinessDays() = %d, expected 3", got)
+	}
+
+	if got := BusinessDaysBetween(DateOf(start), DateOf(end), []CivilDate{{2024, time.May, 7}}); got != 2 {
+		t.Errorf("BusinessDaysBetween() = %d, expected 2", got)
+	}
+}
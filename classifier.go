@@ -0,0 +1,182 @@
+package temporalis
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Predicate reports whether a time has some property, such as falling on a
+// weekend or within peak hours. Predicates are the rules of a Classifier and
+// can be combined with AllOf, AnyOf and Not.
+type Predicate func(t time.Time) bool
+
+// Classifier labels times with the names of the rules they match, so that
+// pricing, alert routing or batch gating can ask "what kind of time is
+// this?" in one place instead of scattering date logic:
+//
+//	c := temporalis.NewClassifier()
+//	c.Register("weekend", temporalis.OnWeekdays(time.Saturday, time.Sunday))
+//	c.Register("peak_hours", temporalis.AllOf(
+//		temporalis.OnBusinessDays(nil),
+//		temporalis.DuringHours(temporalis.NewTimeOfDay(8, 0, 0, 0), temporalis.NewTimeOfDay(18, 0, 0, 0)),
+//	))
+//	c.Register("month_end_freeze", temporalis.InLastDaysOfMonth(3))
+//	labels := c.Classify(time.Now()) // for example [peak_hours month_end_freeze]
+//
+// The zero value has no rules. A Classifier is safe for concurrent use.
+type Classifier struct {
+	// Location is the zone in which times are classified. If nil, every
+	// time is classified in its own location.
+	Location *time.Location
+
+	mu    sync.RWMutex
+	names []string
+	rules []Predicate
+}
+
+// NewClassifier returns a classifier without rules.
+func NewClassifier() *Classifier {
+	return &Classifier{}
+}
+
+// Register adds a rule under the given name. Registering a name again
+// replaces its rule but keeps its position in the order of Classify.
+func (c *Classifier) Register(name string, p Predicate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i := slices.Index(c.names, name); i >= 0 {
+		c.rules[i] = p
+		return
+	}
+
+	c.names = append(c.names, name)
+	c.rules = append(c.rules, p)
+}
+
+// Unregister removes the rule with the given name, if there is one.
+func (c *Classifier) Unregister(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i := slices.Index(c.names, name); i >= 0 {
+		c.names = slices.Delete(c.names, i, i+1)
+		c.rules = slices.Delete(c.rules, i, i+1)
+	}
+}
+
+// Names returns the names of the rules in the order they were registered.
+func (c *Classifier) Names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.names)
+}
+
+// Classify returns the names of the rules that t matches, in the order they
+// were registered. The result is empty, not nil, if t matches none.
+func (c *Classifier) Classify(t time.Time) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	t = c.in(t)
+
+	labels := []string{}
+	for i, rule := range c.rules {
+		if rule(t) {
+			labels = append(labels, c.names[i])
+		}
+	}
+
+	return labels
+}
+
+// Is reports whether t matches the rule with the given name. It reports
+// false if there is no such rule.
+func (c *Classifier) Is(t time.Time, name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i := slices.Index(c.names, name)
+
+	return i >= 0 && c.rules[i](c.in(t))
+}
+
+// in converts t to the location of the classifier.
+func (c *Classifier) in(t time.Time) time.Time {
+	if c.Location == nil {
+		return t
+	}
+
+	return t.In(c.Location)
+}
+
+// AllOf returns a predicate that matches when all of ps match. With no
+// predicates it matches everything.
+func AllOf(ps ...Predicate) Predicate {
+	return func(t time.Time) bool {
+		for _, p := range ps {
+			if !p(t) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// AnyOf returns a predicate that matches when at least one of ps matches.
+// With no predicates it matches nothing.
+func AnyOf(ps ...Predicate) Predicate {
+	return func(t time.Time) bool {
+		for _, p := range ps {
+			if p(t) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not returns a predicate that matches when p does not.
+func Not(p Predicate) Predicate {
+	return func(t time.Time) bool { return !p(t) }
+}
+
+// OnWeekdays returns a predicate that matches times on the given days of the
+// week.
+func OnWeekdays(days ...time.Weekday) Predicate {
+	return func(t time.Time) bool { return slices.Contains(days, t.Weekday()) }
+}
+
+// OnBusinessDays returns a predicate that matches times on business days of
+// cal, which may be nil for a plain Monday to Friday week.
+func OnBusinessDays(cal *BusinessCalendar) Predicate {
+	return func(t time.Time) bool { return cal.IsBusinessDay(DateOf(t)) }
+}
+
+// DuringHours returns a predicate that matches times whose time of day is
+// from start up to, but not including, end. If end is not after start, the
+// window wraps around midnight, so 22:00 to 06:00 matches nights.
+func DuringHours(start, end TimeOfDay) Predicate {
+	r := ClockRange{Start: start, End: end}
+
+	return func(t time.Time) bool { return r.Contains(TimeOfDayOf(t)) }
+}
+
+// InLastDaysOfMonth returns a predicate that matches times on the last n
+// calendar days of their month, such as a month-end freeze.
+func InLastDaysOfMonth(n int) Predicate {
+	return func(t time.Time) bool {
+		y, m, d := t.Date()
+
+		return d > daysIn(y, m)-n
+	}
+}
+
+// InInterval returns a predicate that matches times within i.
+func InInterval(i Interval) Predicate {
+	return i.Contains
+}
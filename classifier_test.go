@@ -0,0 +1,66 @@
+package temporalis
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestClassifier tests classification with rules built from the predicate
+// helpers, including replacing and removing rules.
+func TestClassifier(t *testing.T) {
+	c := NewClassifier()
+	c.Register("weekend", OnWeekdays(time.Saturday, time.Sunday))
+	c.Register("peak_hours", AllOf(OnBusinessDays(nil), DuringHours(NewTimeOfDay(8, 0, 0, 0), NewTimeOfDay(18, 0, 0, 0))))
+	c.Register("month_end_freeze", InLastDaysOfMonth(3))
+	c.Register("night", DuringHours(NewTimeOfDay(22, 0, 0, 0), NewTimeOfDay(6, 0, 0, 0)))
+
+	tests := []struct {
+		t        time.Time
+		expected []string
+	}{
+		{time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC), []string{"peak_hours"}},
+		{time.Date(2024, 5, 15, 18, 0, 0, 0, time.UTC), []string{}},
+		{time.Date(2024, 5, 18, 23, 0, 0, 0, time.UTC), []string{"weekend", "night"}},
+		{time.Date(2024, 5, 29, 5, 59, 0, 0, time.UTC), []string{"month_end_freeze", "night"}},
+		{time.Date(2024, 2, 27, 12, 0, 0, 0, time.UTC), []string{"peak_hours", "month_end_freeze"}},
+		{time.Date(2023, 2, 26, 12, 0, 0, 0, time.UTC), []string{"weekend", "month_end_freeze"}},
+	}
+
+	for _, test := range tests {
+		if actual := c.Classify(test.t); !slices.Equal(actual, test.expected) {
+			t.Errorf("Classify(%v) = %v, expected %v", test.t, actual, test.expected)
+		}
+	}
+
+	c.Register("weekend", Not(OnBusinessDays(nil)))
+	c.Unregister("night")
+	c.Unregister("missing")
+
+	if names, expected := c.Names(), []string{"weekend", "peak_hours", "month_end_freeze"}; !slices.Equal(names, expected) {
+		t.Errorf("Names() = %v, expected %v", names, expected)
+	}
+
+	if at := time.Date(2024, 5, 18, 23, 0, 0, 0, time.UTC); !c.Is(at, "weekend") || c.Is(at, "night") {
+		t.Errorf("Is(%v) did not reflect the replaced and removed rules", at)
+	}
+}
+
+// TestClassifierLocation tests that times are classified in the location of
+// the classifier.
+func TestClassifierLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+
+	c := &Classifier{Location: tokyo}
+	c.Register("weekend", OnWeekdays(time.Saturday, time.Sunday))
+	c.Register("any", AnyOf(InInterval(Interval{}), OnWeekdays(time.Saturday)))
+
+	// Friday 20:00 UTC is already Saturday in Tokyo.
+	at := time.Date(2024, 5, 17, 20, 0, 0, 0, time.UTC)
+	if actual := c.Classify(at); !slices.Equal(actual, []string{"weekend", "any"}) {
+		t.Errorf("Classify(%v) = %v, expected [weekend any]", at, actual)
+	}
+}
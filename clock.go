@@ -0,0 +1,243 @@
+package temporalis
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a source of the current time and of timers. Code that takes a
+// Clock instead of calling time.Now directly can be tested with a FakeClock
+// without real sleeps.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// AfterFunc waits for the duration to elapse and then calls f. The
+	// returned Timer can cancel the call.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending call created by Clock.AfterFunc. Stop prevents the call
+// and reports whether it did so, returning false if the call already
+// happened or was stopped before. *time.Timer implements Timer.
+type Timer interface {
+	Stop() bool
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// clockOrSystem returns c, or SystemClock if c is nil.
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return SystemClock
+	}
+
+	return c
+}
+
+// sleepClock pauses for d on the given clock, or until ctx is cancelled, and
+// returns the context error in the latter case.
+func sleepClock(ctx context.Context, c Clock, d time.Duration) error {
+	if _, ok := c.(systemClock); ok || d <= 0 {
+		return SleepContext(ctx, d)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.After(d):
+		return nil
+	}
+}
+
+// withClockTimeout is like context.WithTimeout with the timeout measured on
+// c. With a clock other than the system clock, the context has no deadline
+// and is cancelled with context.DeadlineExceeded as its cause.
+func withClockTimeout(ctx context.Context, c Clock, d time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := c.(systemClock); ok {
+		return context.WithTimeout(ctx, d)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	timer := c.AfterFunc(d, func() { cancel(context.DeadlineExceeded) })
+
+	return ctx, func() {
+		timer.Stop()
+		cancel(context.Canceled)
+	}
+}
+
+// FakeClock is a Clock whose time only moves when Advance or Set is called.
+// Channels returned by After fire, and functions passed to AfterFunc are
+// called, once the fake time reaches their deadline. The functions run
+// synchronously in the goroutine calling Advance or Set, in deadline order.
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	c  chan time.Time
+	f  func()
+}
+
+// fakeTimer is the Timer returned by FakeClock.AfterFunc.
+type fakeTimer struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+// Stop removes the pending call from the fake clock.
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, w := range c.waiters {
+		if w == t.waiter {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewFakeClock returns a fake clock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+
+	return c
+}
+
+// Now returns the fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After returns a channel that receives the fake time once it has advanced
+// by at least d. A non-positive d fires immediately.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+
+	c.waiters = append(c.waiters, &fakeWaiter{at: c.now.Add(d), c: ch})
+	c.cond.Broadcast()
+
+	return ch
+}
+
+// AfterFunc arranges for f to be called once the fake time has advanced by
+// at least d. A non-positive d still waits for the next Advance or Set.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &fakeWaiter{at: c.now.Add(d), f: f}
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+
+	return &fakeTimer{clock: c, waiter: w}
+}
+
+// Advance moves the fake time forward by d and fires the timers that became
+// due, in deadline order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	funcs := c.setLocked(c.now.Add(d))
+	c.mu.Unlock()
+
+	for _, f := range funcs {
+		f()
+	}
+}
+
+// Set moves the fake time to t and fires the timers that became due. Moving
+// the time backwards fires nothing.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	funcs := c.setLocked(t)
+	c.mu.Unlock()
+
+	for _, f := range funcs {
+		f()
+	}
+}
+
+// Waiters returns the number of pending After channels and AfterFunc calls.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until at least n timers are pending. Tests use
+// it to wait for the code under test to start sleeping before advancing.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// setLocked sets the time, fires due channels and returns the due functions
+// for the caller to run after releasing c.mu, which must be held.
+func (c *FakeClock) setLocked(t time.Time) []func() {
+	c.now = t
+
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].at.Before(c.waiters[j].at)
+	})
+
+	var funcs []func()
+
+	fired := 0
+	for _, w := range c.waiters {
+		if w.at.After(t) {
+			break
+		}
+
+		if w.f != nil {
+			funcs = append(funcs, w.f)
+		} else {
+			w.c <- t
+		}
+		fired++
+	}
+
+	c.waiters = c.waiters[fired:]
+
+	return funcs
+}
@@ -0,0 +1,44 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFakeClock checks that After channels fire only once the fake time
+// reaches their deadline.
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	short := clock.After(time.Second)
+	long := clock.After(time.Minute)
+
+	clock.Advance(30 * time.Second)
+
+	select {
+	case now := <-short:
+		if !now.Equal(start.Add(30 * time.Second)) {
+			t.Errorf("After() fired with %v, expected %v", now, start.Add(30*time.Second))
+		}
+	default:
+		t.Error("After(1s) did not fire after advancing 30s")
+	}
+
+	select {
+	case <-long:
+		t.Error("After(1m) fired after advancing 30s")
+	default:
+	}
+
+	if n := clock.Waiters(); n != 1 {
+		t.Errorf("Waiters() = %d, expected 1", n)
+	}
+
+	clock.Set(start.Add(time.Hour))
+	<-long
+
+	if now := clock.Now(); !now.Equal(start.Add(time.Hour)) {
+		t.Errorf("Now() = %v, expected %v", now, start.Add(time.Hour))
+	}
+}
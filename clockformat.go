@@ -0,0 +1,89 @@
+package temporalis
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClockStyle configures FormatClock. The zero value formats like a media
+// player, as "1:05:09" or "5:09".
+type ClockStyle struct {
+	// Fraction is the number of digits of fractional seconds, from 0 to 9,
+	// so 1 gives tenths as in "5:09.3".
+	Fraction int
+	// Hours shows the hours even when they are zero, as in "0:05:09".
+	Hours bool
+	// Pad pads the leading field to two digits, as in "01:05:09" or
+	// "05:09".
+	Pad bool
+	// Signed prefixes durations that are not negative with a plus sign, as
+	// in "+00:12", for showing the difference to a reference time. Negative
+	// durations always have a minus sign, even where they round to zero as
+	// in "-0:00", so that a timer that has run over never reads as on time.
+	Signed bool
+	// Rounding sets how the duration is rounded to the last digit shown.
+	// The default is RoundDown, which suits stopwatches and count-ups;
+	// countdowns usually want RoundUp so that they show zero only when the
+	// time is up.
+	Rounding RoundingMode
+}
+
+// FormatClock formats d the way clocks, timers and media players show
+// elapsed time, as hours, minutes and seconds separated by colons, such as
+// "1:05:09", "05:09.3" or "+00:12". Hours are shown only if the duration
+// has any, unless style asks for them, and are not wrapped at 24. It is the
+// counterpart of FormatDuration for displays where prose does not fit.
+// FormatClock panics if style.Fraction is not between 0 and 9.
+func FormatClock(d time.Duration, style ClockStyle) string {
+	if style.Fraction < 0 || style.Fraction > 9 {
+		panic("temporalis: fractional digits out of range for FormatClock")
+	}
+
+	unit := time.Second
+	for range style.Fraction {
+		unit /= 10
+	}
+
+	a := roundDuration(abs(d), unit, style.Rounding)
+
+	var b strings.Builder
+	switch {
+	case d < 0:
+		b.WriteByte('-')
+	case style.Signed:
+		b.WriteByte('+')
+	}
+
+	width := 1
+	if style.Pad {
+		width = 2
+	}
+
+	hours, minutes, seconds := a/time.Hour, a%time.Hour/time.Minute, a%time.Minute/time.Second
+	if hours > 0 || style.Hours {
+		writePadded(&b, int64(hours), width)
+		b.WriteByte(':')
+		width = 2
+	}
+	writePadded(&b, int64(minutes), width)
+	b.WriteByte(':')
+	writePadded(&b, int64(seconds), 2)
+
+	if style.Fraction > 0 {
+		b.WriteByte('.')
+		writePadded(&b, int64(a%time.Second/unit), style.Fraction)
+	}
+
+	return b.String()
+}
+
+// writePadded writes the non-negative n to b, padded with zeros to width
+// digits.
+func writePadded(b *strings.Builder, n int64, width int) {
+	s := strconv.FormatInt(n, 10)
+	for i := len(s); i < width; i++ {
+		b.WriteByte('0')
+	}
+	b.WriteString(s)
+}
@@ -0,0 +1,38 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFormatClock tests the style settings of FormatClock.
+func TestFormatClock(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		style    ClockStyle
+		expected string
+	}{
+		{time.Hour + 5*time.Minute + 9*time.Second, ClockStyle{}, "1:05:09"},
+		{5*time.Minute + 9*time.Second, ClockStyle{}, "5:09"},
+		{5*time.Minute + 9*time.Second + 370*time.Millisecond, ClockStyle{Pad: true, Fraction: 1}, "05:09.3"},
+		{5*time.Minute + 9*time.Second + 370*time.Millisecond, ClockStyle{Fraction: 1, Rounding: RoundNearest}, "5:09.4"},
+		{12 * time.Second, ClockStyle{Pad: true, Signed: true}, "+00:12"},
+		{-12 * time.Second, ClockStyle{Pad: true, Signed: true}, "-00:12"},
+		{-400 * time.Millisecond, ClockStyle{}, "-0:00"},
+		{-500 * time.Millisecond, ClockStyle{Signed: true}, "-0:00"},
+		{-400 * time.Millisecond, ClockStyle{Rounding: RoundUp}, "-0:01"},
+		{9 * time.Second, ClockStyle{Hours: true}, "0:00:09"},
+		{9 * time.Second, ClockStyle{Hours: true, Pad: true}, "00:00:09"},
+		{26 * time.Hour, ClockStyle{}, "26:00:00"},
+		{59*time.Second + 999*time.Millisecond, ClockStyle{Rounding: RoundUp}, "1:00"},
+		{1500 * time.Microsecond, ClockStyle{Fraction: 3}, "0:00.001"},
+		{time.Nanosecond, ClockStyle{Fraction: 9}, "0:00.000000001"},
+		{0, ClockStyle{}, "0:00"},
+	}
+
+	for _, test := range tests {
+		if actual := FormatClock(test.d, test.style); actual != test.expected {
+			t.Errorf("FormatClock(%v, %+v) = %q, expected %q", test.d, test.style, actual, test.expected)
+		}
+	}
+}
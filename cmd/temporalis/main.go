@@ -0,0 +1,280 @@
+// Command temporalis exposes the temporalis package on the command line, so
+// that its conversions and calculations can be used from scripts.
+//
+// Usage:
+//
+//	temporalis convert [-from zone] [-to zone] [-layout layout] time
+//	temporalis next [-n count] [-zone zone] [-after time] expression
+//	temporalis bizdays [-holidays list] from to
+//	temporalis humanize [-units n] [-compact] [-locale tag] [-relative] duration
+//	temporalis parse [-zone zone] phrase
+//
+// Times are accepted as RFC 3339, as "2006-01-02 15:04:05", "2006-01-02
+// 15:04" or "2006-01-02", as Unix timestamps, or as "now". A time without an
+// offset is read in the zone given with -from or -zone, or the local zone.
+// Zones accept anything LoadLocation understands.
+//
+// The expression of next is a five-field cron expression, or an RRULE such
+// as "FREQ=WEEKLY;BYDAY=MO" anchored at the -after time. Dates for bizdays
+// are written as 2006-01-02; holidays are a comma-separated list of dates.
+// Durations for humanize are accepted as by ParseDuration, such as "90m" or
+// "2d 4h", and the phrases for parse as by ParseDeadline, such as "EOD" or
+// "noon friday".
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// commands maps subcommand names to their implementations.
+var commands = map[string]func(args []string, stdout io.Writer) error{
+	"convert":  convert,
+	"next":     next,
+	"bizdays":  bizdays,
+	"humanize": humanize,
+	"parse":    parse,
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "temporalis:", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches to the subcommand named by the first argument.
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command given; use convert, next, bizdays, humanize or parse")
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	return cmd(args[1:], stdout)
+}
+
+// convert prints a time in another zone and layout.
+func convert(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	from := fs.String("from", "", "zone of a time without an offset")
+	to := fs.String("to", "UTC", "zone to convert to")
+	layout := fs.String("layout", time.RFC3339, "layout of the output")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	fromLoc, err := loadZone(*from)
+	if err != nil {
+		return err
+	}
+
+	toLoc, err := loadZone(*to)
+	if err != nil {
+		return err
+	}
+
+	t, err := parseTime(fs.Arg(0), fromLoc)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, t.In(toLoc).Format(*layout))
+
+	return nil
+}
+
+// next prints the next runs of a cron expression or RRULE.
+func next(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("next", flag.ContinueOnError)
+	count := fs.Int("n", 5, "number of runs to print")
+	zone := fs.String("zone", "", "zone in which the expression is evaluated")
+	after := fs.String("after", "now", "time after which to look for runs")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	loc, err := loadZone(*zone)
+	if err != nil {
+		return err
+	}
+
+	start, err := parseTime(*after, loc)
+	if err != nil {
+		return err
+	}
+	start = start.In(loc)
+
+	// An RRULE occurs at its start, which Next would skip.
+	t := start
+	var rule temporalis.Recurrence
+	if expr := fs.Arg(0); strings.Contains(strings.ToUpper(expr), "FREQ=") {
+		rule, err = temporalis.ParseRRule(expr, start)
+		t = start.Add(-time.Nanosecond)
+	} else {
+		var cron *temporalis.CronRule
+		cron, err = temporalis.ParseCron(expr)
+		if cron != nil {
+			cron.Location = loc
+		}
+		rule = cron
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < *count; i++ {
+		if t = rule.Next(t); t.IsZero() {
+			break
+		}
+
+		fmt.Fprintln(stdout, t.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// bizdays prints the number of business days between two dates, both
+// inclusive.
+func bizdays(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("bizdays", flag.ContinueOnError)
+	list := fs.String("holidays", "", "comma-separated dates that are closed")
+	if err := parseFlags(fs, args, 2); err != nil {
+		return err
+	}
+
+	from, err := temporalis.ParseCivilDate(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	to, err := temporalis.ParseCivilDate(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	var holidays []temporalis.CivilDate
+	for _, s := range strings.Split(*list, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+
+		d, err := temporalis.ParseCivilDate(s)
+		if err != nil {
+			return err
+		}
+		holidays = append(holidays, d)
+	}
+
+	fmt.Fprintln(stdout, temporalis.BusinessDaysBetween(from, to, holidays))
+
+	return nil
+}
+
+// humanize prints a duration for people.
+func humanize(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("humanize", flag.ContinueOnError)
+	units := fs.Int("units", 0, "maximum number of units, or 0 for all")
+	compact := fs.Bool("compact", false, "use abbreviated units")
+	locale := fs.String("locale", "", "language of the unit names")
+	relative := fs.Bool("relative", false, `describe the duration as "in X" or "X ago"`)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	d, err := temporalis.ParseDuration(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	opts := []temporalis.Option{temporalis.WithMaxUnits(*units), temporalis.WithLocale(*locale)}
+	if *compact {
+		opts = append(opts, temporalis.WithCompact())
+	}
+	if *relative {
+		opts = append(opts, temporalis.WithRelative())
+	}
+
+	fmt.Fprintln(stdout, temporalis.FormatDuration(d, opts...))
+
+	return nil
+}
+
+// parse prints the instant a natural-language deadline refers to.
+func parse(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("parse", flag.ContinueOnError)
+	zone := fs.String("zone", "", "zone in which the phrase is read")
+	if err := parseFlags(fs, args, -1); err != nil {
+		return err
+	}
+
+	loc, err := loadZone(*zone)
+	if err != nil {
+		return err
+	}
+
+	t, err := temporalis.ParseDeadline(strings.Join(fs.Args(), " "), temporalis.WithLocation(loc))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, t.Format(time.RFC3339))
+
+	return nil
+}
+
+// parseFlags parses the flags of a subcommand and checks that n positional
+// arguments remain, or at least one if n is negative.
+func parseFlags(fs *flag.FlagSet, args []string, n int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if got := fs.NArg(); (n < 0 && got == 0) || (n >= 0 && got != n) {
+		return fmt.Errorf("%s: wrong number of arguments", fs.Name())
+	}
+
+	return nil
+}
+
+// loadZone loads a zone by name, defaulting to the local zone.
+func loadZone(name string) (*time.Location, error) {
+	if name == "" {
+		return temporalis.LocalLocation(), nil
+	}
+
+	return temporalis.LoadLocation(name)
+}
+
+// timeLayouts are the layouts tried by parseTime, in order.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02"}
+
+// parseTime reads a time in one of the accepted forms, using loc for times
+// without an offset.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if s == "now" {
+		return temporalis.Now().In(loc), nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	t, err := temporalis.ParseUnix(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse time %q", s)
+	}
+
+	return t.In(loc), nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRun tests every subcommand on fixed inputs.
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"convert", "-from", "UTC", "-to", "Asia/Tokyo", "2024-05-01 09:00"}, "2024-05-01T18:00:00+09:00\n"},
+		{[]string{"next", "-n", "2", "-zone", "UTC", "-after", "2024-05-01T10:00:00Z", "30 9 * * 1-5"}, "2024-05-02T09:30:00Z\n2024-05-03T09:30:00Z\n"},
+		{[]string{"next", "-n", "2", "-zone", "UTC", "-after", "2024-05-06", "FREQ=WEEKLY;BYDAY=MO"}, "2024-05-06T00:00:00Z\n2024-05-13T00:00:00Z\n"},
+		{[]string{"bizdays", "-holidays", "2024-05-01", "2024-04-29", "2024-05-05"}, "4\n"},
+		{[]string{"humanize", "-units", "2", "2d 4h30m"}, "2 days and 4 hours\n"},
+		{[]string{"humanize", "-compact", "90m"}, "1h30m\n"},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+		if err := run(test.args, &out); err != nil {
+			t.Errorf("run(%v) returned error: %v", test.args, err)
+			continue
+		}
+
+		if actual := out.String(); actual != test.expected {
+			t.Errorf("run(%v) = %q, expected %q", test.args, actual, test.expected)
+		}
+	}
+}
+
+// TestRunErrors tests that bad commands and arguments are reported.
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{
+		nil,
+		{"frobnicate"},
+		{"convert"},
+		{"convert", "yesterday-ish"},
+		{"next", "61 * * * *"},
+		{"bizdays", "2024-05-01"},
+		{"parse", "sometime"},
+	} {
+		if err := run(args, new(bytes.Buffer)); err == nil {
+			t.Errorf("run(%v) returned no error", args)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"parse", "-zone", "UTC", "in", "2", "hours"}, &out); err != nil || !strings.HasSuffix(out.String(), "Z\n") {
+		t.Errorf("run(parse in 2 hours) = %q, %v, expected a UTC time", out.String(), err)
+	}
+}
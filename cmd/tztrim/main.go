@@ -0,0 +1,111 @@
+// Command tztrim writes a zoneinfo archive holding only an allowlisted set
+// of zones from the snapshot embedded in temporalis, together with a Go file
+// that embeds the archive and makes LoadLocation use it. It is meant to be
+// run with go generate:
+//
+//	//go:generate go run github.com/goify/temporalis/cmd/tztrim -zones Europe/Berlin,America/New_York
+//
+// Building the program with the temporalis_notzdata tag then leaves the full
+// snapshot out of the binary, while the listed zones load even in scratch
+// containers without /usr/share/zoneinfo.
+//
+// Usage:
+//
+//	tztrim [-zones list] [-file path] [-o name] [-pkg package]
+//
+// The -zones flag takes a comma-separated list of zone names and -file a
+// file with one zone name per line, where blank lines and lines starting
+// with # are ignored. Both may be given. The output is written to name.zip
+// and name.go, by default tzdata_trimmed.zip and tzdata_trimmed.go. The
+// package name defaults to the package being generated, or main.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goify/temporalis"
+)
+
+func main() {
+	zones := flag.String("zones", "", "comma-separated zone names")
+	file := flag.String("file", "", "file with one zone name per line")
+	output := flag.String("o", "tzdata_trimmed", "base name of the output files")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated Go file")
+	flag.Parse()
+
+	if err := run(*zones, *file, *output, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "tztrim:", err)
+		os.Exit(1)
+	}
+}
+
+// run collects the zone names, trims the snapshot and writes both files.
+func run(zoneList, file, output, pkg string) error {
+	var names []string
+	for _, name := range strings.Split(zoneList, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				names = append(names, line)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return fmt.Errorf("no zones given; use -zones or -file")
+	}
+
+	if pkg == "" {
+		pkg = "main"
+	}
+
+	archive, err := temporalis.TrimTZData(temporalis.EmbeddedTZDataVersion(), names)
+	if err != nil {
+		return err
+	}
+
+	source, err := generate(pkg, filepath.Base(output)+".zip", temporalis.EmbeddedTZDataVersion()+"-trimmed")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(output+".zip", archive, 0o644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(output+".go", source, 0o644)
+}
+
+// generate returns the formatted source of the Go file that embeds and
+// registers the archive.
+func generate(pkg, archive, version string) ([]byte, error) {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by tztrim; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "import (\n\t_ \"embed\"\n\n\t\"github.com/goify/temporalis\"\n)\n\n")
+	fmt.Fprintf(&b, "//go:embed %s\n", archive)
+	fmt.Fprintf(&b, "var trimmedTZData []byte\n\n")
+	fmt.Fprintf(&b, "func init() {\n")
+	fmt.Fprintf(&b, "\tif err := temporalis.RegisterTZData(%q, trimmedTZData); err != nil {\n\t\tpanic(err)\n\t}\n", version)
+	fmt.Fprintf(&b, "\tif err := temporalis.UseTZData(%q); err != nil {\n\t\tpanic(err)\n\t}\n", version)
+	fmt.Fprintf(&b, "}\n")
+
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,151 @@
+package temporalis
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Granularity is the size of a calendar period used to group times.
+type Granularity int
+
+const (
+	Daily Granularity = iota
+	Weekly
+	Monthly
+	Quarterly
+	Yearly
+)
+
+// String returns the lower-case name of the granularity, such as "monthly".
+func (g Granularity) String() string {
+	switch g {
+	case Daily:
+		return "daily"
+	case Weekly:
+		return "weekly"
+	case Monthly:
+		return "monthly"
+	case Quarterly:
+		return "quarterly"
+	case Yearly:
+		return "yearly"
+	default:
+		return "Granularity(" + strconv.Itoa(int(g)) + ")"
+	}
+}
+
+// Age returns the number of full years between birth and now, counting a
+// February 29 birthday as reached on March 1 in non-leap years. Both times
+// are reduced to their dates in their own locations.
+func Age(birth, now time.Time) int {
+	b, n := DateOf(birth), DateOf(now)
+
+	age := n.Year - b.Year
+	if n.Month < b.Month || (n.Month == b.Month && n.Day < b.Day) {
+		age--
+	}
+
+	return age
+}
+
+// AgeBand returns the label of the age band that the age at now of someone
+// born at birth falls into. The bands are given by their ascending lower
+// bounds, so []int{18, 25, 35, 50} yields the labels "<18", "18-24",
+// "25-34", "35-49" and "50+". Without bounds the label is "all".
+func AgeBand(birth, now time.Time, bounds []int) string {
+	if len(bounds) == 0 {
+		return "all"
+	}
+
+	age := Age(birth, now)
+	if age < bounds[0] {
+		return fmt.Sprintf("<%d", bounds[0])
+	}
+
+	for i := 1; i < len(bounds); i++ {
+		if age < bounds[i] {
+			return fmt.Sprintf("%d-%d", bounds[i-1], bounds[i]-1)
+		}
+	}
+
+	return fmt.Sprintf("%d+", bounds[len(bounds)-1])
+}
+
+// CohortOf returns a sortable label for the period of the given granularity
+// that contains t, in t's location: "2024-01-15" for days, "2024-W03" for ISO
+// weeks, "2024-01" for months, "2024-Q1" for quarters and "2024" for years.
+// Weeks use the ISO year, so December 30, 2024 belongs to "2025-W01".
+func CohortOf(t time.Time, g Granularity) string {
+	switch g {
+	case Daily:
+		return t.Format(time.DateOnly)
+	case Weekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case Monthly:
+		return t.Format("2006-01")
+	case Quarterly:
+		return fmt.Sprintf("%04d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	default:
+		return fmt.Sprintf("%04d", t.Year())
+	}
+}
+
+// StartOfPeriod returns midnight at the start of the period of the given
+// granularity that contains t, in t's location. Weeks start on Monday. The
+// options WithLocation and WithWeekStart select another zone or first day of
+// the week.
+func StartOfPeriod(t time.Time, g Granularity, opts ...Option) time.Time {
+	o := NewOptions(opts...)
+	t = t.In(o.locationOr(t.Location()))
+
+	y, m, d := t.Date()
+	loc := t.Location()
+
+	switch g {
+	case Daily:
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	case Weekly:
+		back := (int(t.Weekday()) - int(o.WeekStart) + 7) % 7
+		return time.Date(y, m, d-back, 0, 0, 0, 0, loc)
+	case Monthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc)
+	case Quarterly:
+		return time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc)
+	}
+}
+
+// NextPeriod returns midnight at the start of the period following the one
+// that contains t. It accepts the same options as StartOfPeriod.
+func NextPeriod(t time.Time, g Granularity, opts ...Option) time.Time {
+	start := StartOfPeriod(t, g, opts...)
+
+	switch g {
+	case Daily:
+		return start.AddDate(0, 0, 1)
+	case Weekly:
+		return start.AddDate(0, 0, 7)
+	case Monthly:
+		return start.AddDate(0, 1, 0)
+	case Quarterly:
+		return start.AddDate(0, 3, 0)
+	default:
+		return start.AddDate(1, 0, 0)
+	}
+}
+
+// SeedForPeriod returns a deterministic seed for the period of the given
+// granularity that contains t in loc. The seed is the same for every time in
+// the period and on every machine, and changes exactly at the period
+// boundaries in loc, which suits features such as a "deal of the day" that
+// must rotate in step across instances. A nil loc means UTC.
+func SeedForPeriod(t time.Time, g Granularity, loc *time.Location) int64 {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return int64(stableHash(g.String() + ":" + CohortOf(t.In(loc), g)))
+}
@@ -0,0 +1,79 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAgeBand checks ages just before and on a birthday and the band labels.
+func TestAgeBand(t *testing.T) {
+	birth := time.Date(2000, time.June, 15, 0, 0, 0, 0, time.UTC)
+	bounds := []int{18, 25, 35}
+
+	tests := []struct {
+		now      time.Time
+		expected string
+	}{
+		{time.Date(2018, time.June, 14, 0, 0, 0, 0, time.UTC), "<18"},
+		{time.Date(2018, time.June, 15, 0, 0, 0, 0, time.UTC), "18-24"},
+		{time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), "25-34"},
+		{time.Date(2040, time.January, 1, 0, 0, 0, 0, time.UTC), "35+"},
+	}
+
+	for _, test := range tests {
+		if actual := AgeBand(birth, test.now, bounds); actual != test.expected {
+			t.Errorf("AgeBand(%v) = %q, expected %q", test.now, actual, test.expected)
+		}
+	}
+}
+
+// TestCohortOf checks the label of each granularity, including an ISO week
+// that belongs to the following year.
+func TestCohortOf(t *testing.T) {
+	ts := time.Date(2024, time.December, 30, 12, 0, 0, 0, time.UTC)
+
+	expected := map[Granularity]string{
+		Daily:     "2024-12-30",
+		Weekly:    "2025-W01",
+		Monthly:   "2024-12",
+		Quarterly: "2024-Q4",
+		Yearly:    "2024",
+	}
+
+	for g, label := range expected {
+		if actual := CohortOf(ts, g); actual != label {
+			t.Errorf("CohortOf(%v) = %q, expected %q", g, actual, label)
+		}
+	}
+
+	if start := StartOfPeriod(ts, Quarterly); !start.Equal(time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("StartOfPeriod(quarterly) = %v", start)
+	}
+
+	if next := NextPeriod(ts, Weekly); !next.Equal(time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextPeriod(weekly) = %v", next)
+	}
+}
+
+// TestSeedForPeriod checks that seeds change exactly at local day boundaries.
+func TestSeedForPeriod(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*3600)
+	midnight := time.Date(2024, time.March, 10, 0, 0, 0, 0, loc)
+
+	before := SeedForPeriod(midnight.Add(-time.Nanosecond), Daily, loc)
+	start := SeedForPeriod(midnight, Daily, loc)
+	end := SeedForPeriod(midnight.Add(24*time.Hour-time.Nanosecond), Daily, loc)
+
+	if before == start {
+		t.Errorf("SeedForPeriod() did not change at midnight")
+	}
+	if start != end {
+		t.Errorf("SeedForPeriod() = %d at end of day, expected %d", end, start)
+	}
+	if utc := SeedForPeriod(midnight, Daily, nil); utc != before {
+		t.Errorf("SeedForPeriod(UTC) = %d, expected the previous UTC day %d", utc, before)
+	}
+	if weekly := SeedForPeriod(midnight, Weekly, loc); weekly == start {
+		t.Errorf("SeedForPeriod() is the same for different granularities")
+	}
+}
@@ -0,0 +1,114 @@
+package temporalis
+
+import (
+	"strconv"
+	"time"
+)
+
+// Inclusivity selects which ends of a range count as inside it, in interval
+// notation. The zero value is IncludeStart, the half-open range used by
+// Interval.
+type Inclusivity int
+
+const (
+	// IncludeStart includes the start but not the end: [start, end).
+	IncludeStart Inclusivity = iota
+	// IncludeBoth includes both ends: [start, end].
+	IncludeBoth
+	// IncludeEnd includes the end but not the start: (start, end].
+	IncludeEnd
+	// ExcludeBoth includes neither end: (start, end).
+	ExcludeBoth
+)
+
+// String returns the inclusivity in interval notation, such as "[)".
+func (i Inclusivity) String() string {
+	switch i {
+	case IncludeStart:
+		return "[)"
+	case IncludeBoth:
+		return "[]"
+	case IncludeEnd:
+		return "(]"
+	case ExcludeBoth:
+		return "()"
+	default:
+		return "Inclusivity(" + strconv.Itoa(int(i)) + ")"
+	}
+}
+
+// Between reports whether t lies between start and end, with inclusivity
+// deciding whether the ends themselves count. Times are compared as
+// instants, so their locations and monotonic clock readings do not matter.
+// If end is before start, no time is between them.
+func Between(t, start, end time.Time, inclusivity Inclusivity) bool {
+	lower, upper := t.Compare(start), t.Compare(end)
+
+	switch inclusivity {
+	case IncludeBoth:
+		return lower >= 0 && upper <= 0
+	case IncludeEnd:
+		return lower > 0 && upper <= 0
+	case ExcludeBoth:
+		return lower > 0 && upper < 0
+	default:
+		return lower >= 0 && upper < 0
+	}
+}
+
+// Clamp returns t limited to the range [min, max]: min if t is before it,
+// max if t is after it, and t otherwise. It panics if max is before min.
+func Clamp(t, min, max time.Time) time.Time {
+	if max.Before(min) {
+		panic("temporalis: Clamp called with max before min")
+	}
+
+	switch {
+	case t.Before(min):
+		return min
+	case t.After(max):
+		return max
+	default:
+		return t
+	}
+}
+
+// Max returns the latest of times, or the zero time if there are none. Of
+// times that are the same instant, the first is returned.
+func Max(times ...time.Time) time.Time {
+	var latest time.Time
+	for i, t := range times {
+		if i == 0 || t.After(latest) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+// Min returns the earliest of times, or the zero time if there are none. Of
+// times that are the same instant, the first is returned.
+func Min(times ...time.Time) time.Time {
+	var earliest time.Time
+	for i, t := range times {
+		if i == 0 || t.Before(earliest) {
+			earliest = t
+		}
+	}
+
+	return earliest
+}
+
+// IsZeroOrBefore reports whether t is the zero time or before u, the usual
+// test for an optional time such as a last-run time that is unset or stale.
+func IsZeroOrBefore(t, u time.Time) bool {
+	return t.IsZero() || t.Before(u)
+}
+
+// IsZeroOrAfter reports whether t is the zero time or after u, the usual
+// test for an optional time such as an expiry where unset means never. Plain
+// t.After(u) is false for the zero time, which is the opposite of what is
+// meant.
+func IsZeroOrAfter(t, u time.Time) bool {
+	return t.IsZero() || t.After(u)
+}
@@ -0,0 +1,111 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBetween tests each inclusivity at and between the ends of a range.
+func TestBetween(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	middle := start.Add(30 * time.Minute).In(time.FixedZone("", 3600))
+
+	tests := []struct {
+		inclusivity        Inclusivity
+		atStart, atEnd, in bool
+	}{
+		{IncludeStart, true, false, true},
+		{IncludeBoth, true, true, true},
+		{IncludeEnd, false, true, true},
+		{ExcludeBoth, false, false, true},
+	}
+
+	for _, test := range tests {
+		if actual := Between(start, start, end, test.inclusivity); actual != test.atStart {
+			t.Errorf("Between(start, %v) = %v, expected %v", test.inclusivity, actual, test.atStart)
+		}
+		if actual := Between(end, start, end, test.inclusivity); actual != test.atEnd {
+			t.Errorf("Between(end, %v) = %v, expected %v", test.inclusivity, actual, test.atEnd)
+		}
+		if actual := Between(middle, start, end, test.inclusivity); actual != test.in {
+			t.Errorf("Between(middle, %v) = %v, expected %v", test.inclusivity, actual, test.in)
+		}
+		if Between(middle, end, start, test.inclusivity) {
+			t.Errorf("Between(middle, %v) with an inverted range = true, expected false", test.inclusivity)
+		}
+	}
+}
+
+// TestClamp tests times before, within and after a range, and that an
+// inverted range panics.
+func TestClamp(t *testing.T) {
+	lo := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hi := lo.AddDate(0, 1, 0)
+	within := lo.AddDate(0, 0, 10)
+
+	tests := []struct {
+		input, expected time.Time
+	}{
+		{lo.AddDate(-1, 0, 0), lo},
+		{within, within},
+		{hi.Add(time.Nanosecond), hi},
+	}
+
+	for _, test := range tests {
+		if actual := Clamp(test.input, lo, hi); !actual.Equal(test.expected) {
+			t.Errorf("Clamp(%v) = %v, expected %v", test.input, actual, test.expected)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Clamp() with max before min did not panic")
+		}
+	}()
+	Clamp(within, hi, lo)
+}
+
+// TestMinMax tests the earliest and latest of several times and of none.
+func TestMinMax(t *testing.T) {
+	a := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := a.Add(-time.Hour)
+	c := a.Add(time.Hour)
+
+	if actual := Max(a, b, c); !actual.Equal(c) {
+		t.Errorf("Max() = %v, expected %v", actual, c)
+	}
+	if actual := Min(a, b, c); !actual.Equal(b) {
+		t.Errorf("Min() = %v, expected %v", actual, b)
+	}
+	if actual := Max(); !actual.IsZero() {
+		t.Errorf("Max() of nothing = %v, expected the zero time", actual)
+	}
+	if actual := Min(); !actual.IsZero() {
+		t.Errorf("Min() of nothing = %v, expected the zero time", actual)
+	}
+}
+
+// TestIsZeroOrBeforeAfter tests that the zero time satisfies both helpers.
+func TestIsZeroOrBeforeAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		t             time.Time
+		before, after bool
+	}{
+		{time.Time{}, true, true},
+		{now.Add(-time.Second), true, false},
+		{now, false, false},
+		{now.Add(time.Second), false, true},
+	}
+
+	for _, test := range tests {
+		if actual := IsZeroOrBefore(test.t, now); actual != test.before {
+			t.Errorf("IsZeroOrBefore(%v) = %v, expected %v", test.t, actual, test.before)
+		}
+		if actual := IsZeroOrAfter(test.t, now); actual != test.after {
+			t.Errorf("IsZeroOrAfter(%v) = %v, expected %v", test.t, actual, test.after)
+		}
+	}
+}
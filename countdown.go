@@ -0,0 +1,56 @@
+package temporalis
+
+import (
+	"context"
+	"time"
+)
+
+// RemainingUntil returns the time left until t, or zero if t has passed.
+func RemainingUntil(t time.Time) time.Duration {
+	if d := time.Until(t); d > 0 {
+		return d
+	}
+
+	return 0
+}
+
+// Countdown emits the time remaining until target right away and then every
+// interval, with the last tick shortened so that a final zero is sent at
+// target, after which the channel is closed. The channel is also closed,
+// without a final zero, when ctx is done. If target has already passed, only
+// the zero is sent. Values are not buffered and the next interval only
+// starts once a value has been received, so a receiver that falls behind
+// gets the remaining time as of the tick that was waiting for it, which is
+// older by the delay, and never a backlog of values. Countdown panics if
+// interval is not positive.
+func Countdown(ctx context.Context, target time.Time, interval time.Duration) <-chan time.Duration {
+	if interval <= 0 {
+		panic("temporalis: non-positive interval for Countdown")
+	}
+
+	c := make(chan time.Duration)
+
+	go func() {
+		defer close(c)
+
+		for {
+			remaining := RemainingUntil(target)
+
+			select {
+			case c <- remaining:
+			case <-ctx.Done():
+				return
+			}
+
+			if remaining == 0 {
+				return
+			}
+
+			if err := SleepContext(ctx, min(interval, RemainingUntil(target))); err != nil {
+				return
+			}
+		}
+	}()
+
+	return c
+}
@@ -0,0 +1,73 @@
+package temporalis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRemainingUntil tests that RemainingUntil never returns a negative
+// duration.
+func TestRemainingUntil(t *testing.T) {
+	if d := RemainingUntil(time.Now().Add(-time.Hour)); d != 0 {
+		t.Errorf("RemainingUntil(past) = %v, expected 0", d)
+	}
+
+	if d := RemainingUntil(time.Now().Add(time.Hour)); d <= 59*time.Minute || d > time.Hour {
+		t.Errorf("RemainingUntil(+1h) = %v, expected about 1h", d)
+	}
+}
+
+// TestCountdown tests that Countdown counts down to a final zero and then
+// closes the channel.
+func TestCountdown(t *testing.T) {
+	target := time.Now().Add(50 * time.Millisecond)
+
+	var values []time.Duration
+	for d := range Countdown(context.Background(), target, 20*time.Millisecond) {
+		values = append(values, d)
+	}
+
+	if time.Now().Before(target) {
+		t.Errorf("Countdown() closed before the target")
+	}
+	if len(values) < 3 || values[len(values)-1] != 0 {
+		t.Fatalf("Countdown() = %v, expected at least 3 values ending in 0", values)
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i] >= values[i-1] {
+			t.Errorf("Countdown() = %v, expected decreasing values", values)
+			break
+		}
+	}
+}
+
+// TestCountdownCancel tests that Countdown closes its channel without a final
+// zero when the context is cancelled.
+func TestCountdownCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := Countdown(ctx, time.Now().Add(time.Hour), time.Millisecond)
+
+	if d := <-c; d <= 0 {
+		t.Errorf("first value = %v, expected a positive duration", d)
+	}
+
+	cancel()
+	for d := range c {
+		if d == 0 {
+			t.Errorf("Countdown() sent zero after cancellation")
+		}
+	}
+}
+
+// TestCountdownPast tests that a countdown to a past time sends only zero.
+func TestCountdownPast(t *testing.T) {
+	var values []time.Duration
+	for d := range Countdown(context.Background(), time.Now().Add(-time.Second), time.Second) {
+		values = append(values, d)
+	}
+
+	if len(values) != 1 || values[0] != 0 {
+		t.Errorf("Countdown(past) = %v, expected [0]", values)
+	}
+}
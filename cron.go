@@ -0,0 +1,215 @@
+package temporalis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronRule is a parsed five-field cron expression. It implements Recurrence.
+type CronRule struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+	// Location is the zone in which the expression is evaluated. If nil,
+	// the location of the time passed to Next is used.
+	Location *time.Location
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseCron parses a standard five-field cron expression ("minute hour
+// day-of-month month day-of-week"). Fields accept "*", numbers, ranges
+// ("1-5"), lists ("1,15"), steps ("*/10", "0-30/5") and, for months and
+// weekdays, three-letter names. Day of week 7 is Sunday like 0. The macros
+// @yearly, @monthly, @weekly, @daily and @hourly are also accepted. As in
+// Vixie cron, when both day fields are restricted a day matches if either
+// field matches.
+func ParseCron(expr string) (*CronRule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, syntaxError("ParseCron", expr, fmt.Sprintf("expected 5 fields, found %d", len(fields)))
+	}
+
+	r := &CronRule{expr: expr}
+
+	var err error
+	if r.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, &ParseError{Func: "ParseCron", Input: expr, Err: fmt.Errorf("minute field: %w", err)}
+	}
+	if r.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, &ParseError{Func: "ParseCron", Input: expr, Err: fmt.Errorf("hour field: %w", err)}
+	}
+	if r.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, &ParseError{Func: "ParseCron", Input: expr, Err: fmt.Errorf("day-of-month field: %w", err)}
+	}
+	if r.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, &ParseError{Func: "ParseCron", Input: expr, Err: fmt.Errorf("month field: %w", err)}
+	}
+	if r.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, &ParseError{Func: "ParseCron", Input: expr, Err: fmt.Errorf("day-of-week field: %w", err)}
+	}
+
+	if r.dow&(1<<7) != 0 {
+		r.dow |= 1
+	}
+
+	r.domStar = strings.HasPrefix(fields[2], "*")
+	r.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return r, nil
+}
+
+// String returns the expression the rule was parsed from.
+func (r *CronRule) String() string {
+	return r.expr
+}
+
+// Next returns the first minute strictly after the given time that matches
+// the expression, or the zero time if none matches within five years, which
+// happens for expressions such as "0 0 30 2 *".
+func (r *CronRule) Next(after time.Time) time.Time {
+	loc := r.Location
+	if loc == nil {
+		loc = after.Location()
+	}
+
+	t := after.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if r.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !r.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if r.hour&(1<<uint(t.Hour())) == 0 {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				next = t.Add(time.Hour).Truncate(time.Hour)
+			}
+			t = next
+			continue
+		}
+
+		if r.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches applies the day-of-month and day-of-week fields.
+func (r *CronRule) dayMatches(t time.Time) bool {
+	dom := r.dom&(1<<uint(t.Day())) != 0
+	dow := r.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case r.domStar && r.dowStar:
+		return true
+	case r.domStar:
+		return dow
+	case r.dowStar:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// parseCronField parses one field into a bit set of allowed values.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, detailed(ErrSyntax, "invalid step "+strconv.Quote(stepPart))
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if lo, err = cronValue(from, names); err != nil {
+				return 0, err
+			}
+
+			hi = lo
+			if isRange {
+				if hi, err = cronValue(to, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, detailed(ErrOutOfRange, strconv.Quote(part))
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// cronValue parses a number or a name from names.
+func cronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, detailed(ErrSyntax, "invalid value "+strconv.Quote(s))
+	}
+
+	return v, nil
+}
@@ -0,0 +1,46 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCronNext checks the next run of a few expressions, including names,
+// steps and the Vixie rule for restricted day fields.
+func TestCronNext(t *testing.T) {
+	after := time.Date(2024, time.January, 15, 10, 7, 30, 0, time.UTC) // Monday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, time.January, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2024, time.January, 16, 9, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 7", time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{"30 12 29 feb *", time.Date(2024, time.February, 29, 12, 30, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseCron(test.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned error: %v", test.expr, err)
+			continue
+		}
+
+		if actual := rule.Next(after); !actual.Equal(test.expected) {
+			t.Errorf("ParseCron(%q).Next() = %v, expected %v", test.expr, actual, test.expected)
+		}
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "* * * foo *", "*/0 * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) expected error", expr)
+		}
+	}
+
+	impossible, _ := ParseCron("0 0 30 2 *")
+	if next := impossible.Next(after); !next.IsZero() {
+		t.Errorf("Next() of impossible rule = %v, expected zero", next)
+	}
+}
@@ -0,0 +1,58 @@
+package temporalis
+
+import "time"
+
+// CutoffPolicy captures rules such as "orders placed after 15:00 local time
+// ship on the next business day". Events at or after the cutoff on a business
+// day, and events on non-business days, take effect on the next business day.
+type CutoffPolicy struct {
+	// Cutoff is the wall-clock time after which the current day is missed.
+	Cutoff TimeOfDay
+	// Location is the zone in which Cutoff is evaluated. Nil means UTC.
+	Location *time.Location
+	// Calendar decides which days are business days. Nil means Monday to
+	// Friday without holidays.
+	Calendar *BusinessCalendar
+}
+
+// EffectiveDate returns the business day on which an event at t takes effect.
+func (p CutoffPolicy) EffectiveDate(t time.Time) CivilDate {
+	local := t.In(p.location())
+	d := DateOf(local)
+
+	if p.Calendar.IsBusinessDay(d) && local.Before(p.Cutoff.OnDate(d, p.location())) {
+		return d
+	}
+
+	return p.Calendar.NextBusinessDay(d)
+}
+
+// NextCutoff returns the first cutoff instant strictly after t on a business
+// day, which is the deadline for an event at t to make its effective date.
+func (p CutoffPolicy) NextCutoff(t time.Time) time.Time {
+	local := t.In(p.location())
+	d := DateOf(local)
+
+	if p.Calendar.IsBusinessDay(d) {
+		if cutoff := p.Cutoff.OnDate(d, p.location()); cutoff.After(local) {
+			return cutoff
+		}
+	}
+
+	return p.Cutoff.OnDate(p.Calendar.NextBusinessDay(d), p.location())
+}
+
+// MissedCutoff reports whether an event at t takes effect on a later date
+// than the one it occurred on.
+func (p CutoffPolicy) MissedCutoff(t time.Time) bool {
+	return p.EffectiveDate(t) != DateOf(t.In(p.location()))
+}
+
+// location returns the policy's zone, defaulting to UTC.
+func (p CutoffPolicy) location() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+
+	return p.Location
+}
@@ -0,0 +1,39 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCutoffPolicy checks orders before and after a 15:00 cutoff on a Friday,
+// and on a weekend.
+func TestCutoffPolicy(t *testing.T) {
+	policy := CutoffPolicy{Cutoff: TimeOfDay{Hour: 15}}
+
+	friday := CivilDate{2024, time.May, 3}
+	monday := CivilDate{2024, time.May, 6}
+
+	tests := []struct {
+		at       time.Time
+		date     CivilDate
+		deadline time.Time
+	}{
+		{friday.At(14, 59, 0, time.UTC), friday, friday.At(15, 0, 0, time.UTC)},
+		{friday.At(15, 0, 0, time.UTC), monday, monday.At(15, 0, 0, time.UTC)},
+		{friday.AddDays(1).At(10, 0, 0, time.UTC), monday, monday.At(15, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if got := policy.EffectiveDate(test.at); got != test.date {
+			t.Errorf("EffectiveDate(%v) = %v, expected %v", test.at, got, test.date)
+		}
+
+		if got := policy.NextCutoff(test.at); !got.Equal(test.deadline) {
+			t.Errorf("NextCutoff(%v) = %v, expected %v", test.at, got, test.deadline)
+		}
+	}
+
+	if policy.MissedCutoff(tests[0].at) || !policy.MissedCutoff(tests[1].at) {
+		t.Errorf("MissedCutoff() is wrong around the cutoff")
+	}
+}
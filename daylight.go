@@ -0,0 +1,68 @@
+package temporalis
+
+import (
+	"math"
+	"time"
+)
+
+// DaylightDuration returns the time from sunrise to sunset on the given date
+// at the given latitude and longitude, in degrees north and east. The date
+// is the local date at that longitude. It is 24 hours during polar day and
+// zero during polar night.
+func DaylightDuration(d CivilDate, lat, lon float64) time.Duration {
+	day, allDay, ok := daylight(d, lat, lon)
+	switch {
+	case !ok:
+		return 0
+	case allDay:
+		return 24 * time.Hour
+	}
+
+	return day.Duration()
+}
+
+// SplitDayNight returns how much of the interval falls in daylight, between
+// sunrise and sunset, and how much in darkness, at the given latitude and
+// longitude. The two always add up to the duration of the interval.
+func SplitDayNight(i Interval, lat, lon float64) (day, night time.Duration) {
+	if i.IsEmpty() {
+		return 0, 0
+	}
+
+	// Dates at lon run up to half a day either side of UTC dates.
+	last := DateOf(i.End.UTC()).AddDays(1)
+	for d := DateOf(i.Start.UTC()).AddDays(-1); !d.After(last); d = d.AddDays(1) {
+		if light, _, ok := daylight(d, lat, lon); ok {
+			if overlap, ok := light.Intersect(i); ok {
+				day += overlap.Duration()
+			}
+		}
+	}
+
+	return day, i.Duration() - day
+}
+
+// daylight returns the interval from sunrise to sunset on the date, or
+// false during polar night. During polar day, which is reported by allDay,
+// the interval runs from halfway between the solar noons of the previous day
+// and the date to halfway between those of the date and the next day, so
+// consecutive days do not overlap.
+func daylight(d CivilDate, lat, lon float64) (day Interval, allDay, ok bool) {
+	const rad = math.Pi / 180
+
+	transit, sinDecl := solarDay(d, lon)
+	if hour, ok := solarHourAngle(lat, sinDecl, -0.833); ok {
+		return Interval{Start: fromJ2000Days(transit - hour), End: fromJ2000Days(transit + hour)}, false, true
+	}
+
+	// The sun neither rises nor sets, so it is up all day if it is up at
+	// noon.
+	if 90-math.Abs(lat-math.Asin(sinDecl)/rad) < -0.833 {
+		return Interval{}, false, false
+	}
+
+	before, _ := solarDay(d.AddDays(-1), lon)
+	after, _ := solarDay(d.AddDays(1), lon)
+
+	return Interval{Start: fromJ2000Days((before + transit) / 2), End: fromJ2000Days((transit + after) / 2)}, true, true
+}
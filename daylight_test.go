@@ -0,0 +1,63 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDaylightDuration tests day lengths at the solstices, including polar
+// day and night.
+func TestDaylightDuration(t *testing.T) {
+	tests := []struct {
+		date     CivilDate
+		lat, lon float64
+		expected time.Duration
+	}{
+		// London: 16h38m at midsummer and 7h50m at midwinter.
+		{CivilDate{2024, time.June, 21}, 51.5074, -0.1278, 16*time.Hour + 38*time.Minute},
+		{CivilDate{2024, time.December, 21}, 51.5074, -0.1278, 7*time.Hour + 50*time.Minute},
+		// The equator has about 12h07m all year.
+		{CivilDate{2024, time.March, 20}, 0, 0, 12*time.Hour + 7*time.Minute},
+		// Tromsø.
+		{CivilDate{2024, time.June, 21}, 69.6492, 18.9553, 24 * time.Hour},
+		{CivilDate{2024, time.December, 21}, 69.6492, 18.9553, 0},
+	}
+
+	for _, test := range tests {
+		if actual := DaylightDuration(test.date, test.lat, test.lon); (actual - test.expected).Abs() > 2*time.Minute {
+			t.Errorf("DaylightDuration(%v, %v, %v) = %v, expected %v", test.date, test.lat, test.lon, actual, test.expected)
+		}
+	}
+}
+
+// TestSplitDayNight tests splitting intervals of a day and of several days.
+func TestSplitDayNight(t *testing.T) {
+	const lat, lon = 51.5074, -0.1278
+	date := CivilDate{2024, time.June, 21}
+
+	// A whole day from midnight to midnight in London contains one
+	// sunrise and one sunset.
+	i := Interval{Start: date.In(time.UTC), End: date.AddDays(1).In(time.UTC)}
+	day, night := SplitDayNight(i, lat, lon)
+	if expected := DaylightDuration(date, lat, lon); (day-expected).Abs() > time.Minute || day+night != 24*time.Hour {
+		t.Errorf("SplitDayNight(%v) = %v, %v, expected %v of daylight", i, day, night, expected)
+	}
+
+	// A week of polar day in Tromsø is all daylight, without gaps or
+	// overlaps between the days.
+	week := Interval{Start: date.In(time.UTC), End: date.AddDays(7).In(time.UTC)}
+	if day, night := SplitDayNight(week, 69.6492, 18.9553); day != 7*24*time.Hour || night != 0 {
+		t.Errorf("SplitDayNight(%v) in Tromsø = %v, %v, expected all daylight", week, day, night)
+	}
+
+	// From sunset to the next sunrise it is dark.
+	sunset, _ := Sunset.On(date, lat, lon)
+	sunrise, _ := Sunrise.On(date.AddDays(1), lat, lon)
+	if day, night := SplitDayNight(Interval{Start: sunset, End: sunrise}, lat, lon); day != 0 || night != sunrise.Sub(sunset) {
+		t.Errorf("SplitDayNight(sunset to sunrise) = %v, %v, expected all darkness", day, night)
+	}
+
+	if day, night := SplitDayNight(Interval{}, lat, lon); day != 0 || night != 0 {
+		t.Errorf("SplitDayNight(empty) = %v, %v, expected 0, 0", day, night)
+	}
+}
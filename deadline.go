@@ -0,0 +1,226 @@
+package temporalis
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EndOfBusinessHour is the wall-clock hour that "EOD", "COB" and bare day
+// names refer to in ParseDeadlinePhrase.
+const EndOfBusinessHour = 17
+
+// ParseDeadlinePhrase turns a deadline written the way people write them in
+// tickets into an absolute instant in loc, relative to now. Business days come
+// from cal, which may be nil for a plain Monday to Friday week. Supported
+// phrases, with an optional leading "by", "before" or "due":
+//
+//	EOD, COB, end of day         17:00 today, or on the next business day if
+//	                             that has passed or today is not a business day
+//	end of week, EOW             17:00 on the last business day of this week
+//	tomorrow, friday             17:00 on that day
+//	noon friday, 3pm tomorrow    the given time on that day (today if omitted)
+//	midnight friday              00:00 at the end of that day
+//	within 2 business days       now plus two business days, same wall time
+//	in 4 hours, within 3 days    now plus the given amount
+//
+// Day names refer to the next occurrence of that day, which is today if the
+// deadline has not passed yet. Matching is case-insensitive.
+func ParseDeadlinePhrase(phrase string, now time.Time, cal *BusinessCalendar, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = LocalLocation()
+	}
+	now = now.In(loc)
+
+	words := strings.Fields(strings.ToLower(strings.TrimSpace(phrase)))
+	if len(words) > 0 && (words[0] == "by" || words[0] == "before" || words[0] == "due") {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return time.Time{}, syntaxError("ParseDeadlinePhrase", phrase, "empty phrase")
+	}
+
+	text := strings.Join(words, " ")
+
+	switch text {
+	case "eod", "cob", "end of day", "close of business", "end of business":
+		return endOfBusiness(now, DateOf(now), cal, loc), nil
+	case "eow", "end of week", "end of the week":
+		return endOfWeek(now, cal, loc), nil
+	}
+
+	if words[0] == "within" || words[0] == "in" {
+		return parseRelativeDeadline(words[1:], now, cal, text)
+	}
+
+	// What remains is an optional time followed by an optional day.
+	clock, rest, hasTime := parseDeadlineClock(words)
+	day, hasDay, weekly, err := parseDeadlineDay(rest, now)
+	if err != nil || (!hasTime && !hasDay) {
+		return time.Time{}, syntaxError("ParseDeadlinePhrase", phrase, "")
+	}
+
+	if !hasTime {
+		clock = TimeOfDay{Hour: EndOfBusinessHour}
+	}
+
+	// Midnight ends the day it is given with, so it is 00:00 on the next.
+	if hasTime && words[0] == "midnight" {
+		day = day.AddDays(1)
+	}
+
+	deadline := clock.OnDate(day, loc)
+	switch {
+	case deadline.After(now):
+	case !hasDay:
+		deadline = clock.OnDate(day.AddDays(1), loc)
+	case weekly:
+		deadline = clock.OnDate(day.AddDays(7), loc)
+	}
+
+	return deadline, nil
+}
+
+// ParseDeadline is ParseDeadlinePhrase configured with options: the current
+// time comes from WithClock, business days from WithCalendar and the zone
+// from WithLocation, defaulting to the system clock, a Monday to Friday week
+// and LocalLocation.
+func ParseDeadline(phrase string, opts ...Option) (time.Time, error) {
+	o := NewOptions(opts...)
+
+	return ParseDeadlinePhrase(phrase, o.now(), o.Calendar, o.locationOr(nil))
+}
+
+// endOfBusiness returns the close of business on d, or on the next business
+// day if d is not one or its close has already passed.
+func endOfBusiness(now time.Time, d CivilDate, cal *BusinessCalendar, loc *time.Location) time.Time {
+	if !cal.IsBusinessDay(d) {
+		d = cal.NextBusinessDay(d)
+	}
+
+	deadline := d.At(EndOfBusinessHour, 0, 0, loc)
+	if !deadline.After(now) {
+		deadline = cal.NextBusinessDay(d).At(EndOfBusinessHour, 0, 0, loc)
+	}
+
+	return deadline
+}
+
+// endOfWeek returns the close of business on the last business day of the
+// current Monday-to-Sunday week, or of the next week if that has passed.
+func endOfWeek(now time.Time, cal *BusinessCalendar, loc *time.Location) time.Time {
+	today := DateOf(now)
+	sunday := today.AddDays((7 - int(today.Weekday())) % 7)
+
+	for week := 0; week < 2; week++ {
+		for d := sunday.AddDays(7 * week); !d.Before(today); d = d.AddDays(-1) {
+			if cal.IsBusinessDay(d) {
+				if deadline := d.At(EndOfBusinessHour, 0, 0, loc); deadline.After(now) {
+					return deadline
+				}
+				break
+			}
+		}
+	}
+
+	return endOfBusiness(now, today, cal, loc)
+}
+
+// parseRelativeDeadline handles "N unit" and "N business days".
+func parseRelativeDeadline(words []string, now time.Time, cal *BusinessCalendar, text string) (time.Time, error) {
+	if len(words) < 2 {
+		return time.Time{}, syntaxError("ParseDeadlinePhrase", text, "")
+	}
+
+	n, err := strconv.Atoi(words[0])
+	if words[0] == "a" || words[0] == "an" || words[0] == "one" {
+		n, err = 1, nil
+	}
+	if err != nil || n < 0 {
+		return time.Time{}, syntaxError("ParseDeadlinePhrase", text, "invalid amount")
+	}
+
+	unit := strings.Join(words[1:], " ")
+	unit = strings.TrimSuffix(unit, "s")
+
+	switch unit {
+	case "business day", "working day", "workday":
+		d := cal.AddBusinessDays(DateOf(now), n)
+		return d.At(now.Hour(), now.Minute(), now.Second(), now.Location()), nil
+	case "minute", "min":
+		return now.Add(time.Duration(n) * time.Minute), nil
+	case "hour", "hr":
+		return now.Add(time.Duration(n) * time.Hour), nil
+	case "day":
+		return now.AddDate(0, 0, n), nil
+	case "week":
+		return now.AddDate(0, 0, 7*n), nil
+	}
+
+	return time.Time{}, syntaxError("ParseDeadlinePhrase", text, "unknown unit "+strconv.Quote(unit))
+}
+
+// parseDeadlineClock consumes a leading time such as "noon", "3pm", "3:30 pm"
+// or "15:00" and returns the remaining words. "Midnight" is 00:00, which
+// ParseDeadlinePhrase takes at the end of the day rather than its start.
+func parseDeadlineClock(words []string) (clock TimeOfDay, rest []string, ok bool) {
+	if len(words) == 0 {
+		return TimeOfDay{}, words, false
+	}
+
+	if words[0] == "midnight" {
+		return Midnight, words[1:], true
+	}
+
+	if len(words) > 1 && (words[1] == "am" || words[1] == "pm") {
+		if clock, err := ParseTimeOfDay(words[0] + words[1]); err == nil {
+			return clock, words[2:], true
+		}
+	}
+
+	if clock, err := ParseTimeOfDay(words[0]); err == nil {
+		return clock, words[1:], true
+	}
+
+	return TimeOfDay{}, words, false
+}
+
+// parseDeadlineDay interprets what follows the time: nothing, "today",
+// "tomorrow" or a weekday name, optionally preceded by "on" or "next". The
+// weekly result reports whether the day was given by name.
+func parseDeadlineDay(words []string, now time.Time) (day CivilDate, ok, weekly bool, err error) {
+	today := DateOf(now)
+
+	if len(words) > 0 && words[0] == "on" {
+		words = words[1:]
+	}
+
+	next := false
+	if len(words) > 0 && words[0] == "next" {
+		next, words = true, words[1:]
+	}
+
+	switch {
+	case len(words) == 0 && !next:
+		return today, false, false, nil
+	case len(words) != 1:
+		return CivilDate{}, false, false, ErrSyntax
+	case words[0] == "today":
+		return today, true, false, nil
+	case words[0] == "tomorrow":
+		return today.AddDays(1), true, false, nil
+	}
+
+	for wd, name := range Weekdays {
+		name = strings.ToLower(name)
+		if words[0] == name || words[0] == name[:3] {
+			ahead := (wd - int(today.Weekday()) + 7) % 7
+			if next && ahead == 0 {
+				ahead = 7
+			}
+			return today.AddDays(ahead), true, true, nil
+		}
+	}
+
+	return CivilDate{}, false, false, ErrSyntax
+}
@@ -0,0 +1,50 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseDeadlinePhrase evaluates a set of phrases on a Thursday afternoon
+// in a calendar where the following Monday is a holiday.
+func TestParseDeadlinePhrase(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2024, time.May, 2, 14, 0, 0, 0, loc) // Thursday
+	cal := NewBusinessCalendar(CivilDate{2024, time.May, 6})
+
+	tests := []struct {
+		phrase   string
+		expected time.Time
+	}{
+		{"by EOD", time.Date(2024, time.May, 2, 17, 0, 0, 0, loc)},
+		{"end of week", time.Date(2024, time.May, 3, 17, 0, 0, 0, loc)},
+		{"by noon Friday", time.Date(2024, time.May, 3, 12, 0, 0, 0, loc)},
+		{"by 3pm tomorrow", time.Date(2024, time.May, 3, 15, 0, 0, 0, loc)},
+		{"by 9:30 am", time.Date(2024, time.May, 3, 9, 30, 0, 0, loc)},
+		{"by midnight", time.Date(2024, time.May, 3, 0, 0, 0, 0, loc)},
+		{"midnight friday", time.Date(2024, time.May, 4, 0, 0, 0, 0, loc)},
+		{"midnight thursday", time.Date(2024, time.May, 3, 0, 0, 0, 0, loc)},
+		{"Thursday", time.Date(2024, time.May, 2, 17, 0, 0, 0, loc)},
+		{"noon thursday", time.Date(2024, time.May, 9, 12, 0, 0, 0, loc)},
+		{"within 2 business days", time.Date(2024, time.May, 7, 14, 0, 0, 0, loc)},
+		{"in 4 hours", time.Date(2024, time.May, 2, 18, 0, 0, 0, loc)},
+	}
+
+	for _, test := range tests {
+		actual, err := ParseDeadlinePhrase(test.phrase, now, cal, loc)
+		if err != nil {
+			t.Errorf("ParseDeadlinePhrase(%q) returned error: %v", test.phrase, err)
+			continue
+		}
+
+		if !actual.Equal(test.expected) {
+			t.Errorf("ParseDeadlinePhrase(%q) = %v, expected %v", test.phrase, actual, test.expected)
+		}
+	}
+
+	for _, phrase := range []string{"", "by the heat death of the universe", "within two fortnights", "13pm friday"} {
+		if _, err := ParseDeadlinePhrase(phrase, now, cal, loc); err == nil {
+			t.Errorf("ParseDeadlinePhrase(%q) returned no error", phrase)
+		}
+	}
+}
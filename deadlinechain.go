@@ -0,0 +1,138 @@
+package temporalis
+
+import "slices"
+
+// DayCount selects how the days of a deadline in a DeadlineChain are
+// counted.
+type DayCount int
+
+const (
+	// CalendarDayCount counts every day, and moves a deadline that falls on a
+	// weekend or holiday to the next business day, as court rules such as
+	// the United States Federal Rules of Civil Procedure do.
+	CalendarDayCount DayCount = iota
+	// BusinessDayCount counts only business days, skipping weekends and
+	// holidays, as service level agreements usually do.
+	BusinessDayCount
+)
+
+// Deadline is one materialized date of a DeadlineChain.
+type Deadline struct {
+	// Name identifies the step, such as "answer due".
+	Name string
+	// After names the step or trigger the deadline is counted from.
+	After string
+	// Date is the deadline, or the recorded date if Recorded is true.
+	Date CivilDate
+	// Recorded reports whether Date was recorded with DeadlineChain.Record
+	// rather than computed.
+	Recorded bool
+}
+
+// DeadlineChain computes a sequence of dependent deadlines from a single
+// trigger date, such as the deadlines of a lawsuit from the date a complaint
+// is filed:
+//
+//	chain := temporalis.NewDeadlineChain("filed", courtHolidays).
+//		Within("served", 14, temporalis.CalendarDayCount, "filed").
+//		Within("answer due", 21, temporalis.CalendarDayCount, "served")
+//	deadlines := chain.Deadlines(filingDate)
+//
+// When a step actually happens on a different date, Record it, and the steps
+// counted from it are computed from that date instead. Days are never
+// counted on the date of the step they follow. Business days come from the
+// calendar, which may be nil for a plain Monday to Friday week. A
+// DeadlineChain is not safe for concurrent use.
+type DeadlineChain struct {
+	trigger  string
+	cal      *BusinessCalendar
+	steps    []deadlineStep
+	recorded map[string]CivilDate
+}
+
+// deadlineStep is one step of a DeadlineChain.
+type deadlineStep struct {
+	name  string
+	after string
+	days  int
+	count DayCount
+}
+
+// NewDeadlineChain returns an empty chain whose trigger, the event that
+// starts all the deadlines, is called trigger.
+func NewDeadlineChain(trigger string, cal *BusinessCalendar) *DeadlineChain {
+	return &DeadlineChain{trigger: trigger, cal: cal, recorded: make(map[string]CivilDate)}
+}
+
+// Within adds a step that is due days after the trigger or an earlier step,
+// counted as count says, and returns the chain. It panics if name is already
+// in use or after is neither the trigger nor an earlier step, so that the
+// steps are always in dependency order.
+func (c *DeadlineChain) Within(name string, days int, count DayCount, after string) *DeadlineChain {
+	if c.known(name) {
+		panic("temporalis: duplicate deadline " + name)
+	}
+	if !c.known(after) {
+		panic("temporalis: unknown deadline " + after)
+	}
+
+	c.steps = append(c.steps, deadlineStep{name: name, after: after, days: days, count: count})
+
+	return c
+}
+
+// Record sets the date on which a step actually happened, such as the day a
+// document was served, so that the deadlines that follow it are computed
+// from that date. Recording the zero date removes a recorded date. It
+// returns the chain, and panics if name is not a step of the chain.
+func (c *DeadlineChain) Record(name string, d CivilDate) *DeadlineChain {
+	if name == c.trigger || !c.known(name) {
+		panic("temporalis: unknown deadline " + name)
+	}
+
+	if d.IsZero() {
+		delete(c.recorded, name)
+	} else {
+		c.recorded[name] = d
+	}
+
+	return c
+}
+
+// Deadlines returns the date of every step when the trigger happens on the
+// given date, in the order the steps were added.
+func (c *DeadlineChain) Deadlines(trigger CivilDate) []Deadline {
+	dates := map[string]CivilDate{c.trigger: trigger}
+
+	deadlines := make([]Deadline, 0, len(c.steps))
+	for _, s := range c.steps {
+		d, recorded := c.recorded[s.name]
+		if !recorded {
+			d = c.due(dates[s.after], s)
+		}
+		dates[s.name] = d
+
+		deadlines = append(deadlines, Deadline{Name: s.name, After: s.after, Date: d, Recorded: recorded})
+	}
+
+	return deadlines
+}
+
+// due returns the deadline of a step that follows an event on from.
+func (c *DeadlineChain) due(from CivilDate, s deadlineStep) CivilDate {
+	if s.count == BusinessDayCount {
+		return c.cal.AddBusinessDays(from, s.days)
+	}
+
+	d := from.AddDays(s.days)
+	if !c.cal.IsBusinessDay(d) {
+		d = c.cal.NextBusinessDay(d)
+	}
+
+	return d
+}
+
+// known reports whether name is the trigger or a step of the chain.
+func (c *DeadlineChain) known(name string) bool {
+	return name == c.trigger || slices.ContainsFunc(c.steps, func(s deadlineStep) bool { return s.name == name })
+}
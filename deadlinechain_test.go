@@ -0,0 +1,77 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDeadlineChain tests a chain of court deadlines across a holiday, and
+// its recomputation when service happens later than required.
+func TestDeadlineChain(t *testing.T) {
+	cal := NewBusinessCalendar()
+	cal.AddHoliday(CivilDate{2024, time.April, 5}, "Court holiday")
+
+	chain := NewDeadlineChain("filed", cal).
+		Within("served", 14, CalendarDayCount, "filed").
+		Within("answer", 21, CalendarDayCount, "served").
+		Within("reply", 5, BusinessDayCount, "answer")
+
+	check := func(name string, expected ...CivilDate) {
+		t.Helper()
+
+		deadlines := chain.Deadlines(CivilDate{2024, time.March, 1})
+		if len(deadlines) != len(expected) {
+			t.Fatalf("Deadlines(%s) = %v, expected %v", name, deadlines, expected)
+		}
+		for i, d := range deadlines {
+			if d.Date != expected[i] {
+				t.Errorf("Deadlines(%s)[%s] = %v, expected %v", name, d.Name, d.Date, expected[i])
+			}
+		}
+	}
+
+	// The answer would be due on the holiday, so it moves to Monday.
+	check("computed", CivilDate{2024, time.March, 15}, CivilDate{2024, time.April, 8}, CivilDate{2024, time.April, 15})
+
+	chain.Record("served", CivilDate{2024, time.March, 20})
+	check("recorded", CivilDate{2024, time.March, 20}, CivilDate{2024, time.April, 10}, CivilDate{2024, time.April, 17})
+
+	if d := chain.Deadlines(CivilDate{2024, time.March, 1})[0]; !d.Recorded || d.After != "filed" {
+		t.Errorf("Deadlines()[0] = %+v, expected recorded after filed", d)
+	}
+
+	chain.Record("served", CivilDate{})
+	check("cleared", CivilDate{2024, time.March, 15}, CivilDate{2024, time.April, 8}, CivilDate{2024, time.April, 15})
+}
+
+// TestDeadlineChainWeekend tests that a calendar-day deadline on a weekend
+// moves to Monday with the default calendar.
+func TestDeadlineChainWeekend(t *testing.T) {
+	chain := NewDeadlineChain("notice", nil).Within("response", 14, CalendarDayCount, "notice")
+
+	if actual, expected := chain.Deadlines(CivilDate{2024, time.March, 2})[0].Date, (CivilDate{2024, time.March, 18}); actual != expected {
+		t.Errorf("Deadlines() = %v, expected %v", actual, expected)
+	}
+}
+
+// TestDeadlineChainPanics tests that steps must follow known steps and have
+// unique names.
+func TestDeadlineChainPanics(t *testing.T) {
+	tests := map[string]func(c *DeadlineChain){
+		"duplicate": func(c *DeadlineChain) { c.Within("a", 1, CalendarDayCount, "start") },
+		"unknown":   func(c *DeadlineChain) { c.Within("b", 1, CalendarDayCount, "missing") },
+		"trigger":   func(c *DeadlineChain) { c.Within("start", 1, CalendarDayCount, "a") },
+		"record":    func(c *DeadlineChain) { c.Record("missing", CivilDate{2024, time.March, 1}) },
+	}
+
+	for name, fn := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			fn(NewDeadlineChain("start", nil).Within("a", 1, CalendarDayCount, "start"))
+		}()
+	}
+}
@@ -0,0 +1,260 @@
+package temporalis
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// ioResult is the outcome of a read or write running in the background.
+type ioResult struct {
+	n   int
+	err error
+}
+
+// awaitIO waits up to timeout on c for the result of a background read or
+// write. It returns false if the timeout passed first, together with the
+// deadline that passed.
+func awaitIO(c Clock, timeout time.Duration, done <-chan ioResult) (ioResult, time.Time, bool) {
+	deadline := c.Now().Add(timeout)
+	expired := make(chan struct{})
+	timer := c.AfterFunc(timeout, func() { close(expired) })
+	defer timer.Stop()
+
+	select {
+	case res := <-done:
+		return res, deadline, true
+	case <-expired:
+		return ioResult{}, deadline, false
+	}
+}
+
+// TimeoutReader is an io.Reader that gives up on a read that makes no
+// progress within a timeout, for readers such as pipes and serial ports
+// that have no deadlines of their own. A read that times out keeps running
+// in the background and its data is returned by the next Read, so nothing
+// is lost and reading can resume after a timeout. Reads are serialized.
+type TimeoutReader struct {
+	r       io.Reader
+	timeout time.Duration
+	clock   Clock
+
+	mu      sync.Mutex
+	pending chan ioResult
+	buf     []byte
+	rest    []byte
+	err     error
+}
+
+// NewTimeoutReader returns a TimeoutReader that reads from r, giving up on
+// each Read after timeout as measured by c, or the system clock if c is nil.
+func NewTimeoutReader(r io.Reader, timeout time.Duration, c Clock) *TimeoutReader {
+	return &TimeoutReader{r: r, timeout: timeout, clock: clockOrSystem(c)}
+}
+
+// Read implements io.Reader. If the underlying reader returns nothing
+// within the timeout, Read returns a *TimeoutError.
+func (r *TimeoutReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.rest) == 0 && r.err == nil {
+		if r.pending == nil {
+			r.buf = make([]byte, len(p))
+			r.pending = make(chan ioResult, 1)
+			go func(buf []byte, done chan<- ioResult) {
+				n, err := r.r.Read(buf)
+				done <- ioResult{n, err}
+			}(r.buf, r.pending)
+		}
+
+		res, deadline, ok := awaitIO(r.clock, r.timeout, r.pending)
+		if !ok {
+			return 0, &TimeoutError{Deadline: deadline}
+		}
+
+		r.pending = nil
+		r.rest, r.err = r.buf[:res.n], res.err
+	}
+
+	n := copy(p, r.rest)
+	r.rest = r.rest[n:]
+	if len(r.rest) > 0 {
+		return n, nil
+	}
+
+	err := r.err
+	r.err = nil
+
+	return n, err
+}
+
+// TimeoutWriter is an io.Writer that gives up on a write that does not
+// complete within a timeout, for writers that have no deadlines of their
+// own. Since a write that times out may still complete in the background,
+// the stream is then in an unknown state, so every later Write fails with
+// the same error.
+type TimeoutWriter struct {
+	w       io.Writer
+	timeout time.Duration
+	clock   Clock
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewTimeoutWriter returns a TimeoutWriter that writes to w, giving up on
+// each Write after timeout as measured by c, or the system clock if c is
+// nil.
+func NewTimeoutWriter(w io.Writer, timeout time.Duration, c Clock) *TimeoutWriter {
+	return &TimeoutWriter{w: w, timeout: timeout, clock: clockOrSystem(c)}
+}
+
+// Write implements io.Writer. If the underlying write does not complete
+// within the timeout, Write returns a *TimeoutError.
+func (w *TimeoutWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	// The caller may reuse p once Write returns, which it does on a timeout
+	// while the write is still running.
+	buf := append([]byte(nil), p...)
+	done := make(chan ioResult, 1)
+	go func() {
+		n, err := w.w.Write(buf)
+		done <- ioResult{n, err}
+	}()
+
+	res, deadline, ok := awaitIO(w.clock, w.timeout, done)
+	if !ok {
+		w.err = &TimeoutError{Deadline: deadline}
+		return 0, w.err
+	}
+
+	return res.n, res.err
+}
+
+// IdleTimeoutConn is a net.Conn that is closed for reading and writing once
+// no data has been read or written for an idle timeout, the usual guard
+// against peers that stop responding without closing the connection. Each
+// read or write that transfers data restarts the timeout. The timeout runs
+// on a Clock rather than on the connection's own deadlines, so it can be
+// tested with a FakeClock; when it expires, the connection's deadline is
+// moved into the past to abort blocked calls, which overrides any deadline
+// set with SetDeadline. Reads and writes after that return a
+// *TimeoutError.
+type IdleTimeoutConn struct {
+	net.Conn
+
+	idle  time.Duration
+	clock Clock
+
+	mu       sync.Mutex
+	timer    Timer
+	deadline time.Time
+	expired  bool
+}
+
+// NewIdleTimeoutConn returns conn wrapped to expire after idle without
+// activity as measured by c, or the system clock if c is nil. The timeout
+// starts immediately.
+func NewIdleTimeoutConn(conn net.Conn, idle time.Duration, c Clock) *IdleTimeoutConn {
+	ic := &IdleTimeoutConn{Conn: conn, idle: idle, clock: clockOrSystem(c)}
+
+	ic.mu.Lock()
+	ic.resetLocked()
+	ic.mu.Unlock()
+
+	return ic
+}
+
+// Read implements net.Conn.
+func (c *IdleTimeoutConn) Read(p []byte) (int, error) {
+	if err := c.check(); err != nil {
+		return 0, err
+	}
+
+	n, err := c.Conn.Read(p)
+
+	return n, c.done(n, err)
+}
+
+// Write implements net.Conn.
+func (c *IdleTimeoutConn) Write(p []byte) (int, error) {
+	if err := c.check(); err != nil {
+		return 0, err
+	}
+
+	n, err := c.Conn.Write(p)
+
+	return n, c.done(n, err)
+}
+
+// Close stops the idle timeout and closes the connection.
+func (c *IdleTimeoutConn) Close() error {
+	c.mu.Lock()
+	c.timer.Stop()
+	c.mu.Unlock()
+
+	return c.Conn.Close()
+}
+
+// check returns the timeout error if the connection has expired.
+func (c *IdleTimeoutConn) check() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.expired {
+		return &TimeoutError{Deadline: c.deadline}
+	}
+
+	return nil
+}
+
+// done restarts the timeout after a call that transferred n bytes and
+// returned err, and returns err or, if the call was aborted by the
+// timeout, a *TimeoutError wrapping it.
+func (c *IdleTimeoutConn) done(n int, err error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.expired {
+		if err != nil {
+			return &TimeoutError{Deadline: c.deadline, Err: err}
+		}
+		return nil
+	}
+
+	if n > 0 {
+		c.resetLocked()
+	}
+
+	return err
+}
+
+// resetLocked restarts the idle timeout. c.mu must be held.
+func (c *IdleTimeoutConn) resetLocked() {
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+
+	c.deadline = c.clock.Now().Add(c.idle)
+
+	var timer Timer
+	timer = c.clock.AfterFunc(c.idle, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.timer != timer {
+			return
+		}
+		c.expired = true
+		c.Conn.SetDeadline(time.Unix(1, 0))
+	})
+	c.timer = timer
+}
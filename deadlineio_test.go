@@ -0,0 +1,121 @@
+package temporalis
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestTimeoutReader tests that a stalled read times out and that its data
+// is returned by the next read.
+func TestTimeoutReader(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	pr, pw := io.Pipe()
+	r := NewTimeoutReader(pr, time.Second, clock)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := r.Read(make([]byte, 8))
+		errs <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if err := <-errs; !errors.Is(err, ErrTimeout) {
+		t.Errorf("Read() error = %v, expected %v", err, ErrTimeout)
+	}
+
+	go pw.Write([]byte("hello"))
+
+	buf := make([]byte, 3)
+	if n, err := r.Read(buf); err != nil || string(buf[:n]) != "hel" {
+		t.Errorf("Read() = %q, %v, expected %q", buf[:n], err, "hel")
+	}
+	if n, err := r.Read(buf); err != nil || string(buf[:n]) != "lo" {
+		t.Errorf("Read() = %q, %v, expected %q", buf[:n], err, "lo")
+	}
+
+	pw.Close()
+	if _, err := r.Read(buf); err != io.EOF {
+		t.Errorf("Read() after close = %v, expected %v", err, io.EOF)
+	}
+}
+
+// TestTimeoutWriter tests that a stalled write times out and that the
+// writer stays failed.
+func TestTimeoutWriter(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	w := NewTimeoutWriter(pw, time.Second, clock)
+
+	go func() {
+		buf := make([]byte, 2)
+		io.ReadFull(pr, buf)
+	}()
+	if n, err := w.Write([]byte("ok")); n != 2 || err != nil {
+		t.Errorf("Write() = %d, %v, expected 2, nil", n, err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := w.Write([]byte("stalled"))
+		errs <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if err := <-errs; !errors.Is(err, ErrTimeout) {
+		t.Errorf("Write() error = %v, expected %v", err, ErrTimeout)
+	}
+	if _, err := w.Write([]byte("more")); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Write() after a timeout error = %v, expected %v", err, ErrTimeout)
+	}
+}
+
+// TestIdleTimeoutConn tests that activity restarts the idle timeout and
+// that blocked calls are aborted when it expires.
+func TestIdleTimeoutConn(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, server := net.Pipe()
+	defer server.Close()
+	conn := NewIdleTimeoutConn(client, time.Minute, clock)
+	defer conn.Close()
+
+	clock.Advance(50 * time.Second)
+	go server.Write([]byte("ping"))
+	buf := make([]byte, 4)
+	if n, err := conn.Read(buf); err != nil || string(buf[:n]) != "ping" {
+		t.Errorf("Read() = %q, %v, expected %q", buf[:n], err, "ping")
+	}
+
+	clock.Advance(50 * time.Second)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := conn.Read(buf)
+		errs <- err
+	}()
+
+	clock.Advance(10 * time.Second)
+	err := <-errs
+	var timeout *TimeoutError
+	if !errors.As(err, &timeout) {
+		t.Errorf("Read() error = %v, expected a timeout", err)
+	} else if expected := clock.Now(); !timeout.Deadline.Equal(expected) {
+		t.Errorf("Read() deadline = %v, expected %v", timeout.Deadline, expected)
+	}
+
+	// Whether or not the read above had started, the expiry must have
+	// moved the deadline of the connection to abort blocked calls.
+	if _, err := client.Read(buf); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Read() on the underlying connection = %v, expected %v", err, os.ErrDeadlineExceeded)
+	}
+
+	if _, err := conn.Write([]byte("pong")); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Write() after expiry = %v, expected %v", err, ErrTimeout)
+	}
+}
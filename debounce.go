@@ -0,0 +1,184 @@
+package temporalis
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer coalesces bursts of calls into a single call of a function made
+// once the calls have stopped for a quiet period. It is safe for concurrent
+// use.
+type Debouncer struct {
+	// Clock provides the timers. If nil, SystemClock is used. It must be
+	// set before the first call.
+	Clock Clock
+
+	fn      func()
+	wait    time.Duration
+	mu      sync.Mutex
+	timer   Timer
+	stopped bool
+}
+
+// Debounce returns a debouncer that calls fn once d has passed without a
+// further call to Call. Each Call restarts the quiet period.
+func Debounce(d time.Duration, fn func()) *Debouncer {
+	return &Debouncer{fn: fn, wait: d}
+}
+
+// Call schedules fn for the end of the quiet period, cancelling the call
+// scheduled by the previous Call. It does nothing after Stop.
+func (d *Debouncer) Call() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return
+	}
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+
+	var timer Timer
+	timer = clockOrSystem(d.Clock).AfterFunc(d.wait, func() {
+		d.mu.Lock()
+		if d.timer != timer {
+			d.mu.Unlock()
+			return
+		}
+		d.timer = nil
+		d.mu.Unlock()
+
+		d.fn()
+	})
+	d.timer = timer
+}
+
+// Flush calls fn immediately if a call is pending and cancels the pending
+// call. It reports whether fn was called.
+func (d *Debouncer) Flush() bool {
+	d.mu.Lock()
+	pending := d.timer != nil
+	if pending {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+
+	if pending {
+		d.fn()
+	}
+
+	return pending
+}
+
+// Stop cancels the pending call, if any, and makes further calls to Call
+// do nothing.
+func (d *Debouncer) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopped = true
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
+
+// Throttler limits calls of a function to at most one per interval. The
+// first call in an interval runs at once; later calls in the same interval
+// are coalesced into a single trailing call at the end of the interval. It
+// is safe for concurrent use.
+type Throttler struct {
+	// Clock provides the time and the timers. If nil, SystemClock is used.
+	// It must be set before the first call.
+	Clock Clock
+
+	fn       func()
+	interval time.Duration
+	mu       sync.Mutex
+	last     time.Time
+	ran      bool
+	timer    Timer
+	stopped  bool
+}
+
+// Throttle returns a throttler that calls fn at most once every d.
+func Throttle(d time.Duration, fn func()) *Throttler {
+	return &Throttler{fn: fn, interval: d}
+}
+
+// Call runs fn now if it has not run within the interval, and otherwise
+// makes sure it runs once at the end of the interval. It does nothing after
+// Stop.
+func (t *Throttler) Call() {
+	clock := clockOrSystem(t.Clock)
+
+	t.mu.Lock()
+	if t.stopped || t.timer != nil {
+		t.mu.Unlock()
+		return
+	}
+
+	now := clock.Now()
+	if wait := t.interval - now.Sub(t.last); t.ran && wait > 0 {
+		var timer Timer
+		timer = clock.AfterFunc(wait, func() { t.trailing(timer) })
+		t.timer = timer
+		t.mu.Unlock()
+		return
+	}
+
+	t.last, t.ran = now, true
+	t.mu.Unlock()
+
+	t.fn()
+}
+
+// trailing runs the call coalesced during an interval, unless timer has
+// been stopped or flushed in the meantime.
+func (t *Throttler) trailing(timer Timer) {
+	t.mu.Lock()
+	if t.timer != timer {
+		t.mu.Unlock()
+		return
+	}
+	t.timer = nil
+	t.last = clockOrSystem(t.Clock).Now()
+	t.mu.Unlock()
+
+	t.fn()
+}
+
+// Flush runs the pending trailing call immediately, if any, and reports
+// whether fn was called. The interval restarts from the flush.
+func (t *Throttler) Flush() bool {
+	t.mu.Lock()
+	pending := t.timer != nil
+	if pending {
+		t.timer.Stop()
+		t.timer = nil
+		t.last = clockOrSystem(t.Clock).Now()
+	}
+	t.mu.Unlock()
+
+	if pending {
+		t.fn()
+	}
+
+	return pending
+}
+
+// Stop cancels the pending trailing call, if any, and makes further calls to
+// Call do nothing.
+func (t *Throttler) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
@@ -0,0 +1,74 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDebounce checks that a burst of calls results in one call after the
+// quiet period, and that Flush and Stop act on the pending call.
+func TestDebounce(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	calls := 0
+	d := Debounce(time.Second, func() { calls++ })
+	d.Clock = clock
+
+	for i := 0; i < 5; i++ {
+		d.Call()
+		clock.Advance(500 * time.Millisecond)
+	}
+
+	if calls != 0 {
+		t.Errorf("debounced function called %d times during the burst, expected 0", calls)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if calls != 1 {
+		t.Errorf("debounced function called %d times after the burst, expected 1", calls)
+	}
+
+	d.Call()
+	if !d.Flush() || calls != 2 {
+		t.Errorf("Flush() did not call the pending function")
+	}
+
+	d.Call()
+	d.Stop()
+	clock.Advance(time.Minute)
+	if calls != 2 {
+		t.Errorf("debounced function called after Stop()")
+	}
+}
+
+// TestThrottle checks leading and trailing calls within an interval.
+func TestThrottle(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	calls := 0
+	th := Throttle(time.Second, func() { calls++ })
+	th.Clock = clock
+
+	th.Call()
+	th.Call()
+	th.Call()
+	if calls != 1 {
+		t.Errorf("throttled function called %d times at once, expected 1", calls)
+	}
+
+	clock.Advance(time.Second)
+	if calls != 2 {
+		t.Errorf("throttled function called %d times after the interval, expected 2", calls)
+	}
+
+	th.Call()
+	if !th.Flush() || calls != 3 {
+		t.Errorf("Flush() did not call the pending function")
+	}
+
+	clock.Advance(2 * time.Second)
+	th.Call()
+	if calls != 4 {
+		t.Errorf("throttled function called %d times after a quiet interval, expected 4", calls)
+	}
+}
@@ -0,0 +1,45 @@
+package temporalis
+
+import "time"
+
+// DayRange is an inclusive range of day counts, such as "3 to 5 days".
+type DayRange struct {
+	Min int
+	Max int
+}
+
+// DeliveryEstimate is the result of EstimateDelivery.
+type DeliveryEstimate struct {
+	// Effective is the business day on which the order is considered
+	// received, after applying the cutoff.
+	Effective CivilDate
+	// Ship is the day on which the order leaves the warehouse.
+	Ship CivilDate
+	// Earliest and Latest bound the delivery date, both inclusive.
+	Earliest CivilDate
+	Latest   CivilDate
+}
+
+// EstimateDelivery estimates when an order placed at orderTime arrives. The
+// cutoff policy decides on which business day the order is received, using
+// its own calendar for the warehouse. The order then spends processingDays
+// business days in the warehouse before shipping, and between transitDays.Min
+// and transitDays.Max business days of the carrier calendar cal in transit,
+// so regional holidays at the destination push the estimate out. A nil cal
+// means Monday to Friday without holidays. If transitDays.Max is less than
+// transitDays.Min, Min is used for both ends.
+func EstimateDelivery(orderTime time.Time, processingDays int, transitDays DayRange, cal *BusinessCalendar, cutoff CutoffPolicy) DeliveryEstimate {
+	effective := cutoff.EffectiveDate(orderTime)
+	ship := cutoff.Calendar.AddBusinessDays(effective, processingDays)
+
+	if transitDays.Max < transitDays.Min {
+		transitDays.Max = transitDays.Min
+	}
+
+	return DeliveryEstimate{
+		Effective: effective,
+		Ship:      ship,
+		Earliest:  cal.AddBusinessDays(ship, transitDays.Min),
+		Latest:    cal.AddBusinessDays(ship, transitDays.Max),
+	}
+}
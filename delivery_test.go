@@ -0,0 +1,27 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEstimateDelivery places an order after the Thursday cutoff with a
+// carrier holiday on the following Tuesday.
+func TestEstimateDelivery(t *testing.T) {
+	cutoff := CutoffPolicy{Cutoff: TimeOfDay{Hour: 15}}
+	carrier := NewBusinessCalendar(CivilDate{2024, time.May, 7})
+
+	order := time.Date(2024, time.May, 2, 16, 0, 0, 0, time.UTC) // Thursday, after cutoff
+	estimate := EstimateDelivery(order, 1, DayRange{Min: 1, Max: 3}, carrier, cutoff)
+
+	expected := DeliveryEstimate{
+		Effective: CivilDate{2024, time.May, 3},
+		Ship:      CivilDate{2024, time.May, 6},
+		Earliest:  CivilDate{2024, time.May, 8},
+		Latest:    CivilDate{2024, time.May, 10},
+	}
+
+	if estimate != expected {
+		t.Errorf("EstimateDelivery() = %+v, expected %+v", estimate, expected)
+	}
+}
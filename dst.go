@@ -0,0 +1,284 @@
+package temporalis
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+// AmbiguityPolicy selects which instant is used when a wall-clock time occurs
+// twice in a location, as happens when clocks "fall back" at the end of
+// daylight saving time.
+type AmbiguityPolicy int
+
+const (
+	// PreferEarlier resolves an ambiguous wall-clock time to the first of the
+	// two instants, which is the one still observing the old offset.
+	PreferEarlier AmbiguityPolicy = iota
+	// PreferLater resolves an ambiguous wall-clock time to the second of the
+	// two instants, which is the one observing the new offset.
+	PreferLater
+	// RejectAmbiguous makes ResolveAmbiguous return an error wrapping
+	// ErrAmbiguousTime instead of picking one of the two instants.
+	RejectAmbiguous
+	// RejectInvalid is like RejectAmbiguous and also makes ResolveAmbiguous
+	// return an error wrapping ErrNonexistentTime for a wall-clock time that
+	// falls into a gap, instead of shifting it forward.
+	RejectInvalid
+)
+
+// dstSearchLimit bounds how far NextDSTTransition looks ahead. Zones that do
+// not change offset within this window are reported as having no transition.
+const dstSearchLimit = 2 * 366 * 24 * time.Hour
+
+// NextDSTTransition returns the first instant strictly after the given time at
+// which the UTC offset of loc changes. The boolean result is false if loc does
+// not change its offset within the next two years, which is the case for UTC
+// and for zones that have abolished daylight saving time.
+// The returned time is expressed in loc and is exact to the second.
+func NextDSTTransition(loc *time.Location, after time.Time) (time.Time, bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	start := after.In(loc)
+	_, offset := start.Zone()
+
+	step := 24 * time.Hour
+	prev := start
+
+	for elapsed := time.Duration(0); elapsed < dstSearchLimit; elapsed += step {
+		next := prev.Add(step)
+		if _, o := next.Zone(); o != offset {
+			return bisectTransition(prev, next, offset), true
+		}
+		prev = next
+	}
+
+	return time.Time{}, false
+}
+
+// DSTTransition is a change of the UTC offset of a location. Offsets are in
+// seconds east of UTC, as returned by time.Time.Zone.
+type DSTTransition struct {
+	// At is the first instant observing the new offset, expressed in the
+	// location.
+	At        time.Time
+	OldName   string
+	OldOffset int
+	NewName   string
+	NewOffset int
+}
+
+// Shift returns how far wall clocks move at the transition: positive when
+// they spring forward, negative when they fall back.
+func (t DSTTransition) Shift() time.Duration {
+	return time.Duration(t.NewOffset-t.OldOffset) * time.Second
+}
+
+// DSTTransitions yields the transitions of loc in [start, end), in order. It
+// finds them with NextDSTTransition, so a stretch of more than two years
+// without a transition ends the sequence early.
+func DSTTransitions(loc *time.Location, start, end time.Time) iter.Seq[DSTTransition] {
+	return func(yield func(DSTTransition) bool) {
+		after := start.Add(-time.Second)
+		for {
+			tr, ok := nextDSTTransition(loc, after)
+			if !ok || !tr.At.Before(end) {
+				return
+			}
+
+			if !yield(tr) {
+				return
+			}
+			after = tr.At
+		}
+	}
+}
+
+// nextDSTTransition is like NextDSTTransition but also reports the zone
+// abbreviations and offsets on either side of the transition.
+func nextDSTTransition(loc *time.Location, after time.Time) (DSTTransition, bool) {
+	at, ok := NextDSTTransition(loc, after)
+	if !ok {
+		return DSTTransition{}, false
+	}
+
+	oldName, oldOffset := at.Add(-time.Second).Zone()
+	newName, newOffset := at.Zone()
+
+	return DSTTransition{At: at, OldName: oldName, OldOffset: oldOffset, NewName: newName, NewOffset: newOffset}, true
+}
+
+// bisectTransition narrows the window (lo, hi] down to the first second at
+// which the offset differs from offset.
+func bisectTransition(lo, hi time.Time, offset int) time.Time {
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if _, o := mid.Zone(); o == offset {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return hi.Truncate(time.Second)
+}
+
+// IsDST reports whether the given time is in daylight saving time in its
+// location. It is a convenience wrapper around time.Time.IsDST.
+func IsDST(t time.Time) bool {
+	return t.IsDST()
+}
+
+// IsAmbiguous reports whether the wall-clock reading of t (its year, month,
+// day, hour, minute, second and nanosecond fields) occurs twice in loc. This
+// happens during the repeated hour when clocks are turned back.
+func IsAmbiguous(t time.Time, loc *time.Location) bool {
+	return len(wallClockInstants(t, loc)) > 1
+}
+
+// IsSkipped reports whether the wall-clock reading of t does not exist in loc
+// at all. This happens during the missing hour when clocks are turned forward.
+func IsSkipped(t time.Time, loc *time.Location) bool {
+	return len(wallClockInstants(t, loc)) == 0
+}
+
+// ResolveAmbiguous interprets the wall-clock reading of t in loc and returns
+// the matching instant. When the reading occurs twice, policy decides which
+// of the two instants is returned; with RejectAmbiguous or RejectInvalid an
+// error wrapping ErrAmbiguousTime is returned instead. When the reading falls
+// into a gap it is shifted forward by the length of the gap, so 02:30 on a
+// spring-forward night becomes 03:30, unless policy is RejectInvalid, which
+// returns an error wrapping ErrNonexistentTime.
+func ResolveAmbiguous(t time.Time, loc *time.Location, policy AmbiguityPolicy) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	candidates := wallClockInstants(t, loc)
+
+	switch len(candidates) {
+	case 0:
+		if policy == RejectInvalid {
+			return time.Time{}, fmt.Errorf("%s in %s: %w", t.Format("2006-01-02 15:04:05"), loc, ErrNonexistentTime)
+		}
+		return shiftOverGap(t, loc), nil
+	case 1:
+		return candidates[0], nil
+	}
+
+	switch policy {
+	case PreferEarlier:
+		return candidates[0], nil
+	case PreferLater:
+		return candidates[1], nil
+	default:
+		return time.Time{}, fmt.Errorf("%s in %s: %w", t.Format("2006-01-02 15:04:05"), loc, ErrAmbiguousTime)
+	}
+}
+
+// SameWallClockIn returns the instant in loc with the same date and
+// wall-clock reading as t, so 09:00 in New York becomes 09:00 in London
+// rather than the 14:00 that t.In(loc) and ConvertTimezone give. This is
+// what is usually wanted when a user picks a time in the wrong zone or a
+// local schedule moves to another office. A reading that occurs twice in
+// loc resolves to the earlier instant, and one that does not exist is
+// shifted forward by the length of the gap. Use ResolveAmbiguous with
+// RejectInvalid to get an error in these cases instead. A nil loc means
+// UTC.
+func SameWallClockIn(t time.Time, loc *time.Location) time.Time {
+	// ResolveAmbiguous only fails for the rejecting policies.
+	r, _ := ResolveAmbiguous(t, loc, PreferEarlier)
+
+	return r
+}
+
+// AddDateSafe adds the given number of years, months and days to t like
+// time.Time.AddDate, but keeps the wall-clock reading of t intact across DST
+// transitions. If the resulting wall-clock time occurs twice, the instant with
+// the same offset as t is preferred (falling back to the earlier one); if it
+// does not exist, it is shifted forward by the length of the gap.
+func AddDateSafe(t time.Time, years, months, days int) time.Time {
+	loc := t.Location()
+	y, m, d := t.Date()
+
+	// Normalise the date in UTC so the calendar arithmetic is not affected by
+	// the offset in effect on the target day.
+	target := time.Date(y+years, m+time.Month(months), d+days, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+
+	candidates := wallClockInstants(target, loc)
+
+	switch len(candidates) {
+	case 0:
+		return shiftOverGap(target, loc)
+	case 1:
+		return candidates[0]
+	}
+
+	_, offset := t.Zone()
+	for _, c := range candidates {
+		if _, o := c.Zone(); o == offset {
+			return c
+		}
+	}
+
+	return candidates[0]
+}
+
+// wallClockInstants returns every instant, in chronological order, whose
+// wall-clock reading in loc equals the wall-clock reading of t. The result has
+// zero elements for skipped times, two for repeated times and one otherwise.
+func wallClockInstants(t time.Time, loc *time.Location) []time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+
+	// Any offset that can apply to this wall time is in effect somewhere
+	// within a day on either side of it.
+	var offsets []int
+	for _, probe := range []time.Time{wall.Add(-24 * time.Hour), wall, wall.Add(24 * time.Hour)} {
+		_, o := probe.In(loc).Zone()
+		if !slices.Contains(offsets, o) {
+			offsets = append(offsets, o)
+		}
+	}
+
+	var instants []time.Time
+	for _, o := range offsets {
+		candidate := wall.Add(-time.Duration(o) * time.Second).In(loc)
+		if sameWallClock(candidate, wall) && !slices.ContainsFunc(instants, candidate.Equal) {
+			instants = append(instants, candidate)
+		}
+	}
+
+	if len(instants) == 2 && instants[1].Before(instants[0]) {
+		instants[0], instants[1] = instants[1], instants[0]
+	}
+
+	return instants
+}
+
+// shiftOverGap maps a wall-clock reading that does not exist in loc to the
+// reading that is later by the length of the gap.
+func shiftOverGap(t time.Time, loc *time.Location) time.Time {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+
+	_, before := wall.Add(-24 * time.Hour).In(loc).Zone()
+
+	return wall.Add(-time.Duration(before) * time.Second).In(loc)
+}
+
+// sameWallClock reports whether a and b have identical wall-clock fields,
+// ignoring their locations.
+func sameWallClock(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+
+	return ay == by && am == bm && ad == bd &&
+		a.Hour() == b.Hour() && a.Minute() == b.Minute() &&
+		a.Second() == b.Second() && a.Nanosecond() == b.Nanosecond()
+}
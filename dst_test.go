@@ -0,0 +1,138 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNextDSTTransition checks that the spring-forward transition in New York
+// is found to the second and that UTC reports no transition at all.
+func TestNextDSTTransition(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("America/New_York not available")
+	}
+
+	actual, ok := NextDSTTransition(loc, time.Date(2024, time.January, 1, 0, 0, 0, 0, loc))
+	expected := time.Date(2024, time.March, 10, 7, 0, 0, 0, time.UTC)
+
+	if !ok || !actual.Equal(expected) {
+		t.Errorf("NextDSTTransition() = %v, %v, expected %v, true", actual, ok, expected)
+	}
+
+	if _, ok := NextDSTTransition(time.UTC, time.Now()); ok {
+		t.Errorf("NextDSTTransition(UTC) reported a transition")
+	}
+}
+
+// TestAmbiguousAndSkipped checks the classification of wall-clock times around
+// both DST transitions in New York, and the resolution of those times.
+func TestAmbiguousAndSkipped(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("America/New_York not available")
+	}
+
+	skipped := time.Date(2024, time.March, 10, 2, 30, 0, 0, time.UTC)
+	repeated := time.Date(2024, time.November, 3, 1, 30, 0, 0, time.UTC)
+	normal := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	if !IsSkipped(skipped, loc) || IsAmbiguous(skipped, loc) {
+		t.Errorf("%v should be skipped", skipped)
+	}
+	if !IsAmbiguous(repeated, loc) || IsSkipped(repeated, loc) {
+		t.Errorf("%v should be ambiguous", repeated)
+	}
+	if IsAmbiguous(normal, loc) || IsSkipped(normal, loc) {
+		t.Errorf("%v should be neither skipped nor ambiguous", normal)
+	}
+
+	earlier, _ := ResolveAmbiguous(repeated, loc, PreferEarlier)
+	later, _ := ResolveAmbiguous(repeated, loc, PreferLater)
+	if later.Sub(earlier) != time.Hour || !earlier.IsDST() {
+		t.Errorf("ResolveAmbiguous() = %v and %v, expected EDT and EST one hour apart", earlier, later)
+	}
+
+	if _, err := ResolveAmbiguous(repeated, loc, RejectAmbiguous); err == nil {
+		t.Errorf("ResolveAmbiguous(RejectAmbiguous) returned no error")
+	}
+
+	shifted, _ := ResolveAmbiguous(skipped, loc, PreferEarlier)
+	if shifted.Hour() != 3 || shifted.Minute() != 30 {
+		t.Errorf("ResolveAmbiguous(skipped) = %v, expected 03:30", shifted)
+	}
+}
+
+// TestAddDateSafe checks that adding a day across the spring-forward
+// transition keeps the wall-clock time rather than the elapsed duration.
+func TestAddDateSafe(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("America/New_York not available")
+	}
+
+	start := time.Date(2024, time.March, 9, 9, 0, 0, 0, loc)
+	actual := AddDateSafe(start, 0, 0, 1)
+
+	if actual.Day() != 10 || actual.Hour() != 9 {
+		t.Errorf("AddDateSafe() = %v, expected 2024-03-10 09:00", actual)
+	}
+}
+
+// TestSameWallClockIn checks that the wall-clock reading is kept in the new
+// zone, including readings that fall into a gap or occur twice there.
+func TestSameWallClockIn(t *testing.T) {
+	newYork, err1 := time.LoadLocation("America/New_York")
+	london, err2 := time.LoadLocation("Europe/London")
+	if err1 != nil || err2 != nil {
+		t.Skip("time zone data not available")
+	}
+
+	tests := []struct {
+		input    time.Time
+		expected time.Time
+	}{
+		{time.Date(2024, time.May, 1, 9, 0, 0, 0, newYork), time.Date(2024, time.May, 1, 9, 0, 0, 0, london)},
+		// London springs forward at 01:00 on 31 March 2024.
+		{time.Date(2024, time.March, 31, 1, 30, 0, 0, newYork), time.Date(2024, time.March, 31, 2, 30, 0, 0, london)},
+		// London falls back at 02:00 on 27 October 2024; 01:30 is BST first.
+		{time.Date(2024, time.October, 27, 1, 30, 0, 0, newYork), time.Date(2024, time.October, 27, 0, 30, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		actual := SameWallClockIn(test.input, london)
+		if !actual.Equal(test.expected) || actual.Location() != london {
+			t.Errorf("SameWallClockIn(%v) = %v, expected %v", test.input, actual, test.expected)
+		}
+	}
+
+	if actual := SameWallClockIn(tests[0].input, nil); actual.Location() != time.UTC || actual.Hour() != 9 {
+		t.Errorf("SameWallClockIn(nil) = %v, expected 09:00 UTC", actual)
+	}
+}
+
+// TestDSTTransitions checks that a year in Berlin has exactly the two
+// transitions, with the expected offsets and shifts.
+func TestDSTTransitions(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("Europe/Berlin not available")
+	}
+
+	var actual []DSTTransition
+	for tr := range DSTTransitions(loc, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		actual = append(actual, tr)
+	}
+
+	if len(actual) != 2 {
+		t.Fatalf("DSTTransitions() = %v, expected 2 transitions", actual)
+	}
+
+	spring, autumn := actual[0], actual[1]
+	if expected := time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC); !spring.At.Equal(expected) || spring.OldName != "CET" || spring.NewName != "CEST" || spring.Shift() != time.Hour {
+		t.Errorf("spring transition = %+v, expected %v CET -> CEST", spring, expected)
+	}
+	if expected := time.Date(2024, 10, 27, 1, 0, 0, 0, time.UTC); !autumn.At.Equal(expected) || autumn.NewOffset != 3600 || autumn.Shift() != -time.Hour {
+		t.Errorf("autumn transition = %+v, expected %v CEST -> CET", autumn, expected)
+	}
+}
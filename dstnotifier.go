@@ -0,0 +1,146 @@
+package temporalis
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// DSTEvent announces an upcoming transition in one of the zones watched by a
+// DSTNotifier.
+type DSTEvent struct {
+	Zone       string
+	Transition DSTTransition
+	// Notify is when the event is due: the given number of days before the
+	// transition, at the same wall-clock time in the zone.
+	Notify time.Time
+}
+
+// DSTNotifier watches a set of zones and announces each of their offset
+// transitions a fixed number of days in advance, so that teams operating in
+// several regions can coordinate cutovers, freeze deployments or warn
+// customers whose scheduled jobs move.
+type DSTNotifier struct {
+	// Clock provides the time. If nil, SystemClock is used.
+	Clock Clock
+
+	days  int
+	zones []string
+	locs  []*time.Location
+}
+
+// NewDSTNotifier returns a notifier for the named zones that announces each
+// transition days in advance. Zones are loaded with LoadLocation, and the
+// first one that cannot be loaded is returned as an error. It panics if days
+// is negative.
+func NewDSTNotifier(days int, zones ...string) (*DSTNotifier, error) {
+	if days < 0 {
+		panic("temporalis: negative lead time for NewDSTNotifier")
+	}
+
+	n := &DSTNotifier{days: days}
+	for _, zone := range zones {
+		loc, err := LoadLocation(zone)
+		if err != nil {
+			return nil, err
+		}
+
+		n.zones = append(n.zones, zone)
+		n.locs = append(n.locs, loc)
+	}
+
+	return n, nil
+}
+
+// Upcoming returns the next transition of every watched zone that has one
+// within two years, ordered by when they are due. Events that are already
+// due, because the transition is closer than the lead time, are included.
+func (n *DSTNotifier) Upcoming() []DSTEvent {
+	now := clockOrSystem(n.Clock).Now()
+
+	var events []DSTEvent
+	for i := range n.zones {
+		if event, ok := n.next(i, now); ok {
+			events = append(events, event)
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b DSTEvent) int {
+		return a.Notify.Compare(b.Notify)
+	})
+
+	return events
+}
+
+// Run calls notify for every transition of the watched zones when it is due,
+// in order, until ctx is cancelled, and then returns the context error.
+// Transitions that are closer than the lead time when Run starts are
+// announced immediately. Zones without a transition in the next two years
+// are checked again a year later.
+func (n *DSTNotifier) Run(ctx context.Context, notify func(DSTEvent)) error {
+	c := clockOrSystem(n.Clock)
+	now := c.Now()
+
+	type watch struct {
+		event DSTEvent
+		ok    bool
+		wake  time.Time
+	}
+
+	watches := make([]watch, len(n.zones))
+	schedule := func(i int, after time.Time) {
+		event, ok := n.next(i, after)
+		if !ok {
+			watches[i] = watch{wake: after.Add(dstSearchLimit / 2)}
+			return
+		}
+
+		watches[i] = watch{event: event, ok: true, wake: event.Notify}
+	}
+
+	for i := range watches {
+		schedule(i, now)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if len(watches) == 0 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		due := 0
+		for i := range watches {
+			if watches[i].wake.Before(watches[due].wake) {
+				due = i
+			}
+		}
+
+		w := watches[due]
+		if err := sleepClock(ctx, c, w.wake.Sub(c.Now())); err != nil {
+			return err
+		}
+
+		if !w.ok {
+			schedule(due, w.wake)
+			continue
+		}
+
+		notify(w.event)
+		schedule(due, w.event.Transition.At)
+	}
+}
+
+// next returns the event for the first transition of zone i after the given
+// time.
+func (n *DSTNotifier) next(i int, after time.Time) (DSTEvent, bool) {
+	tr, ok := nextDSTTransition(n.locs[i], after)
+	if !ok {
+		return DSTEvent{}, false
+	}
+
+	return DSTEvent{Zone: n.zones[i], Transition: tr, Notify: tr.At.AddDate(0, 0, -n.days)}, true
+}
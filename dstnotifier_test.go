@@ -0,0 +1,66 @@
+package temporalis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestDSTNotifierUpcoming checks that Upcoming orders zones by when their
+// announcement is due.
+func TestDSTNotifierUpcoming(t *testing.T) {
+	n, err := NewDSTNotifier(7, "Europe/Berlin", "America/New_York", "UTC")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+	n.Clock = NewFakeClock(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	events := n.Upcoming()
+	if len(events) != 2 || events[0].Zone != "America/New_York" || events[1].Zone != "Europe/Berlin" {
+		t.Fatalf("Upcoming() = %v, expected New York then Berlin", events)
+	}
+
+	if expected := time.Date(2024, 3, 3, 8, 0, 0, 0, time.UTC); !events[0].Notify.Equal(expected) {
+		t.Errorf("Upcoming()[0].Notify = %v, expected %v", events[0].Notify, expected)
+	}
+}
+
+// TestDSTNotifierRun checks that Run announces transitions when they are due
+// on the notifier's clock, and that an unknown zone is rejected as by
+// LoadLocation.
+func TestDSTNotifierRun(t *testing.T) {
+	if _, err := NewDSTNotifier(7, "Nowhere/Special"); !errors.Is(err, ErrInvalidZone) {
+		t.Errorf("NewDSTNotifier(Nowhere/Special) error = %v, expected %v", err, ErrInvalidZone)
+	}
+
+	n, err := NewDSTNotifier(7, "Europe/Berlin", "America/New_York")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+	clock := NewFakeClock(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	n.Clock = clock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan DSTEvent)
+	done := make(chan error)
+	go func() { done <- n.Run(ctx, func(e DSTEvent) { events <- e }) }()
+
+	clock.BlockUntil(1)
+	clock.Set(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC))
+	if e := <-events; e.Zone != "America/New_York" || e.Transition.Shift() != time.Hour {
+		t.Errorf("first event = %+v, expected New York springing forward", e)
+	}
+
+	clock.BlockUntil(1)
+	clock.Set(time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC))
+	if e := <-events; e.Zone != "Europe/Berlin" {
+		t.Errorf("second event = %+v, expected Berlin", e)
+	}
+
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run() = %v, expected %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,404 @@
+package temporalis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits applied by ParseDuration to keep untrusted input cheap to reject.
+const (
+	// MaxDurationLength is the longest input ParseDuration accepts, in bytes.
+	MaxDurationLength = 64
+	// MaxDurationUnits is the largest number of value-unit components, such
+	// as the three in "1h30m15s", that ParseDuration accepts.
+	MaxDurationUnits = 10
+)
+
+// DurationError describes why ParseDuration rejected its input. Offset is the
+// byte offset in Input at which the problem was found. Err is ErrOutOfRange
+// for values and inputs beyond the limits, and ErrSyntax otherwise.
+type DurationError struct {
+	Input  string
+	Offset int
+	Reason string
+	Err    error
+}
+
+// Error implements error. Long inputs are abbreviated.
+func (e *DurationError) Error() string {
+	input := e.Input
+	if len(input) > MaxDurationLength {
+		input = input[:MaxDurationLength] + "..."
+	}
+
+	return fmt.Sprintf("invalid duration %q at offset %d: %s", input, e.Offset, e.Reason)
+}
+
+// Unwrap returns Err.
+func (e *DurationError) Unwrap() error {
+	return e.Err
+}
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"μs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+// ParseDuration parses a duration such as "300ms", "-1.5h" or "2d 4h30m". It
+// accepts the syntax of time.ParseDuration plus days ("d", 24 hours) and
+// weeks ("w", 7 days), and allows spaces between components. Unlike
+// time.ParseDuration it is meant for untrusted input: inputs longer than
+// MaxDurationLength or with more than MaxDurationUnits components are
+// rejected before any arithmetic, every step is checked for overflow, and
+// errors are returned as *DurationError, which wraps ErrSyntax or
+// ErrOutOfRange.
+func ParseDuration(s string) (time.Duration, error) {
+	fail := func(offset int, err error, reason string) (time.Duration, error) {
+		return 0, &DurationError{Input: s, Offset: offset, Reason: reason, Err: err}
+	}
+
+	if len(s) > MaxDurationLength {
+		return fail(MaxDurationLength, ErrOutOfRange, fmt.Sprintf("longer than %d bytes", MaxDurationLength))
+	}
+
+	i := 0
+	negative := false
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		negative = s[i] == '-'
+		i++
+	}
+
+	if s[i:] == "0" {
+		return 0, nil
+	}
+
+	if i == len(s) {
+		return fail(i, ErrSyntax, "empty duration")
+	}
+
+	// The magnitude of a negative duration may be one more than that of a
+	// positive one, as for time.ParseDuration.
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+
+	var total uint64
+	units := 0
+
+	for i < len(s) {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i == len(s) {
+			break
+		}
+
+		units++
+		if units > MaxDurationUnits {
+			return fail(i, ErrOutOfRange, fmt.Sprintf("more than %d components", MaxDurationUnits))
+		}
+
+		start := i
+
+		// Integer part, accumulated with an overflow check.
+		var whole uint64
+		digits := 0
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			if whole > (limit-uint64(s[i]-'0'))/10 {
+				return fail(start, ErrOutOfRange, "value out of range")
+			}
+			whole = whole*10 + uint64(s[i]-'0')
+			digits++
+		}
+
+		// Fractional part, keeping at most the digits that can matter.
+		var frac uint64
+		scale := uint64(1)
+		if i < len(s) && s[i] == '.' {
+			i++
+			for ; i < len(s) && isDigit(s[i]); i++ {
+				if scale < 1e18 {
+					frac = frac*10 + uint64(s[i]-'0')
+					scale *= 10
+				}
+				digits++
+			}
+		}
+
+		if digits == 0 {
+			return fail(start, ErrSyntax, "expected a number")
+		}
+
+		unitStart := i
+		for i < len(s) && s[i] != '.' && s[i] != ' ' && !isDigit(s[i]) {
+			i++
+		}
+
+		if unitStart == i {
+			return fail(unitStart, ErrSyntax, "missing unit")
+		}
+
+		unit, ok := durationUnits[s[unitStart:i]]
+		if !ok {
+			return fail(unitStart, ErrSyntax, fmt.Sprintf("unknown unit %q", s[unitStart:i]))
+		}
+
+		if whole > limit/uint64(unit) {
+			return fail(start, ErrOutOfRange, "value out of range")
+		}
+
+		value := whole * uint64(unit)
+		if frac > 0 {
+			value += uint64(float64(frac) * (float64(unit) / float64(scale)))
+		}
+
+		total += value
+		if total > limit || value > limit {
+			return fail(start, ErrOutOfRange, "value out of range")
+		}
+	}
+
+	if negative {
+		// Negating 1<<63 as a time.Duration wraps around to itself, which is
+		// the value wanted.
+		return -time.Duration(total), nil
+	}
+
+	return time.Duration(total), nil
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+var humanDurationUnits = map[string]string{
+	"ns": "ns", "nanosecond": "ns", "nanoseconds": "ns",
+	"us": "us", "µs": "us", "μs": "us", "microsecond": "us", "microseconds": "us",
+	"ms": "ms", "msec": "ms", "msecs": "ms", "millisecond": "ms", "milliseconds": "ms",
+	"s": "s", "sec": "s", "secs": "s", "second": "s", "seconds": "s",
+	"m": "m", "min": "m", "mins": "m", "minute": "m", "minutes": "m",
+	"h": "h", "hr": "h", "hrs": "h", "hour": "h", "hours": "h",
+	"d": "d", "day": "d", "days": "d",
+	"w": "w", "wk": "w", "wks": "w", "week": "w", "weeks": "w",
+}
+
+// ParseHumanDuration parses a duration written for people, such as
+// "2 days 4 hours", "1.5d", "an hour and 30 minutes" or the output of
+// FormatDuration ("1 day, 2 hours and 3 minutes"). Units may be abbreviated
+// or spelled out in the singular or plural, components may be separated by
+// spaces, commas or "and", and the compact forms accepted by ParseDuration
+// work as well. The input is limited to four times MaxDurationLength bytes,
+// and the same component and overflow limits as ParseDuration apply. Errors
+// are returned as *ParseError wrapping ErrSyntax or ErrOutOfRange.
+func ParseHumanDuration(s string) (time.Duration, error) {
+	const fn = "ParseHumanDuration"
+
+	if len(s) > 4*MaxDurationLength {
+		return 0, rangeError(fn, s[:4*MaxDurationLength]+"...", "input too long")
+	}
+
+	str := strings.ToLower(strings.TrimSpace(s))
+
+	var compact strings.Builder
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		compact.WriteByte(str[0])
+		str = str[1:]
+	}
+
+	expectNumber, afterUnit := true, false
+	for _, token := range humanDurationTokens(str) {
+		switch {
+		case token == "," || token == "and":
+			if !afterUnit {
+				return 0, syntaxError(fn, s, "unexpected "+strconv.Quote(token))
+			}
+			afterUnit = false
+		case expectNumber && (token == "a" || token == "an"):
+			compact.WriteString("1")
+			expectNumber, afterUnit = false, false
+		case expectNumber && (isDigit(token[0]) || token[0] == '.'):
+			compact.WriteString(token)
+			expectNumber, afterUnit = false, false
+		case !expectNumber:
+			unit, ok := humanDurationUnits[token]
+			if !ok {
+				return 0, syntaxError(fn, s, "unknown unit "+strconv.Quote(token))
+			}
+			compact.WriteString(unit)
+			expectNumber, afterUnit = true, true
+		default:
+			return 0, syntaxError(fn, s, "expected a number before "+strconv.Quote(token))
+		}
+	}
+
+	if !expectNumber && compact.String() != "0" {
+		return 0, syntaxError(fn, s, "missing unit")
+	}
+
+	d, err := ParseDuration(compact.String())
+	if err != nil {
+		var de *DurationError
+		if errors.As(err, &de) {
+			return 0, &ParseError{Func: fn, Input: s, Err: detailed(de.Err, de.Reason)}
+		}
+		return 0, err
+	}
+
+	return d, nil
+}
+
+// humanDurationTokens splits s into numbers, words and commas, dropping
+// whitespace.
+func humanDurationTokens(s string) []string {
+	var tokens []string
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		start := i
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case c == ',':
+			i++
+		case isDigit(c) || c == '.':
+			for i < len(s) && (isDigit(s[i]) || s[i] == '.') {
+				i++
+			}
+		default:
+			for i < len(s) && !isDigit(s[i]) && s[i] != '.' && s[i] != ',' && s[i] != ' ' && s[i] != '\t' {
+				i++
+			}
+		}
+
+		tokens = append(tokens, s[start:i])
+	}
+
+	return tokens
+}
+
+// Duration wraps time.Duration to encode it as a string such as "1h30m0s" in
+// JSON, YAML and other text formats, and to serve as a command-line flag:
+//
+//	type Config struct {
+//		Timeout temporalis.Duration `json:"timeout"`
+//	}
+//
+//	var interval temporalis.Duration
+//	flag.Var(&interval, "interval", "polling interval")
+//
+// Decoding text accepts the syntax of ParseDuration, such as "1h30m" or
+// "2d", as well as integers, which are nanoseconds as in time.Duration, and
+// numbers with a fraction or exponent, which are seconds. In JSON these
+// forms are strings; a bare JSON number is always nanoseconds, whether
+// written as 1500000000, 1.5e9 or 1500000000.0, as encoding/json uses for
+// time.Duration, and null decodes as zero.
+type Duration struct {
+	time.Duration
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, d.String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		d.Duration = 0
+		return nil
+	}
+
+	if len(data) >= 2 && data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return syntaxError("Duration.UnmarshalJSON", string(data), "invalid JSON string")
+		}
+
+		return d.UnmarshalText([]byte(s))
+	}
+
+	return d.unmarshalNanoseconds(string(data))
+}
+
+// unmarshalNanoseconds sets d to a JSON number of nanoseconds, which must be
+// whole.
+func (d *Duration) unmarshalNanoseconds(s string) error {
+	const fn = "Duration.UnmarshalJSON"
+
+	if ns, err := strconv.ParseInt(s, 10, 64); err == nil {
+		d.Duration = time.Duration(ns)
+		return nil
+	}
+
+	ns, err := strconv.ParseFloat(s, 64)
+	switch {
+	case err != nil && !errors.Is(err, strconv.ErrRange), math.IsNaN(ns):
+		return syntaxError(fn, s, "not a number")
+	case math.Abs(ns) >= math.MaxInt64:
+		return rangeError(fn, s, "nanoseconds out of range")
+	case ns != math.Trunc(ns):
+		return rangeError(fn, s, "fraction of a nanosecond")
+	}
+
+	d.Duration = time.Duration(ns)
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(data []byte) error {
+	const fn = "Duration.UnmarshalText"
+	s := string(data)
+
+	if ns, err := strconv.ParseInt(s, 10, 64); err == nil {
+		d.Duration = time.Duration(ns)
+		return nil
+	} else if errors.Is(err, strconv.ErrRange) {
+		return rangeError(fn, s, "nanoseconds out of range")
+	}
+
+	parsed, err := ParseDuration(s)
+	if err == nil {
+		d.Duration = parsed
+		return nil
+	}
+
+	// A plain number with a fraction or exponent is seconds.
+	seconds, ferr := strconv.ParseFloat(s, 64)
+	if ferr != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return err
+	}
+	if math.Abs(seconds) >= math.MaxInt64/float64(time.Second) {
+		return rangeError(fn, s, "seconds out of range")
+	}
+
+	d.Duration = time.Duration(math.Round(seconds * float64(time.Second)))
+
+	return nil
+}
+
+// Set implements flag.Value, parsing the flag's value as UnmarshalText does.
+func (d *Duration) Set(s string) error {
+	return d.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,172 @@
+package temporalis
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseDuration checks valid inputs, including the day and week units,
+// and the errors for malformed and oversized inputs.
+func TestParseDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"0":         0,
+		"300ms":     300 * time.Millisecond,
+		"-1.5h":     -90 * time.Minute,
+		"2d 4h30m":  52*time.Hour + 30*time.Minute,
+		"1w":        7 * 24 * time.Hour,
+		"1.000001s": time.Second + time.Microsecond,
+		"10µs":      10 * time.Microsecond,
+
+		"-9223372036854775808ns":    math.MinInt64,
+		"-2562047h47m16.854775808s": math.MinInt64,
+	}
+
+	for input, expected := range valid {
+		if actual, err := ParseDuration(input); err != nil || actual != expected {
+			t.Errorf("ParseDuration(%q) = %v, %v, expected %v", input, actual, err, expected)
+		}
+	}
+
+	invalid := []string{
+		"", "-", "h", "10", "1x", "1..5h", "999999999999999h", "9223372036854775807ns1ns",
+		"9223372036854775808ns", "-9223372036854775809ns",
+		"106752d", strings.Repeat("1s", 11), strings.Repeat("9", 100) + "s",
+	}
+
+	for _, input := range invalid {
+		_, err := ParseDuration(input)
+
+		var durationErr *DurationError
+		if !errors.As(err, &durationErr) {
+			t.Errorf("ParseDuration(%q) = %v, expected a *DurationError", input, err)
+		}
+	}
+}
+
+// FuzzParseDuration checks that ParseDuration never panics and agrees with
+// time.ParseDuration on the inputs that both accept.
+func FuzzParseDuration(f *testing.F) {
+	for _, seed := range []string{"1h30m", "-1.5h", "2d", "999999999999999h", "0.000000001s", "1µs", ".5m"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		actual, err := ParseDuration(input)
+		if err != nil {
+			return
+		}
+
+		if strings.ContainsAny(input, "dw ") {
+			return
+		}
+
+		if expected, err := time.ParseDuration(input); err == nil && actual != expected {
+			t.Errorf("ParseDuration(%q) = %v, time.ParseDuration = %v", input, actual, expected)
+		}
+	})
+}
+
+// TestParseHumanDuration checks human-written inputs and that the output of
+// FormatDuration parses back to the original duration.
+func TestParseHumanDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"1h30m":                    90 * time.Minute,
+		"2 days 4 hours":           52 * time.Hour,
+		"1.5d":                     36 * time.Hour,
+		"an hour and 30 minutes":   90 * time.Minute,
+		"1 week, 2 days":           9 * 24 * time.Hour,
+		"-3 secs":                  -3 * time.Second,
+		"250 milliseconds":         250 * time.Millisecond,
+		"0 seconds":                0,
+		"1 Day, 2 Hours and 3 Min": 26*time.Hour + 3*time.Minute,
+	}
+
+	for input, expected := range valid {
+		if actual, err := ParseHumanDuration(input); err != nil || actual != expected {
+			t.Errorf("ParseHumanDuration(%q) = %v, %v, expected %v", input, actual, err, expected)
+		}
+	}
+
+	for _, d := range []time.Duration{0, time.Second, 61 * time.Minute, 3*24*time.Hour + 4*time.Hour + 5*time.Minute + 6*time.Second} {
+		formatted := FormatDuration(d)
+		if actual, err := ParseHumanDuration(formatted); err != nil || actual != d {
+			t.Errorf("ParseHumanDuration(%q) = %v, %v, expected %v", formatted, actual, err, d)
+		}
+	}
+
+	invalid := map[string]error{
+		"":                              ErrSyntax,
+		"5":                             ErrSyntax,
+		"hours":                         ErrSyntax,
+		"3 fortnights":                  ErrSyntax,
+		"and 3 hours":                   ErrSyntax,
+		"999999999999 weeks":            ErrOutOfRange,
+		strings.Repeat("1 second ", 40): ErrOutOfRange,
+	}
+
+	for input, expected := range invalid {
+		if _, err := ParseHumanDuration(input); !errors.Is(err, expected) {
+			t.Errorf("ParseHumanDuration(%q) error = %v, expected %v", input, err, expected)
+		}
+	}
+}
+
+// TestDurationEncoding tests that Duration decodes strings, nanoseconds and
+// seconds, that bare JSON numbers are always nanoseconds, and that it
+// encodes as a string.
+func TestDurationEncoding(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{`"1h30m"`, 90 * time.Minute},
+		{`"2d"`, 48 * time.Hour},
+		{`1500000000`, 1500 * time.Millisecond},
+		{`"1500000000"`, 1500 * time.Millisecond},
+		{`1.5e9`, 1500 * time.Millisecond},
+		{`1.0`, time.Nanosecond},
+		{`"1.5"`, 1500 * time.Millisecond},
+		{`"-2.5e-3"`, -2500 * time.Microsecond},
+		{`null`, 0},
+	}
+
+	for _, test := range tests {
+		d := Duration{time.Hour}
+		if err := json.Unmarshal([]byte(test.input), &d); err != nil || d.Duration != test.expected {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, expected %v", test.input, d, err, test.expected)
+		}
+	}
+
+	for _, input := range []string{`"soon"`, `"1.5x"`, `true`, `"NaN"`, `1e300`, `99999999999999999999`, `1.5`, `"1e300"`} {
+		var d Duration
+		if err := json.Unmarshal([]byte(input), &d); err == nil {
+			t.Errorf("json.Unmarshal(%s) = %v, expected an error", input, d)
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Timeout Duration `json:"timeout"`
+	}{Duration{90 * time.Minute}})
+	if err != nil || string(data) != `{"timeout":"1h30m0s"}` {
+		t.Errorf("json.Marshal() = %s, %v, expected %s", data, err, `{"timeout":"1h30m0s"}`)
+	}
+}
+
+// TestDurationFlag tests Duration as a flag.Value.
+func TestDurationFlag(t *testing.T) {
+	var d Duration
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&d, "interval", "polling interval")
+
+	if err := fs.Parse([]string{"-interval", "2m30s"}); err != nil || d.Duration != 150*time.Second {
+		t.Errorf("Parse() = %v, %v, expected 2m30s", d, err)
+	}
+	if d.String() != "2m30s" {
+		t.Errorf("String() = %q, expected %q", d.String(), "2m30s")
+	}
+}
@@ -0,0 +1,377 @@
+package temporalis
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoundingMode selects how FormatDuration rounds a duration to the smallest
+// unit it shows. Negative durations are rounded by magnitude, so RoundUp
+// moves them away from zero.
+type RoundingMode int
+
+const (
+	// RoundDown drops the remainder, so 1h59m shown in hours is "1 hour".
+	RoundDown RoundingMode = iota
+	// RoundNearest rounds half away from zero, so 1h30m shown in hours is
+	// "2 hours".
+	RoundNearest
+	// RoundUp rounds any remainder up, so 1h1m shown in hours is "2 hours".
+	RoundUp
+)
+
+// Average calendar lengths used by the approximate style of FormatDuration.
+const (
+	averageYear  = time.Duration(365.2425 * 24 * float64(time.Hour))
+	averageMonth = averageYear / 12
+)
+
+// durationFormat holds the settings that apply only to FormatDuration.
+type durationFormat struct {
+	maxUnits    int
+	compact     bool
+	precision   time.Duration
+	rounding    RoundingMode
+	weeks       bool
+	relative    bool
+	approximate bool
+}
+
+// WithMaxUnits limits the output to the n largest non-zero units, so with
+// n = 2 a duration of 2 days, 3 hours and 4 minutes is "2 days and 3 hours".
+// The dropped units are handled according to the rounding mode.
+func WithMaxUnits(n int) Option {
+	return func(o *Options) { o.duration.maxUnits = n }
+}
+
+// WithCompact selects the compact style, such as "2d3h4m" or "1s500ms",
+// which does not depend on the locale and is accepted by ParseDuration.
+func WithCompact() Option {
+	return func(o *Options) { o.duration.compact = true }
+}
+
+// WithPrecision sets the smallest unit shown, which is one of time.Hour,
+// time.Minute, time.Second, time.Millisecond, time.Microsecond and
+// time.Nanosecond, or 24 hours for days. Other values are rounded down to
+// the nearest of these units. The default is time.Second, except that a
+// non-zero duration shorter than a second is shown in the largest unit that
+// does not make it zero, so 500ms is "500 milliseconds" rather than
+// "0 seconds".
+func WithPrecision(unit time.Duration) Option {
+	return func(o *Options) { o.duration.precision = unit }
+}
+
+// WithRounding sets how the duration is rounded to the smallest unit shown.
+// The default is RoundDown.
+func WithRounding(mode RoundingMode) Option {
+	return func(o *Options) { o.duration.rounding = mode }
+}
+
+// WithWeeks shows whole weeks as a separate unit, so 9 days is "1 week and
+// 2 days" rather than "9 days".
+func WithWeeks() Option {
+	return func(o *Options) { o.duration.weeks = true }
+}
+
+// WithRelative describes the duration as an offset from now, so one hour is
+// "in 1 hour", minus one hour is "1 hour ago" and zero is "now". Without it,
+// negative durations are prefixed with a minus sign.
+func WithRelative() Option {
+	return func(o *Options) { o.duration.relative = true }
+}
+
+// WithApproximate shows only the largest unit, rounded to the nearest whole
+// number, such as "about 2 months". Months and years are calendar averages
+// of 30.44 and 365.24 days. The word "about" is left out when the duration
+// is exact. In the compact style the output looks like "~2mo", which
+// ParseDuration does not accept.
+func WithApproximate() Option {
+	return func(o *Options) { o.duration.approximate = true }
+}
+
+// durationUnit is a unit that FormatDuration can show.
+type durationUnit struct {
+	size   time.Duration
+	symbol string
+}
+
+// Indexes into durationFormatUnits of the units that are not always shown.
+const (
+	unitYear = iota
+	unitMonth
+	unitWeek
+	unitDay
+)
+
+// durationFormatUnits lists the units from the largest to the smallest. The
+// index of each unit is also its index in the name arrays of durationNames.
+// Years and months are only used by the approximate style.
+var durationFormatUnits = []durationUnit{
+	{averageYear, "y"},
+	{averageMonth, "mo"},
+	{7 * 24 * time.Hour, "w"},
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+	{time.Millisecond, "ms"},
+	{time.Microsecond, "µs"},
+	{time.Nanosecond, "ns"},
+}
+
+// durationNames holds the unit names and phrases of a language. The phrases
+// contain %s where the formatted duration goes. Languages whose relative
+// phrases take another case, such as the German dative in "vor 2 Tagen", set
+// relative to the plurals used there.
+type durationNames struct {
+	singular [10]string
+	plural   [10]string
+	relative [10]string
+	and      string
+	now      string
+	future   string
+	past     string
+	about    string
+}
+
+var durationUnitNames = map[string]durationNames{
+	"en": {
+		singular: [10]string{"year", "month", "week", "day", "hour", "minute", "second", "millisecond", "microsecond", "nanosecond"},
+		plural:   [10]string{"years", "months", "weeks", "days", "hours", "minutes", "seconds", "milliseconds", "microseconds", "nanoseconds"},
+		and:      "and",
+		now:      "now",
+		future:   "in %s",
+		past:     "%s ago",
+		about:    "about %s",
+	},
+	"de": {
+		singular: [10]string{"Jahr", "Monat", "Woche", "Tag", "Stunde", "Minute", "Sekunde", "Millisekunde", "Mikrosekunde", "Nanosekunde"},
+		plural:   [10]string{"Jahre", "Monate", "Wochen", "Tage", "Stunden", "Minuten", "Sekunden", "Millisekunden", "Mikrosekunden", "Nanosekunden"},
+		relative: [10]string{"Jahren", "Monaten", "Wochen", "Tagen", "Stunden", "Minuten", "Sekunden", "Millisekunden", "Mikrosekunden", "Nanosekunden"},
+		and:      "und",
+		now:      "jetzt",
+		future:   "in %s",
+		past:     "vor %s",
+		about:    "etwa %s",
+	},
+	"fr": {
+		singular: [10]string{"an", "mois", "semaine", "jour", "heure", "minute", "seconde", "milliseconde", "microseconde", "nanoseconde"},
+		plural:   [10]string{"ans", "mois", "semaines", "jours", "heures", "minutes", "secondes", "millisecondes", "microsecondes", "nanosecondes"},
+		and:      "et",
+		now:      "maintenant",
+		future:   "dans %s",
+		past:     "il y a %s",
+		about:    "environ %s",
+	},
+	"es": {
+		singular: [10]string{"año", "mes", "semana", "día", "hora", "minuto", "segundo", "milisegundo", "microsegundo", "nanosegundo"},
+		plural:   [10]string{"años", "meses", "semanas", "días", "horas", "minutos", "segundos", "milisegundos", "microsegundos", "nanosegundos"},
+		and:      "y",
+		now:      "ahora",
+		future:   "dentro de %s",
+		past:     "hace %s",
+		about:    "aproximadamente %s",
+	},
+}
+
+// namesForLocale returns the unit names for a locale such as "de-AT",
+// falling back to the language and then to English.
+func namesForLocale(locale string) durationNames {
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+
+	if names, ok := durationUnitNames[lang]; ok {
+		return names
+	}
+
+	return durationUnitNames["en"]
+}
+
+// formatDuration implements FormatDuration.
+func formatDuration(d time.Duration, o Options) string {
+	f := o.duration
+	names := namesForLocale(o.Locale)
+	if f.relative && names.relative[0] != "" {
+		names.plural = names.relative
+	}
+
+	var s string
+	var zero bool
+	if f.approximate {
+		s, zero = formatApproximate(abs(d), f, names)
+	} else {
+		s, zero = formatExact(abs(d), f, names)
+	}
+
+	switch {
+	case f.relative && zero:
+		return names.now
+	case f.relative && d < 0:
+		return strings.Replace(names.past, "%s", s, 1)
+	case f.relative:
+		return strings.Replace(names.future, "%s", s, 1)
+	case d < 0 && !zero:
+		return "-" + s
+	default:
+		return s
+	}
+}
+
+// formatExact formats the non-negative duration d as a list of units down to
+// the precision, and reports whether all of them are zero.
+func formatExact(d time.Duration, f durationFormat, names durationNames) (string, bool) {
+	// The indexes of the largest and the smallest unit that may be shown.
+	first := unitDay
+	if f.weeks {
+		first = unitWeek
+	}
+
+	last := unitIndex(f.precision, first)
+	if f.precision == 0 {
+		last = unitIndex(time.Second, first)
+		if d != 0 && d < time.Second {
+			last = unitIndex(d, first)
+		}
+	}
+
+	// Limit the number of units, counting from the largest non-zero one.
+	if f.maxUnits > 0 {
+		largest := unitIndex(d, first)
+		if largest < last && largest+f.maxUnits-1 < last {
+			last = largest + f.maxUnits - 1
+		}
+	}
+
+	counts := splitDuration(roundDuration(d, durationFormatUnits[last].size, f.rounding), first, last)
+
+	var parts []string
+	for i := first; i <= last; i++ {
+		if n := counts[i-first]; n != 0 {
+			parts = append(parts, formatUnit(n, i, f.compact, names))
+		}
+	}
+
+	switch {
+	case len(parts) == 0:
+		return formatUnit(0, last, f.compact, names), true
+	case f.compact:
+		return strings.Join(parts, ""), false
+	case len(parts) == 1:
+		return parts[0], false
+	default:
+		return strings.Join(parts[:len(parts)-1], ", ") + " " + names.and + " " + parts[len(parts)-1], false
+	}
+}
+
+// formatApproximate formats the non-negative duration d in its largest unit,
+// rounded to the nearest whole number, and reports whether it is zero.
+func formatApproximate(d time.Duration, f durationFormat, names durationNames) (string, bool) {
+	if d == 0 {
+		return formatUnit(0, unitIndex(time.Second, unitDay), f.compact, names), true
+	}
+
+	var units []int
+	for i := range durationFormatUnits {
+		if i != unitWeek || f.weeks {
+			units = append(units, i)
+		}
+	}
+
+	k := 0
+	for k < len(units)-1 && d < durationFormatUnits[units[k]].size {
+		k++
+	}
+
+	// Rounding may reach the next larger unit, as 23.6 hours does a day.
+	n := roundDuration(d, durationFormatUnits[units[k]].size, RoundNearest)
+	if k > 0 && n >= durationFormatUnits[units[k-1]].size {
+		k--
+		n = roundDuration(d, durationFormatUnits[units[k]].size, RoundNearest)
+	}
+
+	unit := durationFormatUnits[units[k]].size
+	s := formatUnit(int64(n/unit), units[k], f.compact, names)
+
+	switch {
+	case n == d:
+		return s, false
+	case f.compact:
+		return "~" + s, false
+	default:
+		return strings.Replace(names.about, "%s", s, 1), false
+	}
+}
+
+// formatUnit formats n of the unit at index i.
+func formatUnit(n int64, i int, compact bool, names durationNames) string {
+	switch {
+	case compact:
+		return strconv.FormatInt(n, 10) + durationFormatUnits[i].symbol
+	case n == 1:
+		return "1 " + names.singular[i]
+	default:
+		return strconv.FormatInt(n, 10) + " " + names.plural[i]
+	}
+}
+
+// unitIndex returns the index of the largest unit not longer than d, from the
+// unit at index first down, or of the smallest unit if d is shorter than all
+// of them.
+func unitIndex(d time.Duration, first int) int {
+	for i := first; i < len(durationFormatUnits); i++ {
+		if d >= durationFormatUnits[i].size {
+			return i
+		}
+	}
+
+	return len(durationFormatUnits) - 1
+}
+
+// roundDuration rounds the non-negative duration d to a multiple of unit
+// according to mode.
+func roundDuration(d, unit time.Duration, mode RoundingMode) time.Duration {
+	rem := d % unit
+	if rem == 0 {
+		return d
+	}
+
+	truncated := d - rem
+	if truncated > math.MaxInt64-unit {
+		return truncated
+	}
+
+	switch {
+	case mode == RoundNearest && rem >= unit-rem, mode == RoundUp:
+		return truncated + unit
+	default:
+		return truncated
+	}
+}
+
+// splitDuration returns the number of each unit in the non-negative duration
+// d, from the unit at index first to the unit at index last.
+func splitDuration(d time.Duration, first, last int) []int64 {
+	counts := make([]int64, last-first+1)
+
+	for i := first; i <= last; i++ {
+		size := durationFormatUnits[i].size
+		counts[i-first] = int64(d / size)
+		d %= size
+	}
+
+	return counts
+}
+
+// abs returns the absolute value of d, saturating for the minimum duration.
+func abs(d time.Duration) time.Duration {
+	if d >= 0 {
+		return d
+	}
+	if d == math.MinInt64 {
+		return math.MaxInt64
+	}
+
+	return -d
+}
@@ -0,0 +1,149 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFormatDurationOptions tests the options of FormatDuration: the limit on
+// units, the compact style, precision, rounding and localized unit names.
+func TestFormatDurationOptions(t *testing.T) {
+	long := 2*24*time.Hour + 3*time.Hour + 4*time.Minute + 35*time.Second
+
+	tests := []struct {
+		duration time.Duration
+		opts     []Option
+		expected string
+	}{
+		{500 * time.Millisecond, nil, "500 milliseconds"},
+		{1500 * time.Microsecond, nil, "1 millisecond"},
+		{42 * time.Nanosecond, nil, "42 nanoseconds"},
+		{1500 * time.Millisecond, nil, "1 second"},
+		{-time.Hour - 5*time.Minute, nil, "-1 hour and 5 minutes"},
+		{-500 * time.Millisecond, []Option{WithPrecision(time.Second)}, "0 seconds"},
+		{long, []Option{WithMaxUnits(2)}, "2 days and 3 hours"},
+		{long, []Option{WithMaxUnits(3), WithRounding(RoundNearest)}, "2 days, 3 hours and 5 minutes"},
+		{long, []Option{WithCompact()}, "2d3h4m35s"},
+		{0, []Option{WithCompact()}, "0s"},
+		{1500 * time.Millisecond, []Option{WithCompact(), WithPrecision(time.Millisecond)}, "1s500ms"},
+		{1500 * time.Millisecond, []Option{WithPrecision(time.Millisecond)}, "1 second and 500 milliseconds"},
+		{90 * time.Minute, []Option{WithPrecision(time.Hour)}, "1 hour"},
+		{90 * time.Minute, []Option{WithPrecision(time.Hour), WithRounding(RoundNearest)}, "2 hours"},
+		{61 * time.Minute, []Option{WithPrecision(time.Hour), WithRounding(RoundUp)}, "2 hours"},
+		{30 * time.Minute, []Option{WithPrecision(time.Hour)}, "0 hours"},
+		{long, []Option{WithLocale("de-AT")}, "2 Tage, 3 Stunden, 4 Minuten und 35 Sekunden"},
+		{time.Hour + time.Second, []Option{WithLocale("fr")}, "1 heure et 1 seconde"},
+		{24 * time.Hour, []Option{WithLocale("es_MX")}, "1 día"},
+		{time.Minute, []Option{WithLocale("xx")}, "1 minute"},
+	}
+
+	for _, test := range tests {
+		actual := FormatDuration(test.duration, test.opts...)
+		if actual != test.expected {
+			t.Errorf("FormatDuration(%v) = %q, expected %q", test.duration, actual, test.expected)
+		}
+	}
+}
+
+// TestFormatDurationCompactRoundTrip tests that the compact style is parsed
+// back to the same duration by ParseDuration.
+func TestFormatDurationCompactRoundTrip(t *testing.T) {
+	durations := []time.Duration{
+		time.Nanosecond,
+		1500 * time.Millisecond,
+		26*time.Hour + 3*time.Minute + 7*time.Microsecond,
+		1<<63 - 1,
+	}
+
+	for _, d := range durations {
+		s := FormatDuration(d, WithCompact(), WithPrecision(time.Nanosecond))
+
+		parsed, err := ParseDuration(s)
+		if err != nil || parsed != d {
+			t.Errorf("ParseDuration(%q) = %v, %v, expected %v", s, parsed, err, d)
+		}
+	}
+}
+
+// TestFormatDurationRoundingOverflow tests that rounding up the longest
+// duration does not overflow.
+func TestFormatDurationRoundingOverflow(t *testing.T) {
+	actual := FormatDuration(1<<63-1, WithCompact(), WithPrecision(time.Hour), WithRounding(RoundUp))
+	expected := "106751d23h"
+
+	if actual != expected {
+		t.Errorf("FormatDuration(max) = %q, expected %q", actual, expected)
+	}
+}
+
+// TestFormatDurationRelative tests the relative style for future, past and
+// zero durations.
+func TestFormatDurationRelative(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		locale   string
+		expected string
+	}{
+		{time.Hour + 5*time.Minute, "en", "in 1 hour and 5 minutes"},
+		{-time.Hour - 5*time.Minute, "en", "1 hour and 5 minutes ago"},
+		{0, "en", "now"},
+		{-2 * time.Hour, "de", "vor 2 Stunden"},
+		{48 * time.Hour, "de", "in 2 Tagen"},
+		{-24 * time.Hour, "de", "vor 1 Tag"},
+		{-time.Minute, "fr", "il y a 1 minute"},
+		{3 * time.Second, "es", "dentro de 3 segundos"},
+	}
+
+	for _, test := range tests {
+		actual := FormatDuration(test.duration, WithRelative(), WithLocale(test.locale))
+		if actual != test.expected {
+			t.Errorf("FormatDuration(%v, %s) = %q, expected %q", test.duration, test.locale, actual, test.expected)
+		}
+	}
+
+	// German relative phrases take the dative plural.
+	for d, expected := range map[time.Duration]string{
+		-61 * 24 * time.Hour:      "vor etwa 2 Monaten",
+		-3 * 366 * 24 * time.Hour: "vor etwa 3 Jahren",
+		15 * 24 * time.Hour:       "in 15 Tagen",
+	} {
+		actual := FormatDuration(d, WithRelative(), WithApproximate(), WithLocale("de"))
+		if actual != expected {
+			t.Errorf("FormatDuration(%v, de, approximate) = %q, expected %q", d, actual, expected)
+		}
+	}
+}
+
+// TestFormatDurationWeeksAndApproximate tests the week unit and the
+// approximate style.
+func TestFormatDurationWeeksAndApproximate(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		duration time.Duration
+		opts     []Option
+		expected string
+	}{
+		{9 * day, nil, "9 days"},
+		{9 * day, []Option{WithWeeks()}, "1 week and 2 days"},
+		{15*day + time.Hour, []Option{WithWeeks(), WithCompact()}, "2w1d1h"},
+		{61 * day, []Option{WithApproximate()}, "about 2 months"},
+		{-61 * day, []Option{WithApproximate(), WithRelative()}, "about 2 months ago"},
+		{3 * time.Hour, []Option{WithApproximate()}, "3 hours"},
+		{23*time.Hour + 40*time.Minute, []Option{WithApproximate()}, "about 1 day"},
+		{20 * day, []Option{WithApproximate()}, "20 days"},
+		{20 * day, []Option{WithApproximate(), WithWeeks()}, "about 3 weeks"},
+		{800 * day, []Option{WithApproximate()}, "about 2 years"},
+		{800 * day, []Option{WithApproximate(), WithCompact()}, "~2y"},
+		{0, []Option{WithApproximate()}, "0 seconds"},
+		{400 * time.Millisecond, []Option{WithApproximate(), WithLocale("de")}, "400 Millisekunden"},
+		{45 * day, []Option{WithApproximate(), WithLocale("de")}, "etwa 1 Monat"},
+	}
+
+	for _, test := range tests {
+		actual := FormatDuration(test.duration, test.opts...)
+		if actual != test.expected {
+			t.Errorf("FormatDuration(%v) = %q, expected %q", test.duration, actual, test.expected)
+		}
+	}
+}
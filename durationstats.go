@@ -0,0 +1,117 @@
+package temporalis
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DurationSummary summarizes a stream of durations. The embedded
+// Measurement is exact; the percentiles are estimated.
+type DurationSummary struct {
+	Measurement
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+}
+
+// DurationStats aggregates an unbounded stream of durations, such as
+// request latencies, in constant memory. The count, total, mean, minimum
+// and maximum are exact; percentiles are estimated from a uniform random
+// sample of the durations seen so far, kept with reservoir sampling, so
+// their accuracy depends on the sample size rather than on the length of
+// the stream. A sample of 1000 typically puts the p95 within a percentile
+// point of the truth. A DurationStats is safe for concurrent use.
+type DurationStats struct {
+	mu        sync.Mutex
+	m         Measurement
+	reservoir []time.Duration
+	size      int
+}
+
+// NewDurationStats returns an empty aggregator that keeps a sample of at
+// most size durations. It panics if size is not positive.
+func NewDurationStats(size int) *DurationStats {
+	if size <= 0 {
+		panic("temporalis: non-positive sample size for NewDurationStats")
+	}
+
+	return &DurationStats{reservoir: make([]time.Duration, 0, size), size: size}
+}
+
+// Add records a duration.
+func (s *DurationStats) Add(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.m.N == 0 || d < s.m.Min {
+		s.m.Min = d
+	}
+	if s.m.N == 0 || d > s.m.Max {
+		s.m.Max = d
+	}
+	s.m.N++
+	s.m.Total += d
+	s.m.Mean = s.m.Total / time.Duration(s.m.N)
+
+	// Algorithm R: the nth duration replaces a random element of the
+	// sample with probability size/n, which keeps every duration seen
+	// equally likely to be in it.
+	if len(s.reservoir) < s.size {
+		s.reservoir = append(s.reservoir, d)
+	} else if i := rand.Int63n(int64(s.m.N)); i < int64(s.size) {
+		s.reservoir[i] = d
+	}
+}
+
+// Count returns the number of durations recorded.
+func (s *DurationStats) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.m.N
+}
+
+// Percentile returns the estimated nearest-rank percentile p, in [0, 1], of
+// the durations recorded, or zero if there are none. While no more
+// durations than the sample size have been recorded, it is exact.
+func (s *DurationStats) Percentile(p float64) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return percentile(s.sortedLocked(), p)
+}
+
+// Summary returns the exact measurement and the estimated 50th, 95th and
+// 99th percentiles of the durations recorded.
+func (s *DurationStats) Summary() DurationSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sorted := s.sortedLocked()
+
+	return DurationSummary{
+		Measurement: s.m,
+		P50:         percentile(sorted, 0.5),
+		P95:         percentile(sorted, 0.95),
+		P99:         percentile(sorted, 0.99),
+	}
+}
+
+// Reset discards every duration recorded.
+func (s *DurationStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.m = Measurement{}
+	s.reservoir = s.reservoir[:0]
+}
+
+// sortedLocked returns a sorted copy of the sample. s.mu must be held.
+func (s *DurationStats) sortedLocked() []time.Duration {
+	sorted := slices.Clone(s.reservoir)
+	slices.Sort(sorted)
+
+	return sorted
+}
@@ -0,0 +1,57 @@
+package temporalis
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestDurationStats tests exact statistics and exact percentiles while the
+// sample holds every duration.
+func TestDurationStats(t *testing.T) {
+	s := NewDurationStats(100)
+	if actual := s.Summary(); actual != (DurationSummary{}) {
+		t.Errorf("Summary() of nothing = %+v, expected zero", actual)
+	}
+
+	for i := 100; i >= 1; i-- {
+		s.Add(time.Duration(i) * time.Millisecond)
+	}
+
+	expected := DurationSummary{
+		Measurement: Measurement{N: 100, Total: 5050 * time.Millisecond, Mean: 50500 * time.Microsecond, Min: time.Millisecond, Max: 100 * time.Millisecond},
+		P50:         50 * time.Millisecond,
+		P95:         95 * time.Millisecond,
+		P99:         99 * time.Millisecond,
+	}
+	if actual := s.Summary(); actual != expected {
+		t.Errorf("Summary() = %+v, expected %+v", actual, expected)
+	}
+
+	s.Reset()
+	if s.Count() != 0 || s.Percentile(0.5) != 0 {
+		t.Errorf("Count() after Reset() = %d, expected 0", s.Count())
+	}
+}
+
+// TestDurationStatsSampling tests that percentiles estimated from a sample
+// of a long stream are close to the true ones.
+func TestDurationStatsSampling(t *testing.T) {
+	s := NewDurationStats(2000)
+
+	// A uniform distribution over [0, 1s) in random order.
+	for _, i := range rand.Perm(100000) {
+		s.Add(time.Duration(i) * 10 * time.Microsecond)
+	}
+
+	if s.Count() != 100000 || s.Summary().Max != 999990*time.Microsecond {
+		t.Errorf("Summary() = %+v, expected 100000 durations up to 999.99ms", s.Summary())
+	}
+
+	for _, p := range []float64{0.5, 0.95, 0.99} {
+		expected := time.Duration(p * float64(time.Second))
+		if actual := s.Percentile(p); (actual - expected).Abs() > 50*time.Millisecond {
+			t.Errorf("Percentile(%v) = %v, expected about %v", p, actual, expected)
+		}
+	}
+}
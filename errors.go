@@ -0,0 +1,117 @@
+package temporalis
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors returned, usually wrapped, by the functions in this package.
+// Test for them with errors.Is rather than by comparing error messages.
+var (
+	// ErrSyntax reports input that does not have the expected format.
+	ErrSyntax = errors.New("invalid syntax")
+	// ErrOutOfRange reports a value that is well-formed but outside the
+	// range allowed for it, such as February 30 or a latitude of 91.
+	ErrOutOfRange = errors.New("value out of range")
+	// ErrInvalidZone reports a time zone that is unknown or cannot be
+	// loaded.
+	ErrInvalidZone = errors.New("invalid time zone")
+	// ErrAmbiguousTime reports a wall-clock time that occurs twice in a
+	// location because clocks were turned back.
+	ErrAmbiguousTime = errors.New("ambiguous wall clock time")
+	// ErrNonexistentTime reports a wall-clock time that does not occur in a
+	// location because clocks were turned forward.
+	ErrNonexistentTime = errors.New("nonexistent wall clock time")
+	// ErrZeroTime is returned in Strict mode when an argument is the zero
+	// time, which usually means a value was never set.
+	ErrZeroTime = errors.New("zero time")
+	// ErrInvertedRange is returned when the end of a range is before its
+	// start.
+	ErrInvertedRange = errors.New("end is before start")
+	// ErrTimeout is matched by the errors that WithTimeout and RunUntil
+	// return when the function did not finish in time.
+	ErrTimeout = errors.New("timed out")
+)
+
+// ParseError records a failure to parse a value, in the manner of
+// strconv.NumError. Err wraps ErrSyntax or ErrOutOfRange, often together with
+// a more specific cause such as a *time.ParseError.
+type ParseError struct {
+	Func  string // the failing function, such as "ParseCivilDate"
+	Input string // the input
+	Err   error  // the reason the parse failed
+}
+
+// Error implements error.
+func (e *ParseError) Error() string {
+	return "temporalis." + e.Func + ": parsing " + strconv.Quote(e.Input) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the reason the parse failed.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// syntaxError returns a *ParseError for fn and input whose reason wraps
+// ErrSyntax, with detail appended to the message if it is not empty.
+func syntaxError(fn, input, detail string) error {
+	return &ParseError{Func: fn, Input: input, Err: detailed(ErrSyntax, detail)}
+}
+
+// rangeError is like syntaxError for ErrOutOfRange.
+func rangeError(fn, input, detail string) error {
+	return &ParseError{Func: fn, Input: input, Err: detailed(ErrOutOfRange, detail)}
+}
+
+// detailed returns err with detail appended to its message. The result
+// matches err with errors.Is.
+func detailed(err error, detail string) error {
+	if detail == "" {
+		return err
+	}
+
+	return &detailError{err: err, detail: detail}
+}
+
+type detailError struct {
+	err    error
+	detail string
+}
+
+func (e *detailError) Error() string { return e.err.Error() + ": " + e.detail }
+func (e *detailError) Unwrap() error { return e.err }
+
+// timeParseError converts an error from the time package's parsers into a
+// *ParseError for fn, classifying out-of-range fields as ErrOutOfRange and
+// everything else as ErrSyntax. The original error stays in the chain.
+func timeParseError(fn, input string, err error) error {
+	category := ErrSyntax
+
+	var pe *time.ParseError
+	if errors.As(err, &pe) && strings.Contains(pe.Message, "out of range") {
+		category = ErrOutOfRange
+	}
+
+	return &ParseError{Func: fn, Input: input, Err: &wrappedError{category: category, err: err}}
+}
+
+// numberError converts an error from strconv into a *ParseError for fn,
+// mapping strconv.ErrRange to ErrOutOfRange and anything else to ErrSyntax.
+func numberError(fn, input string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return &ParseError{Func: fn, Input: input, Err: ErrOutOfRange}
+	}
+
+	return &ParseError{Func: fn, Input: input, Err: ErrSyntax}
+}
+
+// wrappedError matches both a sentinel category and an underlying error.
+type wrappedError struct {
+	category error
+	err      error
+}
+
+func (e *wrappedError) Error() string   { return e.category.Error() + ": " + e.err.Error() }
+func (e *wrappedError) Unwrap() []error { return []error{e.category, e.err} }
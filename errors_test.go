@@ -0,0 +1,61 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestSentinelErrors checks that errors from across the package match the
+// sentinel errors with errors.Is.
+func TestSentinelErrors(t *testing.T) {
+	newYork, _ := LoadLocation("America/New_York")
+
+	_, zoneErr := LoadLocation("Mars/Olympus_Mons")
+	_, ambiguousErr := ResolveAmbiguous(time.Date(2024, time.November, 3, 1, 30, 0, 0, time.UTC), newYork, RejectAmbiguous)
+	_, gapErr := ResolveAmbiguous(time.Date(2024, time.March, 10, 2, 30, 0, 0, time.UTC), newYork, RejectInvalid)
+	_, dateRangeErr := ParseCivilDate("2024-02-30")
+	_, dateSyntaxErr := ParseCivilDate("yesterday")
+	_, clockErr := ParseTimeOfDay("25:00")
+	_, periodErr := ParsePeriod("P1X")
+	_, cronErr := ParseCron("61 * * * *")
+	_, rruleErr := ParseRRule("FREQ=DAILY;COUNT=x", time.Now())
+	_, durationErr := ParseDuration("999999999999999h")
+	_, parseErr := Parse(time.DateOnly, "2024-13-01")
+	_, coordErr := TimezoneOfNearestCity(91, 0)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"LoadLocation", zoneErr, ErrInvalidZone},
+		{"ResolveAmbiguous(ambiguous)", ambiguousErr, ErrAmbiguousTime},
+		{"ResolveAmbiguous(gap)", gapErr, ErrNonexistentTime},
+		{"ParseCivilDate(range)", dateRangeErr, ErrOutOfRange},
+		{"ParseCivilDate(syntax)", dateSyntaxErr, ErrSyntax},
+		{"ParseTimeOfDay", clockErr, ErrOutOfRange},
+		{"ParsePeriod", periodErr, ErrSyntax},
+		{"ParseCron", cronErr, ErrOutOfRange},
+		{"ParseRRule", rruleErr, ErrSyntax},
+		{"ParseDuration", durationErr, ErrOutOfRange},
+		{"Parse", parseErr, ErrOutOfRange},
+		{"TimezoneOfNearestCity", coordErr, ErrOutOfRange},
+	}
+
+	for _, test := range tests {
+		if !errors.Is(test.err, test.expected) {
+			t.Errorf("%s error = %v, expected it to match %v", test.name, test.err, test.expected)
+		}
+	}
+
+	var pe *ParseError
+	if !errors.As(dateSyntaxErr, &pe) || pe.Func != "ParseCivilDate" || pe.Input != "yesterday" {
+		t.Errorf("ParseCivilDate() error = %#v, expected a *ParseError", dateSyntaxErr)
+	}
+
+	var te *time.ParseError
+	if !errors.As(parseErr, &te) {
+		t.Errorf("Parse() error = %v, expected it to wrap a *time.ParseError", parseErr)
+	}
+}
@@ -0,0 +1,235 @@
+package temporalis
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Eval evaluates a time expression such as "now + 3 business days - 2h" or
+// "deadline - 1 week", so that rules like reminder times and grace periods
+// can be configured as text instead of code. An expression is a time
+// followed by any number of amounts, each added or subtracted:
+//
+//	now, today, tomorrow, yesterday   the current time, or midnight of the day
+//	2024-05-01, 2024-05-01T09:30      a date or date and time in the location
+//	"2024-05-01T09:30:00+02:00"       an RFC 3339 time, in single or double quotes
+//	created                           a name bound in env
+//
+//	2h, 90m, 1d12h                    an exact duration, as for ParseDuration
+//	3 hours, 15 minutes, 30 seconds   the same, spelled out
+//	2 days, 1 week, 3 months, 1 year  calendar units, which keep the wall-clock
+//	                                  time across DST changes
+//	3 business days                   business days of the calendar, keeping
+//	                                  the wall-clock time
+//
+// Amounts are applied from left to right, and matching is case-insensitive
+// except for names. The current time comes from WithClock, the location of
+// dates and calendar arithmetic from WithLocation, defaulting to
+// LocalLocation, and business days from WithCalendar. Errors are returned as
+// *ParseError wrapping ErrSyntax.
+func Eval(expr string, env map[string]time.Time, opts ...Option) (time.Time, error) {
+	o := NewOptions(opts...)
+	e := &evaluator{expr: expr, env: env, loc: o.locationOr(nil), now: o.now(), cal: o.Calendar}
+
+	tokens, err := e.tokenize()
+	if err != nil {
+		return time.Time{}, err
+	}
+	e.tokens = tokens
+
+	if len(e.tokens) == 0 {
+		return time.Time{}, syntaxError("Eval", expr, "empty expression")
+	}
+
+	t, err := e.base(e.next())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for e.more() {
+		op := e.next()
+		if op != "+" && op != "-" {
+			return time.Time{}, e.error("expected + or - before " + strconv.Quote(op))
+		}
+		if !e.more() {
+			return time.Time{}, e.error("missing amount after " + op)
+		}
+
+		if t, err = e.amount(t, op == "-"); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return t, nil
+}
+
+// evalUnits maps the spelled-out units of Eval to the fixed duration they
+// stand for.
+var evalUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+	"ms": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+}
+
+// evalCalendarUnits maps calendar units to the years, months and days they
+// add.
+var evalCalendarUnits = map[string][3]int{
+	"day": {0, 0, 1}, "days": {0, 0, 1},
+	"week": {0, 0, 7}, "weeks": {0, 0, 7},
+	"month": {0, 1, 0}, "months": {0, 1, 0},
+	"year": {1, 0, 0}, "years": {1, 0, 0},
+}
+
+// evalLayouts are the layouts of date literals in Eval.
+var evalLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+// evaluator holds the state of one call to Eval.
+type evaluator struct {
+	expr   string
+	env    map[string]time.Time
+	loc    *time.Location
+	now    time.Time
+	cal    *BusinessCalendar
+	tokens []string
+	pos    int
+}
+
+// tokenize splits the expression into operators, quoted literals and words.
+// A minus sign between two digits is part of a date rather than an
+// operator.
+func (e *evaluator) tokenize() ([]string, error) {
+	var tokens []string
+
+	s := e.expr
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '+' || c == '-':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, e.error("unterminated quote")
+			}
+			tokens = append(tokens, s[i:i+end+2])
+			i += end + 2
+		default:
+			start := i
+			for i < len(s) && !strings.ContainsRune(" \t\n+\"'", rune(s[i])) {
+				if s[i] == '-' && !(i > start && isDigit(s[i-1]) && i+1 < len(s) && isDigit(s[i+1])) {
+					break
+				}
+				i++
+			}
+			tokens = append(tokens, s[start:i])
+		}
+	}
+
+	return tokens, nil
+}
+
+// more reports whether tokens remain.
+func (e *evaluator) more() bool {
+	return e.pos < len(e.tokens)
+}
+
+// next consumes and returns the next token.
+func (e *evaluator) next() string {
+	token := e.tokens[e.pos]
+	e.pos++
+
+	return token
+}
+
+// error returns a syntax error for the expression.
+func (e *evaluator) error(detail string) error {
+	return syntaxError("Eval", e.expr, detail)
+}
+
+// base evaluates the time an expression starts from.
+func (e *evaluator) base(token string) (time.Time, error) {
+	today := DateOf(e.now.In(e.loc))
+
+	switch strings.ToLower(token) {
+	case "now":
+		return e.now.In(e.loc), nil
+	case "today":
+		return today.In(e.loc), nil
+	case "tomorrow":
+		return today.AddDays(1).In(e.loc), nil
+	case "yesterday":
+		return today.AddDays(-1).In(e.loc), nil
+	}
+
+	if t, ok := e.env[token]; ok {
+		return t, nil
+	}
+
+	literal := strings.Trim(token, `"'`)
+	for _, layout := range evalLayouts {
+		if t, err := time.ParseInLocation(layout, literal, e.loc); err == nil {
+			return t, nil
+		}
+	}
+
+	if token == "+" || token == "-" {
+		return time.Time{}, e.error("expected a time before " + token)
+	}
+
+	return time.Time{}, e.error("unknown time " + strconv.Quote(token))
+}
+
+// amount consumes an amount and adds it to t, or subtracts it if negate is
+// set.
+func (e *evaluator) amount(t time.Time, negate bool) (time.Time, error) {
+	token := e.next()
+
+	sign := 1
+	if negate {
+		sign = -1
+	}
+
+	n, err := strconv.Atoi(token)
+	if err != nil || !e.more() {
+		// Not a count followed by a unit, so an exact duration.
+		d, err := ParseDuration(token)
+		if err != nil {
+			return time.Time{}, e.error("invalid amount " + strconv.Quote(token))
+		}
+
+		return t.Add(time.Duration(sign) * d), nil
+	}
+
+	unit := strings.ToLower(e.next())
+	if unit == "business" || unit == "working" {
+		if !e.more() {
+			return time.Time{}, e.error("missing unit after " + unit)
+		}
+		if days := strings.ToLower(e.next()); days != "day" && days != "days" {
+			return time.Time{}, e.error("unknown unit " + strconv.Quote(unit+" "+days))
+		}
+
+		local := t.In(e.loc)
+		d := e.cal.AddBusinessDays(DateOf(local), sign*n)
+
+		return AddDateSafe(local, 0, 0, d.DaysSince(DateOf(local))), nil
+	}
+
+	if ymd, ok := evalCalendarUnits[unit]; ok {
+		// Months are clamped, so Jan 31 + 1 month is the end of February.
+		local := AddMonthsClamped(t.In(e.loc), sign*n*(12*ymd[0]+ymd[1]))
+
+		return AddDateSafe(local, 0, 0, sign*n*ymd[2]), nil
+	}
+
+	if d, ok := evalUnits[unit]; ok {
+		return t.Add(time.Duration(sign*n) * d), nil
+	}
+
+	return time.Time{}, e.error("unknown unit " + strconv.Quote(unit))
+}
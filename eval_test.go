@@ -0,0 +1,76 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestEval tests expressions over the current time, names, literals and
+// every kind of amount.
+func TestEval(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+
+	// Friday, 2024-03-29 10:00 in Berlin, two days before the DST change.
+	now := time.Date(2024, 3, 29, 10, 0, 0, 0, berlin)
+	env := map[string]time.Time{"created": time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)}
+	opts := []Option{WithClock(NewFakeClock(now)), WithLocation(berlin)}
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"now", now},
+		{"NOW + 2h", now.Add(2 * time.Hour)},
+		{"now+90m-30m", now.Add(time.Hour)},
+		{"now + 3 business days - 2h", time.Date(2024, 4, 3, 8, 0, 0, 0, berlin)},
+		{"now - 1 business day", time.Date(2024, 3, 28, 10, 0, 0, 0, berlin)},
+		{"now + 3 days", time.Date(2024, 4, 1, 10, 0, 0, 0, berlin)},
+		{"now + 72 hours", time.Date(2024, 4, 1, 11, 0, 0, 0, berlin)},
+		{"today + 1 month", time.Date(2024, 4, 29, 0, 0, 0, 0, berlin)},
+		{"tomorrow", time.Date(2024, 3, 30, 0, 0, 0, 0, berlin)},
+		{"yesterday - 1 year", time.Date(2023, 3, 28, 0, 0, 0, 0, berlin)},
+		{"created + 1 week", time.Date(2024, 3, 8, 9, 0, 0, 0, berlin)},
+		{"2024-05-01 - 1d", time.Date(2024, 4, 30, 0, 0, 0, 0, berlin)},
+		{"2024-05-01T09:30 + 15 minutes", time.Date(2024, 5, 1, 9, 45, 0, 0, berlin)},
+		{`"2024-05-01T09:30:00+09:00" + 1 working day`, time.Date(2024, 5, 2, 2, 30, 0, 0, berlin)},
+		{"2024-01-31 + 1 month", time.Date(2024, 2, 29, 0, 0, 0, 0, berlin)},
+		{"2024-03-31 - 1 month + 1 year", time.Date(2025, 2, 28, 0, 0, 0, 0, berlin)},
+		{"2024-03-30T02:30 + 1 day", time.Date(2024, 3, 31, 3, 30, 0, 0, berlin)},
+	}
+
+	for _, test := range tests {
+		actual, err := Eval(test.expr, env, opts...)
+		if err != nil {
+			t.Errorf("Eval(%q) returned error: %v", test.expr, err)
+			continue
+		}
+
+		if !actual.Equal(test.expected) {
+			t.Errorf("Eval(%q) = %v, expected %v", test.expr, actual, test.expected)
+		}
+	}
+}
+
+// TestEvalErrors tests that malformed expressions are rejected with
+// ErrSyntax.
+func TestEvalErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"+ 2h",
+		"later",
+		"now 2h",
+		"now +",
+		"now + 2 fortnights",
+		"now + 2 business hours",
+		"now + soon",
+		`"2024-05-01`,
+	} {
+		if _, err := Eval(expr, nil, WithLocation(time.UTC)); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Eval(%q) error = %v, expected ErrSyntax", expr, err)
+		}
+	}
+}
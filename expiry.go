@@ -0,0 +1,254 @@
+package temporalis
+
+import (
+	"sync"
+	"time"
+)
+
+// Expiring holds a value that is only valid until a deadline, such as a
+// cached token or lookup result. The zero value holds nothing and reads the
+// system clock. An Expiring is safe for concurrent use.
+type Expiring[T any] struct {
+	clock Clock
+
+	mu       sync.Mutex
+	value    T
+	deadline time.Time
+}
+
+// NewExpiring returns an Expiring holding v for ttl, reading the clock c or
+// the system clock if c is nil.
+func NewExpiring[T any](v T, ttl time.Duration, c Clock) *Expiring[T] {
+	e := &Expiring[T]{clock: c}
+	e.Set(v, ttl)
+
+	return e
+}
+
+// Get returns the value and true if it has not expired, and the zero value
+// and false otherwise.
+func (e *Expiring[T]) Get() (T, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.liveLocked() {
+		var zero T
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+// Set replaces the value and makes it valid for ttl from now.
+func (e *Expiring[T]) Set(v T, ttl time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.value = v
+	e.deadline = clockOrSystem(e.clock).Now().Add(ttl)
+}
+
+// Refresh makes the value valid for ttl from now, for sliding expiry. It
+// does nothing and returns false if the value has already expired, since an
+// expired value must not come back.
+func (e *Expiring[T]) Refresh(ttl time.Duration) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.liveLocked() {
+		return false
+	}
+
+	e.deadline = clockOrSystem(e.clock).Now().Add(ttl)
+
+	return true
+}
+
+// Remaining returns how long the value stays valid, or zero if it has
+// expired.
+func (e *Expiring[T]) Remaining() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return max(e.deadline.Sub(clockOrSystem(e.clock).Now()), 0)
+}
+
+// Deadline returns when the value expires, or the zero time if no value has
+// been set.
+func (e *Expiring[T]) Deadline() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.deadline
+}
+
+// liveLocked reports whether the value has not expired. e.mu must be held.
+func (e *Expiring[T]) liveLocked() bool {
+	return clockOrSystem(e.clock).Now().Before(e.deadline)
+}
+
+// expiryEntry is a value in an ExpiryMap with its deadline.
+type expiryEntry[V any] struct {
+	value    V
+	deadline time.Time
+}
+
+// ExpiryMap is a map whose entries expire a time-to-live after they are set.
+// Expired entries are never returned: they are removed when they are found
+// by a lookup, and, if the map was created with a sweep interval, by a
+// periodic sweep so that keys that are never looked up again do not keep
+// their memory. The sweep only runs while the map has entries. An ExpiryMap
+// is safe for concurrent use.
+type ExpiryMap[K comparable, V any] struct {
+	clock Clock
+	ttl   time.Duration
+	sweep time.Duration
+
+	mu      sync.Mutex
+	entries map[K]expiryEntry[V]
+	timer   Timer
+	stopped bool
+}
+
+// NewExpiryMap returns an empty ExpiryMap whose entries expire ttl after
+// they are set, reading the clock c or the system clock if c is nil. If
+// sweep is positive, expired entries are also removed in the background
+// every sweep; otherwise they are only removed when they are found. It
+// panics if ttl is not positive.
+func NewExpiryMap[K comparable, V any](ttl, sweep time.Duration, c Clock) *ExpiryMap[K, V] {
+	if ttl <= 0 {
+		panic("temporalis: non-positive TTL for NewExpiryMap")
+	}
+
+	return &ExpiryMap[K, V]{
+		clock:   clockOrSystem(c),
+		ttl:     ttl,
+		sweep:   sweep,
+		entries: make(map[K]expiryEntry[V]),
+	}
+}
+
+// Get returns the value for key and true if it is present and has not
+// expired, removing it if it has.
+func (m *ExpiryMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok || !m.clock.Now().Before(e.deadline) {
+		delete(m.entries, key)
+		var zero V
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+// Set stores value for key, valid for the map's time-to-live.
+func (m *ExpiryMap[K, V]) Set(key K, value V) {
+	m.SetTTL(key, value, m.ttl)
+}
+
+// SetTTL stores value for key, valid for ttl instead of the map's
+// time-to-live.
+func (m *ExpiryMap[K, V]) SetTTL(key K, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = expiryEntry[V]{value: value, deadline: m.clock.Now().Add(ttl)}
+	m.scheduleLocked()
+}
+
+// Refresh makes the entry for key valid for the map's time-to-live from now.
+// It returns false if there is no entry or it has expired.
+func (m *ExpiryMap[K, V]) Refresh(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	e, ok := m.entries[key]
+	if !ok || !now.Before(e.deadline) {
+		delete(m.entries, key)
+		return false
+	}
+
+	e.deadline = now.Add(m.ttl)
+	m.entries[key] = e
+
+	return true
+}
+
+// Remaining returns how long the entry for key stays valid, or zero if
+// there is none.
+func (m *ExpiryMap[K, V]) Remaining(key K) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return 0
+	}
+
+	return max(e.deadline.Sub(m.clock.Now()), 0)
+}
+
+// Delete removes the entry for key.
+func (m *ExpiryMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+}
+
+// Len returns the number of entries that have not expired, removing the
+// others.
+func (m *ExpiryMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictLocked()
+
+	return len(m.entries)
+}
+
+// Stop ends the background sweep. The map keeps working, with expired
+// entries removed only when they are found.
+func (m *ExpiryMap[K, V]) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stopped = true
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+}
+
+// evictLocked removes expired entries. m.mu must be held.
+func (m *ExpiryMap[K, V]) evictLocked() {
+	now := m.clock.Now()
+	for key, e := range m.entries {
+		if !now.Before(e.deadline) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// scheduleLocked starts the background sweep if it is enabled and not
+// already pending. m.mu must be held.
+func (m *ExpiryMap[K, V]) scheduleLocked() {
+	if m.sweep <= 0 || m.stopped || m.timer != nil {
+		return
+	}
+
+	m.timer = m.clock.AfterFunc(m.sweep, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.timer = nil
+		m.evictLocked()
+		if len(m.entries) > 0 {
+			m.scheduleLocked()
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestExpiring tests reading, refreshing and expiry of a single value.
+func TestExpiring(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	e := NewExpiring("token", time.Minute, clock)
+
+	if v, ok := e.Get(); !ok || v != "token" {
+		t.Errorf("Get() = %q, %v, expected %q, true", v, ok, "token")
+	}
+
+	clock.Advance(40 * time.Second)
+	if !e.Refresh(time.Minute) || e.Remaining() != time.Minute {
+		t.Errorf("Remaining() after Refresh() = %v, expected 1m", e.Remaining())
+	}
+
+	clock.Advance(time.Minute)
+	if v, ok := e.Get(); ok || v != "" {
+		t.Errorf("Get() after expiry = %q, %v, expected %q, false", v, ok, "")
+	}
+	if e.Refresh(time.Minute) {
+		t.Errorf("Refresh() after expiry = true, expected false")
+	}
+	if e.Remaining() != 0 {
+		t.Errorf("Remaining() after expiry = %v, expected 0", e.Remaining())
+	}
+
+	var zero Expiring[int]
+	if _, ok := zero.Get(); ok {
+		t.Errorf("Get() on the zero value = true, expected false")
+	}
+}
+
+// TestExpiryMap tests lazy expiry, per-entry TTLs and refreshing.
+func TestExpiryMap(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	m := NewExpiryMap[string, int](time.Minute, 0, clock)
+
+	m.Set("a", 1)
+	m.SetTTL("b", 2, 2*time.Minute)
+	m.Set("c", 3)
+
+	clock.Advance(30 * time.Second)
+	if !m.Refresh("c") {
+		t.Errorf("Refresh(c) = false, expected true")
+	}
+
+	clock.Advance(45 * time.Second)
+	if _, ok := m.Get("a"); ok {
+		t.Errorf("Get(a) after its TTL = true, expected false")
+	}
+	if v, ok := m.Get("b"); !ok || v != 2 {
+		t.Errorf("Get(b) = %d, %v, expected 2, true", v, ok)
+	}
+	if m.Remaining("c") != 15*time.Second {
+		t.Errorf("Remaining(c) = %v, expected 15s", m.Remaining("c"))
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, expected 2", m.Len())
+	}
+
+	m.Delete("b")
+	clock.Advance(time.Minute)
+	if m.Refresh("c") || m.Len() != 0 {
+		t.Errorf("Refresh(c) after expiry succeeded or Len() = %d, expected 0", m.Len())
+	}
+}
+
+// TestExpiryMapSweep tests that the background sweep removes entries that
+// are never looked up and stops once the map is empty.
+func TestExpiryMapSweep(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	m := NewExpiryMap[int, string](time.Minute, 30*time.Second, clock)
+	defer m.Stop()
+
+	if clock.Waiters() != 0 {
+		t.Errorf("Waiters() of an empty map = %d, expected 0", clock.Waiters())
+	}
+
+	m.Set(1, "one")
+	m.Set(2, "two")
+	clock.Advance(30 * time.Second)
+	m.Set(3, "three")
+
+	clock.Advance(30 * time.Second)
+	m.mu.Lock()
+	n := len(m.entries)
+	m.mu.Unlock()
+	if n != 1 {
+		t.Errorf("entries after sweep = %d, expected 1", n)
+	}
+
+	clock.Advance(30 * time.Second)
+	if clock.Waiters() != 0 {
+		t.Errorf("Waiters() after the last entry expired = %d, expected 0", clock.Waiters())
+	}
+}
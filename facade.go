@@ -0,0 +1,158 @@
+package temporalis
+
+import (
+	"context"
+	"time"
+)
+
+// Temporalis bundles the defaults an application would otherwise pass to
+// every call: the clock, the time zone, the locale and the business calendar.
+// Its methods mirror the package functions and apply those defaults, with
+// options passed to a method taking precedence. Applications configure one
+// instance at startup and inject it where needed; tests inject one with a
+// FakeClock. The zero value uses the system clock, LocalLocation, English
+// and a Monday to Friday week. The fields must not be changed while methods
+// are running on other goroutines.
+type Temporalis struct {
+	// Clock provides the current time. If nil, SystemClock is used.
+	Clock Clock
+	// Location is the default time zone. If nil, LocalLocation is used.
+	Location *time.Location
+	// Locale is the default language of generated text.
+	Locale string
+	// Calendar decides which days are business days. If nil, Saturday and
+	// Sunday are the weekend and there are no holidays.
+	Calendar *BusinessCalendar
+}
+
+// New returns a Temporalis whose defaults are taken from opts, so that
+// New(WithLocation(loc), WithClock(clock)) is equivalent to setting the
+// fields directly.
+func New(opts ...Option) *Temporalis {
+	o := NewOptions(opts...)
+
+	return &Temporalis{Clock: o.Clock, Location: o.Location, Locale: o.Locale, Calendar: o.Calendar}
+}
+
+// Options returns the defaults of tp as options, followed by opts, for
+// passing to package functions that are not mirrored by a method.
+func (tp *Temporalis) Options(opts ...Option) []Option {
+	defaults := []Option{WithClock(tp.Clock), WithLocation(tp.location()), WithLocale(tp.Locale), WithCalendar(tp.Calendar)}
+
+	return append(defaults, opts...)
+}
+
+// Now returns the current time of the clock in the default location.
+func (tp *Temporalis) Now() time.Time {
+	return clockOrSystem(tp.Clock).Now().In(tp.location())
+}
+
+// Today returns the current date in the default location.
+func (tp *Temporalis) Today() CivilDate {
+	return DateOf(tp.Now())
+}
+
+// Since returns the time elapsed since t according to the clock.
+func (tp *Temporalis) Since(t time.Time) time.Duration {
+	return clockOrSystem(tp.Clock).Now().Sub(t)
+}
+
+// Until returns the duration until t according to the clock.
+func (tp *Temporalis) Until(t time.Time) time.Duration {
+	return t.Sub(clockOrSystem(tp.Clock).Now())
+}
+
+// After waits for the duration to elapse on the clock and then sends the
+// current time on the returned channel.
+func (tp *Temporalis) After(d time.Duration) <-chan time.Time {
+	return clockOrSystem(tp.Clock).After(d)
+}
+
+// Sleep pauses for d on the clock, or until ctx is cancelled, and returns
+// the context error in the latter case.
+func (tp *Temporalis) Sleep(ctx context.Context, d time.Duration) error {
+	return sleepClock(ctx, clockOrSystem(tp.Clock), d)
+}
+
+// Parse parses a formatted string like the package function Parse, except
+// that times without zone information are taken to be in the default
+// location.
+func (tp *Temporalis) Parse(layout, value string) (time.Time, error) {
+	return ParseInLocation(layout, value, tp.location())
+}
+
+// FormatDuration formats d like the package function, in the default
+// locale unless opts select another.
+func (tp *Temporalis) FormatDuration(d time.Duration, opts ...Option) string {
+	return FormatDuration(d, tp.Options(opts...)...)
+}
+
+// ParseDeadline parses a deadline phrase relative to the current time of the
+// clock, in the default location and with the default calendar.
+func (tp *Temporalis) ParseDeadline(phrase string, opts ...Option) (time.Time, error) {
+	return ParseDeadline(phrase, tp.Options(opts...)...)
+}
+
+// Eval evaluates a time expression relative to the current time of the
+// clock, in the default location and with the default calendar.
+func (tp *Temporalis) Eval(expr string, env map[string]time.Time, opts ...Option) (time.Time, error) {
+	return Eval(expr, env, tp.Options(opts...)...)
+}
+
+// StartOfPeriod returns the start of the period of the given granularity
+// that contains t, in the default location.
+func (tp *Temporalis) StartOfPeriod(t time.Time, g Granularity, opts ...Option) time.Time {
+	return StartOfPeriod(t, g, tp.Options(opts...)...)
+}
+
+// NextPeriod returns the start of the period following the one that
+// contains t, in the default location.
+func (tp *Temporalis) NextPeriod(t time.Time, g Granularity, opts ...Option) time.Time {
+	return NextPeriod(t, g, tp.Options(opts...)...)
+}
+
+// BusinessDays returns the number of business days of the calendar from the
+// date of from to the date of to, both inclusive and taken in the default
+// location, or zero if to is before from.
+func (tp *Temporalis) BusinessDays(from, to time.Time) int {
+	loc := tp.location()
+
+	return tp.Calendar.CountBusinessDays(DateOf(from.In(loc)), DateOf(to.In(loc)))
+}
+
+// IsBusinessDay reports whether the date of t in the default location is a
+// business day of the calendar.
+func (tp *Temporalis) IsBusinessDay(t time.Time) bool {
+	return tp.Calendar.IsBusinessDay(DateOf(t.In(tp.location())))
+}
+
+// AddBusinessDays returns the date n business days after d according to
+// the calendar, or before it if n is negative.
+func (tp *Temporalis) AddBusinessDays(d CivilDate, n int) CivilDate {
+	return tp.Calendar.AddBusinessDays(d, n)
+}
+
+// ResolveReportPeriod resolves the name of a reporting period at the
+// current time of the clock, in the default location.
+func (tp *Temporalis) ResolveReportPeriod(spec string) (Interval, error) {
+	return ResolveReportPeriod(spec, tp.Now(), tp.location())
+}
+
+// NewScheduler returns a scheduler that runs on the clock.
+func (tp *Temporalis) NewScheduler(ctx context.Context, opts ...Option) *Scheduler {
+	return NewScheduler(ctx, tp.Options(opts...)...)
+}
+
+// NewBudget returns a time budget measured on the clock.
+func (tp *Temporalis) NewBudget(total time.Duration, opts ...Option) *Budget {
+	return NewBudget(total, tp.Options(opts...)...)
+}
+
+// location returns the default location.
+func (tp *Temporalis) location() *time.Location {
+	if tp.Location == nil {
+		return LocalLocation()
+	}
+
+	return tp.Location
+}
@@ -0,0 +1,72 @@
+package temporalis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestTemporalis tests that the methods of Temporalis apply its defaults and
+// that per-call options override them.
+func TestTemporalis(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	clock := NewFakeClock(time.Date(2024, time.March, 15, 20, 0, 0, 0, time.UTC))
+
+	cal := NewBusinessCalendar(NewCivilDate(2024, time.March, 18))
+	tp := New(WithClock(clock), WithLocation(tokyo), WithLocale("de"), WithCalendar(cal))
+
+	if now := tp.Now(); now.Location() != tokyo || !now.Equal(clock.Now()) {
+		t.Errorf("Now() = %v, expected %v in JST", now, clock.Now())
+	}
+	if today := tp.Today(); today != NewCivilDate(2024, time.March, 16) {
+		t.Errorf("Today() = %v, expected 2024-03-16", today)
+	}
+	if since := tp.Since(clock.Now().Add(-time.Minute)); since != time.Minute {
+		t.Errorf("Since() = %v, expected 1m", since)
+	}
+
+	if s := tp.FormatDuration(2 * time.Hour); s != "2 Stunden" {
+		t.Errorf("FormatDuration() = %q, expected %q", s, "2 Stunden")
+	}
+	if s := tp.FormatDuration(2*time.Hour, WithLocale("en")); s != "2 hours" {
+		t.Errorf("FormatDuration() = %q, expected %q", s, "2 hours")
+	}
+
+	parsed, err := tp.Parse("2006-01-02 15:04", "2024-03-15 09:30")
+	if err != nil || parsed.Location() != tokyo {
+		t.Errorf("Parse() = %v, %v, expected a time in JST", parsed, err)
+	}
+
+	from := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC)
+	if n := tp.BusinessDays(from, to); n != 3 {
+		t.Errorf("BusinessDays() = %d, expected 3", n)
+	}
+	if tp.IsBusinessDay(time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("IsBusinessDay() = true for a holiday, expected false")
+	}
+
+	week := tp.StartOfPeriod(clock.Now(), Weekly)
+	if expected := time.Date(2024, time.March, 11, 0, 0, 0, 0, tokyo); !week.Equal(expected) {
+		t.Errorf("StartOfPeriod() = %v, expected %v", week, expected)
+	}
+
+	deadline, err := tp.ParseDeadline("EOD")
+	if expected := time.Date(2024, time.March, 19, EndOfBusinessHour, 0, 0, 0, tokyo); err != nil || !deadline.Equal(expected) {
+		t.Errorf("ParseDeadline() = %v, %v, expected %v", deadline, err, expected)
+	}
+}
+
+// TestTemporalisZeroValue tests that the zero value uses the system clock
+// and time.Local.
+func TestTemporalisZeroValue(t *testing.T) {
+	var tp Temporalis
+
+	if now := tp.Now(); now.Location() != time.Local || time.Since(now) > time.Minute {
+		t.Errorf("Now() = %v, expected the current local time", now)
+	}
+
+	if err := tp.Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("Sleep() = %v, expected nil", err)
+	}
+}
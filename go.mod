@@ -1,3 +1,3 @@
 module github.com/goify/temporalis
 
-go 1.21.0
+go 1.24
@@ -0,0 +1,63 @@
+package temporalis
+
+import "math"
+
+// Altitudes of the centre of the sun, in degrees, that bound the light
+// windows photographers plan around.
+const (
+	goldenHourLow  = -4
+	goldenHourHigh = 6
+	blueHourLow    = -6
+	blueHourHigh   = -4
+)
+
+// GoldenHour returns the morning and evening golden hours on the given date
+// at the given latitude and longitude, in degrees north and east: the times
+// when the sun is between 4 degrees below and 6 degrees above the horizon,
+// and the light is warm and soft. The date is the local date at that
+// longitude and the intervals are in UTC. Near the poles the windows can
+// last for hours, and in midwinter, when the sun stays low all day, the
+// morning window runs into the evening one at solar noon. The boolean
+// result is false if the sun does not enter the window that day.
+func GoldenHour(d CivilDate, lat, lon float64) (morning, evening Interval, ok bool) {
+	return solarWindow(d, lat, lon, goldenHourLow, goldenHourHigh)
+}
+
+// BlueHour returns the morning and evening blue hours, when the sun is
+// between 6 and 4 degrees below the horizon and the sky takes on a deep blue,
+// in the manner of GoldenHour. The morning blue hour ends as the golden hour
+// begins, and the evening one begins as it ends.
+func BlueHour(d CivilDate, lat, lon float64) (morning, evening Interval, ok bool) {
+	return solarWindow(d, lat, lon, blueHourLow, blueHourHigh)
+}
+
+// solarWindow returns the morning and evening intervals on the date in
+// which the altitude of the centre of the sun is from low to high degrees.
+func solarWindow(d CivilDate, lat, lon, low, high float64) (morning, evening Interval, ok bool) {
+	const rad = math.Pi / 180
+
+	transit, sinDecl := solarDay(d, lon)
+	decl := math.Asin(sinDecl) / rad
+
+	highest := 90 - math.Abs(lat-decl)
+	lowest := math.Abs(lat+decl) - 90
+	if highest < low || lowest > high {
+		return Interval{}, Interval{}, false
+	}
+
+	// The window opens when the sun rises through low, or at the lowest
+	// point of the sun if it never sets that far, and closes when it
+	// rises through high, or at noon if it never gets that high.
+	opens, closes := 0.5, 0.0
+	if lowest < low {
+		opens, _ = solarHourAngle(lat, sinDecl, low)
+	}
+	if highest > high {
+		closes, _ = solarHourAngle(lat, sinDecl, high)
+	}
+
+	morning = Interval{Start: fromJ2000Days(transit - opens), End: fromJ2000Days(transit - closes)}
+	evening = Interval{Start: fromJ2000Days(transit + closes), End: fromJ2000Days(transit + opens)}
+
+	return morning, evening, true
+}
@@ -0,0 +1,67 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestGoldenHour tests the golden and blue hours in London at midsummer.
+func TestGoldenHour(t *testing.T) {
+	date := CivilDate{2024, time.June, 21}
+	const lat, lon = 51.5074, -0.1278
+
+	golden, goldenEvening, ok := GoldenHour(date, lat, lon)
+	if !ok {
+		t.Fatal("GoldenHour() reported no golden hour")
+	}
+	blue, blueEvening, ok := BlueHour(date, lat, lon)
+	if !ok {
+		t.Fatal("BlueHour() reported no blue hour")
+	}
+
+	sunrise, _ := Sunrise.On(date, lat, lon)
+	sunset, _ := Sunset.On(date, lat, lon)
+	if !golden.Contains(sunrise) || !goldenEvening.Contains(sunset) {
+		t.Errorf("GoldenHour() = %v, %v, expected to contain sunrise %v and sunset %v", golden, goldenEvening, sunrise, sunset)
+	}
+
+	// The sun takes a little over an hour to climb ten degrees in a London
+	// summer.
+	if d := golden.Duration(); d < time.Hour || d > 90*time.Minute {
+		t.Errorf("GoldenHour() morning lasts %v, expected 60 to 90 minutes", d)
+	}
+
+	if !blue.End.Equal(golden.Start) || !blueEvening.Start.Equal(goldenEvening.End) {
+		t.Errorf("BlueHour() = %v, %v, expected to adjoin GoldenHour() = %v, %v", blue, blueEvening, golden, goldenEvening)
+	}
+	if !blue.Start.Before(blue.End) || !blueEvening.Start.Before(blueEvening.End) {
+		t.Errorf("BlueHour() = %v, %v, expected non-empty intervals", blue, blueEvening)
+	}
+}
+
+// TestGoldenHourPolar tests the windows in Tromsø, where the sun never sets
+// at midsummer and never rises at midwinter.
+func TestGoldenHourPolar(t *testing.T) {
+	const lat, lon = 69.6492, 18.9553
+
+	summer := CivilDate{2024, time.June, 21}
+	if _, _, ok := BlueHour(summer, lat, lon); ok {
+		t.Error("BlueHour() at midsummer reported a blue hour")
+	}
+
+	// The sun stays above -4 degrees, so the evening golden hour runs into
+	// the morning one at midnight.
+	morning, evening, ok := GoldenHour(summer, lat, lon)
+	if !ok || !evening.End.Equal(morning.Start.Add(24*time.Hour)) {
+		t.Errorf("GoldenHour() at midsummer = %v, %v, %v, expected to meet at midnight", morning, evening, ok)
+	}
+
+	// At midwinter the sun peaks below -3 degrees, so the golden hour
+	// lasts from late morning to early afternoon.
+	winter := CivilDate{2024, time.December, 21}
+	morning, evening, ok = GoldenHour(winter, lat, lon)
+	noon, _ := SolarNoon.On(winter, lat, lon)
+	if !ok || !morning.End.Equal(noon) || !evening.Start.Equal(noon) {
+		t.Errorf("GoldenHour() at midwinter = %v, %v, %v, expected to meet at %v", morning, evening, ok, noon)
+	}
+}
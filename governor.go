@@ -0,0 +1,244 @@
+package temporalis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// governorSamples is the number of recent task durations a PoolGovernor
+// computes its percentile from.
+const governorSamples = 100
+
+// governorMinSamples is the number of durations a PoolGovernor needs before
+// it resizes the pool.
+const governorMinSamples = 20
+
+// PoolStats is a snapshot of the state of a PoolGovernor.
+type PoolStats struct {
+	// Limit is the current number of tasks allowed to run at once.
+	Limit int
+	// Running is the number of tasks running, including tasks that timed
+	// out but have not returned yet.
+	Running int
+	// Waiting is the number of tasks waiting for a slot.
+	Waiting int
+	// P95 is the 95th percentile of the durations of recent tasks.
+	P95 time.Duration
+	// Completed is the number of tasks that have returned.
+	Completed int
+	// TimedOut is the number of tasks that exceeded the timeout.
+	TimedOut int
+}
+
+// PoolGovernor limits how many tasks of a worker pool run at once and
+// adapts the limit to keep the 95th percentile of task durations under a
+// target, on the assumption that tasks slow down when they compete for a
+// shared resource such as a database. Like TCP congestion control, it
+// raises the limit by one while tasks are fast and there is work waiting,
+// and cuts it by a quarter when they are slow. It can also enforce a
+// timeout on each task. A PoolGovernor is safe for concurrent use.
+type PoolGovernor struct {
+	// Timeout, if positive, is the longest a task may run. It must be set
+	// before the first task.
+	Timeout time.Duration
+
+	clock    Clock
+	target   time.Duration
+	min, max int
+
+	mu        sync.Mutex
+	limit     int
+	running   int
+	waiters   []chan struct{}
+	samples   []time.Duration
+	next      int
+	rounds    int
+	saturated bool
+	completed int
+	timedOut  int
+}
+
+// NewPoolGovernor returns a governor that keeps the 95th percentile of task
+// durations under target by running between minWorkers and maxWorkers tasks
+// at once, starting with minWorkers. The WithClock option selects the clock
+// that measures the tasks. It panics if target is not positive, minWorkers
+// is less than one or maxWorkers is less than minWorkers.
+func NewPoolGovernor(target time.Duration, minWorkers, maxWorkers int, opts ...Option) *PoolGovernor {
+	if target <= 0 || minWorkers < 1 || maxWorkers < minWorkers {
+		panic("temporalis: invalid target or worker counts for NewPoolGovernor")
+	}
+
+	o := NewOptions(opts...)
+
+	return &PoolGovernor{
+		clock:  clockOrSystem(o.Clock),
+		target: target,
+		min:    minWorkers,
+		max:    maxWorkers,
+		limit:  minWorkers,
+	}
+}
+
+// Run waits for a slot, runs task and returns its error. If ctx is done
+// while waiting, Run returns the error of ctx without running task. If the
+// governor has a timeout and task runs longer, the context passed to task
+// is cancelled and Run returns a *TimeoutError without waiting for task to
+// return; the slot stays taken until it does.
+func (g *PoolGovernor) Run(ctx context.Context, task func(ctx context.Context) error) error {
+	if err := g.acquire(ctx); err != nil {
+		return err
+	}
+
+	taskCtx, cancel := ctx, context.CancelFunc(func() {})
+	if g.Timeout > 0 {
+		taskCtx, cancel = withClockTimeout(ctx, g.clock, g.Timeout)
+	}
+	deadline := g.clock.Now().Add(g.Timeout)
+
+	done := make(chan error, 1)
+	go func() {
+		defer cancel()
+
+		sw := &Stopwatch{Clock: g.clock}
+		sw.Start()
+		err := task(taskCtx)
+		g.release(sw.Stop())
+
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if g.Timeout > 0 && errors.Is(context.Cause(taskCtx), context.DeadlineExceeded) && ctx.Err() == nil {
+			g.countTimeout()
+			return &TimeoutError{Deadline: deadline, Err: err}
+		}
+		return err
+	case <-taskCtx.Done():
+		if ctx.Err() != nil {
+			return <-done
+		}
+		g.countTimeout()
+		return &TimeoutError{Deadline: deadline}
+	}
+}
+
+// Stats returns the current state of the governor.
+func (g *PoolGovernor) Stats() PoolStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return PoolStats{
+		Limit:     g.limit,
+		Running:   g.running,
+		Waiting:   len(g.waiters),
+		P95:       g.p95Locked(),
+		Completed: g.completed,
+		TimedOut:  g.timedOut,
+	}
+}
+
+// acquire waits for a slot or for ctx to be done.
+func (g *PoolGovernor) acquire(ctx context.Context) error {
+	g.mu.Lock()
+	if g.running < g.limit && len(g.waiters) == 0 {
+		g.running++
+		g.mu.Unlock()
+		return nil
+	}
+
+	g.saturated = true
+	ready := make(chan struct{})
+	g.waiters = append(g.waiters, ready)
+	g.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		if i := slices.Index(g.waiters, ready); i >= 0 {
+			g.waiters = slices.Delete(g.waiters, i, i+1)
+			return ctx.Err()
+		}
+
+		// The slot was handed over while ctx was done; pass it on.
+		g.running--
+		g.wakeLocked()
+
+		return ctx.Err()
+	}
+}
+
+// release frees the slot of a task that took d and resizes the pool.
+func (g *PoolGovernor) release(d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.running--
+	g.completed++
+
+	if len(g.samples) < governorSamples {
+		g.samples = append(g.samples, d)
+	} else {
+		g.samples[g.next] = d
+		g.next = (g.next + 1) % governorSamples
+	}
+
+	// Resize once per round of limit tasks, so that each decision sees
+	// the effect of the previous one.
+	g.rounds++
+	if g.rounds >= g.limit && len(g.samples) >= governorMinSamples {
+		g.adjustLocked()
+	}
+
+	g.wakeLocked()
+}
+
+// adjustLocked resizes the pool from the recent durations. g.mu must be
+// held.
+func (g *PoolGovernor) adjustLocked() {
+	p95 := g.p95Locked()
+
+	switch {
+	case p95 > g.target && g.limit > g.min:
+		g.limit = max(g.limit*3/4, g.min)
+		// Durations measured at the old limit no longer apply.
+		g.samples, g.next = g.samples[:0], 0
+	case p95 <= g.target && g.saturated && g.limit < g.max:
+		g.limit++
+	}
+
+	g.rounds, g.saturated = 0, false
+}
+
+// wakeLocked hands free slots to waiting tasks in order. g.mu must be held.
+func (g *PoolGovernor) wakeLocked() {
+	for g.running < g.limit && len(g.waiters) > 0 {
+		g.running++
+		close(g.waiters[0])
+		g.waiters = g.waiters[1:]
+	}
+}
+
+// p95Locked returns the 95th percentile of the recent durations. g.mu must
+// be held.
+func (g *PoolGovernor) p95Locked() time.Duration {
+	sorted := slices.Clone(g.samples)
+	slices.Sort(sorted)
+
+	return percentile(sorted, 0.95)
+}
+
+// countTimeout counts a task that exceeded the timeout.
+func (g *PoolGovernor) countTimeout() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.timedOut++
+}
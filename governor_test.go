@@ -0,0 +1,112 @@
+package temporalis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestPoolGovernorResize tests that the limit grows while saturated tasks
+// are fast and shrinks when they are slow.
+func TestPoolGovernorResize(t *testing.T) {
+	g := NewPoolGovernor(100*time.Millisecond, 1, 4)
+
+	complete := func(n int, d time.Duration) {
+		for range n {
+			g.mu.Lock()
+			g.running++
+			g.saturated = true
+			g.mu.Unlock()
+			g.release(d)
+		}
+	}
+
+	complete(governorMinSamples, 10*time.Millisecond)
+	if limit := g.Stats().Limit; limit != 2 {
+		t.Errorf("Limit after fast tasks = %d, expected 2", limit)
+	}
+
+	complete(100, 10*time.Millisecond)
+	if limit := g.Stats().Limit; limit != 4 {
+		t.Errorf("Limit after many fast tasks = %d, expected the maximum 4", limit)
+	}
+
+	complete(governorMinSamples, time.Second)
+	if stats := g.Stats(); stats.Limit != 3 || stats.P95 != time.Second {
+		t.Errorf("Stats() after slow tasks = %+v, expected limit 3 and P95 1s", stats)
+	}
+
+	complete(100, time.Second)
+	if limit := g.Stats().Limit; limit != 1 {
+		t.Errorf("Limit after many slow tasks = %d, expected the minimum 1", limit)
+	}
+}
+
+// TestPoolGovernorLimit tests that tasks beyond the limit wait, and that a
+// waiting task gives up when its context is done.
+func TestPoolGovernorLimit(t *testing.T) {
+	g := NewPoolGovernor(time.Second, 1, 1)
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	go g.Run(context.Background(), func(context.Context) error {
+		close(started)
+		<-block
+		return nil
+	})
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- g.Run(ctx, func(context.Context) error {
+			t.Error("task ran after its context was cancelled")
+			return nil
+		})
+	}()
+
+	for g.Stats().Waiting == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, expected %v", err, context.Canceled)
+	}
+
+	close(block)
+	failure := errors.New("failure")
+	if err := g.Run(context.Background(), func(context.Context) error { return failure }); err != failure {
+		t.Errorf("Run() = %v, expected %v", err, failure)
+	}
+	if stats := g.Stats(); stats.Running != 0 || stats.Waiting != 0 || stats.Completed != 2 {
+		t.Errorf("Stats() = %+v, expected 2 completed tasks", stats)
+	}
+}
+
+// TestPoolGovernorTimeout tests that a task running past the timeout is
+// cancelled and reported on the fake clock.
+func TestPoolGovernorTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	g := NewPoolGovernor(time.Second, 1, 1, WithClock(clock))
+	g.Timeout = time.Second
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- g.Run(context.Background(), func(ctx context.Context) error {
+			<-ctx.Done()
+			return context.Cause(ctx)
+		})
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+
+	var timeout *TimeoutError
+	if err := <-errs; !errors.As(err, &timeout) || !timeout.Deadline.Equal(clock.Now()) {
+		t.Errorf("Run() error = %v, expected a timeout at %v", err, clock.Now())
+	}
+	if stats := g.Stats(); stats.TimedOut != 1 {
+		t.Errorf("Stats() = %+v, expected 1 timed out task", stats)
+	}
+}
@@ -0,0 +1,63 @@
+package temporalis
+
+import "time"
+
+// HeatmapCell is one day of a Heatmap.
+type HeatmapCell struct {
+	Date  CivilDate
+	Count int
+	// InYear reports whether the date is in the year of the heatmap rather
+	// than padding in the first or last week.
+	InYear bool
+}
+
+// Heatmap counts events per day of a year, laid out as a grid of weeks by
+// days of the week like a contribution calendar. Weeks[w][d] is day d of
+// week w, counting from the first day of the week; the first and last weeks
+// are padded with days of the adjacent years, which have no counts.
+type Heatmap struct {
+	Year      int
+	WeekStart time.Weekday
+	Weeks     [][7]HeatmapCell
+	// Total is the number of events in the year and Max the largest count
+	// of a single day, for scaling colours.
+	Total int
+	Max   int
+}
+
+// HeatmapBuckets counts events per day of year, taking the date of each
+// event on the wall clock of loc, so that an event late in the evening in
+// New York counts on that day even though it is the next day in UTC. If loc
+// is nil, the WithLocation option or LocalLocation is used. The weeks start
+// on Monday unless the WithWeekStart option selects another day. Events
+// outside the year are ignored.
+func HeatmapBuckets(events []time.Time, year int, loc *time.Location, opts ...Option) Heatmap {
+	o := NewOptions(opts...)
+	if loc == nil {
+		loc = o.locationOr(nil)
+	}
+
+	first, last := NewCivilDate(year, time.January, 1), NewCivilDate(year, time.December, 31)
+
+	counts := make(map[CivilDate]int)
+	h := Heatmap{Year: year, WeekStart: o.WeekStart}
+	for _, t := range events {
+		if d := DateOf(t.In(loc)); d.Year == year {
+			counts[d]++
+			h.Total++
+			h.Max = max(h.Max, counts[d])
+		}
+	}
+
+	start := first.AddDays(-((int(first.Weekday()) - int(o.WeekStart) + 7) % 7))
+	for d := start; !d.After(last); d = d.AddDays(7) {
+		var week [7]HeatmapCell
+		for i := range week {
+			day := d.AddDays(i)
+			week[i] = HeatmapCell{Date: day, Count: counts[day], InYear: day.Year == year}
+		}
+		h.Weeks = append(h.Weeks, week)
+	}
+
+	return h
+}
@@ -0,0 +1,53 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestHeatmapBuckets tests the grid layout for both week starts and that
+// events are dated in the given zone.
+func TestHeatmapBuckets(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	events := []time.Time{
+		// 23:30 on 31 December 2023 in New York is already 2024 in UTC.
+		time.Date(2024, 1, 1, 4, 30, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 12, 0, 0, 0, newYork),
+		time.Date(2024, 3, 10, 9, 0, 0, 0, newYork),
+		time.Date(2024, 3, 10, 18, 0, 0, 0, newYork),
+		time.Date(2024, 12, 31, 23, 0, 0, 0, newYork),
+	}
+
+	// 1 January 2024 is a Monday and 31 December a Tuesday.
+	h := HeatmapBuckets(events, 2024, newYork)
+	if len(h.Weeks) != 53 || h.Total != 4 || h.Max != 2 || h.WeekStart != time.Monday {
+		t.Fatalf("HeatmapBuckets() = %d weeks, total %d, max %d, week start %v, expected 53, 4, 2, Monday", len(h.Weeks), h.Total, h.Max, h.WeekStart)
+	}
+	if cell := h.Weeks[0][0]; cell.Date != NewCivilDate(2024, 1, 1) || cell.Count != 1 || !cell.InYear {
+		t.Errorf("Weeks[0][0] = %+v, expected 1 event on 2024-01-01", cell)
+	}
+	if cell := h.Weeks[9][6]; cell.Date != NewCivilDate(2024, 3, 10) || cell.Count != 2 {
+		t.Errorf("Weeks[9][6] = %+v, expected 2 events on 2024-03-10", cell)
+	}
+	if cell := h.Weeks[52][1]; cell.Date != NewCivilDate(2024, 12, 31) || cell.Count != 1 {
+		t.Errorf("Weeks[52][1] = %+v, expected 1 event on 2024-12-31", cell)
+	}
+	if cell := h.Weeks[52][2]; cell.Date != NewCivilDate(2025, 1, 1) || cell.InYear {
+		t.Errorf("Weeks[52][2] = %+v, expected padding on 2025-01-01", cell)
+	}
+
+	h = HeatmapBuckets(events, 2024, newYork, WithWeekStart(time.Sunday))
+	if len(h.Weeks) != 53 {
+		t.Fatalf("HeatmapBuckets() with Sunday weeks = %d weeks, expected 53", len(h.Weeks))
+	}
+	if cell := h.Weeks[0][0]; cell.Date != NewCivilDate(2023, 12, 31) || cell.Count != 0 || cell.InYear {
+		t.Errorf("Weeks[0][0] with Sunday weeks = %+v, expected padding on 2023-12-31", cell)
+	}
+	if cell := h.Weeks[10][0]; cell.Date != NewCivilDate(2024, 3, 10) || cell.Count != 2 {
+		t.Errorf("Weeks[10][0] with Sunday weeks = %+v, expected 2 events on 2024-03-10", cell)
+	}
+}
@@ -0,0 +1,97 @@
+// Package ics reads and writes iCalendar (RFC 5545) data, the format of .ics
+// files and calendar subscriptions, so that events can be exchanged with
+// calendar applications without a separate library:
+//
+//	cal, err := ics.Parse(f)
+//	if err != nil {
+//		return err
+//	}
+//	for _, e := range cal.Events {
+//		next := e.Next(time.Now())
+//		...
+//	}
+//
+// Events are read from VEVENT components into Event values, whose times
+// carry the location named by their TZID parameter. A TZID that names an
+// IANA zone, such as "Europe/Berlin", resolves to the full zone history with
+// temporalis.LoadLocation; any other TZID, such as the Windows names used by
+// Outlook, is built from the VTIMEZONE component that defines it.
+// Recurrence rules are parsed into temporalis.RRule values, and every Event
+// is a temporalis.Recurrence of its occurrences. Other components, such as
+// VTODO and VALARM, and unknown properties are skipped.
+package ics
+
+import (
+	"slices"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// Calendar is the content of a VCALENDAR component.
+type Calendar struct {
+	// ProdID identifies the product that created the calendar. Written
+	// calendars without one are identified as temporalis.
+	ProdID string
+	// Name is the calendar name from the X-WR-CALNAME property, as shown by
+	// most calendar applications.
+	Name   string
+	Events []Event
+}
+
+// Event is a VEVENT component.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	// Start is the start of the first occurrence. End is the exclusive end
+	// of the first occurrence, computed from DURATION if the event has no
+	// DTEND.
+	Start, End time.Time
+	// AllDay reports whether Start and End are dates rather than times, in
+	// which case they are midnight in the location the calendar was parsed
+	// in.
+	AllDay bool
+	// RRule is the recurrence rule, or nil for an event that does not
+	// repeat by rule.
+	RRule *temporalis.RRule
+	// RDates are occurrences in addition to those of RRule, and ExDates are
+	// occurrences that are cancelled.
+	RDates  []time.Time
+	ExDates []time.Time
+	// Stamp is the DTSTAMP of the event. Events written without one are
+	// stamped with the current time.
+	Stamp time.Time
+}
+
+// Next returns the start of the first occurrence of the event strictly
+// after the given time, or the zero time if there are no more. Occurrences
+// are Start, those of RRule and the RDates, except the ExDates. Next makes
+// *Event a temporalis.Recurrence, so events can drive a temporalis.Scheduler.
+func (e *Event) Next(after time.Time) time.Time {
+	for t := after; ; {
+		var next time.Time
+		if e.Start.After(t) {
+			next = e.Start
+		} else if e.RRule != nil {
+			next = e.RRule.Next(t)
+		}
+
+		for _, d := range e.RDates {
+			if d.After(t) && (next.IsZero() || d.Before(next)) {
+				next = d
+			}
+		}
+
+		if next.IsZero() || !slices.ContainsFunc(e.ExDates, next.Equal) {
+			return next
+		}
+		t = next
+	}
+}
+
+// Duration returns the length of each occurrence.
+func (e *Event) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
@@ -0,0 +1,393 @@
+package ics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// property is one content line: a name, its parameters and its value.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+	line   int
+}
+
+// component is a BEGIN/END block with its properties and nested blocks.
+type component struct {
+	name       string
+	props      []property
+	components []*component
+}
+
+// get returns the first property with the given name.
+func (c *component) get(name string) (property, bool) {
+	for _, p := range c.props {
+		if p.name == name {
+			return p, true
+		}
+	}
+
+	return property{}, false
+}
+
+// Parse reads an iCalendar stream containing one VCALENDAR. Floating times,
+// which have no time zone, and dates are read in the location
+// temporalis.LocalLocation returns.
+func Parse(r io.Reader) (*Calendar, error) {
+	return ParseInLocation(r, temporalis.LocalLocation())
+}
+
+// ParseInLocation is like Parse but reads floating times and dates in loc.
+// Errors wrap temporalis.ErrSyntax, or temporalis.ErrInvalidZone for a TZID
+// that is neither an IANA zone nor defined in the calendar.
+func ParseInLocation(r io.Reader, loc *time.Location) (*Calendar, error) {
+	root, err := parseComponents(r)
+	if err != nil {
+		return nil, err
+	}
+	if root.name != "VCALENDAR" {
+		return nil, fmt.Errorf("ics: %s instead of VCALENDAR: %w", root.name, temporalis.ErrSyntax)
+	}
+
+	p := &parser{loc: loc, definitions: make(map[string]*component), zones: make(map[string]*time.Location)}
+	for _, c := range root.components {
+		if c.name == "VTIMEZONE" {
+			if id, ok := c.get("TZID"); ok {
+				p.definitions[id.value] = c
+			}
+		}
+	}
+
+	cal := &Calendar{}
+	if prop, ok := root.get("PRODID"); ok {
+		cal.ProdID = unescape(prop.value)
+	}
+	if prop, ok := root.get("X-WR-CALNAME"); ok {
+		cal.Name = unescape(prop.value)
+	}
+
+	for _, c := range root.components {
+		if c.name != "VEVENT" {
+			continue
+		}
+
+		e, err := p.event(c)
+		if err != nil {
+			return nil, err
+		}
+		cal.Events = append(cal.Events, e)
+	}
+
+	return cal, nil
+}
+
+// parseComponents reads the content lines of r, unfolding continuation
+// lines, and returns the outermost component.
+func parseComponents(r io.Reader) (*component, error) {
+	var (
+		root   *component
+		stack  []*component
+		line   string
+		number int
+		start  int
+	)
+
+	handle := func() error {
+		if line == "" {
+			return nil
+		}
+
+		prop, err := parseProperty(line, start)
+		if err != nil {
+			return err
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			c := &component{name: strings.ToUpper(prop.value)}
+			if len(stack) > 0 {
+				top := stack[len(stack)-1]
+				top.components = append(top.components, c)
+			} else if root != nil {
+				return fmt.Errorf("ics: line %d: more than one top-level component: %w", start, temporalis.ErrSyntax)
+			} else {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].name != strings.ToUpper(prop.value) {
+				return fmt.Errorf("ics: line %d: unexpected END:%s: %w", start, prop.value, temporalis.ErrSyntax)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return fmt.Errorf("ics: line %d: property outside a component: %w", start, temporalis.ErrSyntax)
+			}
+			top := stack[len(stack)-1]
+			top.props = append(top.props, prop)
+		}
+
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+
+		if strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t") {
+			line += text[1:]
+			continue
+		}
+
+		if err := handle(); err != nil {
+			return nil, err
+		}
+		line, start = text, number
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ics: %w", err)
+	}
+	if err := handle(); err != nil {
+		return nil, err
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("ics: no components: %w", temporalis.ErrSyntax)
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("ics: missing END:%s: %w", stack[len(stack)-1].name, temporalis.ErrSyntax)
+	}
+
+	return root, nil
+}
+
+// parseProperty splits a content line of the form
+// NAME;PARAM=VALUE;PARAM="QUOTED":VALUE. Parameter names are upper-cased.
+func parseProperty(line string, number int) (property, error) {
+	prop := property{line: number}
+
+	// Find the colon that ends the name and parameters, skipping any in
+	// quoted parameter values.
+	quoted, colon := false, -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return prop, fmt.Errorf("ics: line %d: missing colon: %w", number, temporalis.ErrSyntax)
+	}
+	prop.value = line[colon+1:]
+
+	parts := splitQuoted(line[:colon], ';')
+	prop.name = strings.ToUpper(parts[0])
+	if prop.name == "" {
+		return prop, fmt.Errorf("ics: line %d: missing property name: %w", number, temporalis.ErrSyntax)
+	}
+
+	for _, param := range parts[1:] {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return prop, fmt.Errorf("ics: line %d: invalid parameter %q: %w", number, param, temporalis.ErrSyntax)
+		}
+		if prop.params == nil {
+			prop.params = make(map[string]string)
+		}
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return prop, nil
+}
+
+// splitQuoted splits s at sep, except within double quotes.
+func splitQuoted(s string, sep rune) []string {
+	var parts []string
+
+	quoted, start := false, 0
+	for i, c := range s {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == sep && !quoted {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// unescape decodes a TEXT value.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			if s[i] == 'n' || s[i] == 'N' {
+				b.WriteByte('\n')
+			} else {
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// parser resolves the times of a calendar's events.
+type parser struct {
+	loc         *time.Location
+	definitions map[string]*component
+	zones       map[string]*time.Location
+}
+
+// event converts a VEVENT component.
+func (p *parser) event(c *component) (Event, error) {
+	var e Event
+
+	for _, prop := range c.props {
+		var err error
+
+		switch prop.name {
+		case "UID":
+			e.UID = prop.value
+		case "SUMMARY":
+			e.Summary = unescape(prop.value)
+		case "DESCRIPTION":
+			e.Description = unescape(prop.value)
+		case "LOCATION":
+			e.Location = unescape(prop.value)
+		case "DTSTART":
+			e.Start, e.AllDay, err = p.time(prop, prop.value)
+		case "DTEND":
+			e.End, _, err = p.time(prop, prop.value)
+		case "DTSTAMP":
+			e.Stamp, _, err = p.time(prop, prop.value)
+		case "RDATE":
+			e.RDates, err = p.times(prop, e.RDates)
+		case "EXDATE":
+			e.ExDates, err = p.times(prop, e.ExDates)
+		}
+
+		if err != nil {
+			return e, err
+		}
+	}
+
+	if e.Start.IsZero() {
+		return e, fmt.Errorf("ics: event %q has no DTSTART: %w", e.UID, temporalis.ErrSyntax)
+	}
+
+	if prop, ok := c.get("DURATION"); ok && e.End.IsZero() {
+		period, err := temporalis.ParsePeriod(prop.value)
+		if err != nil {
+			return e, fmt.Errorf("ics: line %d: %w", prop.line, err)
+		}
+		e.End = period.AddTo(e.Start)
+	}
+	if e.End.IsZero() {
+		e.End = e.Start
+		if e.AllDay {
+			e.End = e.Start.AddDate(0, 0, 1)
+		}
+	}
+
+	if prop, ok := c.get("RRULE"); ok {
+		rule, err := temporalis.ParseRRule(prop.value, e.Start)
+		if err != nil {
+			return e, fmt.Errorf("ics: line %d: %w", prop.line, err)
+		}
+		e.RRule = rule
+	}
+
+	return e, nil
+}
+
+// times appends the comma-separated DATE or DATE-TIME values of an RDATE or
+// EXDATE property to list. PERIOD values contribute their start.
+func (p *parser) times(prop property, list []time.Time) ([]time.Time, error) {
+	for _, value := range strings.Split(prop.value, ",") {
+		value, _, _ = strings.Cut(value, "/")
+
+		t, _, err := p.time(prop, value)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+
+	return list, nil
+}
+
+// time parses a DATE or DATE-TIME value of prop in its TZID, in UTC if it
+// ends with "Z", or else in the floating location. The boolean result
+// reports whether the value is a date.
+func (p *parser) time(prop property, value string) (time.Time, bool, error) {
+	loc := p.loc
+	if id, ok := prop.params["TZID"]; ok {
+		var err error
+		if loc, err = p.zone(id); err != nil {
+			return time.Time{}, false, fmt.Errorf("ics: line %d: %w", prop.line, err)
+		}
+	}
+
+	var (
+		t   time.Time
+		err error
+	)
+
+	date := prop.params["VALUE"] == "DATE" || !strings.Contains(value, "T")
+	switch {
+	case date:
+		t, err = time.ParseInLocation("20060102", value, loc)
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("ics: line %d: %s %q: %w", prop.line, prop.name, value, errors.Join(temporalis.ErrSyntax, err))
+	}
+
+	return t, date, nil
+}
+
+// zone returns the location for a TZID: the IANA zone of that name if there
+// is one, or else the zone defined by the calendar's VTIMEZONE.
+func (p *parser) zone(id string) (*time.Location, error) {
+	if loc, ok := p.zones[id]; ok {
+		return loc, nil
+	}
+
+	loc, err := temporalis.LoadLocation(strings.TrimPrefix(id, "/"))
+	if err != nil {
+		def, ok := p.definitions[id]
+		if !ok {
+			return nil, fmt.Errorf("ics: TZID %q: %w", id, temporalis.ErrInvalidZone)
+		}
+		if loc, err = timezone(id, def); err != nil {
+			return nil, err
+		}
+	}
+
+	p.zones[id] = loc
+
+	return loc, nil
+}
@@ -0,0 +1,174 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// outlook is an export in the style of Outlook, whose TZID is a Windows zone
+// name defined only by the VTIMEZONE component.
+const outlook = "BEGIN:VCALENDAR\r\n" +
+	"PRODID:-//Microsoft Corporation//Outlook 16.0 MIMEDIR//EN\r\n" +
+	"VERSION:2.0\r\n" +
+	"X-WR-CALNAME:Team\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:W. Europe Standard Time\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:16011028T030000\r\n" +
+	"RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=10\r\n" +
+	"TZOFFSETFROM:+0200\r\n" +
+	"TZOFFSETTO:+0100\r\n" +
+	"END:STANDARD\r\n" +
+	"BEGIN:DAYLIGHT\r\n" +
+	"DTSTART:16010325T020000\r\n" +
+	"RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=3\r\n" +
+	"TZOFFSETFROM:+0100\r\n" +
+	"TZOFFSETTO:+0200\r\n" +
+	"END:DAYLIGHT\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup-1\r\n" +
+	"DTSTAMP:20240101T120000Z\r\n" +
+	"DTSTART;TZID=W. Europe Standard Time:20240318T093000\r\n" +
+	"DURATION:PT15M\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO\r\n" +
+	"EXDATE;TZID=W. Europe Standard Time:20240401T093000\r\n" +
+	"SUMMARY:Stand-up\\, daily\r\n" +
+	"DESCRIPTION:Line one\\nline two that is long enough to need folding when \r\n" +
+	" written\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"TRIGGER:-PT5M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday-1\r\n" +
+	"DTSTART;VALUE=DATE:20240501\r\n" +
+	"SUMMARY:Labour Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:todo-1\r\n" +
+	"END:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+// TestParse tests an Outlook export with a custom VTIMEZONE, a recurring
+// event with an exception and an all-day event.
+func TestParse(t *testing.T) {
+	cal, err := ParseInLocation(strings.NewReader(outlook), time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if cal.Name != "Team" || len(cal.Events) != 2 {
+		t.Fatalf("Parse() = %q with %d events, expected Team with 2", cal.Name, len(cal.Events))
+	}
+
+	standup := &cal.Events[0]
+	if standup.Summary != "Stand-up, daily" {
+		t.Errorf("Summary = %q, expected %q", standup.Summary, "Stand-up, daily")
+	}
+	if expected := "Line one\nline two that is long enough to need folding when written"; standup.Description != expected {
+		t.Errorf("Description = %q, expected %q", standup.Description, expected)
+	}
+
+	// 09:30 in Central European Time is 08:30 UTC in March, and 07:30 UTC
+	// after the clocks go forward on 31 March.
+	if expected := time.Date(2024, 3, 18, 8, 30, 0, 0, time.UTC); !standup.Start.Equal(expected) {
+		t.Errorf("Start = %v, expected %v", standup.Start, expected)
+	}
+	if standup.Duration() != 15*time.Minute {
+		t.Errorf("Duration() = %v, expected 15m", standup.Duration())
+	}
+
+	expected := []time.Time{
+		time.Date(2024, 3, 18, 8, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 25, 8, 30, 0, 0, time.UTC),
+		time.Date(2024, 4, 8, 7, 30, 0, 0, time.UTC),
+	}
+	actual := temporalis.Occurrences(standup, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 3)
+	for i := range expected {
+		if i >= len(actual) || !actual[i].Equal(expected[i]) {
+			t.Errorf("Occurrences() = %v, expected %v", actual, expected)
+			break
+		}
+	}
+
+	holiday := cal.Events[1]
+	if !holiday.AllDay || !holiday.Start.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) || holiday.Duration() != 24*time.Hour {
+		t.Errorf("all-day event = %v to %v, all day %v, expected 1 May", holiday.Start, holiday.End, holiday.AllDay)
+	}
+}
+
+// TestParseTimezoneFuture tests that a VTIMEZONE keeps its rules after the
+// years whose transitions are expanded.
+func TestParseTimezoneFuture(t *testing.T) {
+	cal, err := ParseInLocation(strings.NewReader(outlook), time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	loc := cal.Events[0].Start.Location()
+
+	tests := []struct {
+		t        time.Time
+		expected int
+	}{
+		{time.Date(2040, 1, 15, 12, 0, 0, 0, time.UTC), 3600},
+		{time.Date(2040, 7, 15, 12, 0, 0, 0, time.UTC), 7200},
+		// The clocks go forward at 01:00 UTC on the last Sunday of March,
+		// which is 27 March in 2050, and back at 01:00 UTC on 30 October.
+		{time.Date(2050, 3, 27, 0, 59, 0, 0, time.UTC), 3600},
+		{time.Date(2050, 3, 27, 1, 0, 0, 0, time.UTC), 7200},
+		{time.Date(2050, 10, 30, 0, 59, 0, 0, time.UTC), 7200},
+		{time.Date(2050, 10, 30, 1, 0, 0, 0, time.UTC), 3600},
+	}
+
+	for _, test := range tests {
+		if _, offset := test.t.In(loc).Zone(); offset != test.expected {
+			t.Errorf("offset at %v = %d, expected %d", test.t, offset, test.expected)
+		}
+	}
+}
+
+// TestParseIANA tests that a TZID naming an IANA zone needs no VTIMEZONE.
+func TestParseIANA(t *testing.T) {
+	input := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:1\nDTSTART;TZID=America/New_York:20240310T120000\nDTEND;TZID=America/New_York:20240310T130000\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	cal, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Skip(err)
+	}
+
+	e := cal.Events[0]
+	if e.Start.Location().String() != "America/New_York" || !e.Start.Equal(time.Date(2024, 3, 10, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("Start = %v, expected 12:00 EDT", e.Start)
+	}
+}
+
+// TestParseErrors tests that malformed input fails with ErrSyntax or
+// ErrInvalidZone.
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{"empty", "", temporalis.ErrSyntax},
+		{"not a calendar", "BEGIN:VEVENT\nEND:VEVENT\n", temporalis.ErrSyntax},
+		{"unterminated", "BEGIN:VCALENDAR\nBEGIN:VEVENT\n", temporalis.ErrSyntax},
+		{"mismatched", "BEGIN:VCALENDAR\nEND:VEVENT\n", temporalis.ErrSyntax},
+		{"no colon", "BEGIN:VCALENDAR\nSUMMARY\nEND:VCALENDAR\n", temporalis.ErrSyntax},
+		{"no start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:1\nEND:VEVENT\nEND:VCALENDAR\n", temporalis.ErrSyntax},
+		{"bad time", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2024-03-10\nEND:VEVENT\nEND:VCALENDAR\n", temporalis.ErrSyntax},
+		{"bad rule", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240310\nRRULE:FREQ=SOMETIMES\nEND:VEVENT\nEND:VCALENDAR\n", temporalis.ErrSyntax},
+		{"unknown zone", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;TZID=Nowhere:20240310T120000\nEND:VEVENT\nEND:VCALENDAR\n", temporalis.ErrInvalidZone},
+	}
+
+	for _, test := range tests {
+		if _, err := Parse(strings.NewReader(test.input)); !errors.Is(err, test.expected) {
+			t.Errorf("Parse(%s) error = %v, expected %v", test.name, err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package ics
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// lastTransitionYear bounds the expansion of VTIMEZONE recurrence rules.
+// Later changes follow the rules in the footer of the TZif data, if they can
+// be written as a POSIX TZ string.
+const lastTransitionYear = 2037
+
+// transition is a change of offset in a VTIMEZONE definition.
+type transition struct {
+	at   time.Time
+	from int
+	zone zoneType
+}
+
+// zoneType is an offset from UTC, as in a TZif ttinfo record.
+type zoneType struct {
+	offset int
+	dst    bool
+	name   string
+}
+
+// openRule is an observance whose RRULE has neither COUNT nor UNTIL, and so
+// still applies after lastTransitionYear.
+type openRule struct {
+	zone zoneType
+	rule *temporalis.RRule
+}
+
+// timezone builds a location named id from the STANDARD and DAYLIGHT
+// observances of a VTIMEZONE component. Each observance changes the offset
+// from TZOFFSETFROM to TZOFFSETTO at DTSTART, and again at every RDATE and
+// occurrence of its RRULE, up to lastTransitionYear. After that the zone
+// keeps to the rules that do not end, as computed by posixRule.
+func timezone(id string, c *component) (*time.Location, error) {
+	var transitions []transition
+	var open []openRule
+
+	for _, o := range c.components {
+		if o.name != "STANDARD" && o.name != "DAYLIGHT" {
+			continue
+		}
+
+		from, err := offsetProperty(o, "TZOFFSETFROM")
+		if err != nil {
+			return nil, err
+		}
+		to, err := offsetProperty(o, "TZOFFSETTO")
+		if err != nil {
+			return nil, err
+		}
+
+		zone := zoneType{offset: to, dst: o.name == "DAYLIGHT"}
+		if name, ok := o.get("TZNAME"); ok {
+			zone.name = name.value
+		}
+
+		// Onsets are local times in the offset before the change. They are
+		// expanded as UTC wall clocks and then shifted by that offset.
+		p := &parser{loc: time.UTC}
+		start, ok := o.get("DTSTART")
+		if !ok {
+			return nil, fmt.Errorf("ics: %s observance of %q has no DTSTART: %w", o.name, id, temporalis.ErrSyntax)
+		}
+		first, _, err := p.time(start, start.value)
+		if err != nil {
+			return nil, err
+		}
+		onsets := []time.Time{first}
+
+		for _, prop := range o.props {
+			if prop.name == "RDATE" {
+				if onsets, err = p.times(prop, onsets); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if prop, ok := o.get("RRULE"); ok {
+			rule, err := temporalis.ParseRRule(prop.value, first)
+			if err != nil {
+				return nil, fmt.Errorf("ics: line %d: %w", prop.line, err)
+			}
+			for t := rule.Next(first); !t.IsZero() && t.Year() <= lastTransitionYear; t = rule.Next(t) {
+				onsets = append(onsets, t)
+			}
+			if rule.Count == 0 && rule.Until.IsZero() {
+				open = append(open, openRule{zone: zone, rule: rule})
+			}
+		}
+
+		for _, t := range onsets {
+			transitions = append(transitions, transition{at: t.Add(-time.Duration(from) * time.Second), from: from, zone: zone})
+		}
+	}
+
+	if len(transitions) == 0 {
+		return nil, fmt.Errorf("ics: VTIMEZONE %q has no observances: %w", id, temporalis.ErrSyntax)
+	}
+
+	slices.SortFunc(transitions, func(a, b transition) int { return a.at.Compare(b.at) })
+
+	// Before the first transition the zone has the offset it changes from,
+	// named after a standard observance with that offset if there is one.
+	initial := zoneType{offset: transitions[0].from}
+	for _, t := range transitions {
+		if t.zone.offset == initial.offset && !t.zone.dst {
+			initial.name = t.zone.name
+			break
+		}
+	}
+
+	loc, err := time.LoadLocationFromTZData(id, tzif(initial, transitions, posixRule(open)))
+	if err != nil {
+		return nil, fmt.Errorf("ics: VTIMEZONE %q: %w", id, err)
+	}
+
+	return loc, nil
+}
+
+// offsetProperty parses a UTC offset property such as "+0100" or "-033000"
+// into seconds east of UTC.
+func offsetProperty(c *component, name string) (int, error) {
+	prop, ok := c.get(name)
+	if !ok {
+		return 0, fmt.Errorf("ics: %s observance has no %s: %w", c.name, name, temporalis.ErrSyntax)
+	}
+
+	v := prop.value
+	if (len(v) != 5 && len(v) != 7) || (v[0] != '+' && v[0] != '-') {
+		return 0, fmt.Errorf("ics: line %d: invalid offset %q: %w", prop.line, v, temporalis.ErrSyntax)
+	}
+
+	seconds := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+2*i >= len(v) {
+			break
+		}
+		n, err := strconv.Atoi(v[1+2*i : 3+2*i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("ics: line %d: invalid offset %q: %w", prop.line, v, temporalis.ErrSyntax)
+		}
+		seconds += n * unit
+	}
+
+	if v[0] == '-' {
+		seconds = -seconds
+	}
+
+	return seconds, nil
+}
+
+// posixRule returns the POSIX TZ string, such as
+// "CET-1CEST,M3.5.0/2:00:00,M10.5.0/3:00:00", for a standard and a daylight
+// observance whose rules do not end. It returns "" for any other set of
+// rules, including rules that are not of the yearly "nth weekday of a
+// month" form that TZ strings can express.
+func posixRule(open []openRule) string {
+	if len(open) != 2 || open[0].zone.dst == open[1].zone.dst {
+		return ""
+	}
+
+	std, dst := open[0], open[1]
+	if std.zone.dst {
+		std, dst = dst, std
+	}
+
+	// The daylight rule starts DST and the standard rule ends it; both at a
+	// wall-clock time in the offset they change from, as in iCalendar.
+	start, ok := posixDate(dst.rule)
+	if !ok {
+		return ""
+	}
+	end, ok := posixDate(std.rule)
+	if !ok {
+		return ""
+	}
+
+	return posixName(std.zone) + posixOffset(std.zone.offset) +
+		posixName(dst.zone) + posixOffset(dst.zone.offset) + "," + start + "," + end
+}
+
+// posixDate formats a rule of the form FREQ=YEARLY;BYMONTH=m;BYDAY=nDD as a
+// POSIX TZ date and time such as "M3.5.0/2:00:00", where week 5 is the last.
+func posixDate(r *temporalis.RRule) (string, bool) {
+	if r.Freq != temporalis.FreqYearly || r.Interval != 1 || len(r.ByMonth) != 1 || len(r.ByDay) != 1 ||
+		len(r.ByMonthDay) != 0 || len(r.ByHour) != 0 || len(r.ByMinute) != 0 || len(r.BySecond) != 0 || len(r.BySetPos) != 0 {
+		return "", false
+	}
+
+	week := r.ByDay[0].N
+	switch {
+	case week == -1:
+		week = 5
+	case week < 1 || week > 4:
+		return "", false
+	}
+
+	h, m, s := r.Start.Clock()
+
+	return fmt.Sprintf("M%d.%d.%d/%d:%02d:%02d", r.ByMonth[0], week, int(r.ByDay[0].Weekday), h, m, s), true
+}
+
+// posixName returns the name of a zone in a POSIX TZ string, which is quoted
+// in angle brackets unless it consists of three or more letters.
+func posixName(z zoneType) string {
+	name := z.name
+	if name == "" {
+		name = formatOffset(z.offset)
+	}
+
+	letters := len(name) >= 3
+	for _, r := range name {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			letters = false
+		}
+	}
+	if letters {
+		return name
+	}
+
+	return "<" + formatOffset(z.offset) + ">"
+}
+
+// posixOffset formats an offset in seconds east of UTC as a POSIX TZ offset,
+// which counts the other way, so one hour east of UTC is "-1:00:00".
+func posixOffset(offset int) string {
+	sign := ""
+	if offset > 0 {
+		sign = "-"
+	}
+	offset = max(offset, -offset)
+
+	return fmt.Sprintf("%s%d:%02d:%02d", sign, offset/3600, offset/60%60, offset%60)
+}
+
+// tzif encodes sorted transitions as TZif version 2 data, the format of the
+// zoneinfo database, with initial as the zone before the first transition
+// and footer as the POSIX TZ string for the time after the last one. The
+// initial zone is the first type and is not used by any transition, which
+// is how TZif readers identify it. The version 1 block, which readers of
+// version 2 data skip, is left empty.
+func tzif(initial zoneType, transitions []transition, footer string) []byte {
+	types := []zoneType{initial}
+	var times []int64
+	var indexes []byte
+
+	for _, t := range transitions {
+		at := t.at.Unix()
+
+		i := slices.Index(types[1:], t.zone) + 1
+		if i == 0 {
+			types = append(types, t.zone)
+			i = len(types) - 1
+		}
+
+		times = append(times, at)
+		indexes = append(indexes, byte(i))
+	}
+
+	var names []byte
+	nameIndex := make([]int, len(types))
+	for i, z := range types {
+		name := z.name
+		if name == "" {
+			name = formatOffset(z.offset)
+		}
+		nameIndex[i] = len(names)
+		names = append(append(names, name...), 0)
+	}
+
+	// An empty version 1 block still needs one type and one name byte.
+	data := append([]byte("TZif2"), make([]byte, 15)...)
+	for _, n := range []int{0, 0, 0, 0, 1, 1} {
+		data = binary.BigEndian.AppendUint32(data, uint32(n))
+	}
+	data = append(data, make([]byte, 6+1)...)
+
+	data = append(data, "TZif2"...)
+	data = append(data, make([]byte, 15)...)
+	for _, n := range []int{0, 0, 0, len(times), len(types), len(names)} {
+		data = binary.BigEndian.AppendUint32(data, uint32(n))
+	}
+	for _, t := range times {
+		data = binary.BigEndian.AppendUint64(data, uint64(t))
+	}
+	data = append(data, indexes...)
+	for i, z := range types {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(z.offset)))
+		dst := byte(0)
+		if z.dst {
+			dst = 1
+		}
+		data = append(data, dst, byte(nameIndex[i]))
+	}
+
+	data = append(data, names...)
+
+	return append(append(append(data, '\n'), footer...), '\n')
+}
+
+// formatOffset formats an offset in seconds as an abbreviation such as
+// "+0530", for observances without a TZNAME.
+func formatOffset(offset int) string {
+	sign := byte('+')
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset/60%60)
+}
@@ -0,0 +1,264 @@
+package ics
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goify/temporalis"
+)
+
+// defaultProdID identifies calendars written without a ProdID.
+const defaultProdID = "-//goify//temporalis//EN"
+
+// timezoneYears is how many years after the last event start the written
+// VTIMEZONE components describe.
+const timezoneYears = 10
+
+// WriteTo writes the calendar to w in iCalendar format, with CRLF line
+// endings and lines folded at 75 octets. It implements io.WriterTo.
+//
+// Times in UTC, in time.Local and in unnamed fixed zones are written in UTC.
+// Times in other locations are written as local times with a TZID naming
+// the location, and each such location is described by a VTIMEZONE
+// component listing its transitions from the year of the earliest event to
+// ten years after the latest, for applications that do not know IANA zones.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	out := &writer{w: bw}
+
+	prodID := c.ProdID
+	if prodID == "" {
+		prodID = defaultProdID
+	}
+
+	out.line("BEGIN", nil, "VCALENDAR")
+	out.line("VERSION", nil, "2.0")
+	out.line("PRODID", nil, escape(prodID))
+	if c.Name != "" {
+		out.line("X-WR-CALNAME", nil, escape(c.Name))
+	}
+
+	c.writeTimezones(out)
+
+	for i := range c.Events {
+		c.Events[i].write(out)
+	}
+
+	out.line("END", nil, "VCALENDAR")
+
+	if err := bw.Flush(); err != nil {
+		return cw.n, err
+	}
+
+	return cw.n, out.err
+}
+
+// String returns the calendar in iCalendar format.
+func (c *Calendar) String() string {
+	var b strings.Builder
+	_, _ = c.WriteTo(&b)
+
+	return b.String()
+}
+
+// write writes a VEVENT component.
+func (e *Event) write(out *writer) {
+	stamp := e.Stamp
+	if stamp.IsZero() {
+		stamp = temporalis.Now()
+	}
+
+	out.line("BEGIN", nil, "VEVENT")
+	out.line("UID", nil, e.UID)
+	out.line("DTSTAMP", nil, stamp.UTC().Format("20060102T150405Z"))
+	out.time("DTSTART", e.Start, e.AllDay)
+	if e.End.After(e.Start) {
+		out.time("DTEND", e.End, e.AllDay)
+	}
+
+	for _, p := range []struct{ name, value string }{
+		{"SUMMARY", e.Summary},
+		{"DESCRIPTION", e.Description},
+		{"LOCATION", e.Location},
+	} {
+		if p.value != "" {
+			out.line(p.name, nil, escape(p.value))
+		}
+	}
+
+	if e.RRule != nil {
+		out.line("RRULE", nil, e.RRule.String())
+	}
+	for _, d := range e.RDates {
+		out.time("RDATE", d, e.AllDay)
+	}
+	for _, d := range e.ExDates {
+		out.time("EXDATE", d, e.AllDay)
+	}
+
+	out.line("END", nil, "VEVENT")
+}
+
+// writeTimezones writes a VTIMEZONE component for every location that the
+// events are written in.
+func (c *Calendar) writeTimezones(out *writer) {
+	var (
+		zones         []*time.Location
+		first, latest time.Time
+	)
+
+	for _, e := range c.Events {
+		if first.IsZero() || e.Start.Before(first) {
+			first = e.Start
+		}
+		if e.Start.After(latest) {
+			latest = e.Start
+		}
+
+		loc := e.Start.Location()
+		if zoneID(loc) != "" && !e.AllDay && !slices.Contains(zones, loc) {
+			zones = append(zones, loc)
+		}
+	}
+
+	for _, loc := range zones {
+		start := time.Date(first.In(loc).Year(), time.January, 1, 0, 0, 0, 0, loc)
+		end := time.Date(latest.In(loc).Year()+timezoneYears+1, time.January, 1, 0, 0, 0, 0, loc)
+
+		out.line("BEGIN", nil, "VTIMEZONE")
+		out.line("TZID", nil, zoneID(loc))
+
+		// The zone in effect at the start, then every transition.
+		_, offset := start.Zone()
+		observance(out, start, offset)
+
+		for t := start; ; {
+			_, next := t.ZoneBounds()
+			if next.IsZero() || !next.Before(end) {
+				break
+			}
+
+			observance(out, next, offset)
+			_, offset = next.Zone()
+			t = next
+		}
+
+		out.line("END", nil, "VTIMEZONE")
+	}
+}
+
+// observance writes a STANDARD or DAYLIGHT component for the zone that
+// starts at onset and follows a period with the offset from.
+func observance(out *writer, onset time.Time, from int) {
+	kind := "STANDARD"
+	if onset.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, to := onset.Zone()
+
+	out.line("BEGIN", nil, kind)
+	out.line("DTSTART", nil, onset.UTC().Add(time.Duration(from)*time.Second).Format("20060102T150405"))
+	out.line("TZOFFSETFROM", nil, formatUTCOffset(from))
+	out.line("TZOFFSETTO", nil, formatUTCOffset(to))
+	out.line("TZNAME", nil, name)
+	out.line("END", nil, kind)
+}
+
+// formatUTCOffset formats an offset in seconds as a UTC-OFFSET value, such
+// as "+0100" or "-033000".
+func formatUTCOffset(offset int) string {
+	s := formatOffset(offset)
+	if offset%60 != 0 {
+		if offset < 0 {
+			offset = -offset
+		}
+		s += string(rune('0'+offset%60/10)) + string(rune('0'+offset%10))
+	}
+
+	return s
+}
+
+// zoneID returns the TZID for times in loc, or "" if they are written in
+// UTC.
+func zoneID(loc *time.Location) string {
+	switch name := loc.String(); name {
+	case "", "UTC", "Local":
+		return ""
+	default:
+		return name
+	}
+}
+
+// escape encodes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writer writes content lines, remembering the first error.
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+// time writes a DATE or DATE-TIME property.
+func (out *writer) time(name string, t time.Time, date bool) {
+	if date {
+		out.line(name, []string{"VALUE=DATE"}, t.Format("20060102"))
+		return
+	}
+
+	if id := zoneID(t.Location()); id != "" {
+		out.line(name, []string{"TZID=" + id}, t.Format("20060102T150405"))
+		return
+	}
+
+	out.line(name, nil, t.UTC().Format("20060102T150405Z"))
+}
+
+// line writes a content line, folding it so that no line is longer than 75
+// octets without splitting a UTF-8 sequence.
+func (out *writer) line(name string, params []string, value string) {
+	if out.err != nil {
+		return
+	}
+
+	s := name
+	for _, p := range params {
+		s += ";" + p
+	}
+	s += ":" + value
+
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if _, out.err = out.w.WriteString(s[:cut] + "\r\n "); out.err != nil {
+			return
+		}
+		s = s[cut:]
+		// Continuation lines start with a space, which counts.
+		limit = 74
+	}
+
+	_, out.err = out.w.WriteString(s + "\r\n")
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,120 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// TestWriteTo tests that a written calendar is folded, escaped and parses
+// back to the same events.
+func TestWriteTo(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	start := time.Date(2024, 3, 18, 9, 30, 0, 0, berlin)
+	rule, err := temporalis.ParseRRule("FREQ=WEEKLY;BYDAY=MO;COUNT=10", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cal := &Calendar{
+		Name: "Team",
+		Events: []Event{
+			{
+				UID:         "standup-1",
+				Summary:     "Stand-up; daily, mostly",
+				Description: strings.Repeat("Täglich ", 20),
+				Start:       start,
+				End:         start.Add(15 * time.Minute),
+				RRule:       rule,
+				ExDates:     []time.Time{time.Date(2024, 4, 1, 9, 30, 0, 0, berlin)},
+				Stamp:       time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+			{
+				UID:    "holiday-1",
+				Start:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+				AllDay: true,
+				Stamp:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	text := cal.String()
+
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+	}
+	for _, expected := range []string{
+		"PRODID:" + defaultProdID + "\r\n",
+		"SUMMARY:Stand-up\\; daily\\, mostly\r\n",
+		"DTSTART;TZID=Europe/Berlin:20240318T093000\r\n",
+		"DTSTART;VALUE=DATE:20240501\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20240331T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\n",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("WriteTo() does not contain %q:\n%s", expected, text)
+		}
+	}
+
+	parsed, err := ParseInLocation(strings.NewReader(text), time.UTC)
+	if err != nil {
+		t.Fatalf("Parse(WriteTo()) error = %v", err)
+	}
+	if len(parsed.Events) != 2 {
+		t.Fatalf("Parse(WriteTo()) has %d events, expected 2", len(parsed.Events))
+	}
+
+	e := parsed.Events[0]
+	if e.Summary != cal.Events[0].Summary || e.Description != cal.Events[0].Description {
+		t.Errorf("Parse(WriteTo()) text = %q, %q, expected %q, %q", e.Summary, e.Description, cal.Events[0].Summary, cal.Events[0].Description)
+	}
+	if !e.Start.Equal(start) || e.Duration() != 15*time.Minute || e.RRule.String() != rule.String() {
+		t.Errorf("Parse(WriteTo()) = %v for %v, %v, expected %v for 15m, %v", e.Start, e.Duration(), e.RRule, start, rule)
+	}
+
+	after := time.Date(2024, 3, 26, 0, 0, 0, 0, time.UTC)
+	if actual, expected := e.Next(after), time.Date(2024, 4, 8, 9, 30, 0, 0, berlin); !actual.Equal(expected) {
+		t.Errorf("Next() = %v, expected %v", actual, expected)
+	}
+
+	if h := parsed.Events[1]; !h.AllDay || !h.Start.Equal(cal.Events[1].Start) || !h.End.Equal(cal.Events[1].End) {
+		t.Errorf("Parse(WriteTo()) all-day event = %v to %v, expected %v to %v", h.Start, h.End, cal.Events[1].Start, cal.Events[1].End)
+	}
+}
+
+// TestWrittenTimezone tests that a written VTIMEZONE describes the zone
+// when its TZID is not an IANA name.
+func TestWrittenTimezone(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, sydney)
+	text := (&Calendar{Events: []Event{{UID: "1", Start: start, Stamp: start}}}).String()
+	text = strings.ReplaceAll(text, "Australia/Sydney", "AUS Eastern Standard Time")
+
+	cal, err := ParseInLocation(strings.NewReader(text), time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// The zone comes from the VTIMEZONE, and knows that Sydney leaves
+	// daylight saving time in April.
+	loc := cal.Events[0].Start.Location()
+	for _, at := range []time.Time{start, time.Date(2024, 7, 1, 9, 0, 0, 0, sydney), time.Date(2030, 12, 1, 9, 0, 0, 0, sydney)} {
+		_, expected := at.Zone()
+		if _, actual := at.In(loc).Zone(); actual != expected {
+			t.Errorf("offset at %v = %d, expected %d", at, actual, expected)
+		}
+	}
+}
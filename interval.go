@@ -0,0 +1,74 @@
+package temporalis
+
+import (
+	"fmt"
+	"time"
+)
+
+// Interval is a half-open span of time [Start, End). An interval whose End is
+// not after its Start is empty. Intervals compare instants, so the locations
+// of Start and End do not affect the result of any of the methods.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewInterval returns the interval between the two times, swapping them if
+// end is before start.
+func NewInterval(start, end time.Time) Interval {
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	return Interval{Start: start, End: end}
+}
+
+// Duration returns the length of the interval, or zero if it is empty.
+func (i Interval) Duration() time.Duration {
+	if i.IsEmpty() {
+		return 0
+	}
+
+	return i.End.Sub(i.Start)
+}
+
+// IsEmpty reports whether the interval contains no instants.
+func (i Interval) IsEmpty() bool {
+	return !i.End.After(i.Start)
+}
+
+// Contains reports whether t lies within the interval. Start is included and
+// End is excluded.
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Overlaps reports whether the two intervals share at least one instant.
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start.Before(other.End) && other.Start.Before(i.End) && !i.IsEmpty() && !other.IsEmpty()
+}
+
+// Intersect returns the instants shared by both intervals. The boolean result
+// is false if the intervals do not overlap.
+func (i Interval) Intersect(other Interval) (Interval, bool) {
+	if !i.Overlaps(other) {
+		return Interval{}, false
+	}
+
+	start := i.Start
+	if other.Start.After(start) {
+		start = other.Start
+	}
+
+	end := i.End
+	if other.End.Before(end) {
+		end = other.End
+	}
+
+	return Interval{Start: start, End: end}, true
+}
+
+// String returns the interval in the form "[start, end)" using RFC 3339.
+func (i Interval) String() string {
+	return fmt.Sprintf("[%s, %s)", i.Start.Format(RFC3339Nano), i.End.Format(RFC3339Nano))
+}
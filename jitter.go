@@ -0,0 +1,115 @@
+package temporalis
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// SmoothIntervals applies an exponentially weighted moving average to a
+// series of observed intervals and returns the smoothed series, which has the
+// same length as the input. Each output is alpha times the observation plus
+// (1 - alpha) times the previous output; the first output equals the first
+// observation. Alpha is clamped to [0, 1], where values close to 1 follow the
+// input closely and values close to 0 smooth heavily.
+func SmoothIntervals(observations []time.Duration, alpha float64) []time.Duration {
+	if len(observations) == 0 {
+		return nil
+	}
+
+	alpha = math.Max(0, math.Min(1, alpha))
+
+	smoothed := make([]time.Duration, len(observations))
+	avg := float64(observations[0])
+	smoothed[0] = observations[0]
+
+	for i := 1; i < len(observations); i++ {
+		avg = alpha*float64(observations[i]) + (1-alpha)*avg
+		smoothed[i] = time.Duration(math.Round(avg))
+	}
+
+	return smoothed
+}
+
+// InterArrivals returns the gaps between consecutive timestamps, in the order
+// given. Out-of-order timestamps produce negative gaps.
+func InterArrivals(timestamps []time.Time) []time.Duration {
+	if len(timestamps) < 2 {
+		return nil
+	}
+
+	gaps := make([]time.Duration, len(timestamps)-1)
+	for i := 1; i < len(timestamps); i++ {
+		gaps[i-1] = timestamps[i].Sub(timestamps[i-1])
+	}
+
+	return gaps
+}
+
+// JitterSummary describes the distribution of inter-arrival times of a
+// series of events such as heartbeats or packets.
+type JitterSummary struct {
+	Count  int
+	Mean   time.Duration
+	StdDev time.Duration
+	Min    time.Duration
+	Max    time.Duration
+	P99    time.Duration
+}
+
+// JitterStats summarises the gaps between consecutive timestamps. The
+// standard deviation is the population standard deviation, and P99 is the
+// nearest-rank 99th percentile. Fewer than two timestamps yield a zero
+// summary.
+func JitterStats(timestamps []time.Time) JitterSummary {
+	return intervalStats(InterArrivals(timestamps))
+}
+
+// intervalStats summarises a set of durations.
+func intervalStats(gaps []time.Duration) JitterSummary {
+	if len(gaps) == 0 {
+		return JitterSummary{}
+	}
+
+	sorted := append([]time.Duration(nil), gaps...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum float64
+	for _, g := range gaps {
+		sum += float64(g)
+	}
+	mean := sum / float64(len(gaps))
+
+	var variance float64
+	for _, g := range gaps {
+		variance += (float64(g) - mean) * (float64(g) - mean)
+	}
+	variance /= float64(len(gaps))
+
+	return JitterSummary{
+		Count:  len(gaps),
+		Mean:   time.Duration(math.Round(mean)),
+		StdDev: time.Duration(math.Round(math.Sqrt(variance))),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		P99:    percentile(sorted, 0.99),
+	}
+}
+
+// percentile returns the nearest-rank percentile p, in [0, 1], of a sorted
+// slice of durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+
+	return sorted[rank]
+}
@@ -0,0 +1,34 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSmoothIntervals checks the moving average against hand-computed values.
+func TestSmoothIntervals(t *testing.T) {
+	in := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 100 * time.Millisecond}
+	expected := []time.Duration{100 * time.Millisecond, 150 * time.Millisecond, 125 * time.Millisecond}
+
+	actual := SmoothIntervals(in, 0.5)
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("SmoothIntervals()[%d] = %v, expected %v", i, actual[i], expected[i])
+		}
+	}
+}
+
+// TestJitterStats checks the summary of a heartbeat series with one late beat.
+func TestJitterStats(t *testing.T) {
+	start := time.Unix(0, 0)
+	beats := []time.Time{start, start.Add(time.Second), start.Add(2 * time.Second), start.Add(4 * time.Second)}
+
+	stats := JitterStats(beats)
+	if stats.Count != 3 || stats.Min != time.Second || stats.Max != 2*time.Second || stats.P99 != 2*time.Second {
+		t.Errorf("JitterStats() = %+v", stats)
+	}
+
+	if stats.Mean != 1333333333 {
+		t.Errorf("JitterStats().Mean = %v, expected 1.333333333s", stats.Mean)
+	}
+}
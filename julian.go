@@ -0,0 +1,98 @@
+package temporalis
+
+import (
+	"math"
+	"time"
+)
+
+// Epochs of the astronomical day counts, in Unix time.
+const (
+	// unixJulianDay is the Julian day number of the Unix epoch.
+	unixJulianDay = 2440587.5
+	// unixModifiedJulianDate is the modified Julian date of the Unix epoch.
+	unixModifiedJulianDate = 40587
+	// unixJ2000 is the Unix time of the J2000.0 epoch, 2000-01-01 12:00 UTC.
+	unixJ2000 = 946728000
+	// secondsPerDay is the number of SI seconds in a day without a leap
+	// second.
+	secondsPerDay = 86400
+)
+
+// ToJulianDay returns the Julian day of t: the number of days, with a
+// fraction, since noon on 1 January 4713 BC in the proleptic Julian
+// calendar, so 2000-01-01 12:00 UTC is 2451545.0. Leap seconds are not
+// counted, as in most astronomical software working in UTC. A float64 holds
+// a current Julian day to within about 40 microseconds.
+func ToJulianDay(t time.Time) float64 {
+	return unixDays(t) + unixJulianDay
+}
+
+// FromJulianDay returns the UTC instant of the Julian day jd, the inverse of
+// ToJulianDay, rounded to the microsecond.
+func FromJulianDay(jd float64) time.Time {
+	return fromUnixDays(jd - unixJulianDay)
+}
+
+// ToModifiedJulianDate returns the modified Julian date of t, which is the
+// Julian day minus 2400000.5, so that days start at midnight and
+// 1858-11-17 00:00 UTC is zero. Its smaller magnitude keeps more precision
+// than ToJulianDay.
+func ToModifiedJulianDate(t time.Time) float64 {
+	return unixDays(t) + unixModifiedJulianDate
+}
+
+// FromModifiedJulianDate returns the UTC instant of the modified Julian
+// date mjd, the inverse of ToModifiedJulianDate, rounded to the microsecond.
+func FromModifiedJulianDate(mjd float64) time.Time {
+	return fromUnixDays(mjd - unixModifiedJulianDate)
+}
+
+// GreenwichSiderealTime returns the Greenwich mean sidereal time at t, the
+// hour angle of the vernal equinox at the prime meridian, as a duration from
+// zero up to 24 hours of sidereal time. It uses the IAU 1982 expression and
+// treats t as UT1, which differs from UTC by less than a second, so the
+// result is good to about a second.
+func GreenwichSiderealTime(t time.Time) time.Duration {
+	d := float64(t.Unix()-unixJ2000)/secondsPerDay + float64(t.Nanosecond())/1e9/secondsPerDay
+	c := d / 36525
+
+	degrees := 280.46061837 + 360.98564736629*d + 0.000387933*c*c - c*c*c/38710000
+
+	return degreesToHours(degrees)
+}
+
+// LocalSiderealTime returns the local mean sidereal time at t for the given
+// longitude in degrees, positive east of Greenwich. A star culminates when
+// the local sidereal time equals its right ascension.
+func LocalSiderealTime(t time.Time, longitude float64) time.Duration {
+	return degreesToHours(float64(GreenwichSiderealTime(t))/float64(time.Hour)*15 + longitude)
+}
+
+// degreesToHours converts an angle to a duration at 15 degrees per hour,
+// normalised to [0, 24h).
+func degreesToHours(degrees float64) time.Duration {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+
+	return time.Duration(degrees / 15 * float64(time.Hour))
+}
+
+// unixDays returns the number of days from the Unix epoch to t, with a
+// fraction.
+func unixDays(t time.Time) float64 {
+	secs := t.Unix()
+	days := math.Floor(float64(secs) / secondsPerDay)
+	rest := float64(secs-int64(days)*secondsPerDay) + float64(t.Nanosecond())/1e9
+
+	return days + rest/secondsPerDay
+}
+
+// fromUnixDays is the inverse of unixDays, rounded to the microsecond.
+func fromUnixDays(days float64) time.Time {
+	whole := math.Floor(days)
+	us := math.Round((days - whole) * secondsPerDay * 1e6)
+
+	return time.Unix(int64(whole)*secondsPerDay, 0).Add(time.Duration(us) * time.Microsecond).UTC()
+}
@@ -0,0 +1,73 @@
+package temporalis
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestJulianDay tests Julian days and modified Julian dates at known epochs
+// and the round trip back to a time.
+func TestJulianDay(t *testing.T) {
+	tests := []struct {
+		t   time.Time
+		jd  float64
+		mjd float64
+	}{
+		{time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 2451545.0, 51544.5},
+		{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 2440587.5, 40587},
+		{time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC), 2400000.5, 0},
+		{time.Date(1957, 10, 4, 19, 26, 24, 0, time.UTC), 2436116.31, 36115.81},
+		{time.Date(2024, 5, 1, 8, 0, 0, 0, time.FixedZone("", 2*3600)), 2460431.75, 60431.25},
+	}
+
+	for _, test := range tests {
+		if actual := ToJulianDay(test.t); math.Abs(actual-test.jd) > 1e-9 {
+			t.Errorf("ToJulianDay(%v) = %f, expected %f", test.t, actual, test.jd)
+		}
+		if actual := ToModifiedJulianDate(test.t); math.Abs(actual-test.mjd) > 1e-9 {
+			t.Errorf("ToModifiedJulianDate(%v) = %f, expected %f", test.t, actual, test.mjd)
+		}
+		if actual := FromJulianDay(test.jd); actual.Sub(test.t).Abs() > 50*time.Microsecond {
+			t.Errorf("FromJulianDay(%f) = %v, expected %v", test.jd, actual, test.t)
+		}
+		if actual := FromModifiedJulianDate(test.mjd); !actual.Equal(test.t) {
+			t.Errorf("FromModifiedJulianDate(%f) = %v, expected %v", test.mjd, actual, test.t)
+		}
+	}
+
+	at := time.Date(2024, 5, 1, 9, 30, 15, 123456000, time.UTC)
+	if actual := FromModifiedJulianDate(ToModifiedJulianDate(at)); !actual.Equal(at) {
+		t.Errorf("FromModifiedJulianDate(ToModifiedJulianDate(%v)) = %v", at, actual)
+	}
+}
+
+// TestSiderealTime tests sidereal time against the examples in Meeus,
+// Astronomical Algorithms, chapter 12.
+func TestSiderealTime(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected time.Duration
+	}{
+		{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), 13*time.Hour + 10*time.Minute + 46366800*time.Microsecond},
+		{time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC), 8*time.Hour + 34*time.Minute + 57089600*time.Microsecond},
+	}
+
+	for _, test := range tests {
+		if actual := GreenwichSiderealTime(test.t); (actual - test.expected).Abs() > time.Millisecond {
+			t.Errorf("GreenwichSiderealTime(%v) = %v, expected %v", test.t, actual, test.expected)
+		}
+	}
+
+	// 77°03'56" west puts Washington 5h08m15.73s of sidereal time behind
+	// Greenwich.
+	at := tests[1].t
+	expected := tests[1].expected - (5*time.Hour + 8*time.Minute + 15733*time.Millisecond)
+	if actual := LocalSiderealTime(at, -(77 + 3.0/60 + 56.0/3600)); (actual - expected).Abs() > 10*time.Millisecond {
+		t.Errorf("LocalSiderealTime(%v, Washington) = %v, expected %v", at, actual, expected)
+	}
+
+	if actual := LocalSiderealTime(at, 180); actual < 0 || actual >= 24*time.Hour {
+		t.Errorf("LocalSiderealTime(%v, 180) = %v, expected a value in [0, 24h)", at, actual)
+	}
+}
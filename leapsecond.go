@@ -0,0 +1,216 @@
+package temporalis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LeapSecond is an entry of the leap-second table: from Time on, TAI is
+// TAIOffset seconds ahead of UTC.
+type LeapSecond struct {
+	Time      time.Time
+	TAIOffset int
+}
+
+// gpsTAIOffset is how many seconds GPS time is behind TAI. GPS time was
+// equal to UTC at its epoch in 1980, when TAI was 19 seconds ahead, and
+// has not followed leap seconds since.
+const gpsTAIOffset = 19
+
+// leapSeconds is the leap-second table, ordered by time. The built-in table
+// is current as of IERS Bulletin C 70; SetLeapSeconds replaces it when the
+// IERS announces a new leap second.
+var leapSeconds = []LeapSecond{
+	{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+	{time.Date(1972, 7, 1, 0, 0, 0, 0, time.UTC), 11},
+	{time.Date(1973, 1, 1, 0, 0, 0, 0, time.UTC), 12},
+	{time.Date(1974, 1, 1, 0, 0, 0, 0, time.UTC), 13},
+	{time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC), 14},
+	{time.Date(1976, 1, 1, 0, 0, 0, 0, time.UTC), 15},
+	{time.Date(1977, 1, 1, 0, 0, 0, 0, time.UTC), 16},
+	{time.Date(1978, 1, 1, 0, 0, 0, 0, time.UTC), 17},
+	{time.Date(1979, 1, 1, 0, 0, 0, 0, time.UTC), 18},
+	{time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), 19},
+	{time.Date(1981, 7, 1, 0, 0, 0, 0, time.UTC), 20},
+	{time.Date(1982, 7, 1, 0, 0, 0, 0, time.UTC), 21},
+	{time.Date(1983, 7, 1, 0, 0, 0, 0, time.UTC), 22},
+	{time.Date(1985, 7, 1, 0, 0, 0, 0, time.UTC), 23},
+	{time.Date(1988, 1, 1, 0, 0, 0, 0, time.UTC), 24},
+	{time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), 25},
+	{time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC), 26},
+	{time.Date(1992, 7, 1, 0, 0, 0, 0, time.UTC), 27},
+	{time.Date(1993, 7, 1, 0, 0, 0, 0, time.UTC), 28},
+	{time.Date(1994, 7, 1, 0, 0, 0, 0, time.UTC), 29},
+	{time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC), 30},
+	{time.Date(1997, 7, 1, 0, 0, 0, 0, time.UTC), 31},
+	{time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), 32},
+	{time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC), 33},
+	{time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC), 34},
+	{time.Date(2012, 7, 1, 0, 0, 0, 0, time.UTC), 35},
+	{time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC), 36},
+	{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37},
+}
+
+var leapSecondsMu sync.RWMutex
+
+// LeapSeconds returns a copy of the leap-second table in use.
+func LeapSeconds() []LeapSecond {
+	leapSecondsMu.RLock()
+	defer leapSecondsMu.RUnlock()
+
+	return slices.Clone(leapSeconds)
+}
+
+// SetLeapSeconds replaces the leap-second table, for example with one read
+// by ParseLeapSecondsList from the IERS leap-seconds.list file. The entries
+// must be in strictly increasing order of time, and consecutive offsets may
+// differ by one second only; otherwise an error wrapping ErrOutOfRange is
+// returned and the table is left unchanged.
+func SetLeapSeconds(table []LeapSecond) error {
+	if len(table) == 0 {
+		return detailed(ErrOutOfRange, "empty leap-second table")
+	}
+
+	for i := 1; i < len(table); i++ {
+		if !table[i].Time.After(table[i-1].Time) {
+			return detailed(ErrOutOfRange, fmt.Sprintf("leap second at %s is out of order", table[i].Time.Format(time.DateOnly)))
+		}
+		if d := table[i].TAIOffset - table[i-1].TAIOffset; d != 1 && d != -1 {
+			return detailed(ErrOutOfRange, fmt.Sprintf("leap second at %s changes the offset by %d seconds", table[i].Time.Format(time.DateOnly), d))
+		}
+	}
+
+	leapSecondsMu.Lock()
+	defer leapSecondsMu.Unlock()
+
+	leapSeconds = slices.Clone(table)
+
+	return nil
+}
+
+// ParseLeapSecondsList reads a leap-second table in the format of the
+// leap-seconds.list file published by the IERS and distributed with the
+// tz database, in which every line that is not a comment holds a time in
+// seconds since 1900-01-01 and the TAI offset from then on:
+//
+//	2272060800	10	# 1 Jan 1972
+//	3692217600	37	# 1 Jan 2017
+//
+// Lines starting with # are comments, including the expiry and hash lines.
+func ParseLeapSecondsList(r io.Reader) ([]LeapSecond, error) {
+	var table []LeapSecond
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, syntaxError("ParseLeapSecondsList", scanner.Text(), "expected a time and an offset")
+		}
+
+		secs, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, numberError("ParseLeapSecondsList", scanner.Text(), err)
+		}
+
+		offset, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, numberError("ParseLeapSecondsList", scanner.Text(), err)
+		}
+
+		table = append(table, LeapSecond{Time: time.Unix(secs-ntpEpochOffset, 0).UTC(), TAIOffset: offset})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// ntpEpochOffset is the number of seconds from 1900-01-01, the epoch of
+// leap-seconds.list, to the Unix epoch.
+const ntpEpochOffset = 2208988800
+
+// LeapSecondsAt returns the number of seconds by which TAI is ahead of UTC
+// at t: 10 from the start of 1972, when UTC adopted leap seconds, plus one
+// for every leap second since, so 37 from 2017 on. Before 1972 the relation
+// between the scales was not a whole number of seconds, and LeapSecondsAt
+// returns 0.
+func LeapSecondsAt(t time.Time) int {
+	leapSecondsMu.RLock()
+	defer leapSecondsMu.RUnlock()
+
+	i, found := slices.BinarySearchFunc(leapSeconds, t, func(l LeapSecond, t time.Time) int {
+		return l.Time.Compare(t)
+	})
+	if found {
+		i++
+	}
+	if i == 0 {
+		return 0
+	}
+
+	return leapSeconds[i-1].TAIOffset
+}
+
+// ToTAI converts the UTC instant t to International Atomic Time. Because
+// time.Time has no notion of a time scale, the result is a time in UTC whose
+// reading is the TAI reading, so 2017-01-01 00:00:00 UTC becomes
+// 2017-01-01 00:00:37 in the result. Subtracting two TAI readings gives the
+// true elapsed time, including any leap seconds in between.
+func ToTAI(t time.Time) time.Time {
+	return t.UTC().Add(time.Duration(LeapSecondsAt(t)) * time.Second)
+}
+
+// FromTAI converts a TAI reading, as returned by ToTAI, back to a UTC
+// instant. UTC has no representation for an inserted leap second, such as
+// 2016-12-31 23:59:60, so the TAI second during it maps to the first second
+// of the following day.
+func FromTAI(tai time.Time) time.Time {
+	leapSecondsMu.RLock()
+	defer leapSecondsMu.RUnlock()
+
+	tai = tai.UTC()
+	for i := len(leapSeconds) - 1; i >= 0; i-- {
+		l := leapSeconds[i]
+		if utc := tai.Add(-time.Duration(l.TAIOffset) * time.Second); !utc.Before(l.Time) {
+			return utc
+		}
+
+		// A TAI reading inside the leap second itself, or inside the jump
+		// to ten seconds at the start of the table, is after the entry by
+		// the old offset but not by the new one.
+		prev := 0
+		if i > 0 {
+			prev = leapSeconds[i-1].TAIOffset
+		}
+		if !tai.Add(-time.Duration(prev) * time.Second).Before(l.Time) {
+			return l.Time
+		}
+	}
+
+	return tai
+}
+
+// ToGPS converts the UTC instant t to GPS time, represented like the result
+// of ToTAI. GPS time is a constant 19 seconds behind TAI, so it too counts
+// leap seconds, and 2017-01-01 00:00:00 UTC is 00:00:18 in GPS time.
+func ToGPS(t time.Time) time.Time {
+	return ToTAI(t).Add(-gpsTAIOffset * time.Second)
+}
+
+// FromGPS converts a GPS reading, as returned by ToGPS, back to a UTC
+// instant, like FromTAI.
+func FromGPS(gps time.Time) time.Time {
+	return FromTAI(gps.Add(gpsTAIOffset * time.Second))
+}
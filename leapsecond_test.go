@@ -0,0 +1,100 @@
+package temporalis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLeapSecondsAt tests the TAI offset before, at and after leap seconds.
+func TestLeapSecondsAt(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected int
+	}{
+		{time.Date(1971, 12, 31, 23, 59, 59, 0, time.UTC), 0},
+		{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+		{time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), 36},
+		{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37},
+		{time.Date(2017, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)), 37},
+		{time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), 37},
+	}
+
+	for _, test := range tests {
+		if actual := LeapSecondsAt(test.t); actual != test.expected {
+			t.Errorf("LeapSecondsAt(%v) = %d, expected %d", test.t, actual, test.expected)
+		}
+	}
+}
+
+// TestTAIConversions tests that TAI and GPS readings count the leap second
+// and convert back to the original UTC instants.
+func TestTAIConversions(t *testing.T) {
+	before := time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)
+	after := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if d := ToTAI(after).Sub(ToTAI(before)); d != 2*time.Second {
+		t.Errorf("TAI seconds across the 2016 leap second = %v, expected 2s", d)
+	}
+
+	if actual, expected := ToGPS(after), time.Date(2017, 1, 1, 0, 0, 18, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("ToGPS(%v) = %v, expected %v", after, actual, expected)
+	}
+
+	for _, utc := range []time.Time{
+		time.Date(1985, 3, 1, 12, 0, 0, 0, time.UTC),
+		before,
+		after,
+		time.Date(2024, 5, 1, 9, 30, 0, 500, time.UTC),
+	} {
+		if actual := FromTAI(ToTAI(utc)); !actual.Equal(utc) {
+			t.Errorf("FromTAI(ToTAI(%v)) = %v", utc, actual)
+		}
+		if actual := FromGPS(ToGPS(utc)); !actual.Equal(utc) {
+			t.Errorf("FromGPS(ToGPS(%v)) = %v", utc, actual)
+		}
+	}
+
+	// The TAI second of 2016-12-31 23:59:60 has no UTC reading of its own.
+	if actual := FromTAI(time.Date(2017, 1, 1, 0, 0, 36, 0, time.UTC)); !actual.Equal(after) {
+		t.Errorf("FromTAI(leap second) = %v, expected %v", actual, after)
+	}
+}
+
+// TestSetLeapSeconds tests installing a parsed leap-seconds.list table and
+// rejecting inconsistent ones.
+func TestSetLeapSeconds(t *testing.T) {
+	defer SetLeapSeconds(LeapSeconds())
+
+	list := `# leap-seconds.list
+#@	3960057600
+2272060800	10	# 1 Jan 1972
+3692217600	11	# 1 Jan 2017
+4102444800	12	# 1 Jan 2030, hypothetical
+`
+	table, err := ParseLeapSecondsList(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("ParseLeapSecondsList() returned error: %v", err)
+	}
+	if len(table) != 3 || !table[2].Time.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("ParseLeapSecondsList() = %v, expected 3 entries ending in 2030", table)
+	}
+
+	if err := SetLeapSeconds(table); err != nil {
+		t.Fatalf("SetLeapSeconds() returned error: %v", err)
+	}
+	if actual := LeapSecondsAt(time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)); actual != 12 {
+		t.Errorf("LeapSecondsAt(2031) = %d after SetLeapSeconds, expected 12", actual)
+	}
+
+	for _, bad := range [][]LeapSecond{nil, {table[1], table[0]}, {table[0], {table[2].Time, 15}}} {
+		if err := SetLeapSeconds(bad); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("SetLeapSeconds(%v) = %v, expected ErrOutOfRange", bad, err)
+		}
+	}
+
+	if _, err := ParseLeapSecondsList(strings.NewReader("2272060800 ten\n")); !errors.Is(err, ErrSyntax) {
+		t.Errorf("ParseLeapSecondsList(bad offset) = %v, expected ErrSyntax", err)
+	}
+}
@@ -0,0 +1,54 @@
+package temporalis
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	localZoneMu       sync.Mutex
+	localZoneResolver func() string
+	localZoneName     string
+	localZoneLoc      *time.Location
+)
+
+// SetLocalZoneResolver installs a function that reports the IANA name of the
+// local zone, such as "Europe/Berlin". Functions that default to local time,
+// like Today(nil) and the zero Temporalis, then use that zone instead of
+// time.Local. It is meant for platforms where time.Local is only a fixed
+// offset, as in WebAssembly, where the browser knows the real zone. On
+// js/wasm a resolver that asks the browser through Intl.DateTimeFormat is
+// installed by default. Passing nil restores time.Local.
+func SetLocalZoneResolver(resolve func() string) {
+	localZoneMu.Lock()
+	defer localZoneMu.Unlock()
+
+	localZoneResolver = resolve
+	localZoneName, localZoneLoc = "", nil
+}
+
+// LocalLocation returns the local zone: the zone named by the resolver set
+// with SetLocalZoneResolver if it can be loaded, and time.Local otherwise.
+func LocalLocation() *time.Location {
+	localZoneMu.Lock()
+	defer localZoneMu.Unlock()
+
+	if localZoneResolver == nil {
+		return time.Local
+	}
+
+	name := localZoneResolver()
+	if name == "" {
+		return time.Local
+	}
+
+	if name != localZoneName || localZoneLoc == nil {
+		loc, err := LoadLocation(name)
+		if err != nil {
+			return time.Local
+		}
+		localZoneName, localZoneLoc = name, loc
+	}
+
+	return localZoneLoc
+}
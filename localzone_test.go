@@ -0,0 +1,55 @@
+package temporalis
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// TestLocalLocation tests that LocalLocation honours the zone resolver and
+// falls back to time.Local for empty or unknown names.
+func TestLocalLocation(t *testing.T) {
+	defer SetLocalZoneResolver(localZoneResolver)
+
+	SetLocalZoneResolver(nil)
+	if loc := LocalLocation(); loc != time.Local {
+		t.Errorf("LocalLocation() = %v, expected time.Local", loc)
+	}
+
+	zone := "Asia/Tokyo"
+	SetLocalZoneResolver(func() string { return zone })
+
+	if loc := LocalLocation(); loc.String() != "Asia/Tokyo" {
+		t.Errorf("LocalLocation() = %v, expected Asia/Tokyo", loc)
+	}
+
+	now := time.Now()
+	if today, expected := Today(nil), DateOf(now.In(LocalLocation())); today != expected {
+		t.Errorf("Today(nil) = %v, expected %v", today, expected)
+	}
+
+	for _, zone = range []string{"", "Not/AZone"} {
+		if loc := LocalLocation(); loc != time.Local {
+			t.Errorf("LocalLocation() with %q = %v, expected time.Local", zone, loc)
+		}
+	}
+}
+
+// TestTrimmedTZData tests that the trimmed snapshot used by WebAssembly
+// builds contains the canonical zones.
+func TestTrimmedTZData(t *testing.T) {
+	data, err := os.ReadFile("tzdata/zoneinfo_trimmed.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RegisterTZData("trimmed", data); err != nil {
+		t.Fatalf("RegisterTZData() returned error: %v", err)
+	}
+
+	for _, name := range []string{"Europe/Berlin", "America/New_York", "Etc/GMT+5"} {
+		if _, err := LoadLocationVersion(name, "trimmed"); err != nil {
+			t.Errorf("LoadLocationVersion(%q) returned error: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package temporalis
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+)
+
+// The wrapper types in this file embed time.Time and only change how the
+// value is encoded, so struct fields can declare their wire format:
+//
+//	type Event struct {
+//		Created temporalis.UnixMillisTime `json:"created"`
+//		Day     temporalis.DateOnly       `json:"day"`
+//	}
+//
+// All of them encode the zero time as JSON null and decode null as the zero
+// time.
+
+var jsonNull = []byte("null")
+
+// RFC3339Time encodes a time as an RFC 3339 string with nanosecond precision,
+// such as "2024-05-01T09:30:00.5Z".
+type RFC3339Time struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t RFC3339Time) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(t.Time, t.MarshalText)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *RFC3339Time) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, &t.Time, t.UnmarshalText)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t RFC3339Time) MarshalText() ([]byte, error) {
+	return []byte(t.Format(RFC3339Nano)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *RFC3339Time) UnmarshalText(data []byte) error {
+	parsed, err := parseRFC3339("RFC3339Time.UnmarshalText", string(data))
+	if err != nil {
+		return err
+	}
+
+	t.Time = parsed
+
+	return nil
+}
+
+// UnixSecondsTime encodes a time as an integer number of seconds since the
+// Unix epoch. Decoding also accepts fractional seconds and quoted numbers.
+type UnixSecondsTime struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t UnixSecondsTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return jsonNull, nil
+	}
+
+	return t.MarshalText()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *UnixSecondsTime) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, &t.Time, t.UnmarshalText)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t UnixSecondsTime) MarshalText() ([]byte, error) {
+	return strconv.AppendInt(nil, t.Unix(), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *UnixSecondsTime) UnmarshalText(data []byte) error {
+	parsed, err := ParseUnix(string(data))
+	if err != nil {
+		return err
+	}
+
+	t.Time = parsed
+
+	return nil
+}
+
+// UnixMillisTime encodes a time as an integer number of milliseconds since
+// the Unix epoch, as used by JavaScript's Date.now. Decoding also accepts
+// quoted numbers.
+type UnixMillisTime struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t UnixMillisTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return jsonNull, nil
+	}
+
+	return t.MarshalText()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *UnixMillisTime) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, &t.Time, t.UnmarshalText)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t UnixMillisTime) MarshalText() ([]byte, error) {
+	return strconv.AppendInt(nil, t.UnixMilli(), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *UnixMillisTime) UnmarshalText(data []byte) error {
+	ms, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return numberError("UnixMillisTime.UnmarshalText", string(data), err)
+	}
+
+	t.Time = time.UnixMilli(ms)
+
+	return nil
+}
+
+// DateOnly encodes only the calendar date of a time as "2006-01-02". Decoded
+// values are at midnight UTC.
+type DateOnly struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t DateOnly) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(t.Time, t.MarshalText)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *DateOnly) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, &t.Time, t.UnmarshalText)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t DateOnly) MarshalText() ([]byte, error) {
+	return []byte(t.Format(time.DateOnly)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *DateOnly) UnmarshalText(data []byte) error {
+	parsed, err := time.Parse(time.DateOnly, string(data))
+	if err != nil {
+		return timeParseError("DateOnly.UnmarshalText", string(data), err)
+	}
+
+	t.Time = parsed
+
+	return nil
+}
+
+// TimeOfDayJSON encodes only the clock reading of a time as "15:04:05".
+// Decoding also accepts "15:04" and fractional seconds; decoded values are on
+// January 1, year 0, UTC.
+type TimeOfDayJSON struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t TimeOfDayJSON) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(t.Time, t.MarshalText)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *TimeOfDayJSON) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, &t.Time, t.UnmarshalText)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDayJSON) MarshalText() ([]byte, error) {
+	return []byte(t.Format(time.TimeOnly)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOfDayJSON) UnmarshalText(data []byte) error {
+	for _, layout := range []string{"15:04:05.999999999", "15:04"} {
+		if parsed, err := time.Parse(layout, string(data)); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return syntaxError("TimeOfDayJSON.UnmarshalText", string(data), "")
+}
+
+// marshalJSONText encodes the text form of t as a JSON string, or the zero
+// time as null.
+func marshalJSONText(t time.Time, text func() ([]byte, error)) ([]byte, error) {
+	if t.IsZero() {
+		return jsonNull, nil
+	}
+
+	b, err := text()
+	if err != nil {
+		return nil, err
+	}
+
+	return strconv.AppendQuote(nil, string(b)), nil
+}
+
+// unmarshalJSONText decodes a JSON string or bare number through the text
+// decoder, and null into the zero time.
+func unmarshalJSONText(data []byte, t *time.Time, text func([]byte) error) error {
+	if bytes.Equal(data, jsonNull) {
+		*t = time.Time{}
+		return nil
+	}
+
+	if len(data) >= 2 && data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return syntaxError("UnmarshalJSON", string(data), "invalid JSON string")
+		}
+		data = []byte(s)
+	}
+
+	return text(data)
+}
+
+// parseRFC3339 parses an RFC 3339 time on behalf of fn.
+func parseRFC3339(fn, s string) (time.Time, error) {
+	t, err := time.Parse(RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, timeParseError(fn, s, err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,57 @@
+package temporalis
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestMarshalWrappers checks that each wrapper type encodes the same instant in
+// its declared format and decodes it back, and that the zero time maps to null.
+func TestMarshalWrappers(t *testing.T) {
+	instant := time.Date(2024, time.May, 6, 12, 53, 20, 123000000, time.UTC)
+
+	type record struct {
+		RFC3339 RFC3339Time     `json:"rfc3339"`
+		Seconds UnixSecondsTime `json:"seconds"`
+		Millis  UnixMillisTime  `json:"millis"`
+		Day     DateOnly        `json:"day"`
+		Clock   TimeOfDayJSON   `json:"clock"`
+		Missing RFC3339Time     `json:"missing"`
+	}
+
+	in := record{
+		RFC3339: RFC3339Time{instant},
+		Seconds: UnixSecondsTime{instant},
+		Millis:  UnixMillisTime{instant},
+		Day:     DateOnly{instant},
+		Clock:   TimeOfDayJSON{instant},
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned error: %v", err)
+	}
+
+	expected := `{"rfc3339":"2024-05-06T12:53:20.123Z","seconds":1715000000,"millis":1715000000123,"day":"2024-05-06","clock":"12:53:20","missing":null}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal() = %s, expected %s", data, expected)
+	}
+
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal() returned error: %v", err)
+	}
+
+	if !out.RFC3339.Equal(instant) || !out.Millis.Equal(instant) || !out.Seconds.Equal(instant.Truncate(time.Second)) {
+		t.Errorf("json.Unmarshal() did not round-trip the instant: %+v", out)
+	}
+
+	if out.Day.Format(time.DateOnly) != "2024-05-06" || out.Clock.Format(time.TimeOnly) != "12:53:20" || !out.Missing.IsZero() {
+		t.Errorf("json.Unmarshal() decoded unexpected values: %+v", out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"seconds":"1715000000.5"}`), &out); err != nil || out.Seconds.Nanosecond() != 500000000 {
+		t.Errorf("json.Unmarshal() of quoted fractional seconds = %v, %v", out.Seconds, err)
+	}
+}
@@ -0,0 +1,152 @@
+package temporalis
+
+import (
+	"slices"
+	"time"
+)
+
+// Availability describes when one participant can attend a meeting: during
+// Hours on the business days of Calendar, on the wall clock of Location,
+// except when Busy.
+type Availability struct {
+	// Location is the participant's time zone. Nil means UTC.
+	Location *time.Location
+	// Hours are the daily working hours, which may wrap around midnight for
+	// a night shift. The zero value is the whole day.
+	Hours ClockRange
+	// Calendar gives the days the participant works. Nil means Monday to
+	// Friday.
+	Calendar *BusinessCalendar
+	// Busy lists meetings and other commitments, in any order and possibly
+	// overlapping.
+	Busy []Interval
+}
+
+// FindCommonSlots returns the intervals within the given interval during
+// which every participant is available, that are at least duration long, in
+// order. Each slot is as long as possible, so a meeting can start anywhere
+// from its start up to duration before its end. Availability is evaluated in
+// each participant's own zone, so a Friday afternoon in New York is not
+// offered to a colleague for whom it is already the weekend in Sydney.
+func FindCommonSlots(participants []Availability, duration time.Duration, within Interval) []Interval {
+	if within.IsEmpty() {
+		return nil
+	}
+
+	free := []Interval{within}
+	for _, p := range participants {
+		free = intersectIntervals(free, p.available(within))
+	}
+
+	var slots []Interval
+	for _, i := range free {
+		if i.Duration() >= duration {
+			slots = append(slots, i)
+		}
+	}
+
+	return slots
+}
+
+// available returns the free intervals of the participant that overlap
+// within, sorted and disjoint.
+func (a Availability) available(within Interval) []Interval {
+	loc := a.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var hours []Interval
+	last := DateOf(within.End.In(loc))
+	// A range that wraps around midnight starts on the day before.
+	for d := DateOf(within.Start.In(loc)).AddDays(-1); !d.After(last); d = d.AddDays(1) {
+		if !a.Calendar.IsBusinessDay(d) {
+			continue
+		}
+		for _, i := range a.Hours.shiftsOn(d, loc) {
+			if i.Overlaps(within) {
+				hours = append(hours, i)
+			}
+		}
+	}
+
+	hours = normalizeIntervals(hours)
+	for _, busy := range a.Busy {
+		hours = subtractInterval(hours, busy)
+	}
+
+	return hours
+}
+
+// shiftsOn returns the working period that starts on the date in loc: from
+// Start to End, running into the next day if the range wraps around
+// midnight. The zero range is the whole date.
+func (r ClockRange) shiftsOn(d CivilDate, loc *time.Location) []Interval {
+	start := r.Start.OnDate(d, loc)
+	if r.Start.Before(r.End) {
+		return []Interval{{Start: start, End: r.End.OnDate(d, loc)}}
+	}
+
+	return []Interval{{Start: start, End: r.End.OnDate(d.AddDays(1), loc)}}
+}
+
+// normalizeIntervals sorts the intervals and merges those that overlap or
+// touch, dropping empty ones.
+func normalizeIntervals(intervals []Interval) []Interval {
+	intervals = slices.DeleteFunc(slices.Clone(intervals), Interval.IsEmpty)
+	slices.SortFunc(intervals, func(a, b Interval) int { return a.Start.Compare(b.Start) })
+
+	var result []Interval
+	for _, i := range intervals {
+		if n := len(result); n > 0 && !i.Start.After(result[n-1].End) {
+			if i.End.After(result[n-1].End) {
+				result[n-1].End = i.End
+			}
+			continue
+		}
+		result = append(result, i)
+	}
+
+	return result
+}
+
+// intersectIntervals returns the parts common to two sorted, disjoint lists
+// of intervals.
+func intersectIntervals(a, b []Interval) []Interval {
+	var result []Interval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if overlap, ok := a[i].Intersect(b[j]); ok {
+			result = append(result, overlap)
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return result
+}
+
+// subtractInterval removes x from a sorted, disjoint list of intervals.
+func subtractInterval(intervals []Interval, x Interval) []Interval {
+	if x.IsEmpty() {
+		return intervals
+	}
+
+	var result []Interval
+	for _, i := range intervals {
+		if !i.Overlaps(x) {
+			result = append(result, i)
+			continue
+		}
+		if i.Start.Before(x.Start) {
+			result = append(result, Interval{Start: i.Start, End: x.Start})
+		}
+		if x.End.Before(i.End) {
+			result = append(result, Interval{Start: x.End, End: i.End})
+		}
+	}
+
+	return result
+}
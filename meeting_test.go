@@ -0,0 +1,82 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFindCommonSlots tests business hours in two zones whose DST periods
+// differ, with a busy hour in between.
+func TestFindCommonSlots(t *testing.T) {
+	newYork, err1 := time.LoadLocation("America/New_York")
+	london, err2 := time.LoadLocation("Europe/London")
+	sydney, err3 := time.LoadLocation("Australia/Sydney")
+	if err1 != nil || err2 != nil || err3 != nil {
+		t.Skip("time zone data not available")
+	}
+
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC) }
+	office := ClockRange{Start: NewTimeOfDay(9, 0, 0, 0), End: NewTimeOfDay(17, 0, 0, 0)}
+
+	// On 11 March New York is on EDT but London is still on GMT, so 09:00 to
+	// 17:00 overlap from 13:00 to 17:00 UTC.
+	ny := Availability{Location: newYork, Hours: office}
+	ldn := Availability{Location: london, Hours: office, Busy: []Interval{{Start: at(11, 14), End: at(11, 15)}}}
+	day := Interval{Start: at(11, 0), End: at(12, 0)}
+
+	tests := []struct {
+		name         string
+		participants []Availability
+		duration     time.Duration
+		within       Interval
+		expected     []Interval
+	}{
+		{"one hour", []Availability{ny, ldn}, time.Hour, day, []Interval{{at(11, 13), at(11, 14)}, {at(11, 15), at(11, 17)}}},
+		{"90 minutes", []Availability{ny, ldn}, 90 * time.Minute, day, []Interval{{at(11, 15), at(11, 17)}}},
+		{"no overlap", []Availability{ldn, {Location: sydney, Hours: office}}, time.Hour, day, nil},
+		{"nobody", nil, time.Hour, day, []Interval{day}},
+		// The weekend starts in Sydney at 13:00 UTC on Friday, while it is
+		// still Friday morning in New York.
+		{"whole days", []Availability{{Location: newYork}, {Location: sydney}}, time.Hour, Interval{at(15, 0), at(16, 0)}, []Interval{{at(15, 0), at(15, 13)}}},
+		// A night shift that starts on Monday covers early Tuesday.
+		{"night shift", []Availability{{Hours: ClockRange{Start: NewTimeOfDay(22, 0, 0, 0), End: NewTimeOfDay(6, 0, 0, 0)}}}, time.Hour, Interval{at(12, 0), at(12, 12)}, []Interval{{at(12, 0), at(12, 6)}}},
+	}
+
+	for _, test := range tests {
+		actual := FindCommonSlots(test.participants, test.duration, test.within)
+		if !equalIntervals(actual, test.expected) {
+			t.Errorf("FindCommonSlots(%s) = %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+}
+
+// TestFindCommonSlotsHolidays tests that a holiday in one participant's
+// calendar removes the whole day.
+func TestFindCommonSlotsHolidays(t *testing.T) {
+	cal := NewBusinessCalendar()
+	cal.AddHoliday(CivilDate{2024, time.March, 12}, "Company day")
+
+	within := Interval{Start: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)}
+	expected := []Interval{
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)},
+	}
+
+	if actual := FindCommonSlots([]Availability{{}, {Calendar: cal}}, time.Hour, within); !equalIntervals(actual, expected) {
+		t.Errorf("FindCommonSlots() = %v, expected %v", actual, expected)
+	}
+}
+
+// equalIntervals reports whether two lists hold the same intervals.
+func equalIntervals(a, b []Interval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+
+	return true
+}
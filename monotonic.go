@@ -0,0 +1,60 @@
+package temporalis
+
+import "time"
+
+// processStart is the time at which the package was initialised. It carries
+// a monotonic clock reading.
+var processStart = time.Now()
+
+// Since returns the time elapsed since t, like time.Since, and reports
+// whether the measurement used the monotonic clock. It does only if t
+// carries a monotonic reading, which times returned by time.Now do until
+// they are stripped, for example by Round(0), In, UTC or a round trip
+// through a string, database or JSON. Without one, the result is the
+// difference between two wall-clock readings and is distorted by any step
+// of the wall clock in between, such as an NTP correction.
+func Since(t time.Time) (time.Duration, bool) {
+	return time.Since(t), HasMonotonic(t)
+}
+
+// Until returns the time remaining until t, like time.Until, and reports
+// whether the measurement used the monotonic clock, as for Since.
+func Until(t time.Time) (time.Duration, bool) {
+	return time.Until(t), HasMonotonic(t)
+}
+
+// SinceStart returns the time elapsed since the package was initialised,
+// which is close to the start of the process. The measurement always uses
+// the monotonic clock, so the boolean result is true; it is returned for
+// symmetry with Since.
+func SinceStart() (time.Duration, bool) {
+	return Since(processStart)
+}
+
+// HasMonotonic reports whether t carries a monotonic clock reading.
+func HasMonotonic(t time.Time) bool {
+	return t != t.Round(0)
+}
+
+// StripMonotonic returns t without its monotonic clock reading, so that
+// comparisons and subtractions use the wall clock. It is equivalent to
+// t.Round(0).
+func StripMonotonic(t time.Time) time.Time {
+	return t.Round(0)
+}
+
+// WallClockJump returns how far the wall clock has been stepped since t was
+// read: the wall-clock time elapsed since t minus the monotonic time elapsed
+// since t. It is positive if the wall clock was set forward and negative if
+// it was set back, and zero, apart from tiny measurement noise, if it ran
+// freely. The boolean result is false, and the duration zero, if t carries
+// no monotonic reading to compare against.
+func WallClockJump(t time.Time) (time.Duration, bool) {
+	if !HasMonotonic(t) {
+		return 0, false
+	}
+
+	now := time.Now()
+
+	return now.Round(0).Sub(t.Round(0)) - now.Sub(t), true
+}
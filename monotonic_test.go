@@ -0,0 +1,69 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSinceMonotonic tests that Since and Until report the monotonic clock
+// only for times that carry a monotonic reading.
+func TestSinceMonotonic(t *testing.T) {
+	now := time.Now()
+
+	if d, mono := Since(now); d < 0 || !mono {
+		t.Errorf("Since(time.Now()) = %v, %v, expected a non-negative duration, true", d, mono)
+	}
+
+	if _, mono := Since(StripMonotonic(now)); mono {
+		t.Errorf("Since(StripMonotonic(now)) reported the monotonic clock")
+	}
+
+	if d, mono := Until(now.Add(time.Hour)); d <= 0 || !mono {
+		t.Errorf("Until(now+1h) = %v, %v, expected a positive duration, true", d, mono)
+	}
+
+	if _, mono := Until(now.UTC().Add(time.Hour)); mono {
+		t.Errorf("Until(now.UTC()+1h) reported the monotonic clock")
+	}
+
+	if d, mono := SinceStart(); d <= 0 || !mono {
+		t.Errorf("SinceStart() = %v, %v, expected a positive duration, true", d, mono)
+	}
+}
+
+// TestHasMonotonic tests HasMonotonic on fresh, stripped and constructed
+// times.
+func TestHasMonotonic(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected bool
+	}{
+		{time.Now(), true},
+		{time.Now().Add(time.Hour), true},
+		{StripMonotonic(time.Now()), false},
+		{time.Now().In(time.UTC), false},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{time.Time{}, false},
+	}
+
+	for _, test := range tests {
+		if actual := HasMonotonic(test.t); actual != test.expected {
+			t.Errorf("HasMonotonic(%v) = %v, expected %v", test.t, actual, test.expected)
+		}
+	}
+}
+
+// TestWallClockJump tests that no jump is reported while the wall clock runs
+// freely, and that times without a monotonic reading are rejected.
+func TestWallClockJump(t *testing.T) {
+	start := time.Now()
+	time.Sleep(time.Millisecond)
+
+	if d, ok := WallClockJump(start); !ok || d.Abs() > time.Second {
+		t.Errorf("WallClockJump(start) = %v, %v, expected about 0, true", d, ok)
+	}
+
+	if d, ok := WallClockJump(StripMonotonic(start)); ok || d != 0 {
+		t.Errorf("WallClockJump(stripped) = %v, %v, expected 0, false", d, ok)
+	}
+}
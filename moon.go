@@ -0,0 +1,171 @@
+package temporalis
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Phase is one of the eight named phases of the moon.
+type Phase int
+
+const (
+	NewMoon Phase = iota
+	WaxingCrescent
+	FirstQuarter
+	WaxingGibbous
+	FullMoon
+	WaningGibbous
+	LastQuarter
+	WaningCrescent
+)
+
+var phaseNames = [...]string{
+	"new moon", "waxing crescent", "first quarter", "waxing gibbous",
+	"full moon", "waning gibbous", "last quarter", "waning crescent",
+}
+
+// String returns the name of the phase, such as "waxing crescent".
+func (p Phase) String() string {
+	if p < 0 || int(p) >= len(phaseNames) {
+		return "Phase(" + strconv.Itoa(int(p)) + ")"
+	}
+
+	return phaseNames[p]
+}
+
+// synodicMonth is the mean length of a lunation in days.
+const synodicMonth = 29.530588861
+
+// MoonPhase returns the phase of the moon at t. The lunation from one new
+// moon to the next is divided into eight equal parts centred on the
+// principal phases, so MoonPhase reports NewMoon for the day or so on either
+// side of the exact new moon.
+func MoonPhase(t time.Time) Phase {
+	return Phase(int(math.Floor(moonAge(t)*8+0.5)) % 8)
+}
+
+// MoonIllumination returns the fraction of the disc of the moon that is lit
+// at t, from 0 at new moon to 1 at full moon. It assumes a uniform motion
+// within the lunation and is good to a few percent.
+func MoonIllumination(t time.Time) float64 {
+	return (1 - math.Cos(2*math.Pi*moonAge(t))) / 2
+}
+
+// NextNewMoon returns the first new moon strictly after the given time, in
+// UTC. Like NextFullMoon it uses the algorithm of Meeus, Astronomical
+// Algorithms, chapter 49, which is accurate to within a minute or so for
+// several centuries around the present.
+func NextNewMoon(after time.Time) time.Time {
+	return nextLunarPhase(after, 0)
+}
+
+// NextFullMoon returns the first full moon strictly after the given time, in
+// UTC.
+func NextFullMoon(after time.Time) time.Time {
+	return nextLunarPhase(after, 0.5)
+}
+
+// moonAge returns how far into its lunation the moon is at t, as a fraction
+// from 0 at new moon up to 1 at the next new moon.
+func moonAge(t time.Time) float64 {
+	next := NextNewMoon(t)
+	prev := nextLunarPhase(t.Add(-31*24*time.Hour), 0)
+	for n := NextNewMoon(prev); !n.After(t); n = NextNewMoon(prev) {
+		prev = n
+	}
+
+	return float64(t.Sub(prev)) / float64(next.Sub(prev))
+}
+
+// nextLunarPhase returns the first new moon (phase 0) or full moon (phase
+// 0.5) after the given time.
+func nextLunarPhase(after time.Time, phase float64) time.Time {
+	k := math.Floor((ToJulianDay(after)-2451550.09766)/synodicMonth) - 1 + phase
+	for {
+		if t := lunarPhaseTime(k); t.After(after) {
+			return t
+		}
+		k++
+	}
+}
+
+// lunarPhaseTime returns the instant of the new moon of lunation k, or the
+// full moon if k ends in .5, counting from the new moon of 2000-01-06.
+func lunarPhaseTime(k float64) time.Time {
+	const rad = math.Pi / 180
+
+	T := k / 1236.85
+	T2, T3, T4 := T*T, T*T*T, T*T*T*T
+
+	jde := 2451550.09766 + synodicMonth*k + 0.00015437*T2 - 0.000000150*T3 + 0.00000000073*T4
+
+	E := 1 - 0.002516*T - 0.0000074*T2
+	M := (2.5534 + 29.10535670*k - 0.0000014*T2 - 0.00000011*T3) * rad
+	Mp := (201.5643 + 385.81693528*k + 0.0107582*T2 + 0.00001238*T3 - 0.000000058*T4) * rad
+	F := (160.7108 + 390.67050284*k - 0.0016118*T2 - 0.00000227*T3 + 0.000000011*T4) * rad
+	Om := (124.7746 - 1.56375588*k + 0.0020672*T2 + 0.00000215*T3) * rad
+
+	// The largest terms differ slightly between new and full moons.
+	c := [...]float64{-0.40720, 0.17241, 0.01608, 0.01039, 0.00739, -0.00514, 0.00208}
+	if k-math.Floor(k) != 0 {
+		c = [...]float64{-0.40614, 0.17302, 0.01614, 0.01043, 0.00734, -0.00515, 0.00209}
+	}
+
+	jde += c[0]*math.Sin(Mp) +
+		c[1]*E*math.Sin(M) +
+		c[2]*math.Sin(2*Mp) +
+		c[3]*math.Sin(2*F) +
+		c[4]*E*math.Sin(Mp-M) +
+		c[5]*E*math.Sin(Mp+M) +
+		c[6]*E*E*math.Sin(2*M) -
+		0.00111*math.Sin(Mp-2*F) -
+		0.00057*math.Sin(Mp+2*F) +
+		0.00056*E*math.Sin(2*Mp+M) -
+		0.00042*math.Sin(3*Mp) +
+		0.00042*E*math.Sin(M+2*F) +
+		0.00038*E*math.Sin(M-2*F) -
+		0.00024*E*math.Sin(2*Mp-M) -
+		0.00017*math.Sin(Om) -
+		0.00007*math.Sin(Mp+2*M) +
+		0.00004*math.Sin(2*Mp-2*F) +
+		0.00004*math.Sin(3*M) +
+		0.00003*math.Sin(Mp+M-2*F) +
+		0.00003*math.Sin(2*Mp+2*F) -
+		0.00003*math.Sin(Mp+M+2*F) +
+		0.00003*math.Sin(Mp-M+2*F) -
+		0.00002*math.Sin(Mp-M-2*F) -
+		0.00002*math.Sin(3*Mp+M) +
+		0.00002*math.Sin(4*Mp)
+
+	// Planetary perturbations.
+	planetary := [...][3]float64{
+		{0.000325, 299.77, 0.107408},
+		{0.000165, 251.88, 0.016321},
+		{0.000164, 251.83, 26.651886},
+		{0.000126, 349.42, 36.412478},
+		{0.000110, 84.66, 18.206239},
+		{0.000062, 141.74, 53.303771},
+		{0.000060, 207.14, 2.453732},
+		{0.000056, 154.84, 7.306860},
+		{0.000047, 34.52, 27.261239},
+		{0.000042, 207.19, 0.121824},
+		{0.000040, 291.34, 1.844379},
+		{0.000037, 161.72, 24.198154},
+		{0.000035, 239.56, 25.513099},
+		{0.000023, 331.55, 3.592518},
+	}
+	for i, p := range planetary {
+		a := p[1] + p[2]*k
+		if i == 0 {
+			a -= 0.009173 * T2
+		}
+		jde += p[0] * math.Sin(a*rad)
+	}
+
+	// The result is in Terrestrial Time, which is 32.184 seconds ahead of
+	// TAI.
+	tt := FromJulianDay(jde).Add(-32184 * time.Millisecond)
+
+	return FromTAI(tt).Truncate(time.Second)
+}
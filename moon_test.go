@@ -0,0 +1,69 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLunarPhaseTime tests the new moon of Meeus' example 49.a.
+func TestLunarPhaseTime(t *testing.T) {
+	// 1977-02-18 03:37:42 TD, which was 48 seconds ahead of UTC.
+	expected := time.Date(1977, 2, 18, 3, 36, 54, 0, time.UTC)
+	if actual := lunarPhaseTime(-283); actual.Sub(expected).Abs() > time.Second {
+		t.Errorf("lunarPhaseTime(-283) = %v, expected %v", actual, expected)
+	}
+}
+
+// TestNextMoons tests new and full moons against published times.
+func TestNextMoons(t *testing.T) {
+	tests := []struct {
+		f        func(time.Time) time.Time
+		after    time.Time
+		expected time.Time
+	}{
+		{NextNewMoon, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC)},
+		{NextFullMoon, time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 23, 23, 49, 0, 0, time.UTC)},
+		{NextFullMoon, time.Date(2024, 4, 23, 23, 50, 0, 0, time.UTC), time.Date(2024, 5, 23, 13, 53, 0, 0, time.UTC)},
+		{NextNewMoon, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2000, 1, 6, 18, 14, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if actual := test.f(test.after); actual.Sub(test.expected).Abs() > 2*time.Minute {
+			t.Errorf("next moon after %v = %v, expected %v", test.after, actual, test.expected)
+		}
+	}
+}
+
+// TestMoonPhase tests phases and illumination through one lunation.
+func TestMoonPhase(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected Phase
+	}{
+		{time.Date(2024, 4, 8, 18, 0, 0, 0, time.UTC), NewMoon},
+		{time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC), WaxingCrescent},
+		{time.Date(2024, 4, 15, 19, 0, 0, 0, time.UTC), FirstQuarter},
+		{time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC), WaxingGibbous},
+		{time.Date(2024, 4, 24, 0, 0, 0, 0, time.UTC), FullMoon},
+		{time.Date(2024, 4, 28, 0, 0, 0, 0, time.UTC), WaningGibbous},
+		{time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), LastQuarter},
+		{time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC), WaningCrescent},
+	}
+
+	for _, test := range tests {
+		if actual := MoonPhase(test.t); actual != test.expected {
+			t.Errorf("MoonPhase(%v) = %v, expected %v", test.t, actual, test.expected)
+		}
+	}
+
+	if f := MoonIllumination(time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC)); f > 0.01 {
+		t.Errorf("MoonIllumination(new moon) = %f, expected about 0", f)
+	}
+	if f := MoonIllumination(time.Date(2024, 4, 23, 23, 49, 0, 0, time.UTC)); f < 0.99 {
+		t.Errorf("MoonIllumination(full moon) = %f, expected about 1", f)
+	}
+
+	if s := Phase(9).String(); s != "Phase(9)" {
+		t.Errorf("Phase(9).String() = %q, expected %q", s, "Phase(9)")
+	}
+}
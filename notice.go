@@ -0,0 +1,57 @@
+package temporalis
+
+// NoticeConvention selects the date on which a notice period ends, as laid
+// down by statute or contract for terminating employment, tenancies and
+// other agreements.
+type NoticeConvention int
+
+const (
+	// NoticeCorrespondingDate ends the period on the date that corresponds
+	// to the day notice was given, or on the last day of the month if there
+	// is no such date: one month's notice given on 15 January ends on 15
+	// February, and given on 31 January on the last day of February.
+	NoticeCorrespondingDate NoticeConvention = iota
+	// NoticeEndOfMonth extends the period to the end of the calendar month
+	// in which it would otherwise end, for notice "to the end of a month":
+	// one month's notice given on 15 January ends on the last day of
+	// February.
+	NoticeEndOfMonth
+	// NoticeFifteenthOrEndOfMonth extends the period to the 15th or the end
+	// of a calendar month, whichever comes first, as for four weeks' notice
+	// under German employment law.
+	NoticeFifteenthOrEndOfMonth
+	// NoticeEndOfQuarter extends the period to the end of a calendar
+	// quarter: 31 March, 30 June, 30 September or 31 December.
+	NoticeEndOfQuarter
+)
+
+// AddNoticePeriod returns the last day of a notice period given on start,
+// the day the notice is received, which itself does not count towards the
+// period. The years, months and days of period are added as CivilDate.AddMonths
+// and CivilDate.AddDays do, and its Time component is ignored. The
+// convention may then extend the period to a month or quarter end. If cal is
+// not nil and the period would end on a day that is not a business day of
+// cal, it is extended to the next business day, as some jurisdictions do for
+// deadlines; with a nil cal the date is not moved.
+func AddNoticePeriod(start CivilDate, period Period, cal *BusinessCalendar, convention NoticeConvention) CivilDate {
+	end := start.AddMonths(12*period.Years + period.Months).AddDays(period.Days)
+
+	switch convention {
+	case NoticeEndOfMonth:
+		end = NewYearMonth(end.Year, end.Month).LastDay()
+	case NoticeFifteenthOrEndOfMonth:
+		if end.Day <= 15 {
+			end.Day = 15
+		} else {
+			end = NewYearMonth(end.Year, end.Month).LastDay()
+		}
+	case NoticeEndOfQuarter:
+		end = NewYearMonth(end.Year, (end.Month-1)/3*3+3).LastDay()
+	}
+
+	if cal != nil && !cal.IsBusinessDay(end) {
+		end = cal.NextBusinessDay(end)
+	}
+
+	return end
+}
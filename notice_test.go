@@ -0,0 +1,56 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAddNoticePeriod tests each convention, including notice given at the
+// end of a month and periods in weeks.
+func TestAddNoticePeriod(t *testing.T) {
+	month := Period{Months: 1}
+	fourWeeks := Period{Days: 28}
+
+	tests := []struct {
+		name       string
+		start      CivilDate
+		period     Period
+		convention NoticeConvention
+		expected   CivilDate
+	}{
+		{"corresponding", CivilDate{2024, time.January, 15}, month, NoticeCorrespondingDate, CivilDate{2024, time.February, 15}},
+		{"corresponding clamped", CivilDate{2024, time.January, 31}, month, NoticeCorrespondingDate, CivilDate{2024, time.February, 29}},
+		{"end of month", CivilDate{2024, time.January, 15}, month, NoticeEndOfMonth, CivilDate{2024, time.February, 29}},
+		{"end of month on the first", CivilDate{2024, time.February, 1}, month, NoticeEndOfMonth, CivilDate{2024, time.March, 31}},
+		{"end of month, three months", CivilDate{2023, time.November, 20}, Period{Months: 3}, NoticeEndOfMonth, CivilDate{2024, time.February, 29}},
+		{"fifteenth", CivilDate{2024, time.March, 10}, fourWeeks, NoticeFifteenthOrEndOfMonth, CivilDate{2024, time.April, 15}},
+		{"end of month after fifteenth", CivilDate{2024, time.March, 20}, fourWeeks, NoticeFifteenthOrEndOfMonth, CivilDate{2024, time.April, 30}},
+		{"end of quarter", CivilDate{2024, time.May, 5}, Period{Months: 6}, NoticeEndOfQuarter, CivilDate{2024, time.December, 31}},
+		{"end of quarter, one year", CivilDate{2024, time.March, 31}, Period{Years: 1}, NoticeEndOfQuarter, CivilDate{2025, time.March, 31}},
+	}
+
+	for _, test := range tests {
+		if actual := AddNoticePeriod(test.start, test.period, nil, test.convention); actual != test.expected {
+			t.Errorf("AddNoticePeriod(%s) = %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+}
+
+// TestAddNoticePeriodBusinessDays tests that a period ending on a weekend or
+// holiday is extended to the next business day only with a calendar.
+func TestAddNoticePeriodBusinessDays(t *testing.T) {
+	cal := NewBusinessCalendar()
+	cal.AddHoliday(CivilDate{2024, time.March, 29}, "Good Friday")
+	cal.AddHoliday(CivilDate{2024, time.April, 1}, "Easter Monday")
+
+	// One month from 29 February ends on Good Friday, before the Easter
+	// weekend and Easter Monday.
+	start := CivilDate{2024, time.February, 29}
+
+	if actual, expected := AddNoticePeriod(start, Period{Months: 1}, nil, NoticeCorrespondingDate), (CivilDate{2024, time.March, 29}); actual != expected {
+		t.Errorf("AddNoticePeriod() = %v, expected %v", actual, expected)
+	}
+	if actual, expected := AddNoticePeriod(start, Period{Months: 1}, cal, NoticeCorrespondingDate), (CivilDate{2024, time.April, 2}); actual != expected {
+		t.Errorf("AddNoticePeriod(cal) = %v, expected %v", actual, expected)
+	}
+}
@@ -0,0 +1,116 @@
+package ntp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// ErrClockUnsynchronized is returned by IntervalClock when the local clock
+// is not synchronized, so no useful bound on its error is known.
+var ErrClockUnsynchronized = errors.New("ntp: local clock is not synchronized")
+
+// Bounds is a closed interval that contains the true time.
+type Bounds struct {
+	Earliest time.Time
+	Latest   time.Time
+}
+
+// Uncertainty returns how far the true time may be from the middle of the
+// bounds.
+func (b Bounds) Uncertainty() time.Duration {
+	return b.Latest.Sub(b.Earliest) / 2
+}
+
+// IntervalClock reports the current time as an interval that is guaranteed
+// to contain the true time, in the style of Spanner's TrueTime, given that
+// the error bound reported by SyncStatus holds. It is a building block for
+// protocols that need external consistency: a transaction stamped with
+// Now().Latest and made visible only after WaitUntilPast of that stamp is
+// ordered after every transaction that completed before it started, on any
+// host with a synchronized clock. An IntervalClock is safe for concurrent
+// use.
+type IntervalClock struct {
+	clock temporalis.Clock
+
+	// status returns the synchronization status of the clock; tests
+	// replace it.
+	status func() (Status, error)
+}
+
+// NewIntervalClock returns an IntervalClock that reads c, or the system
+// clock if c is nil, and bounds its error with SyncStatus.
+func NewIntervalClock(c temporalis.Clock) *IntervalClock {
+	if c == nil {
+		c = temporalis.SystemClock
+	}
+
+	return &IntervalClock{clock: c, status: SyncStatus}
+}
+
+// Now returns bounds on the current time, the clock reading plus and minus
+// its maximum error. It returns an error wrapping ErrClockUnsynchronized if
+// the clock is not synchronized, or the error of SyncStatus if its status
+// is not known.
+func (c *IntervalClock) Now() (Bounds, error) {
+	st, err := c.status()
+	if err != nil {
+		return Bounds{}, err
+	}
+	if !st.Synchronized {
+		return Bounds{}, fmt.Errorf("%w: maximum error %v", ErrClockUnsynchronized, st.MaxError)
+	}
+
+	now := c.clock.Now()
+
+	return Bounds{Earliest: now.Add(-st.MaxError), Latest: now.Add(st.MaxError)}, nil
+}
+
+// After reports whether t has definitely passed, that is, whether it is
+// before the earliest possible current time.
+func (c *IntervalClock) After(t time.Time) (bool, error) {
+	b, err := c.Now()
+	if err != nil {
+		return false, err
+	}
+
+	return b.Earliest.After(t), nil
+}
+
+// Before reports whether t has definitely not arrived, that is, whether it
+// is after the latest possible current time.
+func (c *IntervalClock) Before(t time.Time) (bool, error) {
+	b, err := c.Now()
+	if err != nil {
+		return false, err
+	}
+
+	return b.Latest.Before(t), nil
+}
+
+// WaitUntilPast blocks until t has definitely passed, that is, until the
+// earliest possible current time is after t, so that the true time is after
+// t wherever it is read. This is the commit wait of TrueTime. It returns
+// early with the error of ctx if ctx is done, or with the error of Now if
+// the clock loses synchronization. The bounds are checked again after each
+// wait, so a growing error bound only makes it wait longer.
+func (c *IntervalClock) WaitUntilPast(ctx context.Context, t time.Time) error {
+	for {
+		b, err := c.Now()
+		if err != nil {
+			return err
+		}
+		if b.Earliest.After(t) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.clock.After(t.Sub(b.Earliest) + time.Nanosecond):
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package ntp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// newTestIntervalClock returns an IntervalClock reading a fake clock with a
+// fixed maximum error.
+func newTestIntervalClock(maxError time.Duration, synchronized bool) (*IntervalClock, *temporalis.FakeClock) {
+	fake := temporalis.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := NewIntervalClock(fake)
+	c.status = func() (Status, error) {
+		return Status{Synchronized: synchronized, MaxError: maxError, Source: "kernel"}, nil
+	}
+
+	return c, fake
+}
+
+// TestIntervalClockNow tests the bounds of a synchronized clock and the
+// errors of an unsynchronized one.
+func TestIntervalClockNow(t *testing.T) {
+	c, fake := newTestIntervalClock(5*time.Millisecond, true)
+	now := fake.Now()
+
+	b, err := c.Now()
+	if err != nil || !b.Earliest.Equal(now.Add(-5*time.Millisecond)) || !b.Latest.Equal(now.Add(5*time.Millisecond)) {
+		t.Errorf("Now() = %+v, %v, expected now ± 5ms", b, err)
+	}
+	if b.Uncertainty() != 5*time.Millisecond {
+		t.Errorf("Uncertainty() = %v, expected 5ms", b.Uncertainty())
+	}
+
+	if passed, _ := c.After(now.Add(-6 * time.Millisecond)); !passed {
+		t.Errorf("After(now - 6ms) = false, expected true")
+	}
+	if passed, _ := c.After(now.Add(-4 * time.Millisecond)); passed {
+		t.Errorf("After(now - 4ms) = true, expected false")
+	}
+	if ahead, _ := c.Before(now.Add(6 * time.Millisecond)); !ahead {
+		t.Errorf("Before(now + 6ms) = false, expected true")
+	}
+
+	c, _ = newTestIntervalClock(time.Minute, false)
+	if _, err := c.Now(); !errors.Is(err, ErrClockUnsynchronized) {
+		t.Errorf("Now() error = %v, expected %v", err, ErrClockUnsynchronized)
+	}
+
+	c.status = func() (Status, error) { return Status{}, ErrStatusUnavailable }
+	if err := c.WaitUntilPast(context.Background(), time.Time{}); !errors.Is(err, ErrStatusUnavailable) {
+		t.Errorf("WaitUntilPast() error = %v, expected %v", err, ErrStatusUnavailable)
+	}
+}
+
+// TestWaitUntilPast tests that the commit wait lasts until the time plus the
+// maximum error has passed, and that it can be cancelled.
+func TestWaitUntilPast(t *testing.T) {
+	c, fake := newTestIntervalClock(5*time.Millisecond, true)
+	stamp := fake.Now()
+
+	done := make(chan error, 1)
+	go func() { done <- c.WaitUntilPast(context.Background(), stamp) }()
+
+	fake.BlockUntil(1)
+	fake.Advance(5 * time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("WaitUntilPast() returned %v after 5ms, expected it to wait", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	fake.Advance(time.Nanosecond)
+	if err := <-done; err != nil {
+		t.Errorf("WaitUntilPast() = %v, expected nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { done <- c.WaitUntilPast(ctx, fake.Now()) }()
+	fake.BlockUntil(1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitUntilPast() error = %v, expected %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,206 @@
+// Package ntp is a minimal SNTP client (RFC 4330) for measuring how far the
+// local clock is off. Distributed services can use it to detect host clock
+// skew, which breaks lease expiry, token validation and event ordering, and
+// to compensate for it without an external dependency:
+//
+//	offset, rtt, err := ntp.Query("pool.ntp.org")
+//	if err == nil && offset.Abs() > time.Second {
+//		log.Printf("clock is off by %v (rtt %v)", offset, rtt)
+//	}
+//	now := ntp.NowAdjusted()
+//
+// A single query is only as accurate as the network path is symmetric, which
+// is typically within a few milliseconds; it is not a replacement for a
+// time daemon that disciplines the clock.
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout bounds a Query, including name resolution.
+const DefaultTimeout = 5 * time.Second
+
+// Errors returned by Query for unusable responses.
+var (
+	// ErrKissOfDeath reports that the server asked the client to go away,
+	// usually because it is being queried too often.
+	ErrKissOfDeath = errors.New("ntp: kiss-of-death response")
+	// ErrUnsynchronized reports a server whose own clock is not
+	// synchronized.
+	ErrUnsynchronized = errors.New("ntp: server clock is not synchronized")
+	// ErrInvalidResponse reports a response that is malformed or does not
+	// answer the request.
+	ErrInvalidResponse = errors.New("ntp: invalid response")
+)
+
+// packetSize is the size of an NTP packet without extensions.
+const packetSize = 48
+
+// ntpEpochOffset is the number of seconds from the NTP epoch, 1900-01-01, to
+// the Unix epoch.
+const ntpEpochOffset = 2208988800
+
+// lastOffset is the offset measured by the most recent successful query, in
+// nanoseconds.
+var lastOffset atomic.Int64
+
+// lastMeasurement is the most recent successful query, which SyncStatus
+// falls back to where the kernel does not report on the clock.
+var lastMeasurement atomic.Pointer[measurement]
+
+// Query asks an NTP server for the time and returns the offset of the server
+// clock from the local clock, which is positive if the local clock is
+// behind, together with the round-trip time of the exchange. The server may
+// be given as "host" or "host:port"; the port defaults to 123. A successful
+// query also sets the offset applied by NowAdjusted. Query gives up after
+// DefaultTimeout.
+func Query(server string) (offset, rtt time.Duration, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	return QueryContext(ctx, server)
+}
+
+// QueryContext is like Query but uses ctx to bound the exchange instead of
+// DefaultTimeout.
+func QueryContext(ctx context.Context, server string) (offset, rtt time.Duration, err error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "123")
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	// Expire the deadline to abort a pending read if ctx is cancelled.
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	// Version 4, client mode. The transmit timestamp is random rather than
+	// the local time, as RFC 4330 permits, so the response can be matched
+	// without revealing the local clock.
+	req := make([]byte, packetSize)
+	req[0] = 4<<3 | 3
+	binary.BigEndian.PutUint64(req[40:], rand.Uint64())
+
+	t1 := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, 0, contextError(ctx, err)
+	}
+
+	resp := make([]byte, packetSize+1)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, 0, contextError(ctx, err)
+	}
+
+	// Measure the local round trip on the monotonic clock so a step of the
+	// wall clock during the exchange does not distort it.
+	t4 := t1.Add(time.Since(t1))
+
+	if n < packetSize {
+		return 0, 0, fmt.Errorf("%w: %d bytes", ErrInvalidResponse, n)
+	}
+
+	offset, rtt, err = parseResponse(req, resp[:n], t1, t4)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lastOffset.Store(int64(offset))
+	lastMeasurement.Store(&measurement{offset: offset, rtt: rtt, at: t4})
+
+	return offset, rtt, nil
+}
+
+// contextError returns the error of ctx in place of err if the exchange was
+// aborted because of it. The socket shares the deadline of ctx and may reach
+// it before the context timer fires, so a socket timeout is reported as
+// context.DeadlineExceeded whenever ctx has a deadline.
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if _, ok := ctx.Deadline(); ok && errors.Is(err, os.ErrDeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+
+	return err
+}
+
+// Offset returns the offset measured by the most recent successful query,
+// or zero if there has been none.
+func Offset() time.Duration {
+	return time.Duration(lastOffset.Load())
+}
+
+// SetOffset sets the offset applied by NowAdjusted, for example to a value
+// aggregated from queries to several servers.
+func SetOffset(offset time.Duration) {
+	lastOffset.Store(int64(offset))
+}
+
+// NowAdjusted returns the current time corrected by the most recently
+// measured offset, which is an estimate of the time on the server. The
+// result keeps its monotonic clock reading, so durations measured between
+// two adjusted times are not affected by a later change of the offset
+// unless it happens in between.
+func NowAdjusted() time.Time {
+	return time.Now().Add(Offset())
+}
+
+// parseResponse validates a response to req and computes the clock offset
+// and round-trip time from it, given the local send and receive times.
+func parseResponse(req, resp []byte, t1, t4 time.Time) (offset, rtt time.Duration, err error) {
+	leap, mode, stratum := resp[0]>>6, resp[0]&7, resp[1]
+
+	switch {
+	case mode != 4 && mode != 5:
+		return 0, 0, fmt.Errorf("%w: mode %d", ErrInvalidResponse, mode)
+	case stratum == 0:
+		return 0, 0, fmt.Errorf("%w: %q", ErrKissOfDeath, resp[12:16])
+	case leap == 3 || stratum > 15:
+		return 0, 0, ErrUnsynchronized
+	case binary.BigEndian.Uint64(resp[24:32]) != binary.BigEndian.Uint64(req[40:48]):
+		return 0, 0, fmt.Errorf("%w: originate timestamp does not match", ErrInvalidResponse)
+	}
+
+	t2 := fromNTP(binary.BigEndian.Uint64(resp[32:40]))
+	t3 := fromNTP(binary.BigEndian.Uint64(resp[40:48]))
+	if t3.Before(t2) {
+		return 0, 0, fmt.Errorf("%w: transmitted before received", ErrInvalidResponse)
+	}
+
+	offset = (t2.Sub(t1) + t3.Sub(t4)) / 2
+	rtt = max(t4.Sub(t1)-t3.Sub(t2), 0)
+
+	return offset, rtt, nil
+}
+
+// fromNTP converts a 64-bit NTP timestamp to a time. Timestamps in the
+// first half of era 0, before 1968, are taken to be in era 1, which starts
+// in 2036.
+func fromNTP(ts uint64) time.Time {
+	secs, frac := int64(ts>>32), int64(ts&0xffffffff)
+	if secs < 1<<31 {
+		secs += 1 << 32
+	}
+
+	return time.Unix(secs-ntpEpochOffset, frac*1e9>>32)
+}
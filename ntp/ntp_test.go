@@ -0,0 +1,144 @@
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// toNTP converts a time to a 64-bit NTP timestamp.
+func toNTP(t time.Time) uint64 {
+	secs := uint64(t.Unix()+ntpEpochOffset) & 0xffffffff
+	frac := uint64(t.Nanosecond()) << 32 / 1e9
+
+	return secs<<32 | frac
+}
+
+// serve answers NTP requests on a local UDP socket with a clock that is skew
+// ahead of the local one, letting edit modify each response before it is
+// sent. It returns the address of the socket.
+func serve(t *testing.T, skew time.Duration, edit func([]byte)) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("UDP not available:", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, packetSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < packetSize {
+				continue
+			}
+
+			received := time.Now().Add(skew)
+
+			resp := make([]byte, packetSize)
+			resp[0] = 4<<3 | 4
+			resp[1] = 2
+			copy(resp[24:32], buf[40:48])
+			binary.BigEndian.PutUint64(resp[32:], toNTP(received))
+			binary.BigEndian.PutUint64(resp[40:], toNTP(time.Now().Add(skew)))
+			if edit != nil {
+				edit(resp)
+			}
+
+			conn.WriteTo(resp, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// TestQuery tests that the offset of a skewed server is measured and
+// applied by NowAdjusted.
+func TestQuery(t *testing.T) {
+	defer SetOffset(0)
+
+	addr := serve(t, time.Hour, nil)
+
+	offset, rtt, err := Query(addr)
+	if err != nil {
+		t.Fatalf("Query() returned error: %v", err)
+	}
+
+	if d := offset - time.Hour; d.Abs() > 100*time.Millisecond {
+		t.Errorf("Query() offset = %v, expected about 1h", offset)
+	}
+	if rtt < 0 || rtt > time.Second {
+		t.Errorf("Query() rtt = %v, expected a small positive duration", rtt)
+	}
+
+	if Offset() != offset {
+		t.Errorf("Offset() = %v, expected %v", Offset(), offset)
+	}
+	if d := time.Until(NowAdjusted()) - time.Hour; d.Abs() > 100*time.Millisecond {
+		t.Errorf("NowAdjusted() is %v from now, expected about 1h", time.Until(NowAdjusted()))
+	}
+}
+
+// TestQueryErrors tests that unusable responses are rejected and leave the
+// offset alone.
+func TestQueryErrors(t *testing.T) {
+	defer SetOffset(0)
+	SetOffset(time.Minute)
+
+	tests := []struct {
+		edit     func([]byte)
+		expected error
+	}{
+		{func(b []byte) { b[1] = 0; copy(b[12:], "RATE") }, ErrKissOfDeath},
+		{func(b []byte) { b[0] |= 3 << 6 }, ErrUnsynchronized},
+		{func(b []byte) { b[0] = 4<<3 | 1 }, ErrInvalidResponse},
+		{func(b []byte) { b[24] ^= 1 }, ErrInvalidResponse},
+	}
+
+	for _, test := range tests {
+		_, _, err := Query(serve(t, 0, test.edit))
+		if !errors.Is(err, test.expected) {
+			t.Errorf("Query() error = %v, expected %v", err, test.expected)
+		}
+	}
+
+	if Offset() != time.Minute {
+		t.Errorf("Offset() = %v after failed queries, expected 1m", Offset())
+	}
+}
+
+// TestQueryContext tests that a query to a silent server gives up when the
+// context expires.
+func TestQueryContext(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("UDP not available:", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := QueryContext(ctx, conn.LocalAddr().String()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("QueryContext() error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+// TestFromNTP tests the conversion of NTP timestamps, including the era
+// rollover in 2036.
+func TestFromNTP(t *testing.T) {
+	for _, want := range []time.Time{
+		time.Date(2024, 5, 1, 12, 0, 0, 500000000, time.UTC),
+		time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC),
+		time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if got := fromNTP(toNTP(want)); got.Sub(want).Abs() > time.Microsecond {
+			t.Errorf("fromNTP(toNTP(%v)) = %v", want, got.UTC())
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package ntp
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrStatusUnavailable is returned by SyncStatus when neither the kernel nor
+// a previous query can tell whether the clock is synchronized.
+var ErrStatusUnavailable = errors.New("ntp: clock synchronization status unavailable")
+
+// maxPhaseError is the error bound beyond which the clock is considered
+// unsynchronized, the NTP_PHASE_LIMIT at which the Linux kernel gives up on
+// a clock that is no longer being disciplined.
+const maxPhaseError = 16 * time.Second
+
+// maxDrift is the frequency tolerance of an undisciplined clock, 500 parts
+// per million, by which the error bound of a measurement grows over time.
+const maxDrift = 500e-6
+
+// Status describes how well the host clock is synchronized.
+type Status struct {
+	// Synchronized reports whether the clock appears to be synchronized to
+	// a reference, with MaxError within 16 seconds.
+	Synchronized bool
+	// MaxError is an upper bound on the error of the clock.
+	MaxError time.Duration
+	// EstimatedError is the estimated error of the clock.
+	EstimatedError time.Duration
+	// Source names where the status comes from: "kernel" if it was read
+	// from the operating system, which knows about the time daemon that
+	// disciplines the clock, or "query" if it was derived from the most
+	// recent successful Query.
+	Source string
+}
+
+// SyncStatus reports whether the host clock appears to be synchronized and
+// how far off it may be, so that services that depend on accurate time can
+// refuse to run on an unsynchronized host:
+//
+//	if st, err := ntp.SyncStatus(); err != nil || !st.Synchronized {
+//		log.Fatalf("clock not synchronized: %+v, %v", st, err)
+//	}
+//
+// On Linux it reads the kernel's view with adjtimex, which is kept by the
+// time daemon such as chronyd, ntpd or systemd-timesyncd. Elsewhere, or if
+// the kernel cannot be asked, it falls back to the most recent successful
+// Query: the error bound is the measured offset plus half the round trip,
+// and grows by 500 ppm from then on, as it would for a free-running clock.
+// If there is neither, SyncStatus returns ErrStatusUnavailable.
+func SyncStatus() (Status, error) {
+	if st, ok := kernelStatus(); ok {
+		return st, nil
+	}
+
+	if m := lastMeasurement.Load(); m != nil {
+		return m.status(time.Now()), nil
+	}
+
+	return Status{}, ErrStatusUnavailable
+}
+
+// measurement is the result of a query.
+type measurement struct {
+	offset, rtt time.Duration
+	// at is when the response was received, with a monotonic clock
+	// reading so the age of the measurement is not affected by steps of
+	// the wall clock.
+	at time.Time
+}
+
+// status returns the status of the clock at now implied by m.
+func (m *measurement) status(now time.Time) Status {
+	estimated := m.offset.Abs() + m.rtt/2
+	drift := time.Duration(float64(max(now.Sub(m.at), 0)) * maxDrift)
+
+	st := Status{
+		MaxError:       estimated + drift,
+		EstimatedError: estimated,
+		Source:         "query",
+	}
+	st.Synchronized = st.MaxError <= maxPhaseError
+
+	return st
+}
+
+// Values of the kernel clock status from timex.h.
+const (
+	timeError   = 5      // TIME_ERROR, returned when the clock is unsynchronized
+	staUnsync   = 0x0040 // STA_UNSYNC, set when the clock is unsynchronized
+	staClockErr = 0x1000 // STA_CLOCKERR, set on a clock hardware fault
+)
+
+// timexStatus converts the result of adjtimex, the clock state it returns
+// and the status word and error bounds in microseconds, to a Status.
+func timexStatus(state int, status int32, maxError, estError int64) Status {
+	st := Status{
+		MaxError:       time.Duration(maxError) * time.Microsecond,
+		EstimatedError: time.Duration(estError) * time.Microsecond,
+		Source:         "kernel",
+	}
+	st.Synchronized = state != timeError && status&(staUnsync|staClockErr) == 0 && st.MaxError <= maxPhaseError
+
+	return st
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package ntp
+
+// kernelStatus reports that the kernel status is not available, so that
+// SyncStatus falls back to the most recent query.
+func kernelStatus() (Status, bool) {
+	return Status{}, false
+}
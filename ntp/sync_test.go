@@ -0,0 +1,68 @@
+package ntp
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTimexStatus tests the interpretation of the kernel clock state.
+func TestTimexStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    int
+		status   int32
+		maxError int64
+		expected bool
+	}{
+		{"synchronized", 0, 0x2001, 1500, true},
+		{"unsync flag", 0, staUnsync, 1500, false},
+		{"clock error", 0, staClockErr, 1500, false},
+		{"error state", timeError, 0, 1500, false},
+		{"error bound", 0, 0, 16000001, false},
+	}
+
+	for _, test := range tests {
+		st := timexStatus(test.state, test.status, test.maxError, 20)
+		if st.Synchronized != test.expected {
+			t.Errorf("timexStatus(%s).Synchronized = %v, expected %v", test.name, st.Synchronized, test.expected)
+		}
+		if st.MaxError != time.Duration(test.maxError)*time.Microsecond || st.EstimatedError != 20*time.Microsecond {
+			t.Errorf("timexStatus(%s) errors = %v, %v, expected %v, 20µs", test.name, st.MaxError, st.EstimatedError, time.Duration(test.maxError)*time.Microsecond)
+		}
+	}
+}
+
+// TestMeasurementStatus tests that the error bound of a query grows with
+// its age until the clock is no longer considered synchronized.
+func TestMeasurementStatus(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := &measurement{offset: -30 * time.Millisecond, rtt: 20 * time.Millisecond, at: at}
+
+	st := m.status(at.Add(time.Hour))
+	if !st.Synchronized || st.EstimatedError != 40*time.Millisecond || st.MaxError != 1840*time.Millisecond || st.Source != "query" {
+		t.Errorf("status() after an hour = %+v, expected synchronized with errors 1.84s and 40ms", st)
+	}
+
+	if st := m.status(at.Add(10 * time.Hour)); st.Synchronized {
+		t.Errorf("status() after ten hours = %+v, expected unsynchronized", st)
+	}
+}
+
+// TestSyncStatusQuery tests that SyncStatus reports something once a query
+// has succeeded, whether or not the kernel can be asked.
+func TestSyncStatusQuery(t *testing.T) {
+	defer SetOffset(0)
+	defer lastMeasurement.Store(nil)
+
+	if _, _, err := Query(serve(t, 0, nil)); err != nil {
+		t.Fatalf("Query() returned error: %v", err)
+	}
+
+	st, err := SyncStatus()
+	if err != nil || st.Source == "" {
+		t.Errorf("SyncStatus() = %+v, %v, expected a status", st, err)
+	}
+	if st.Source == "query" && !st.Synchronized {
+		t.Errorf("SyncStatus() = %+v, expected synchronized to the local server", st)
+	}
+}
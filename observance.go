@@ -0,0 +1,72 @@
+package temporalis
+
+import "time"
+
+// ObservanceRule returns the date on which a holiday falling on the given
+// date is observed, which differs from it when the holiday falls on a day
+// off. A nil rule observes every holiday on its date.
+type ObservanceRule func(holiday CivilDate) CivilDate
+
+// Built-in observance rules for a Saturday and Sunday weekend.
+var (
+	// ObserveSaturdayOnFriday moves a Saturday holiday to the Friday
+	// before.
+	ObserveSaturdayOnFriday ObservanceRule = func(d CivilDate) CivilDate {
+		if d.Weekday() == time.Saturday {
+			return d.AddDays(-1)
+		}
+
+		return d
+	}
+
+	// ObserveSundayOnMonday moves a Sunday holiday to the Monday after.
+	ObserveSundayOnMonday ObservanceRule = func(d CivilDate) CivilDate {
+		if d.Weekday() == time.Sunday {
+			return d.AddDays(1)
+		}
+
+		return d
+	}
+
+	// ObserveNearestWeekday moves a Saturday holiday to Friday and a Sunday
+	// holiday to Monday, as for United States federal holidays.
+	ObserveNearestWeekday ObservanceRule = func(d CivilDate) CivilDate {
+		return ObserveSundayOnMonday(ObserveSaturdayOnFriday(d))
+	}
+)
+
+// ObserveNextBusinessDay returns a rule that moves a holiday falling on a
+// weekend day of cal to the first day after it that is neither a weekend day
+// nor a holiday of cal, which may be nil for a plain Monday to Friday week.
+// Holidays on other days stay where they are, even if they are holidays of
+// cal. Used with BusinessCalendar.AddObservedHoliday on the same calendar
+// this gives the substitute days of the United Kingdom, where Christmas Day
+// and Boxing Day on a weekend are observed on the Monday and Tuesday after.
+func ObserveNextBusinessDay(cal *BusinessCalendar) ObservanceRule {
+	return func(d CivilDate) CivilDate {
+		if !cal.IsWeekend(d) {
+			return d
+		}
+
+		return cal.NextBusinessDay(d)
+	}
+}
+
+// ObservedDate returns the date on which a holiday on the given date is
+// observed under rule.
+func ObservedDate(holiday CivilDate, rule ObservanceRule) CivilDate {
+	if rule == nil {
+		return holiday
+	}
+
+	return rule(holiday)
+}
+
+// AddObservedHoliday adds a holiday on the date on which it is observed
+// under rule, so that business-day calculations skip the day off rather
+// than the weekend day the holiday falls on. When a rule such as
+// ObserveNextBusinessDay depends on the holidays already in the calendar,
+// add holidays in date order.
+func (c *BusinessCalendar) AddObservedHoliday(d CivilDate, name string, rule ObservanceRule) {
+	c.AddHoliday(ObservedDate(d, rule), name)
+}
@@ -0,0 +1,58 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestObservedDate tests the built-in rules on each day of a weekend.
+func TestObservedDate(t *testing.T) {
+	friday := CivilDate{2026, time.July, 3}
+	saturday := CivilDate{2026, time.July, 4}
+	sunday := CivilDate{2026, time.July, 5}
+	monday := CivilDate{2026, time.July, 6}
+
+	tests := []struct {
+		name     string
+		rule     ObservanceRule
+		holiday  CivilDate
+		expected CivilDate
+	}{
+		{"nil", nil, saturday, saturday},
+		{"ObserveSaturdayOnFriday", ObserveSaturdayOnFriday, saturday, friday},
+		{"ObserveSaturdayOnFriday", ObserveSaturdayOnFriday, sunday, sunday},
+		{"ObserveSundayOnMonday", ObserveSundayOnMonday, sunday, monday},
+		{"ObserveSundayOnMonday", ObserveSundayOnMonday, saturday, saturday},
+		{"ObserveNearestWeekday", ObserveNearestWeekday, saturday, friday},
+		{"ObserveNearestWeekday", ObserveNearestWeekday, sunday, monday},
+		{"ObserveNearestWeekday", ObserveNearestWeekday, monday, monday},
+		{"ObserveNextBusinessDay", ObserveNextBusinessDay(nil), saturday, monday},
+		{"ObserveNextBusinessDay", ObserveNextBusinessDay(nil), friday, friday},
+	}
+
+	for _, test := range tests {
+		if actual := ObservedDate(test.holiday, test.rule); actual != test.expected {
+			t.Errorf("ObservedDate(%v, %s) = %v, expected %v", test.holiday, test.name, actual, test.expected)
+		}
+	}
+}
+
+// TestAddObservedHoliday tests substitute days for Christmas and Boxing Day
+// on a weekend, which push each other along.
+func TestAddObservedHoliday(t *testing.T) {
+	cal := NewBusinessCalendar()
+	rule := ObserveNextBusinessDay(cal)
+
+	cal.AddObservedHoliday(CivilDate{2021, time.December, 25}, "Christmas Day", rule)
+	cal.AddObservedHoliday(CivilDate{2021, time.December, 26}, "Boxing Day", rule)
+
+	expected := []CivilDate{{2021, time.December, 27}, {2021, time.December, 28}}
+	holidays := cal.Holidays()
+	if len(holidays) != 2 || holidays[0] != expected[0] || holidays[1] != expected[1] {
+		t.Errorf("Holidays() = %v, expected %v", holidays, expected)
+	}
+
+	if n := cal.CountBusinessDays(CivilDate{2021, time.December, 24}, CivilDate{2021, time.December, 31}); n != 4 {
+		t.Errorf("CountBusinessDays() = %d, expected 4", n)
+	}
+}
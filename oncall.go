@@ -0,0 +1,280 @@
+package temporalis
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Shift is a span of time during which one participant of a Rotation is on
+// call.
+type Shift struct {
+	Interval    Interval
+	Participant string
+}
+
+// Rotation is an on-call rotation that hands over from one participant to
+// the next every Interval from Start, in the order of Participants, and
+// starts again from the first after the last:
+//
+//	r := temporalis.NewRotation([]string{"ana", "bo", "cy"}, 7*24*time.Hour, monday9am, holidays)
+//	r.Override(temporalis.Interval{Start: xmasEve, End: boxingDay}, "bo")
+//	who, _ := r.WhoIsOn(time.Now())
+//
+// If Interval is a whole number of days, handoffs keep the wall-clock time of
+// Start in its location across DST changes. If Calendar is not nil, a
+// handoff that falls on a day that is not a business day is postponed to the
+// same time on the next business day; nobody's turn is skipped, so with a
+// daily rotation whoever is on call on Friday covers the weekend and the
+// next participant takes over on Monday. Overrides replace the scheduled
+// participant for their interval. The fields must not be modified while the
+// rotation is in use; Override is safe for concurrent use with the other
+// methods.
+type Rotation struct {
+	Participants []string
+	Interval     time.Duration
+	Start        time.Time
+	Calendar     *BusinessCalendar
+
+	mu        sync.RWMutex
+	overrides []Shift
+}
+
+// NewRotation returns a rotation of the participants every interval from
+// start, postponing handoffs to business days of cal if it is not nil. It
+// panics if there are no participants or interval is not positive.
+func NewRotation(participants []string, interval time.Duration, start time.Time, cal *BusinessCalendar) *Rotation {
+	if len(participants) == 0 {
+		panic("temporalis: rotation without participants")
+	}
+	if interval <= 0 {
+		panic("temporalis: non-positive rotation interval")
+	}
+
+	return &Rotation{Participants: slices.Clone(participants), Interval: interval, Start: start, Calendar: cal}
+}
+
+// Override puts participant on call for the interval instead of whoever is
+// scheduled, for swaps, holidays and sick days. Later overrides take
+// precedence over earlier ones where they overlap.
+func (r *Rotation) Override(i Interval, participant string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.overrides = append(r.overrides, Shift{Interval: i, Participant: participant})
+}
+
+// Overrides returns the overrides in the order they were added.
+func (r *Rotation) Overrides() []Shift {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.overrides)
+}
+
+// WhoIsOn returns the participant on call at t. The boolean result is false
+// before Start, unless an override covers t.
+func (r *Rotation) WhoIsOn(t time.Time) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.whoIsOn(t)
+}
+
+// NextHandoff returns the first instant after the given time at which the
+// participant on call changes, whether by the schedule or by an override.
+// It returns the zero time if the participant never changes again, as with a
+// single participant and no overrides ahead. RecurrenceFunc(r.NextHandoff)
+// is a Recurrence that fires at every handoff.
+func (r *Rotation) NextHandoff(after time.Time) time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	current, _ := r.whoIsOn(after)
+	rotates := slices.ContainsFunc(r.Participants, func(p string) bool { return p != r.Participants[0] })
+
+	for t := after; ; {
+		var next time.Time
+		if rotates || t.Before(r.Start) {
+			next = r.nextBoundary(t)
+		}
+		for _, o := range r.overrides {
+			for _, edge := range []time.Time{o.Interval.Start, o.Interval.End} {
+				if edge.After(t) && (next.IsZero() || edge.Before(next)) {
+					next = edge
+				}
+			}
+		}
+		if next.IsZero() {
+			return next
+		}
+
+		if who, _ := r.whoIsOn(next); who != current {
+			return next
+		}
+		t = next
+	}
+}
+
+// Between returns the shifts from start to end, clipped to that interval and
+// with the overrides applied, in order. Adjacent shifts always have
+// different participants.
+func (r *Rotation) Between(start, end time.Time) []Shift {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	window := Interval{Start: start, End: end}
+
+	var shifts []Shift
+	for s := range r.schedule(start) {
+		if !s.Interval.Start.Before(end) {
+			break
+		}
+		if i, ok := s.Interval.Intersect(window); ok {
+			shifts = append(shifts, Shift{Interval: i, Participant: s.Participant})
+		}
+	}
+
+	for _, o := range r.overrides {
+		if i, ok := o.Interval.Intersect(window); ok {
+			shifts = overlayShift(shifts, Shift{Interval: i, Participant: o.Participant})
+		}
+	}
+
+	return mergeShifts(shifts)
+}
+
+// whoIsOn implements WhoIsOn. The caller must hold r.mu.
+func (r *Rotation) whoIsOn(t time.Time) (string, bool) {
+	for _, o := range slices.Backward(r.overrides) {
+		if o.Interval.Contains(t) {
+			return o.Participant, true
+		}
+	}
+
+	for s := range r.schedule(t) {
+		if s.Interval.Contains(t) {
+			return s.Participant, true
+		}
+		break
+	}
+
+	return "", false
+}
+
+// nextBoundary returns the first scheduled handoff after t.
+func (r *Rotation) nextBoundary(t time.Time) time.Time {
+	for s := range r.schedule(t) {
+		if s.Interval.Start.After(t) {
+			return s.Interval.Start
+		}
+
+		return s.Interval.End
+	}
+
+	return time.Time{}
+}
+
+// schedule returns the scheduled shifts, without overrides, from the one in
+// progress at t, or from the first if t is before Start.
+func (r *Rotation) schedule(t time.Time) iter.Seq[Shift] {
+	return func(yield func(Shift) bool) {
+		k, turn := 0, 0
+
+		// Without a calendar every handoff changes the participant, so the
+		// shift in progress can be computed directly.
+		if r.Calendar == nil && t.After(r.Start) {
+			k = int(t.Sub(r.Start) / r.Interval)
+			for k > 0 && r.nominalHandoff(k).After(t) {
+				k--
+			}
+			for !r.nominalHandoff(k + 1).After(t) {
+				k++
+			}
+			turn = k
+		}
+
+		start := r.handoff(k)
+		for {
+			end := r.handoff(k + 1)
+			k++
+			if !end.After(start) {
+				continue
+			}
+
+			if end.After(t) {
+				s := Shift{Interval: Interval{Start: start, End: end}, Participant: r.Participants[turn%len(r.Participants)]}
+				if !yield(s) {
+					return
+				}
+			}
+
+			start = end
+			turn++
+		}
+	}
+}
+
+// handoff returns the instant of the kth handoff, postponed to a business
+// day of the calendar.
+func (r *Rotation) handoff(k int) time.Time {
+	t := r.nominalHandoff(k)
+	if r.Calendar == nil {
+		return t
+	}
+
+	d := DateOf(t)
+	if r.Calendar.IsBusinessDay(d) {
+		return t
+	}
+
+	return t.AddDate(0, 0, r.Calendar.NextBusinessDay(d).DaysSince(d))
+}
+
+// nominalHandoff returns the instant of the kth handoff before any
+// postponement.
+func (r *Rotation) nominalHandoff(k int) time.Time {
+	if r.Interval%(24*time.Hour) == 0 {
+		return r.Start.AddDate(0, 0, k*int(r.Interval/(24*time.Hour)))
+	}
+
+	return r.Start.Add(time.Duration(k) * r.Interval)
+}
+
+// overlayShift returns the shifts with o replacing whatever they had during
+// its interval, in order.
+func overlayShift(shifts []Shift, o Shift) []Shift {
+	var result []Shift
+	for _, s := range shifts {
+		if !s.Interval.Overlaps(o.Interval) {
+			result = append(result, s)
+			continue
+		}
+		if s.Interval.Start.Before(o.Interval.Start) {
+			result = append(result, Shift{Interval: Interval{Start: s.Interval.Start, End: o.Interval.Start}, Participant: s.Participant})
+		}
+		if o.Interval.End.Before(s.Interval.End) {
+			result = append(result, Shift{Interval: Interval{Start: o.Interval.End, End: s.Interval.End}, Participant: s.Participant})
+		}
+	}
+
+	result = append(result, o)
+	slices.SortFunc(result, func(a, b Shift) int { return a.Interval.Start.Compare(b.Interval.Start) })
+
+	return result
+}
+
+// mergeShifts joins adjacent shifts of the same participant.
+func mergeShifts(shifts []Shift) []Shift {
+	var result []Shift
+	for _, s := range shifts {
+		if n := len(result); n > 0 && result[n-1].Participant == s.Participant && result[n-1].Interval.End.Equal(s.Interval.Start) {
+			result[n-1].Interval.End = s.Interval.End
+			continue
+		}
+		result = append(result, s)
+	}
+
+	return result
+}
@@ -0,0 +1,134 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRotationWhoIsOn tests a weekly rotation, whose handoffs keep their
+// wall-clock time across a DST change.
+func TestRotationWhoIsOn(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	r := NewRotation([]string{"ana", "bo", "cy"}, 7*24*time.Hour, time.Date(2024, 3, 4, 9, 0, 0, 0, berlin), nil)
+
+	tests := []struct {
+		t        time.Time
+		expected string
+		ok       bool
+	}{
+		{time.Date(2024, 3, 4, 8, 59, 0, 0, berlin), "", false},
+		{time.Date(2024, 3, 4, 9, 0, 0, 0, berlin), "ana", true},
+		{time.Date(2024, 3, 12, 0, 0, 0, 0, berlin), "bo", true},
+		{time.Date(2024, 3, 18, 9, 0, 0, 0, berlin), "cy", true},
+		{time.Date(2024, 3, 25, 9, 0, 0, 0, berlin), "ana", true},
+		// Clocks go forward on 31 March, but the handoff stays at 09:00.
+		{time.Date(2024, 4, 1, 8, 59, 0, 0, berlin), "ana", true},
+		{time.Date(2024, 4, 1, 9, 0, 0, 0, berlin), "bo", true},
+	}
+
+	for _, test := range tests {
+		if actual, ok := r.WhoIsOn(test.t); actual != test.expected || ok != test.ok {
+			t.Errorf("WhoIsOn(%v) = %q, %v, expected %q, %v", test.t, actual, ok, test.expected, test.ok)
+		}
+	}
+
+	if actual, expected := r.NextHandoff(time.Date(2024, 3, 28, 0, 0, 0, 0, berlin)), time.Date(2024, 4, 1, 9, 0, 0, 0, berlin); !actual.Equal(expected) {
+		t.Errorf("NextHandoff() = %v, expected %v", actual, expected)
+	}
+}
+
+// TestRotationCalendar tests a daily rotation whose weekend handoffs are
+// postponed to Monday.
+func TestRotationCalendar(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC) }
+
+	r := NewRotation([]string{"ana", "bo", "cy"}, 24*time.Hour, at(4, 9), NewBusinessCalendar())
+
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{at(4, 12), "ana"},
+		{at(5, 12), "bo"},
+		{at(6, 12), "cy"},
+		{at(7, 12), "ana"},
+		{at(8, 12), "bo"},
+		{at(9, 12), "bo"},
+		{at(11, 8), "bo"},
+		{at(11, 9), "cy"},
+	}
+
+	for _, test := range tests {
+		if actual, _ := r.WhoIsOn(test.t); actual != test.expected {
+			t.Errorf("WhoIsOn(%v) = %q, expected %q", test.t, actual, test.expected)
+		}
+	}
+
+	if actual := r.NextHandoff(at(8, 10)); !actual.Equal(at(11, 9)) {
+		t.Errorf("NextHandoff() = %v, expected %v", actual, at(11, 9))
+	}
+}
+
+// TestRotationOverride tests that overrides change WhoIsOn, NextHandoff and
+// Between.
+func TestRotationOverride(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC) }
+
+	r := NewRotation([]string{"ana", "bo", "cy"}, 24*time.Hour, at(4, 9), nil)
+	r.Override(Interval{Start: at(5, 12), End: at(5, 18)}, "cy")
+	r.Override(Interval{Start: at(5, 14), End: at(5, 15)}, "ana")
+	// An override by whoever is on call anyway is not a handoff.
+	r.Override(Interval{Start: at(7, 10), End: at(7, 11)}, "ana")
+
+	if actual, _ := r.WhoIsOn(at(5, 13)); actual != "cy" {
+		t.Errorf("WhoIsOn() = %q, expected %q", actual, "cy")
+	}
+	if actual, _ := r.WhoIsOn(at(5, 14)); actual != "ana" {
+		t.Errorf("WhoIsOn() = %q, expected %q", actual, "ana")
+	}
+
+	if actual := r.NextHandoff(at(5, 10)); !actual.Equal(at(5, 12)) {
+		t.Errorf("NextHandoff() = %v, expected %v", actual, at(5, 12))
+	}
+	if actual := r.NextHandoff(at(7, 9)); !actual.Equal(at(8, 9)) {
+		t.Errorf("NextHandoff() = %v, expected %v", actual, at(8, 9))
+	}
+
+	expected := []Shift{
+		{Interval{at(4, 12), at(5, 9)}, "ana"},
+		{Interval{at(5, 9), at(5, 12)}, "bo"},
+		{Interval{at(5, 12), at(5, 14)}, "cy"},
+		{Interval{at(5, 14), at(5, 15)}, "ana"},
+		{Interval{at(5, 15), at(5, 18)}, "cy"},
+		{Interval{at(5, 18), at(6, 9)}, "bo"},
+		{Interval{at(6, 9), at(6, 10)}, "cy"},
+	}
+
+	actual := r.Between(at(4, 12), at(6, 10))
+	if len(actual) != len(expected) {
+		t.Fatalf("Between() = %v, expected %v", actual, expected)
+	}
+	for i := range expected {
+		if !actual[i].Interval.Start.Equal(expected[i].Interval.Start) || !actual[i].Interval.End.Equal(expected[i].Interval.End) || actual[i].Participant != expected[i].Participant {
+			t.Errorf("Between()[%d] = %v, expected %v", i, actual[i], expected[i])
+		}
+	}
+}
+
+// TestRotationSingleParticipant tests that a rotation of one has no handoffs
+// after it starts.
+func TestRotationSingleParticipant(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	r := NewRotation([]string{"ana"}, 24*time.Hour, start, nil)
+
+	if actual := r.NextHandoff(start.Add(-time.Hour)); !actual.Equal(start) {
+		t.Errorf("NextHandoff() = %v, expected %v", actual, start)
+	}
+	if actual := r.NextHandoff(start); !actual.IsZero() {
+		t.Errorf("NextHandoff() = %v, expected zero time", actual)
+	}
+}
@@ -0,0 +1,315 @@
+package temporalis
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// openingDays are the day abbreviations of the opening_hours syntax, in
+// the order of time.Weekday.
+var openingDays = [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// OpeningHours is a weekly schedule of when a shop, office or support desk
+// is open, such as "Mo-Fr 09:00-17:00; Sa 10:00-14:00". Each day has a list
+// of clock ranges; a range that ends before it starts runs past midnight
+// into the next day, as in "Fr 22:00-02:00". The zero value is always
+// closed.
+type OpeningHours struct {
+	// Location is the time zone of the wall-clock times. If nil, times are
+	// taken in their own locations.
+	Location *time.Location
+
+	days [7][]ClockRange
+}
+
+// ParseOpeningHours parses the subset of the OpenStreetMap opening_hours
+// syntax that describes a regular week. Rules are separated by semicolons;
+// each has an optional day selector such as "Mo-Fr" or "Mo,We,Sa-Su",
+// which defaults to every day, followed by comma-separated time spans such
+// as "09:00-12:00,13:00-17:30", "off" or "closed", or nothing for the whole
+// day. A span may end at "24:00" or before it starts to run past midnight.
+// As in OpenStreetMap, a later rule replaces the hours of the days it
+// selects rather than adding to them, so "Mo-Sa 09:00-18:00; Sa 10:00-14:00"
+// is shorter on Saturdays. "24/7" means always open. Public holidays, months
+// and the other parts of the full syntax are not supported. The result has
+// no Location.
+func ParseOpeningHours(s string) (*OpeningHours, error) {
+	const fn = "ParseOpeningHours"
+
+	if strings.TrimSpace(s) == "" {
+		return nil, syntaxError(fn, s, "empty")
+	}
+
+	h := &OpeningHours{}
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		if rule == "24/7" {
+			for d := range h.days {
+				h.days[d] = []ClockRange{{}}
+			}
+			continue
+		}
+
+		selector, spans := "", rule
+		if c := rule[0]; c < '0' || c > '9' {
+			selector, spans, _ = strings.Cut(rule, " ")
+		}
+		if selector == "off" || selector == "closed" {
+			selector, spans = "", rule
+		}
+
+		days := []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+		if selector != "" {
+			var err error
+			if days, err = parseOpeningDays(selector); err != nil {
+				return nil, syntaxError(fn, s, err.Error())
+			}
+		}
+
+		ranges, err := parseOpeningSpans(strings.Join(strings.Fields(spans), ""))
+		if err != nil {
+			return nil, &ParseError{Func: fn, Input: s, Err: err}
+		}
+
+		for _, d := range days {
+			h.days[d] = ranges
+		}
+	}
+
+	return h, nil
+}
+
+// parseOpeningDays parses a day selector such as "Mo-Fr,Su". A range may
+// wrap around the end of the week, as in "Fr-Mo".
+func parseOpeningDays(s string) ([]time.Weekday, error) {
+	var days []time.Weekday
+
+	for _, part := range strings.Split(s, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+
+		from, ok := parseOpeningDay(first)
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", first)
+		}
+		to := from
+		if isRange {
+			if to, ok = parseOpeningDay(last); !ok {
+				return nil, fmt.Errorf("invalid day %q", last)
+			}
+		}
+
+		for d := from; ; d = (d + 1) % 7 {
+			days = append(days, d)
+			if d == to {
+				break
+			}
+		}
+	}
+
+	return days, nil
+}
+
+// parseOpeningDay parses a two-letter day abbreviation such as "Mo".
+func parseOpeningDay(s string) (time.Weekday, bool) {
+	i := slices.Index(openingDays[:], s)
+
+	return time.Weekday(i), i >= 0
+}
+
+// parseOpeningSpans parses comma-separated time spans such as
+// "09:00-12:00,13:00-17:00", "off" or "closed", or the empty string for the
+// whole day. The error matches ErrSyntax or ErrOutOfRange.
+func parseOpeningSpans(s string) ([]ClockRange, error) {
+	switch s {
+	case "":
+		return []ClockRange{{}}, nil
+	case "off", "closed":
+		return nil, nil
+	}
+
+	var ranges []ClockRange
+	for _, span := range strings.Split(s, ",") {
+		first, last, ok := strings.Cut(span, "-")
+		if !ok {
+			return nil, detailed(ErrSyntax, fmt.Sprintf("invalid time span %q", span))
+		}
+
+		start, err := parseOpeningTime(first, false)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseOpeningTime(last, true)
+		if err != nil {
+			return nil, err
+		}
+
+		ranges = append(ranges, ClockRange{Start: start, End: end})
+	}
+
+	return ranges, nil
+}
+
+// parseOpeningTime parses a time in "hh:mm" format. The end of a span may
+// be "24:00", which is returned as midnight.
+func parseOpeningTime(s string, end bool) (TimeOfDay, error) {
+	if len(s) != 5 || s[2] != ':' || !isDigits(s[:2]) || !isDigits(s[3:]) {
+		return TimeOfDay{}, detailed(ErrSyntax, fmt.Sprintf("invalid time %q", s))
+	}
+
+	hour, _ := strconv.Atoi(s[:2])
+	minute, _ := strconv.Atoi(s[3:])
+	if end && hour == 24 && minute == 0 {
+		return Midnight, nil
+	}
+	if hour > 23 || minute > 59 {
+		return TimeOfDay{}, detailed(ErrOutOfRange, fmt.Sprintf("time %q out of range", s))
+	}
+
+	return NewTimeOfDay(hour, minute, 0, 0), nil
+}
+
+// Hours returns the clock ranges in which the place opens on the given day
+// of the week. A range that ends before it starts runs into the next day.
+func (h *OpeningHours) Hours(day time.Weekday) []ClockRange {
+	return slices.Clone(h.days[day])
+}
+
+// IsOpen reports whether the place is open at t.
+func (h *OpeningHours) IsOpen(t time.Time) bool {
+	for _, i := range h.periods(t, 1) {
+		if i.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextOpen returns t if the place is open at t, and otherwise the time it
+// next opens. It returns the zero time if it never opens.
+func (h *OpeningHours) NextOpen(t time.Time) time.Time {
+	for _, i := range h.periods(t, 8) {
+		if i.End.After(t) {
+			if i.Start.After(t) {
+				return i.Start
+			}
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// NextClose returns t if the place is closed at t, and otherwise the time
+// it next closes, which is after any periods that follow on without a
+// break, such as an evening that runs into an overnight shift. It returns
+// the zero time if it never closes.
+func (h *OpeningHours) NextClose(t time.Time) time.Time {
+	periods := h.periods(t, 8)
+	for _, i := range periods {
+		if i.Contains(t) {
+			// A period that lasts the whole of the next week repeats
+			// without end.
+			if !i.End.Before(t.AddDate(0, 0, 7)) {
+				return time.Time{}
+			}
+			return i.End
+		}
+	}
+
+	return t
+}
+
+// periods returns the opening periods, merged where they meet, that start
+// between the day before the date of t and n days after it.
+func (h *OpeningHours) periods(t time.Time, n int) []Interval {
+	loc := h.Location
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	var periods []Interval
+	d := DateOf(t.In(loc))
+	for day := d.AddDays(-1); !day.After(d.AddDays(n)); day = day.AddDays(1) {
+		for _, r := range h.days[day.Weekday()] {
+			periods = append(periods, r.shiftsOn(day, loc)...)
+		}
+	}
+
+	return normalizeIntervals(periods)
+}
+
+// String returns the schedule in opening_hours syntax, with consecutive
+// days that have the same hours combined, such as
+// "Mo-Fr 09:00-17:00; Sa 10:00-14:00". A schedule that is always open is
+// "24/7" and one that is always closed is "off".
+func (h *OpeningHours) String() string {
+	week := make([][]ClockRange, 7)
+	for i := range week {
+		week[i] = h.days[(i+1)%7]
+	}
+
+	var rules []string
+	for i := 0; i < 7; {
+		j := i + 1
+		for j < 7 && slices.Equal(week[j], week[i]) {
+			j++
+		}
+
+		if len(week[i]) > 0 {
+			days := openingDays[(i+1)%7]
+			if j-i > 1 {
+				days += "-" + openingDays[j%7]
+			}
+			rules = append(rules, days+" "+formatOpeningSpans(week[i]))
+		}
+		i = j
+	}
+
+	switch {
+	case len(rules) == 0:
+		return "off"
+	case len(rules) == 1 && rules[0] == "Mo-Su 00:00-24:00":
+		return "24/7"
+	}
+
+	return strings.Join(rules, "; ")
+}
+
+// formatOpeningSpans formats clock ranges as comma-separated time spans.
+func formatOpeningSpans(ranges []ClockRange) string {
+	spans := make([]string, len(ranges))
+	for i, r := range ranges {
+		end := fmt.Sprintf("%02d:%02d", r.End.Hour, r.End.Minute)
+		if r.End == Midnight {
+			end = "24:00"
+		}
+		spans[i] = fmt.Sprintf("%02d:%02d-%s", r.Start.Hour, r.Start.Minute, end)
+	}
+
+	return strings.Join(spans, ",")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (h *OpeningHours) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It keeps Location.
+func (h *OpeningHours) UnmarshalText(data []byte) error {
+	parsed, err := ParseOpeningHours(string(data))
+	if err != nil {
+		return err
+	}
+
+	h.days = parsed.days
+
+	return nil
+}
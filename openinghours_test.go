@@ -0,0 +1,114 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestParseOpeningHours tests parsing and formatting of schedules.
+func TestParseOpeningHours(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{"Mo-Fr 09:00-17:00; Sa 10:00-14:00", "Mo-Fr 09:00-17:00; Sa 10:00-14:00"},
+		{"Mo-Sa 09:00-18:00; Sa 10:00-14:00", "Mo-Fr 09:00-18:00; Sa 10:00-14:00"},
+		{"Mo,We 08:00-12:00, 13:00-17:00;", "Mo 08:00-12:00,13:00-17:00; We 08:00-12:00,13:00-17:00"},
+		{"Fr-Mo 22:00-02:00", "Mo 22:00-02:00; Fr-Su 22:00-02:00"},
+		{"Mo-Su 18:00-24:00; Tu off", "Mo 18:00-24:00; We-Su 18:00-24:00"},
+		{"24/7; Su closed", "Mo-Sa 00:00-24:00"},
+		{"Mo-Su", "24/7"},
+		{"off", "off"},
+	}
+
+	for _, test := range tests {
+		h, err := ParseOpeningHours(test.input)
+		if err != nil {
+			t.Errorf("ParseOpeningHours(%q) returned error: %v", test.input, err)
+			continue
+		}
+		if actual := h.String(); actual != test.expected {
+			t.Errorf("ParseOpeningHours(%q) = %q, expected %q", test.input, actual, test.expected)
+		}
+	}
+
+	invalid := []struct {
+		input    string
+		expected error
+	}{
+		{"", ErrSyntax},
+		{"Mon-Fri 09:00-17:00", ErrSyntax},
+		{"Mo-Fr 9:00-17:00", ErrSyntax},
+		{"Mo-Fr 09:00", ErrSyntax},
+		{"Mo-Fr 09:00-24:30", ErrOutOfRange},
+		{"Mo-Fr 24:00-02:00", ErrOutOfRange},
+		{"PH off", ErrSyntax},
+	}
+
+	for _, test := range invalid {
+		if _, err := ParseOpeningHours(test.input); !errors.Is(err, test.expected) {
+			t.Errorf("ParseOpeningHours(%q) error = %v, expected %v", test.input, err, test.expected)
+		}
+	}
+}
+
+// TestOpeningHours tests open-now queries, including periods running past
+// midnight and a DST change.
+func TestOpeningHours(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	h, err := ParseOpeningHours("Mo-Fr 09:00-12:00,13:00-17:00; Sa 22:00-24:00; Su 00:00-03:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Location = berlin
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		t                   time.Time
+		open                bool
+		nextOpen, nextClose time.Time
+	}{
+		// Friday 29 March 2024.
+		{at(29, 10, 0), true, at(29, 10, 0), at(29, 12, 0)},
+		{at(29, 12, 0), false, at(29, 13, 0), at(29, 12, 0)},
+		{at(29, 17, 0), false, at(30, 22, 0), at(29, 17, 0)},
+		// Saturday night runs into Sunday 31 March, when the clocks go
+		// forward at 02:00.
+		{at(30, 23, 0).UTC(), true, at(30, 23, 0), at(31, 3, 0)},
+		{at(31, 4, 0), false, time.Date(2024, 4, 1, 9, 0, 0, 0, berlin), at(31, 4, 0)},
+	}
+
+	for _, test := range tests {
+		if actual := h.IsOpen(test.t); actual != test.open {
+			t.Errorf("IsOpen(%v) = %v, expected %v", test.t, actual, test.open)
+		}
+		if actual := h.NextOpen(test.t); !actual.Equal(test.nextOpen) {
+			t.Errorf("NextOpen(%v) = %v, expected %v", test.t, actual, test.nextOpen)
+		}
+		if actual := h.NextClose(test.t); !actual.Equal(test.nextClose) {
+			t.Errorf("NextClose(%v) = %v, expected %v", test.t, actual, test.nextClose)
+		}
+	}
+}
+
+// TestOpeningHoursAlways tests schedules that never open or never close.
+func TestOpeningHoursAlways(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	always, _ := ParseOpeningHours("24/7")
+	if !always.IsOpen(now) || !always.NextClose(now).IsZero() || !always.NextOpen(now).Equal(now) {
+		t.Errorf("24/7 at %v: open %v, next close %v", now, always.IsOpen(now), always.NextClose(now))
+	}
+
+	var never OpeningHours
+	if never.IsOpen(now) || !never.NextOpen(now).IsZero() || !never.NextClose(now).Equal(now) {
+		t.Errorf("zero OpeningHours at %v: open %v, next open %v", now, never.IsOpen(now), never.NextOpen(now))
+	}
+}
@@ -0,0 +1,183 @@
+package temporalis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"strconv"
+	"time"
+)
+
+// OptionalTime is a time that may be absent, for use in APIs and models in
+// place of *time.Time. The zero value is absent. It encodes to JSON as an
+// RFC 3339 string or null and to SQL as a time or NULL, and IsZero reports
+// absence so the field is left out with the omitzero tag option.
+type OptionalTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// SomeTime returns a present OptionalTime holding t.
+func SomeTime(t time.Time) OptionalTime {
+	return OptionalTime{Time: t, Valid: true}
+}
+
+// OptionalTimeFromPtr returns an OptionalTime holding *p, or an absent one if
+// p is nil.
+func OptionalTimeFromPtr(p *time.Time) OptionalTime {
+	if p == nil {
+		return OptionalTime{}
+	}
+
+	return SomeTime(*p)
+}
+
+// Ptr returns a pointer to a copy of the time, or nil if it is absent.
+func (o OptionalTime) Ptr() *time.Time {
+	if !o.Valid {
+		return nil
+	}
+
+	t := o.Time
+
+	return &t
+}
+
+// Get returns the time and whether it is present.
+func (o OptionalTime) Get() (time.Time, bool) {
+	return o.Time, o.Valid
+}
+
+// IsZero reports whether the time is absent.
+func (o OptionalTime) IsZero() bool {
+	return !o.Valid
+}
+
+// OrElse returns the time if it is present and def otherwise.
+func (o OptionalTime) OrElse(def time.Time) time.Time {
+	if !o.Valid {
+		return def
+	}
+
+	return o.Time
+}
+
+// Map returns f applied to the time if it is present, and an absent value
+// otherwise.
+func (o OptionalTime) Map(f func(time.Time) time.Time) OptionalTime {
+	if !o.Valid {
+		return o
+	}
+
+	return SomeTime(f(o.Time))
+}
+
+// Equal reports whether both values are absent, or both are present and
+// hold the same instant.
+func (o OptionalTime) Equal(other OptionalTime) bool {
+	if o.Valid != other.Valid {
+		return false
+	}
+
+	return !o.Valid || o.Time.Equal(other.Time)
+}
+
+// Compare returns -1, 0 or +1 depending on whether o sorts before, with or
+// after other. Absent values sort before every present value.
+func (o OptionalTime) Compare(other OptionalTime) int {
+	switch {
+	case !o.Valid && !other.Valid:
+		return 0
+	case !o.Valid:
+		return -1
+	case !other.Valid:
+		return 1
+	default:
+		return o.Time.Compare(other.Time)
+	}
+}
+
+// Before reports whether both values are present and o is before other.
+func (o OptionalTime) Before(other OptionalTime) bool {
+	return o.Valid && other.Valid && o.Time.Before(other.Time)
+}
+
+// After reports whether both values are present and o is after other.
+func (o OptionalTime) After(other OptionalTime) bool {
+	return o.Valid && other.Valid && o.Time.After(other.Time)
+}
+
+// String returns the time in RFC 3339 format, or "none" if it is absent.
+func (o OptionalTime) String() string {
+	if !o.Valid {
+		return "none"
+	}
+
+	return o.Time.Format(RFC3339Nano)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o OptionalTime) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return jsonNull, nil
+	}
+
+	return strconv.AppendQuote(nil, o.Time.Format(RFC3339Nano)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *OptionalTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, jsonNull) {
+		*o = OptionalTime{}
+		return nil
+	}
+
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return syntaxError("OptionalTime.UnmarshalJSON", string(data), "invalid JSON string")
+	}
+
+	t, err := parseRFC3339("OptionalTime.UnmarshalJSON", s)
+	if err != nil {
+		return err
+	}
+
+	*o = SomeTime(t)
+
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (o OptionalTime) Value() (driver.Value, error) {
+	if !o.Valid {
+		return nil, nil
+	}
+
+	return o.Time, nil
+}
+
+// Scan implements sql.Scanner. It accepts time.Time values and RFC 3339
+// text, and NULL as absent.
+func (o *OptionalTime) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OptionalTime{}
+		return nil
+	case time.Time:
+		*o = SomeTime(v)
+		return nil
+	}
+
+	s, err := scanText(src, "OptionalTime")
+	if err != nil {
+		return err
+	}
+
+	t, err := parseRFC3339("OptionalTime.Scan", s)
+	if err != nil {
+		return err
+	}
+
+	*o = SomeTime(t)
+
+	return nil
+}
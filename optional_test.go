@@ -0,0 +1,53 @@
+package temporalis
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestOptionalTime checks the accessors, ordering and JSON/SQL round trips of
+// present and absent values.
+func TestOptionalTime(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	some, none := SomeTime(now), OptionalTime{}
+
+	if got := none.OrElse(now); !got.Equal(now) {
+		t.Errorf("OrElse() = %v, expected %v", got, now)
+	}
+
+	if got := some.Map(func(t time.Time) time.Time { return t.Add(time.Hour) }); !got.Time.Equal(now.Add(time.Hour)) {
+		t.Errorf("Map() = %v, expected %v", got, now.Add(time.Hour))
+	}
+
+	if none.Compare(some) != -1 || some.Compare(none) != 1 || none.Compare(none) != 0 || none.Before(some) {
+		t.Errorf("Compare/Before ordered absent values incorrectly")
+	}
+
+	if OptionalTimeFromPtr(nil).Valid || !OptionalTimeFromPtr(some.Ptr()).Equal(some) {
+		t.Errorf("pointer conversions do not round trip")
+	}
+
+	type model struct {
+		Deleted OptionalTime `json:"deleted,omitzero"`
+	}
+
+	data, _ := json.Marshal(model{})
+	if string(data) != `{}` {
+		t.Errorf("json.Marshal(absent) = %s, expected {}", data)
+	}
+
+	data, _ = json.Marshal(model{Deleted: some})
+	var decoded model
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Deleted.Equal(some) {
+		t.Errorf("JSON round trip = %v, %v, expected %v", decoded.Deleted, err, some)
+	}
+
+	var scanned OptionalTime
+	if err := scanned.Scan("2024-01-01T12:00:00Z"); err != nil || !scanned.Equal(some) {
+		t.Errorf("Scan() = %v, %v, expected %v", scanned, err, some)
+	}
+	if err := scanned.Scan(nil); err != nil || scanned.Valid {
+		t.Errorf("Scan(nil) = %v, %v, expected absent", scanned, err)
+	}
+}
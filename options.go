@@ -0,0 +1,96 @@
+package temporalis
+
+import "time"
+
+// Option configures a function or constructor that accepts options, such as
+// FormatDuration, ParseDeadline, StartOfPeriod, BusinessDaysBetween and
+// NewScheduler. The same option means the same thing everywhere it is
+// accepted, and options that do not apply to a function are ignored, so a
+// single slice of options can be shared across calls.
+type Option func(*Options)
+
+// Options holds the settings collected from a list of Option values. The
+// zero value of each field selects the package default.
+type Options struct {
+	// Location is the time zone used for wall-clock calculations. If nil,
+	// the location of the input is used, or LocalLocation where there is
+	// none.
+	Location *time.Location
+	// Clock provides the current time. If nil, SystemClock is used.
+	Clock Clock
+	// Calendar decides which days are business days. If nil, Saturday and
+	// Sunday are the weekend and there are no holidays.
+	Calendar *BusinessCalendar
+	// Locale selects the language of generated text, such as "de" or
+	// "fr-CA". If empty, or if the language is unknown, English is used.
+	Locale string
+	// WeekStart is the first day of the week. It defaults to Monday.
+	WeekStart time.Weekday
+
+	// Settings that apply only to FormatDuration.
+	duration durationFormat
+	// The hop between windows, which applies only to Windows.
+	hop time.Duration
+	// The daily working window, which applies only to BusinessHours.
+	workingHours ClockRange
+}
+
+// WithLocation sets the time zone used for wall-clock calculations.
+func WithLocation(loc *time.Location) Option {
+	return func(o *Options) { o.Location = loc }
+}
+
+// WithClock sets the clock that provides the current time, which is
+// typically a FakeClock in tests.
+func WithClock(c Clock) Option {
+	return func(o *Options) { o.Clock = c }
+}
+
+// WithCalendar sets the business calendar that decides which days are
+// working days.
+func WithCalendar(cal *BusinessCalendar) Option {
+	return func(o *Options) { o.Calendar = cal }
+}
+
+// WithLocale sets the language of generated text, such as "de" or "fr-CA".
+// Regional variants fall back to the language, and unknown languages to
+// English.
+func WithLocale(locale string) Option {
+	return func(o *Options) { o.Locale = locale }
+}
+
+// WithWeekStart sets the first day of the week, for example time.Sunday in
+// the United States.
+func WithWeekStart(day time.Weekday) Option {
+	return func(o *Options) { o.WeekStart = day }
+}
+
+// NewOptions applies opts to the default settings and returns the result. It
+// is meant for code that builds its own APIs on top of the package options.
+func NewOptions(opts ...Option) Options {
+	o := Options{WeekStart: time.Monday}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	return o
+}
+
+// locationOr returns the configured location, or def if there is none.
+func (o *Options) locationOr(def *time.Location) *time.Location {
+	if o.Location != nil {
+		return o.Location
+	}
+	if def != nil {
+		return def
+	}
+
+	return LocalLocation()
+}
+
+// now returns the current time of the configured clock.
+func (o *Options) now() time.Time {
+	return clockOrSystem(o.Clock).Now()
+}
@@ -0,0 +1,102 @@
+package temporalis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestNewOptions tests the defaults and that later options override earlier
+// ones.
+func TestNewOptions(t *testing.T) {
+	o := NewOptions()
+	if o.WeekStart != time.Monday || o.Location != nil || o.Clock != nil || o.Calendar != nil || o.Locale != "" {
+		t.Errorf("NewOptions() = %+v, expected the defaults", o)
+	}
+
+	o = NewOptions(WithLocale("de"), WithWeekStart(time.Sunday), nil, WithLocale("fr"))
+	if o.Locale != "fr" || o.WeekStart != time.Sunday {
+		t.Errorf("NewOptions() = %+v, expected locale fr and week start Sunday", o)
+	}
+}
+
+// TestStartOfPeriodOptions tests StartOfPeriod and NextPeriod with a week
+// start and a location.
+func TestStartOfPeriodOptions(t *testing.T) {
+	wed := time.Date(2024, time.March, 13, 15, 0, 0, 0, time.UTC)
+
+	start := StartOfPeriod(wed, Weekly, WithWeekStart(time.Sunday))
+	if expected := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC); !start.Equal(expected) {
+		t.Errorf("StartOfPeriod() = %v, expected %v", start, expected)
+	}
+
+	next := NextPeriod(wed, Weekly, WithWeekStart(time.Sunday))
+	if expected := time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("NextPeriod() = %v, expected %v", next, expected)
+	}
+
+	tokyo := time.FixedZone("JST", 9*3600)
+	late := time.Date(2024, time.March, 31, 20, 0, 0, 0, time.UTC)
+
+	month := StartOfPeriod(late, Monthly, WithLocation(tokyo))
+	if expected := time.Date(2024, time.April, 1, 0, 0, 0, 0, tokyo); !month.Equal(expected) {
+		t.Errorf("StartOfPeriod() = %v, expected %v", month, expected)
+	}
+}
+
+// TestBusinessDaysBetweenCalendar tests that WithCalendar supplies the
+// weekend and holidays.
+func TestBusinessDaysBetweenCalendar(t *testing.T) {
+	cal := NewBusinessCalendar(NewCivilDate(2024, time.March, 14))
+	cal.SetWeekend(time.Friday, time.Saturday)
+
+	start, end := NewCivilDate(2024, time.March, 10), NewCivilDate(2024, time.March, 16)
+	extra := []CivilDate{NewCivilDate(2024, time.March, 10)}
+
+	if actual := BusinessDaysBetween(start, end, extra, WithCalendar(cal)); actual != 3 {
+		t.Errorf("BusinessDaysBetween() = %d, expected 3", actual)
+	}
+	if actual := BusinessDaysBetween(start, end, extra); actual != 5 {
+		t.Errorf("BusinessDaysBetween() = %d, expected 5", actual)
+	}
+}
+
+// TestParseDeadline tests that ParseDeadline takes the current time from the
+// clock option.
+func TestParseDeadline(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.March, 13, 9, 0, 0, 0, time.UTC))
+
+	actual, err := ParseDeadline("EOD", WithClock(clock), WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("ParseDeadline() returned error: %v", err)
+	}
+
+	if expected := time.Date(2024, time.March, 13, EndOfBusinessHour, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("ParseDeadline() = %v, expected %v", actual, expected)
+	}
+}
+
+// TestSchedulerClock tests that a scheduler created with WithClock waits on
+// that clock.
+func TestSchedulerClock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.March, 13, 9, 0, 0, 0, time.UTC))
+	s := NewScheduler(context.Background(), WithClock(clock))
+	defer s.Stop(context.Background())
+
+	ran := make(chan struct{})
+	s.At(clock.Now().Add(time.Hour), func(context.Context) { close(ran) })
+
+	clock.BlockUntil(1)
+	select {
+	case <-ran:
+		t.Fatal("At() ran before the clock reached its time")
+	default:
+	}
+
+	clock.Advance(time.Hour)
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("At() did not run after the clock was advanced")
+	}
+}
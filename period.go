@@ -0,0 +1,190 @@
+package temporalis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is a calendar-aware amount of time such as "1 month and 2 days".
+// Unlike time.Duration, the length of a period depends on where it is
+// applied: one month after January 31 is February 28 or 29, and one day
+// across a DST transition is 23 or 25 hours. The Time component holds the
+// exact part of the period (hours, minutes, seconds).
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+	Time   time.Duration
+}
+
+// IsZero reports whether every component of the period is zero.
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// Negate returns the period with every component negated.
+func (p Period) Negate() Period {
+	return Period{Years: -p.Years, Months: -p.Months, Days: -p.Days, Time: -p.Time}
+}
+
+// AddTo returns t advanced by the period. The calendar components are applied
+// first with time.Time.AddDate, followed by the exact Time component.
+func (p Period) AddTo(t time.Time) time.Time {
+	return t.AddDate(p.Years, p.Months, p.Days).Add(p.Time)
+}
+
+// String returns the period in ISO 8601 notation, such as "P1Y2M3DT4H5M6S".
+// The zero period is "P0D". Components are written with the sign they carry,
+// so a negated period looks like "P-1M".
+func (p Period) String() string {
+	if p.IsZero() {
+		return "P0D"
+	}
+
+	var b strings.Builder
+	b.WriteByte('P')
+
+	for _, part := range []struct {
+		n    int
+		unit byte
+	}{{p.Years, 'Y'}, {p.Months, 'M'}, {p.Days, 'D'}} {
+		if part.n != 0 {
+			b.WriteString(strconv.Itoa(part.n))
+			b.WriteByte(part.unit)
+		}
+	}
+
+	if p.Time == 0 {
+		return b.String()
+	}
+
+	b.WriteByte('T')
+
+	d := p.Time
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+
+	if hours != 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes != 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if d != 0 {
+		b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+		b.WriteByte('S')
+	}
+
+	return b.String()
+}
+
+// ParsePeriod parses an ISO 8601 duration such as "P1Y2M10DT2H30M", "P2W" or
+// "PT0.5S". Weeks are converted to days, a leading "-" negates the whole
+// period, and individual components may carry their own sign. Only the
+// seconds component may have a fraction.
+func ParsePeriod(s string) (Period, error) {
+	var p Period
+
+	str := strings.TrimSpace(s)
+	negate := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+
+	if len(str) < 2 || (str[0] != 'P' && str[0] != 'p') {
+		return Period{}, syntaxError("ParsePeriod", s, "")
+	}
+	str = strings.ToUpper(str[1:])
+
+	inTime, timeFields := false, 0
+	for str != "" {
+		if str[0] == 'T' {
+			if inTime {
+				return Period{}, syntaxError("ParsePeriod", s, "")
+			}
+			inTime = true
+			str = str[1:]
+			continue
+		}
+		if inTime {
+			timeFields++
+		}
+
+		end := strings.IndexAny(str, "YMWDHS")
+		if end <= 0 {
+			return Period{}, syntaxError("ParsePeriod", s, "")
+		}
+
+		number, unit := str[:end], str[end]
+		str = str[end+1:]
+
+		if unit == 'S' && inTime {
+			seconds, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return Period{}, syntaxError("ParsePeriod", s, "invalid seconds")
+			}
+			if math.Abs(seconds) > math.MaxInt64/float64(time.Second) {
+				return Period{}, rangeError("ParsePeriod", s, "seconds")
+			}
+			p.Time += time.Duration(math.Round(seconds * float64(time.Second)))
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if errors.Is(err, strconv.ErrRange) {
+			return Period{}, rangeError("ParsePeriod", s, strconv.Quote(number))
+		}
+		if err != nil {
+			return Period{}, syntaxError("ParsePeriod", s, "invalid number "+strconv.Quote(number))
+		}
+
+		switch {
+		case !inTime && unit == 'Y':
+			p.Years += n
+		case !inTime && unit == 'M':
+			p.Months += n
+		case !inTime && unit == 'W':
+			p.Days += 7 * n
+		case !inTime && unit == 'D':
+			p.Days += n
+		case inTime && unit == 'H':
+			p.Time += time.Duration(n) * time.Hour
+		case inTime && unit == 'M':
+			p.Time += time.Duration(n) * time.Minute
+		default:
+			return Period{}, syntaxError("ParsePeriod", s, "unexpected unit "+strconv.QuoteRune(rune(unit)))
+		}
+	}
+
+	// ISO 8601 requires at least one component after the designator T.
+	if inTime && timeFields == 0 {
+		return Period{}, syntaxError("ParsePeriod", s, "no time components after T")
+	}
+
+	if negate {
+		p = p.Negate()
+	}
+
+	return p, nil
+}
+
+// MarshalText implements encoding.TextMarshaler using ISO 8601 notation.
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ISO 8601 notation.
+func (p *Period) UnmarshalText(data []byte) error {
+	parsed, err := ParsePeriod(string(data))
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestParsePeriod checks ISO 8601 parsing and that String produces the
+// canonical form of the parsed period.
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  Period
+		canonical string
+	}{
+		{"P1Y2M3DT4H5M6S", Period{1, 2, 3, 4*time.Hour + 5*time.Minute + 6*time.Second}, "P1Y2M3DT4H5M6S"},
+		{"P2W", Period{Days: 14}, "P14D"},
+		{"PT0.5S", Period{Time: 500 * time.Millisecond}, "PT0.5S"},
+		{"-P1M", Period{Months: -1}, "P-1M"},
+		{"P0D", Period{}, "P0D"},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePeriod(test.input)
+		if err != nil {
+			t.Errorf("ParsePeriod(%q) returned error: %v", test.input, err)
+			continue
+		}
+
+		if p != test.expected || p.String() != test.canonical {
+			t.Errorf("ParsePeriod(%q) = %+v (%s), expected %+v (%s)", test.input, p, p, test.expected, test.canonical)
+		}
+	}
+
+	for _, input := range []string{"", "P", "1D", "PT1D", "P1H", "P1.5D", "PTT1H", "PT", "P1DT", "-PT"} {
+		if _, err := ParsePeriod(input); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ParsePeriod(%q) error = %v, expected %v", input, err, ErrSyntax)
+		}
+	}
+}
+
+// TestPeriodScan checks the database/sql round trip, including NULL handling.
+func TestPeriodScan(t *testing.T) {
+	in := Period{Months: 1, Days: 2}
+
+	v, err := in.Value()
+	if err != nil {
+		t.Fatalf("Value() returned error: %v", err)
+	}
+
+	var out NullPeriod
+	if err := out.Scan([]byte(v.(string))); err != nil || !out.Valid || out.Period != in {
+		t.Errorf("Scan(%v) = %+v, %v", v, out, err)
+	}
+
+	if err := out.Scan(nil); err != nil || out.Valid {
+		t.Errorf("Scan(nil) = %+v, %v, expected an invalid value", out, err)
+	}
+
+	if v, _ := out.Value(); v != nil {
+		t.Errorf("Value() of NULL = %v, expected nil", v)
+	}
+}
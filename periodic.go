@@ -0,0 +1,213 @@
+package temporalis
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// PeriodicSeries predicts a quasi-periodic event, such as high tide or a
+// bus that leaves roughly every twenty minutes, from instants at which it
+// was observed. It fits a period and an epoch to the observations by least
+// squares, tolerating observations that are missing, and keeps track of how
+// far the observations stray from the fit so that predictions can come with
+// a window:
+//
+//	tides, err := temporalis.NewPeriodicSeries(highTides...)
+//	if err != nil {
+//		return err
+//	}
+//	next := tides.Window(time.Now()) // the next high tide, give or take
+//
+// A PeriodicSeries is a Recurrence, so it can drive a Scheduler. It is not
+// safe for concurrent use while observations are being added.
+type PeriodicSeries struct {
+	events []time.Time
+
+	epoch  time.Time
+	period time.Duration
+	// drift is the largest distance of an observation from the fit, and
+	// periodError the standard error of the period, in seconds.
+	drift       time.Duration
+	periodError float64
+}
+
+// NewPeriodicSeries returns a series fitted to the given observations, in
+// any order. It returns an error wrapping ErrOutOfRange if there are fewer
+// than two distinct observations.
+func NewPeriodicSeries(events ...time.Time) (*PeriodicSeries, error) {
+	s := &PeriodicSeries{}
+	if err := s.Add(events...); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Add adds observations and fits the series again. On error the series is
+// left unchanged.
+func (s *PeriodicSeries) Add(events ...time.Time) error {
+	all := append(slices.Clone(s.events), events...)
+	slices.SortFunc(all, time.Time.Compare)
+	all = slices.CompactFunc(all, time.Time.Equal)
+
+	if len(all) < 2 {
+		return detailed(ErrOutOfRange, "a periodic series needs at least two distinct events")
+	}
+
+	fitted := PeriodicSeries{events: all}
+	fitted.fit()
+	*s = fitted
+
+	return nil
+}
+
+// Period returns the fitted period.
+func (s *PeriodicSeries) Period() time.Duration {
+	return s.period
+}
+
+// Epoch returns the fitted instant of the first observed cycle. Occurrences
+// are predicted at the epoch plus whole multiples of the period.
+func (s *PeriodicSeries) Epoch() time.Time {
+	return s.epoch
+}
+
+// Drift returns how far the observation furthest from the fitted schedule
+// is from it, which is the least uncertainty of a prediction.
+func (s *PeriodicSeries) Drift() time.Duration {
+	return s.drift
+}
+
+// Phase returns how far t is into its cycle, as a fraction from 0 at an
+// occurrence up to 1 at the next, such as how far a tide has come from high
+// water.
+func (s *PeriodicSeries) Phase(t time.Time) float64 {
+	cycles := float64(t.Sub(s.epoch)) / float64(s.period)
+
+	return cycles - math.Floor(cycles)
+}
+
+// Next returns the first predicted occurrence strictly after the given time.
+func (s *PeriodicSeries) Next(after time.Time) time.Time {
+	return s.at(s.cycleAfter(after))
+}
+
+// Window returns the interval in which the first occurrence after the given
+// time is expected: the prediction, give or take the drift of the
+// observations plus the uncertainty of the period over the cycles since the
+// last observation, or before the first.
+func (s *PeriodicSeries) Window(after time.Time) Interval {
+	k := s.cycleAfter(after)
+	t := s.at(k)
+
+	// The period is known least well far from the observed cycles.
+	last := math.Round(float64(s.events[len(s.events)-1].Sub(s.epoch)) / float64(s.period))
+	cycles := math.Max(float64(k)-last, -float64(k))
+	margin := s.drift + time.Duration(math.Max(cycles, 0)*s.periodError*float64(time.Second))
+
+	return Interval{Start: t.Add(-margin), End: t.Add(margin)}
+}
+
+// cycleAfter returns the number of the first cycle predicted after the
+// given time.
+func (s *PeriodicSeries) cycleAfter(after time.Time) int64 {
+	k := int64(math.Floor(float64(after.Sub(s.epoch))/float64(s.period))) + 1
+	for !s.at(k).After(after) {
+		k++
+	}
+	for s.at(k - 1).After(after) {
+		k--
+	}
+
+	return k
+}
+
+// at returns the predicted instant of cycle k.
+func (s *PeriodicSeries) at(k int64) time.Time {
+	return s.epoch.Add(time.Duration(k) * s.period)
+}
+
+// fit fits the period and epoch to the events by least squares. It numbers
+// the events by cycle with a first estimate of the period, the median gap
+// between events, so that missing observations do not distort the fit, and
+// then numbers and fits them again with the improved period.
+func (s *PeriodicSeries) fit() {
+	first := s.events[0]
+
+	y := make([]float64, len(s.events))
+	gaps := make([]float64, 0, len(s.events)-1)
+	for i, t := range s.events {
+		y[i] = t.Sub(first).Seconds()
+		if i > 0 {
+			gaps = append(gaps, y[i]-y[i-1])
+		}
+	}
+	slices.Sort(gaps)
+
+	period, offset := gaps[len(gaps)/2], 0.0
+	x := make([]float64, len(s.events))
+	for range 2 {
+		for i := range y {
+			x[i] = math.Round((y[i] - offset) / period)
+		}
+		slope, intercept := fitLine(x, y)
+		if slope <= 0 {
+			break
+		}
+		period, offset = slope, intercept
+	}
+
+	var squares float64
+	var drift float64
+	for i := range y {
+		r := math.Abs(y[i] - offset - period*x[i])
+		squares += r * r
+		drift = math.Max(drift, r)
+	}
+
+	// The standard error of the slope needs a degree of freedom to spare.
+	if n := len(y); n > 2 {
+		var mean float64
+		for _, k := range x {
+			mean += k / float64(n)
+		}
+		var spread float64
+		for _, k := range x {
+			spread += (k - mean) * (k - mean)
+		}
+		if spread > 0 {
+			s.periodError = math.Sqrt(squares / float64(n-2) / spread)
+		}
+	}
+
+	// Move the epoch to the first observed cycle.
+	s.epoch = first.Add(time.Duration((offset + period*x[0]) * float64(time.Second)))
+	s.period = time.Duration(period * float64(time.Second))
+	s.drift = time.Duration(drift * float64(time.Second))
+}
+
+// fitLine returns the slope and intercept of the least-squares line through
+// the points (x[i], y[i]).
+func fitLine(x, y []float64) (slope, intercept float64) {
+	var mx, my float64
+	for i := range x {
+		mx += x[i]
+		my += y[i]
+	}
+	mx /= float64(len(x))
+	my /= float64(len(y))
+
+	var sxy, sxx float64
+	for i := range x {
+		sxy += (x[i] - mx) * (y[i] - my)
+		sxx += (x[i] - mx) * (x[i] - mx)
+	}
+	if sxx == 0 {
+		return 0, my
+	}
+
+	slope = sxy / sxx
+
+	return slope, my - slope*mx
+}
@@ -0,0 +1,77 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestPeriodicSeries fits noisy high tides with a missing observation and
+// checks the predictions.
+func TestPeriodicSeries(t *testing.T) {
+	const period = 12*time.Hour + 25*time.Minute + 14*time.Second
+	start := time.Date(2024, time.May, 1, 3, 10, 0, 0, time.UTC)
+	noise := []time.Duration{2 * time.Minute, -3 * time.Minute, time.Minute, 0, -2 * time.Minute, 3 * time.Minute, -time.Minute, 2 * time.Minute}
+
+	var events []time.Time
+	for i, n := range noise {
+		if i == 3 {
+			continue // not observed
+		}
+		events = append(events, start.Add(time.Duration(i)*period+n))
+	}
+
+	s, err := NewPeriodicSeries(events...)
+	if err != nil {
+		t.Fatalf("NewPeriodicSeries() returned error: %v", err)
+	}
+
+	if d := (s.Period() - period).Abs(); d > time.Minute {
+		t.Errorf("Period() = %v, expected about %v", s.Period(), period)
+	}
+	if d := s.Epoch().Sub(start).Abs(); d > 3*time.Minute {
+		t.Errorf("Epoch() = %v, expected about %v", s.Epoch(), start)
+	}
+	if s.Drift() < time.Minute || s.Drift() > 4*time.Minute {
+		t.Errorf("Drift() = %v, expected a few minutes", s.Drift())
+	}
+
+	after := start.Add(20*period + period/2)
+	expected := start.Add(21 * period)
+	if next := s.Next(after); next.Sub(expected).Abs() > 10*time.Minute {
+		t.Errorf("Next(%v) = %v, expected about %v", after, next, expected)
+	}
+
+	w := s.Window(after)
+	if !w.Contains(expected) {
+		t.Errorf("Window(%v) = %v, expected to contain %v", after, w, expected)
+	}
+	if near := s.Window(start.Add(5 * period)); near.Duration() >= w.Duration() {
+		t.Errorf("Window() within the observations = %v, expected narrower than %v", near, w)
+	}
+
+	// A PeriodicSeries is a Recurrence.
+	occurrences := Occurrences(s, after, 3)
+	if len(occurrences) != 3 || occurrences[2].Sub(occurrences[0]) != 2*s.Period() {
+		t.Errorf("Occurrences() = %v, expected 3 a period apart", occurrences)
+	}
+
+	if phase := s.Phase(s.Epoch().Add(s.Period() / 4)); phase < 0.249 || phase > 0.251 {
+		t.Errorf("Phase() = %v, expected 0.25", phase)
+	}
+
+	if err := s.Add(start.Add(8*period), start.Add(9*period)); err != nil || len(s.events) != 9 {
+		t.Errorf("Add() = %v with %d events, expected nil with 9", err, len(s.events))
+	}
+}
+
+// TestPeriodicSeriesErrors tests that a series needs two distinct events.
+func TestPeriodicSeriesErrors(t *testing.T) {
+	now := time.Now()
+
+	for _, events := range [][]time.Time{nil, {now}, {now, now}} {
+		if _, err := NewPeriodicSeries(events...); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("NewPeriodicSeries(%v) error = %v, expected ErrOutOfRange", events, err)
+		}
+	}
+}
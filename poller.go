@@ -0,0 +1,107 @@
+package temporalis
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AdaptivePoller computes polling intervals that adapt to how often the polled
+// resource changes. Every poll that observes no change multiplies the interval
+// by Factor, up to Max; a poll that observes a change resets it to Min. This
+// keeps latency low while something is happening and load low while idle.
+// A Min below minPollInterval, including zero, is raised to it so that the
+// poller never spins.
+type AdaptivePoller struct {
+	Min    time.Duration
+	Max    time.Duration
+	Factor float64
+
+	mu      sync.Mutex
+	current time.Duration
+}
+
+// minPollInterval is the shortest interval an AdaptivePoller waits.
+const minPollInterval = time.Millisecond
+
+// NewAdaptivePoller returns a poller that starts at min, doubles its interval
+// on every unchanged poll and never exceeds max. If max is less than min, it
+// is raised to min. NewAdaptivePoller panics if min is not positive.
+func NewAdaptivePoller(min, max time.Duration) *AdaptivePoller {
+	if min <= 0 {
+		panic("temporalis: non-positive minimum interval for NewAdaptivePoller")
+	}
+	if max < min {
+		max = min
+	}
+
+	return &AdaptivePoller{Min: min, Max: max, Factor: 2, current: min}
+}
+
+// Interval returns the interval to wait before the next poll.
+func (p *AdaptivePoller) Interval() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current < p.min() {
+		p.current = p.min()
+	}
+
+	return p.current
+}
+
+// min returns Min, raised to minPollInterval.
+func (p *AdaptivePoller) min() time.Duration {
+	return max(p.Min, minPollInterval)
+}
+
+// Observe records the outcome of a poll and returns the interval to wait
+// before the next one. A changed poll resets the interval to Min; an
+// unchanged poll widens it by Factor, capped at Max.
+func (p *AdaptivePoller) Observe(changed bool) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if changed || p.current < p.min() {
+		p.current = p.min()
+		return p.current
+	}
+
+	factor := p.Factor
+	if factor <= 1 {
+		factor = 2
+	}
+
+	next := time.Duration(float64(p.current) * factor)
+	if next > p.Max || next < p.current {
+		next = max(p.Max, p.current)
+	}
+	p.current = next
+
+	return p.current
+}
+
+// Reset sets the interval back to Min, for example after an external
+// notification suggests the resource is about to change.
+func (p *AdaptivePoller) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = p.min()
+}
+
+// Run calls poll repeatedly, waiting the adaptive interval between calls,
+// until ctx is cancelled or poll returns an error. The first call is made
+// immediately. Run returns the error from poll or the context error.
+func (p *AdaptivePoller) Run(ctx context.Context, poll func(ctx context.Context) (changed bool, err error)) error {
+	for {
+		changed, err := poll(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := SleepContext(ctx, p.Observe(changed)); err != nil {
+			return err
+		}
+	}
+}
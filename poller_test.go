@@ -0,0 +1,47 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAdaptivePoller checks that unchanged polls widen the interval up to the
+// maximum and that a change resets it to the minimum.
+func TestAdaptivePoller(t *testing.T) {
+	p := NewAdaptivePoller(time.Second, 5*time.Second)
+
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := p.Observe(false); got != want {
+			t.Errorf("Observe(false) #%d = %v, expected %v", i, got, want)
+		}
+	}
+
+	if got := p.Observe(true); got != time.Second {
+		t.Errorf("Observe(true) = %v, expected %v", got, time.Second)
+	}
+}
+
+// TestAdaptivePollerZeroMin checks that a zero minimum is rejected by the
+// constructor and raised to minPollInterval in a literal.
+func TestAdaptivePollerZeroMin(t *testing.T) {
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("NewAdaptivePoller(0, 1s) did not panic")
+			}
+		}()
+		NewAdaptivePoller(0, time.Second)
+	}()
+
+	p := &AdaptivePoller{Max: time.Second}
+	if got := p.Interval(); got != minPollInterval {
+		t.Errorf("Interval() = %v, expected %v", got, minPollInterval)
+	}
+	if got := p.Observe(false); got != 2*minPollInterval {
+		t.Errorf("Observe(false) = %v, expected %v", got, 2*minPollInterval)
+	}
+	if got := p.Observe(true); got != minPollInterval {
+		t.Errorf("Observe(true) = %v, expected %v", got, minPollInterval)
+	}
+}
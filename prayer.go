@@ -0,0 +1,190 @@
+package temporalis
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// PrayerMethod is a convention for computing Islamic prayer times. The
+// methods in common use are provided as variables, such as MethodMWL; to
+// change the school of Asr or the high-latitude rule, copy one and modify
+// it.
+type PrayerMethod struct {
+	Name string
+	// FajrAngle is the depression of the sun below the horizon, in degrees,
+	// at which Fajr begins.
+	FajrAngle float64
+	// IshaAngle is the depression of the sun at which Isha begins. If it is
+	// zero, Isha begins IshaInterval after Maghrib.
+	IshaAngle    float64
+	IshaInterval time.Duration
+	// MaghribAngle is the depression of the sun at which Maghrib begins. If
+	// it is zero, Maghrib begins at sunset.
+	MaghribAngle float64
+	// AsrShadow is the length of the shadow of an object at the beginning
+	// of Asr, in multiples of its height, in addition to its length at
+	// noon: 1 in the Shafi'i, Maliki and Hanbali schools and 2 in the Hanafi
+	// school. Zero means 1.
+	AsrShadow int
+	// HighLatitude is how Fajr and Isha are found where the sun does not go
+	// deep enough below the horizon for the angles.
+	HighLatitude HighLatitudeRule
+}
+
+// The major calculation methods.
+var (
+	// MethodMWL is the method of the Muslim World League, used in Europe and
+	// the Far East.
+	MethodMWL = PrayerMethod{Name: "Muslim World League", FajrAngle: 18, IshaAngle: 17}
+	// MethodISNA is the method of the Islamic Society of North America.
+	MethodISNA = PrayerMethod{Name: "Islamic Society of North America", FajrAngle: 15, IshaAngle: 15}
+	// MethodEgypt is the method of the Egyptian General Authority of
+	// Survey, used in Africa, Syria, Iraq and Lebanon.
+	MethodEgypt = PrayerMethod{Name: "Egyptian General Authority of Survey", FajrAngle: 19.5, IshaAngle: 17.5}
+	// MethodMakkah is the Umm al-Qura method of Saudi Arabia. During
+	// Ramadan, Isha is customarily two hours after Maghrib instead of 90
+	// minutes.
+	MethodMakkah = PrayerMethod{Name: "Umm al-Qura University, Makkah", FajrAngle: 18.5, IshaInterval: 90 * time.Minute}
+	// MethodKarachi is the method of the University of Islamic Sciences,
+	// Karachi, used in Pakistan, Bangladesh, India and Afghanistan.
+	MethodKarachi = PrayerMethod{Name: "University of Islamic Sciences, Karachi", FajrAngle: 18, IshaAngle: 18}
+	// MethodTehran is the method of the Institute of Geophysics, University
+	// of Tehran.
+	MethodTehran = PrayerMethod{Name: "Institute of Geophysics, University of Tehran", FajrAngle: 17.7, IshaAngle: 14, MaghribAngle: 4.5}
+	// MethodJafari is the method of the Shia Ithna Ashari, Leva Institute,
+	// Qum.
+	MethodJafari = PrayerMethod{Name: "Shia Ithna Ashari, Leva Institute, Qum", FajrAngle: 16, IshaAngle: 14, MaghribAngle: 4}
+)
+
+// HighLatitudeRule selects how Fajr and Isha are placed at high latitudes,
+// where in summer the sun may not sink as far below the horizon as the
+// angles of the method, or only very briefly. Each rule limits the time from
+// Fajr to sunrise, and from sunset to Isha, to a portion of the night from
+// sunset to sunrise, and applies the limit whenever the angle gives a longer
+// time or none at all.
+type HighLatitudeRule int
+
+const (
+	// MiddleOfTheNight limits the portions to half the night.
+	MiddleOfTheNight HighLatitudeRule = iota
+	// OneSeventhOfTheNight limits the portions to a seventh of the night.
+	OneSeventhOfTheNight
+	// AngleBased limits each portion to a sixtieth of the night for every
+	// degree of its angle, so 18 degrees gives 0.3 of the night.
+	AngleBased
+	// NoHighLatitudeRule uses the angles as they are. PrayerTimes fails
+	// when the sun does not reach them.
+	NoHighLatitudeRule
+)
+
+// Prayers holds the times of the five daily prayers, and of sunrise, which
+// ends the time for Fajr. The times are in UTC.
+type Prayers struct {
+	Fajr    time.Time
+	Sunrise time.Time
+	Dhuhr   time.Time
+	Asr     time.Time
+	Maghrib time.Time
+	Isha    time.Time
+}
+
+// In returns the times converted to loc.
+func (p Prayers) In(loc *time.Location) Prayers {
+	return Prayers{
+		Fajr:    p.Fajr.In(loc),
+		Sunrise: p.Sunrise.In(loc),
+		Dhuhr:   p.Dhuhr.In(loc),
+		Asr:     p.Asr.In(loc),
+		Maghrib: p.Maghrib.In(loc),
+		Isha:    p.Isha.In(loc),
+	}
+}
+
+// PrayerTimes returns the prayer times on the given date at the given
+// latitude and longitude, in degrees north and east, computed with method.
+// The date is the local date at that longitude. Dhuhr is at solar noon;
+// mosques often add a minute or two as a precaution, as well as to the other
+// times, which are to the second but only as accurate as the sunrise
+// equation, about a minute. It returns an error wrapping ErrOutOfRange when
+// the sun does not rise or set on the date, or when it does not reach the
+// angle of the method and method.HighLatitude is NoHighLatitudeRule.
+func PrayerTimes(date CivilDate, lat, lon float64, method PrayerMethod) (Prayers, error) {
+	const rad = math.Pi / 180
+
+	transit, sinDecl := solarDay(date, lon)
+
+	daylight, ok := solarHourAngle(lat, sinDecl, -0.833)
+	if !ok {
+		return Prayers{}, fmt.Errorf("no sunrise or sunset on %v at latitude %v: %w", date, lat, ErrOutOfRange)
+	}
+	night := 1 - 2*daylight
+
+	// twilight returns the time at which the sun is angle degrees below
+	// the horizon before sunrise, for a sign of -1, or after sunset, for 1,
+	// limited by the high-latitude rule of the method.
+	twilight := func(angle, sign float64) (float64, error) {
+		hour, ok := solarHourAngle(lat, sinDecl, -angle)
+
+		var portion float64
+		switch method.HighLatitude {
+		case MiddleOfTheNight:
+			portion = 1.0 / 2
+		case OneSeventhOfTheNight:
+			portion = 1.0 / 7
+		case AngleBased:
+			portion = angle / 60
+		default:
+			if !ok {
+				return 0, fmt.Errorf("sun does not reach %v degrees below the horizon on %v at latitude %v: %w", angle, date, lat, ErrOutOfRange)
+			}
+			return transit + sign*hour, nil
+		}
+
+		if limit := daylight + portion*night; !ok || hour > limit {
+			hour = limit
+		}
+
+		return transit + sign*hour, nil
+	}
+
+	fajr, err := twilight(method.FajrAngle, -1)
+	if err != nil {
+		return Prayers{}, err
+	}
+
+	maghrib := transit + daylight
+	if method.MaghribAngle != 0 {
+		if maghrib, err = twilight(method.MaghribAngle, 1); err != nil {
+			return Prayers{}, err
+		}
+	}
+
+	var isha float64
+	if method.IshaAngle != 0 {
+		if isha, err = twilight(method.IshaAngle, 1); err != nil {
+			return Prayers{}, err
+		}
+	} else {
+		isha = maghrib + method.IshaInterval.Hours()/24
+	}
+
+	// Asr begins when the shadow of an object is its length at noon plus
+	// AsrShadow times its height.
+	shadow := float64(max(method.AsrShadow, 1))
+	noonAngle := math.Abs(lat - math.Asin(sinDecl)/rad)
+	asrAltitude := math.Atan(1/(shadow+math.Tan(noonAngle*rad))) / rad
+	asr, ok := solarHourAngle(lat, sinDecl, asrAltitude)
+	if !ok {
+		return Prayers{}, fmt.Errorf("no Asr on %v at latitude %v: %w", date, lat, ErrOutOfRange)
+	}
+
+	return Prayers{
+		Fajr:    fromJ2000Days(fajr),
+		Sunrise: fromJ2000Days(transit - daylight),
+		Dhuhr:   fromJ2000Days(transit),
+		Asr:     fromJ2000Days(transit + asr),
+		Maghrib: fromJ2000Days(maghrib),
+		Isha:    fromJ2000Days(isha),
+	}, nil
+}
@@ -0,0 +1,115 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestPrayerTimes tests the order of the prayers and their relation to the
+// solar events they are defined by.
+func TestPrayerTimes(t *testing.T) {
+	date := CivilDate{2024, time.March, 15}
+	const lat, lon = 21.4225, 39.8262 // Mecca
+
+	for _, method := range []PrayerMethod{MethodMWL, MethodISNA, MethodEgypt, MethodMakkah, MethodKarachi, MethodTehran, MethodJafari} {
+		p, err := PrayerTimes(date, lat, lon, method)
+		if err != nil {
+			t.Errorf("PrayerTimes(%s) returned error: %v", method.Name, err)
+			continue
+		}
+
+		times := []time.Time{p.Fajr, p.Sunrise, p.Dhuhr, p.Asr, p.Maghrib, p.Isha}
+		for i := 1; i < len(times); i++ {
+			if !times[i-1].Before(times[i]) {
+				t.Errorf("PrayerTimes(%s) = %+v, expected the times in order", method.Name, p)
+				break
+			}
+		}
+
+		if sunrise, _ := Sunrise.On(date, lat, lon); !p.Sunrise.Equal(sunrise) {
+			t.Errorf("PrayerTimes(%s).Sunrise = %v, expected %v", method.Name, p.Sunrise, sunrise)
+		}
+		if noon, _ := SolarNoon.On(date, lat, lon); !p.Dhuhr.Equal(noon) {
+			t.Errorf("PrayerTimes(%s).Dhuhr = %v, expected %v", method.Name, p.Dhuhr, noon)
+		}
+	}
+
+	// Mecca is at UTC+3. With the sun near the equator and the equation of
+	// time at nine minutes, solar noon is at 12:30, Fajr at 18.5 degrees
+	// around 05:14, Asr around 15:54 and sunset around 18:30.
+	p, _ := PrayerTimes(date, lat, lon, MethodMakkah)
+	p = p.In(time.FixedZone("AST", 3*60*60))
+	for _, test := range []struct {
+		name     string
+		actual   time.Time
+		expected time.Time
+	}{
+		{"Fajr", p.Fajr, time.Date(2024, 3, 15, 5, 14, 0, 0, p.Fajr.Location())},
+		{"Asr", p.Asr, time.Date(2024, 3, 15, 15, 54, 0, 0, p.Fajr.Location())},
+		{"Maghrib", p.Maghrib, time.Date(2024, 3, 15, 18, 30, 0, 0, p.Fajr.Location())},
+	} {
+		if test.actual.Sub(test.expected).Abs() > 3*time.Minute {
+			t.Errorf("%s = %v, expected about %v", test.name, test.actual, test.expected)
+		}
+	}
+	if p.Isha.Sub(p.Maghrib) != 90*time.Minute {
+		t.Errorf("Isha - Maghrib = %v, expected 90m", p.Isha.Sub(p.Maghrib))
+	}
+
+	hanafi := MethodMakkah
+	hanafi.AsrShadow = 2
+	if h, _ := PrayerTimes(date, lat, lon, hanafi); !h.Asr.After(p.Asr) {
+		t.Errorf("Hanafi Asr = %v, expected after %v", h.Asr, p.Asr)
+	}
+}
+
+// TestPrayerTimesHighLatitude tests the high-latitude rules in London at
+// midsummer, when the sun does not sink 18 degrees below the horizon.
+func TestPrayerTimesHighLatitude(t *testing.T) {
+	date := CivilDate{2024, time.June, 21}
+	const lat, lon = 51.5074, -0.1278
+
+	sunrise, _ := Sunrise.On(date, lat, lon)
+	sunset, _ := Sunset.On(date, lat, lon)
+	night := sunrise.Add(24 * time.Hour).Sub(sunset)
+
+	tests := []struct {
+		rule    HighLatitudeRule
+		portion float64
+	}{
+		{MiddleOfTheNight, 1.0 / 2},
+		{OneSeventhOfTheNight, 1.0 / 7},
+		{AngleBased, 18.0 / 60},
+	}
+
+	for _, test := range tests {
+		method := MethodMWL
+		method.HighLatitude = test.rule
+
+		p, err := PrayerTimes(date, lat, lon, method)
+		if err != nil {
+			t.Errorf("PrayerTimes(%v) returned error: %v", test.rule, err)
+			continue
+		}
+
+		expected := sunrise.Add(-time.Duration(test.portion * float64(night)))
+		if p.Fajr.Sub(expected).Abs() > time.Minute {
+			t.Errorf("PrayerTimes(%v).Fajr = %v, expected %v", test.rule, p.Fajr, expected)
+		}
+		if !p.Isha.After(p.Maghrib) || p.Isha.After(p.Fajr.Add(24*time.Hour+time.Minute)) {
+			t.Errorf("PrayerTimes(%v).Isha = %v, expected during the night", test.rule, p.Isha)
+		}
+	}
+
+	method := MethodMWL
+	method.HighLatitude = NoHighLatitudeRule
+	if _, err := PrayerTimes(date, lat, lon, method); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("PrayerTimes() without a high-latitude rule error = %v, expected ErrOutOfRange", err)
+	}
+
+	// Tromsø has no sunset at midsummer.
+	if _, err := PrayerTimes(date, 69.6492, 18.9553, MethodMWL); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("PrayerTimes() in polar day error = %v, expected ErrOutOfRange", err)
+	}
+}
@@ -0,0 +1,226 @@
+package temporalis
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate of events. Allow admits an event only if it
+// can happen now, Reserve admits it at the earliest permitted time, and Wait
+// blocks until that time.
+type RateLimiter interface {
+	Allow() bool
+	Reserve() *Reservation
+	Wait(ctx context.Context) error
+}
+
+// Reservation is an event admitted by a RateLimiter for a future time.
+type Reservation struct {
+	at     time.Time
+	clock  Clock
+	cancel func()
+	once   sync.Once
+}
+
+// Time returns the time at which the event may happen.
+func (r *Reservation) Time() time.Time {
+	return r.at
+}
+
+// Delay returns how long the caller must wait before the event may happen,
+// or zero if it may happen now.
+func (r *Reservation) Delay() time.Duration {
+	return max(0, r.at.Sub(r.clock.Now()))
+}
+
+// Cancel gives the reservation back to the limiter, so that later events are
+// not delayed by it. Cancelling more than once has no further effect.
+func (r *Reservation) Cancel() {
+	r.once.Do(r.cancel)
+}
+
+// waitReservation sleeps until the reservation is due, cancelling it if ctx
+// ends first.
+func waitReservation(ctx context.Context, r *Reservation) error {
+	if err := sleepClock(ctx, r.clock, r.Delay()); err != nil {
+		r.Cancel()
+		return err
+	}
+
+	return nil
+}
+
+// TokenBucket is a RateLimiter that refills tokens at a steady rate up to a
+// burst size, and spends one token per event. It is safe for concurrent use.
+type TokenBucket struct {
+	// Clock provides the time. If nil, SystemClock is used. It must be set
+	// before the first event.
+	Clock Clock
+
+	rate   float64 // tokens per nanosecond
+	burst  float64
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a token bucket that admits n events per interval on
+// average, and up to burst events at once. The bucket starts full. It panics
+// if n, per or burst is not positive.
+func NewTokenBucket(n int, per time.Duration, burst int) *TokenBucket {
+	if n <= 0 || per <= 0 || burst <= 0 {
+		panic("temporalis: non-positive rate or burst for NewTokenBucket")
+	}
+
+	return &TokenBucket{
+		rate:   float64(n) / float64(per),
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Allow spends a token and reports true if one is available now.
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(clockOrSystem(b.Clock).Now())
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// Reserve spends a token, going into debt if none is available, and returns
+// the time at which the debt will have been repaid.
+func (b *TokenBucket) Reserve() *Reservation {
+	clock := clockOrSystem(b.Clock)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := clock.Now()
+	b.refill(now)
+	b.tokens--
+
+	at := now
+	if b.tokens < 0 {
+		at = now.Add(time.Duration(-b.tokens / b.rate))
+	}
+
+	return &Reservation{at: at, clock: clock, cancel: func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.tokens = min(b.tokens+1, b.burst)
+	}}
+}
+
+// Wait blocks until an event is admitted or ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	return waitReservation(ctx, b.Reserve())
+}
+
+// refill adds the tokens accrued since the last update. b.mu must be held.
+func (b *TokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = min(b.tokens+float64(now.Sub(b.last))*b.rate, b.burst)
+	}
+
+	if b.last.IsZero() || now.After(b.last) {
+		b.last = now
+	}
+}
+
+// SlidingWindowLimiter is a RateLimiter that admits at most a fixed number
+// of events within any window of the given length. Unlike a fixed window
+// that resets on the minute, it never admits twice the limit across a
+// window boundary. It is safe for concurrent use.
+type SlidingWindowLimiter struct {
+	// Clock provides the time. If nil, SystemClock is used. It must be set
+	// before the first event.
+	Clock Clock
+
+	limit  int
+	window time.Duration
+	mu     sync.Mutex
+	events []time.Time // admitted and reserved event times, in order
+}
+
+// NewSlidingWindowLimiter returns a limiter that admits limit events per
+// window. It panics if limit or window is not positive.
+func NewSlidingWindowLimiter(limit int, window time.Duration) *SlidingWindowLimiter {
+	if limit <= 0 || window <= 0 {
+		panic("temporalis: non-positive limit or window for NewSlidingWindowLimiter")
+	}
+
+	return &SlidingWindowLimiter{limit: limit, window: window}
+}
+
+// Allow records an event and reports true if it may happen now.
+func (w *SlidingWindowLimiter) Allow() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := clockOrSystem(w.Clock).Now()
+	if w.next(now).After(now) {
+		return false
+	}
+
+	w.events = append(w.events, now)
+
+	return true
+}
+
+// Reserve records an event at the earliest time the window allows.
+func (w *SlidingWindowLimiter) Reserve() *Reservation {
+	clock := clockOrSystem(w.Clock)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	at := w.next(clock.Now())
+	w.events = append(w.events, at)
+
+	return &Reservation{at: at, clock: clock, cancel: func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		if i := slices.IndexFunc(w.events, at.Equal); i >= 0 {
+			w.events = slices.Delete(w.events, i, i+1)
+		}
+	}}
+}
+
+// Wait blocks until an event is admitted or ctx is done.
+func (w *SlidingWindowLimiter) Wait(ctx context.Context) error {
+	return waitReservation(ctx, w.Reserve())
+}
+
+// next drops events that have left the window and returns the earliest
+// time, not before now, at which another event fits. w.mu must be held.
+func (w *SlidingWindowLimiter) next(now time.Time) time.Time {
+	expired := 0
+	for expired < len(w.events) && !w.events[expired].After(now.Add(-w.window)) {
+		expired++
+	}
+	w.events = w.events[expired:]
+
+	next := now
+	if n := len(w.events); n > 0 && w.events[n-1].After(next) {
+		next = w.events[n-1]
+	}
+
+	if n := len(w.events); n >= w.limit {
+		if free := w.events[n-w.limit].Add(w.window); free.After(next) {
+			next = free
+		}
+	}
+
+	return next
+}
@@ -0,0 +1,68 @@
+package temporalis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestTokenBucket checks the burst, the refill rate and reservations.
+func TestTokenBucket(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	b := NewTokenBucket(10, time.Second, 2)
+	b.Clock = clock
+
+	if !b.Allow() || !b.Allow() || b.Allow() {
+		t.Errorf("Allow() did not admit exactly the burst of 2")
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	if !b.Allow() {
+		t.Errorf("Allow() rejected an event after a token was refilled")
+	}
+
+	r := b.Reserve()
+	if d := r.Delay(); d != 100*time.Millisecond {
+		t.Errorf("Reserve().Delay() = %v, expected 100ms", d)
+	}
+
+	r.Cancel()
+	if d := b.Reserve().Delay(); d != 100*time.Millisecond {
+		t.Errorf("Reserve().Delay() after Cancel() = %v, expected 100ms", d)
+	}
+}
+
+// TestSlidingWindowLimiter checks that the limit applies to any window, and that
+// Wait sleeps on the clock until a slot frees up.
+func TestSlidingWindowLimiter(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	w := NewSlidingWindowLimiter(2, time.Minute)
+	w.Clock = clock
+
+	w.Allow()
+	clock.Advance(40 * time.Second)
+	w.Allow()
+
+	if w.Allow() {
+		t.Errorf("Allow() admitted a third event within the window")
+	}
+
+	clock.Advance(20 * time.Second)
+	if !w.Allow() {
+		t.Errorf("Allow() rejected an event after the first left the window")
+	}
+
+	done := make(chan error)
+	go func() { done <- w.Wait(context.Background()) }()
+
+	clock.BlockUntil(1)
+	clock.Advance(40 * time.Second)
+
+	if err := <-done; err != nil {
+		t.Errorf("Wait() = %v, expected nil", err)
+	}
+
+	if d := w.Reserve().Delay(); d != 20*time.Second {
+		t.Errorf("Reserve().Delay() = %v, expected 20s", d)
+	}
+}
@@ -0,0 +1,88 @@
+package temporalis
+
+import "time"
+
+// Recurrence produces the instants of a repeating event. Next returns the
+// first occurrence strictly after the given time, or the zero time if there
+// are no further occurrences.
+type Recurrence interface {
+	Next(after time.Time) time.Time
+}
+
+// RecurrenceFunc adapts an ordinary function to the Recurrence interface.
+type RecurrenceFunc func(after time.Time) time.Time
+
+// Next calls f(after).
+func (f RecurrenceFunc) Next(after time.Time) time.Time {
+	return f(after)
+}
+
+// EveryInterval returns a recurrence that fires every d, at the instants
+// start, start+d, start+2d and so on. It fires nothing if d is not positive.
+func EveryInterval(start time.Time, d time.Duration) Recurrence {
+	return RecurrenceFunc(func(after time.Time) time.Time {
+		if d <= 0 {
+			return time.Time{}
+		}
+
+		if after.Before(start) {
+			return start
+		}
+
+		n := after.Sub(start)/d + 1
+
+		return start.Add(n * d)
+	})
+}
+
+// Once returns a recurrence with a single occurrence at t.
+func Once(t time.Time) Recurrence {
+	return RecurrenceFunc(func(after time.Time) time.Time {
+		if after.Before(t) {
+			return t
+		}
+
+		return time.Time{}
+	})
+}
+
+// maxOccurrences bounds the number of occurrences returned by Occurrences and
+// OccurrencesBetween to protect against unbounded rules.
+const maxOccurrences = 100000
+
+// Occurrences returns up to n occurrences of r after the given time, in
+// order. Fewer are returned if the recurrence ends.
+func Occurrences(r Recurrence, after time.Time, n int) []time.Time {
+	var result []time.Time
+
+	for len(result) < n && len(result) < maxOccurrences {
+		next := r.Next(after)
+		if next.IsZero() || !next.After(after) {
+			break
+		}
+
+		result = append(result, next)
+		after = next
+	}
+
+	return result
+}
+
+// OccurrencesBetween returns the occurrences of r within the half-open
+// interval [start, end), in order.
+func OccurrencesBetween(r Recurrence, start, end time.Time) []time.Time {
+	var result []time.Time
+
+	after := start.Add(-time.Nanosecond)
+	for len(result) < maxOccurrences {
+		next := r.Next(after)
+		if next.IsZero() || !next.Before(end) || !next.After(after) {
+			break
+		}
+
+		result = append(result, next)
+		after = next
+	}
+
+	return result
+}
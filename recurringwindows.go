@@ -0,0 +1,74 @@
+package temporalis
+
+import (
+	"slices"
+	"time"
+)
+
+// RecurringWindow is a daily window of wall-clock time on some days of the
+// week, such as 09:00 to 17:00 on weekdays.
+type RecurringWindow struct {
+	// Hours is the daily window. A window that wraps around midnight
+	// starts on the selected day and runs into the next one. The zero
+	// value is the whole day.
+	Hours ClockRange
+	// Weekdays limits the window to the given days of the week. If empty,
+	// it applies every day.
+	Weekdays []time.Weekday
+}
+
+// RecurringWindows is a set of recurring windows in a time zone, such as
+// "weekdays 09:00 to 17:00 and Saturdays 10:00 to 14:00 in Europe/Berlin".
+// Windows that overlap count once.
+type RecurringWindows struct {
+	Windows []RecurringWindow
+	// Location is the zone of the wall-clock windows. If nil, the location
+	// of the start of the interval they are applied to is used.
+	Location *time.Location
+}
+
+// Intervals returns the parts of i that fall inside the windows, in order
+// and without overlaps.
+func (w RecurringWindows) Intervals(i Interval) []Interval {
+	if i.IsEmpty() {
+		return nil
+	}
+
+	loc := w.Location
+	if loc == nil {
+		loc = i.Start.Location()
+	}
+
+	// Windows that wrap around midnight reach into the first date from the
+	// day before.
+	var windows []Interval
+	last := DateOf(i.End.In(loc))
+	for d := DateOf(i.Start.In(loc)).AddDays(-1); !d.After(last); d = d.AddDays(1) {
+		for _, window := range w.Windows {
+			if len(window.Weekdays) > 0 && !slices.Contains(window.Weekdays, d.Weekday()) {
+				continue
+			}
+			for _, p := range window.Hours.shiftsOn(d, loc) {
+				if overlap, ok := p.Intersect(i); ok {
+					windows = append(windows, overlap)
+				}
+			}
+		}
+	}
+
+	return normalizeIntervals(windows)
+}
+
+// OverlapWithWindows returns how much of interval falls inside the windows,
+// for billing only the time spent in them, such as support hours charged
+// at a weekday rate. Time is measured as elapsed, so a window on the day
+// of a DST change may be an hour shorter or longer than its wall-clock
+// times suggest.
+func OverlapWithWindows(interval Interval, windows RecurringWindows) time.Duration {
+	var total time.Duration
+	for _, i := range windows.Intervals(interval) {
+		total += i.Duration()
+	}
+
+	return total
+}
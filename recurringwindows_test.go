@@ -0,0 +1,54 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestOverlapWithWindows tests overlapping windows, windows that wrap
+// around midnight and a time zone other than that of the interval.
+func TestOverlapWithWindows(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	windows := RecurringWindows{
+		Windows: []RecurringWindow{
+			{Hours: ClockRange{Start: NewTimeOfDay(9, 0, 0, 0), End: NewTimeOfDay(17, 0, 0, 0)}, Weekdays: weekdays},
+			{Hours: ClockRange{Start: NewTimeOfDay(16, 0, 0, 0), End: NewTimeOfDay(18, 0, 0, 0)}, Weekdays: weekdays},
+			{Hours: ClockRange{Start: NewTimeOfDay(22, 0, 0, 0), End: NewTimeOfDay(2, 0, 0, 0)}, Weekdays: []time.Weekday{time.Friday}},
+		},
+		Location: berlin,
+	}
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 3, day, hour, 0, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		name     string
+		interval Interval
+		expected time.Duration
+	}{
+		{"overlapping windows count once", Interval{Start: at(11, 8), End: at(11, 20)}, 9 * time.Hour},
+		{"Friday night into Saturday", Interval{Start: at(15, 20), End: at(16, 12)}, 4 * time.Hour},
+		{"from within the night", Interval{Start: at(16, 1), End: at(16, 3)}, time.Hour},
+		{"weekend", Interval{Start: at(16, 3), End: at(18, 0)}, 0},
+		{"in UTC", Interval{Start: at(11, 8).UTC(), End: at(11, 10).UTC()}, time.Hour},
+		{"empty", Interval{Start: at(11, 10), End: at(11, 10)}, 0},
+	}
+
+	for _, test := range tests {
+		if actual := OverlapWithWindows(test.interval, windows); actual != test.expected {
+			t.Errorf("OverlapWithWindows(%s) = %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+
+	parts := windows.Intervals(Interval{Start: at(15, 12), End: at(16, 12)})
+	expected := []Interval{{Start: at(15, 12), End: at(15, 18)}, {Start: at(15, 22), End: at(16, 2)}}
+	if !equalIntervals(parts, expected) {
+		t.Errorf("Intervals() = %v, expected %v", parts, expected)
+	}
+}
@@ -0,0 +1,69 @@
+package temporalis
+
+import (
+	"slices"
+	"time"
+)
+
+// QuietHours is a daily period during which a user should not be disturbed,
+// such as 22:00 to 07:00. The zero value has no quiet hours.
+type QuietHours struct {
+	// Range is the quiet period on the user's wall clock. It may wrap
+	// around midnight.
+	Range ClockRange
+	// Defer moves a reminder that falls in quiet hours to their end rather
+	// than their start, unless that would be after the event.
+	Defer bool
+}
+
+// period returns the quiet period, in loc, that contains t.
+func (q QuietHours) period(t time.Time, loc *time.Location) (Interval, bool) {
+	if q.Range == (ClockRange{}) {
+		return Interval{}, false
+	}
+
+	d := DateOf(t.In(loc))
+	for _, day := range []CivilDate{d.AddDays(-1), d} {
+		for _, i := range q.Range.shiftsOn(day, loc) {
+			if i.Contains(t) {
+				return i, true
+			}
+		}
+	}
+
+	return Interval{}, false
+}
+
+// ReminderTimes returns when to send reminders for an event starting at
+// eventStart, one for each offset before it, such as a week, a day and an
+// hour. The calendar components of the offsets are applied on the user's
+// wall clock in userLoc, or in the location of eventStart if userLoc is
+// nil, so that a reminder a day before a 09:00 meeting arrives at 09:00 the
+// day before even across a DST change. A reminder that falls in quiet hours
+// is moved earlier, to when they begin, or with quiet.Defer to when they end
+// if that is still before the event. The result is sorted, without
+// duplicates, and in userLoc.
+func ReminderTimes(eventStart time.Time, offsets []Period, userLoc *time.Location, quiet QuietHours) []time.Time {
+	if userLoc == nil {
+		userLoc = eventStart.Location()
+	}
+	start := eventStart.In(userLoc)
+
+	times := make([]time.Time, 0, len(offsets))
+	for _, offset := range offsets {
+		t := offset.Negate().AddTo(start)
+
+		if i, ok := quiet.period(t, userLoc); ok {
+			t = i.Start
+			if quiet.Defer && i.End.Before(start) {
+				t = i.End
+			}
+		}
+
+		times = append(times, t)
+	}
+
+	slices.SortFunc(times, time.Time.Compare)
+
+	return slices.CompactFunc(times, time.Time.Equal)
+}
@@ -0,0 +1,72 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestReminderTimes tests offsets across a DST change and reminders moved
+// out of quiet hours.
+func TestReminderTimes(t *testing.T) {
+	berlin, err1 := time.LoadLocation("Europe/Berlin")
+	newYork, err2 := time.LoadLocation("America/New_York")
+	if err1 != nil || err2 != nil {
+		t.Skip("time zone data not available")
+	}
+
+	// Monday after the clocks went forward on 31 March.
+	event := time.Date(2024, 4, 1, 9, 0, 0, 0, berlin)
+	offsets := []Period{{Days: 7}, {Days: 1}, {Time: time.Hour}, {Time: 10 * time.Hour}, {Time: 11 * time.Hour}}
+	night := ClockRange{Start: NewTimeOfDay(22, 0, 0, 0), End: NewTimeOfDay(7, 0, 0, 0)}
+
+	tests := []struct {
+		name     string
+		loc      *time.Location
+		quiet    QuietHours
+		expected []time.Time
+	}{
+		{"no quiet hours", nil, QuietHours{}, []time.Time{
+			time.Date(2024, 3, 25, 9, 0, 0, 0, berlin),
+			time.Date(2024, 3, 31, 9, 0, 0, 0, berlin),
+			time.Date(2024, 3, 31, 22, 0, 0, 0, berlin),
+			time.Date(2024, 3, 31, 23, 0, 0, 0, berlin),
+			time.Date(2024, 4, 1, 8, 0, 0, 0, berlin),
+		}},
+		// The reminders at 22:00 and 23:00 both move to 22:00.
+		{"quiet", berlin, QuietHours{Range: night}, []time.Time{
+			time.Date(2024, 3, 25, 9, 0, 0, 0, berlin),
+			time.Date(2024, 3, 31, 9, 0, 0, 0, berlin),
+			time.Date(2024, 3, 31, 22, 0, 0, 0, berlin),
+			time.Date(2024, 4, 1, 8, 0, 0, 0, berlin),
+		}},
+		{"deferred", berlin, QuietHours{Range: night, Defer: true}, []time.Time{
+			time.Date(2024, 3, 25, 9, 0, 0, 0, berlin),
+			time.Date(2024, 3, 31, 9, 0, 0, 0, berlin),
+			time.Date(2024, 4, 1, 7, 0, 0, 0, berlin),
+			time.Date(2024, 4, 1, 8, 0, 0, 0, berlin),
+		}},
+		// The event is at 03:00 in New York. The reminders a week and a day
+		// before are deferred to the morning, but the one an hour before
+		// cannot be and is sent the evening before.
+		{"elsewhere", newYork, QuietHours{Range: night, Defer: true}, []time.Time{
+			time.Date(2024, 3, 25, 7, 0, 0, 0, newYork),
+			time.Date(2024, 3, 31, 7, 0, 0, 0, newYork),
+			time.Date(2024, 3, 31, 16, 0, 0, 0, newYork),
+			time.Date(2024, 3, 31, 17, 0, 0, 0, newYork),
+			time.Date(2024, 3, 31, 22, 0, 0, 0, newYork),
+		}},
+	}
+
+	for _, test := range tests {
+		actual := ReminderTimes(event, offsets, test.loc, test.quiet)
+		if len(actual) != len(test.expected) {
+			t.Errorf("ReminderTimes(%s) = %v, expected %v", test.name, actual, test.expected)
+			continue
+		}
+		for i := range actual {
+			if !actual[i].Equal(test.expected[i]) {
+				t.Errorf("ReminderTimes(%s)[%d] = %v, expected %v", test.name, i, actual[i], test.expected[i])
+			}
+		}
+	}
+}
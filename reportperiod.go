@@ -0,0 +1,158 @@
+package temporalis
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResolveReportPeriod turns the name of a reporting period into the interval
+// it covers at now, so that every report agrees on what "last week" means.
+// Periods are calendar periods in loc, or in the location of now if loc is
+// nil, from midnight to midnight. Supported specs:
+//
+//	today, yesterday              the current or the previous day
+//	this month, current quarter   the whole current period, including the future
+//	previous full week, last month
+//	                              the last complete period before the current one
+//	month-to-date, MTD, YTD       from the start of the current period to now
+//	trailing 13 weeks, last 7 days
+//	                              the given number of complete periods before
+//	                              the current one
+//
+// The units are day, week, month, quarter and year. Weeks start on Monday
+// unless the spec names its week in parentheses, as in "previous full week
+// (Sun-Sat)". Trailing periods never include the current, incomplete one,
+// so "last 7 days" ends at midnight today. Matching is case-insensitive.
+func ResolveReportPeriod(spec string, now time.Time, loc *time.Location) (Interval, error) {
+	text, weekStart, err := parseReportWeek(spec)
+	if err != nil {
+		return Interval{}, err
+	}
+
+	if loc == nil {
+		loc = now.Location()
+	}
+	now = now.In(loc)
+	opts := []Option{WithLocation(loc), WithWeekStart(weekStart)}
+
+	words := strings.Fields(strings.ReplaceAll(strings.ToLower(text), "-", " "))
+	switch strings.Join(words, " ") {
+	case "today":
+		words = []string{"this", "day"}
+	case "yesterday":
+		words = []string{"previous", "day"}
+	case "wtd":
+		words = []string{"week", "to", "date"}
+	case "mtd":
+		words = []string{"month", "to", "date"}
+	case "qtd":
+		words = []string{"quarter", "to", "date"}
+	case "ytd":
+		words = []string{"year", "to", "date"}
+	}
+
+	if len(words) == 3 && words[1] == "to" && words[2] == "date" {
+		g, ok := parseReportUnit(words[0])
+		if !ok {
+			return Interval{}, syntaxError("ResolveReportPeriod", spec, "unknown unit "+strconv.Quote(words[0]))
+		}
+
+		return Interval{Start: StartOfPeriod(now, g, opts...), End: now}, nil
+	}
+
+	if len(words) < 2 {
+		return Interval{}, syntaxError("ResolveReportPeriod", spec, "")
+	}
+
+	g, ok := parseReportUnit(words[len(words)-1])
+	if !ok {
+		return Interval{}, syntaxError("ResolveReportPeriod", spec, "unknown unit "+strconv.Quote(words[len(words)-1]))
+	}
+	current := StartOfPeriod(now, g, opts...)
+
+	switch rest := words[:len(words)-1]; {
+	case len(rest) == 1 && (rest[0] == "this" || rest[0] == "current"):
+		return Interval{Start: current, End: addPeriods(current, g, 1)}, nil
+
+	case len(rest) == 2 && (rest[0] == "trailing" || rest[0] == "last" || rest[0] == "past"):
+		n, err := strconv.Atoi(rest[1])
+		if err != nil {
+			return Interval{}, syntaxError("ResolveReportPeriod", spec, "")
+		}
+		if n < 1 {
+			return Interval{}, rangeError("ResolveReportPeriod", spec, "count must be positive")
+		}
+
+		return Interval{Start: addPeriods(current, g, -n), End: current}, nil
+
+	case len(rest) >= 1 && len(rest) <= 2 && (rest[0] == "previous" || rest[0] == "last" || rest[0] == "prior"):
+		if len(rest) == 2 && rest[1] != "full" && rest[1] != "complete" {
+			return Interval{}, syntaxError("ResolveReportPeriod", spec, "")
+		}
+
+		return Interval{Start: addPeriods(current, g, -1), End: current}, nil
+	}
+
+	return Interval{}, syntaxError("ResolveReportPeriod", spec, "")
+}
+
+// parseReportWeek splits a trailing week definition such as "(Mon-Sun)" off
+// spec and returns the rest and the first day of the week, which defaults
+// to Monday.
+func parseReportWeek(spec string) (string, time.Weekday, error) {
+	text := strings.TrimSpace(spec)
+
+	open := strings.LastIndexByte(text, '(')
+	if open < 0 || !strings.HasSuffix(text, ")") {
+		return text, time.Monday, nil
+	}
+
+	week := strings.ReplaceAll(text[open+1:len(text)-1], "–", "-")
+	from, to, ok := strings.Cut(week, "-")
+	first, ok1 := parseWeekdayAbbreviation(strings.TrimSpace(from))
+	last, ok2 := parseWeekdayAbbreviation(strings.TrimSpace(to))
+	if !ok || !ok1 || !ok2 {
+		return "", 0, syntaxError("ResolveReportPeriod", spec, "invalid week "+strconv.Quote(week))
+	}
+	if (first+6)%7 != last {
+		return "", 0, rangeError("ResolveReportPeriod", spec, "week must span seven days")
+	}
+
+	return strings.TrimSpace(text[:open]), first, nil
+}
+
+// parseReportUnit parses the unit of a report period, in the singular or
+// the plural.
+func parseReportUnit(s string) (Granularity, bool) {
+	switch strings.TrimSuffix(s, "s") {
+	case "day":
+		return Daily, true
+	case "week":
+		return Weekly, true
+	case "month":
+		return Monthly, true
+	case "quarter":
+		return Quarterly, true
+	case "year":
+		return Yearly, true
+	}
+
+	return 0, false
+}
+
+// addPeriods adds n periods of granularity g to the start of a period.
+func addPeriods(t time.Time, g Granularity, n int) time.Time {
+	switch g {
+	case Daily:
+		return t.AddDate(0, 0, n)
+	case Weekly:
+		return t.AddDate(0, 0, 7*n)
+	case Monthly:
+		return t.AddDate(0, n, 0)
+	case Quarterly:
+		return t.AddDate(0, 3*n, 0)
+	default:
+		return t.AddDate(n, 0, 0)
+	}
+}
@@ -0,0 +1,81 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestResolveReportPeriod tests the supported specs on Wednesday, 15 May
+// 2024.
+func TestResolveReportPeriod(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 10, 30, 0, 0, time.UTC)
+	date := func(m time.Month, d int) time.Time {
+		return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		spec     string
+		expected Interval
+	}{
+		{"today", Interval{Start: date(time.May, 15), End: date(time.May, 16)}},
+		{"Yesterday", Interval{Start: date(time.May, 14), End: date(time.May, 15)}},
+		{"previous full week (Mon–Sun)", Interval{Start: date(time.May, 6), End: date(time.May, 13)}},
+		{"last week (Sun-Sat)", Interval{Start: date(time.May, 5), End: date(time.May, 12)}},
+		{"this month", Interval{Start: date(time.May, 1), End: date(time.June, 1)}},
+		{"month-to-date", Interval{Start: date(time.May, 1), End: now}},
+		{"YTD", Interval{Start: date(time.January, 1), End: now}},
+		{"quarter to date", Interval{Start: date(time.April, 1), End: now}},
+		{"previous quarter", Interval{Start: date(time.January, 1), End: date(time.April, 1)}},
+		{"trailing 13 weeks", Interval{Start: date(time.February, 12), End: date(time.May, 13)}},
+		{"last 7 days", Interval{Start: date(time.May, 8), End: date(time.May, 15)}},
+		{"past 2 years", Interval{Start: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), End: date(time.January, 1)}},
+	}
+
+	for _, test := range tests {
+		actual, err := ResolveReportPeriod(test.spec, now, nil)
+		if err != nil || !actual.Start.Equal(test.expected.Start) || !actual.End.Equal(test.expected.End) {
+			t.Errorf("ResolveReportPeriod(%q) = %v, %v, expected %v", test.spec, actual, err, test.expected)
+		}
+	}
+}
+
+// TestResolveReportPeriodLocation tests that periods follow the calendar of
+// the given location rather than that of now.
+func TestResolveReportPeriodLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	now := time.Date(2024, time.May, 31, 20, 0, 0, 0, time.UTC)
+
+	actual, err := ResolveReportPeriod("this month", now, tokyo)
+	expected := Interval{
+		Start: time.Date(2024, time.June, 1, 0, 0, 0, 0, tokyo),
+		End:   time.Date(2024, time.July, 1, 0, 0, 0, 0, tokyo),
+	}
+	if err != nil || actual != expected {
+		t.Errorf("ResolveReportPeriod() = %v, %v, expected %v", actual, err, expected)
+	}
+}
+
+// TestResolveReportPeriodErrors tests that invalid specs are rejected with
+// the right sentinel error.
+func TestResolveReportPeriodErrors(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected error
+	}{
+		{"", ErrSyntax},
+		{"next week", ErrSyntax},
+		{"previous fortnight", ErrSyntax},
+		{"trailing few weeks", ErrSyntax},
+		{"trailing 0 weeks", ErrOutOfRange},
+		{"last week (Mon-Sat)", ErrOutOfRange},
+		{"last week (Monday)", ErrSyntax},
+	}
+
+	for _, test := range tests {
+		_, err := ResolveReportPeriod(test.spec, time.Now(), nil)
+		if !errors.Is(err, test.expected) {
+			t.Errorf("ResolveReportPeriod(%q) error = %v, expected %v", test.spec, err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package temporalis
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPDateLayout is the preferred format of dates in HTTP headers, the
+// IMF-fixdate of RFC 7231, section 7.1.1.1.
+const HTTPDateLayout = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+// Obsolete HTTP date formats that recipients must still accept.
+const (
+	rfc850Layout  = "Monday, 02-Jan-06 15:04:05 GMT"
+	asctimeLayout = "Mon Jan _2 15:04:05 2006"
+)
+
+// ParseRFC3339Strict parses a timestamp that follows the grammar of RFC 3339
+// exactly: a four-digit year, two-digit fields, a "T" between date and time,
+// seconds, an optional fraction introduced by ".", and "Z" or a numeric
+// offset with a colon. The letters may be lowercase, as the RFC allows.
+// Unlike time.Parse it rejects a space or comma in place of "T" or ".", and
+// offsets of 24 hours or more. A leap second such as "2016-12-31T23:59:60Z"
+// is accepted, as RFC 3339 allows, where it falls at the last second of a
+// UTC day; since time.Time does not count leap seconds, it is returned as
+// the first instant of the next day.
+func ParseRFC3339Strict(s string) (time.Time, error) {
+	const fn = "ParseRFC3339Strict"
+
+	// date-time = full-date "T" partial-time time-offset
+	pattern := "dddd-dd-ddTdd:dd:dd"
+	if len(s) < len(pattern) {
+		return time.Time{}, syntaxError(fn, s, "too short")
+	}
+	for i := range len(pattern) {
+		if !matchRFC3339(pattern[i], s[i]) {
+			return time.Time{}, syntaxError(fn, s, "invalid character at offset "+strconv.Itoa(i))
+		}
+	}
+
+	rest := s[len(pattern):]
+	if strings.HasPrefix(rest, ".") {
+		digits := len(rest[1:]) - len(strings.TrimLeft(rest[1:], "0123456789"))
+		if digits == 0 {
+			return time.Time{}, syntaxError(fn, s, "empty fraction")
+		}
+		rest = rest[1+digits:]
+	}
+
+	switch {
+	case rest == "Z" || rest == "z":
+	case len(rest) == 6 && (rest[0] == '+' || rest[0] == '-') && isDigits(rest[1:3]) && rest[3] == ':' && isDigits(rest[4:]):
+		if rest[1:3] > "23" || rest[4:] > "59" {
+			return time.Time{}, rangeError(fn, s, "offset out of range")
+		}
+	default:
+		return time.Time{}, syntaxError(fn, s, "invalid offset")
+	}
+
+	// time.Parse rejects second 60, so a leap second is parsed as second 59
+	// and moved on by a second.
+	leap := s[17:19] == "60"
+	value := strings.ToUpper(s)
+	if leap {
+		value = value[:17] + "59" + value[19:]
+	}
+
+	t, err := time.Parse(RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, timeParseError(fn, s, err)
+	}
+
+	if leap {
+		if u := t.UTC(); u.Hour() != 23 || u.Minute() != 59 {
+			return time.Time{}, rangeError(fn, s, "leap second not at the end of a UTC day")
+		}
+		t = t.Truncate(time.Second).Add(time.Second)
+	}
+
+	return t, nil
+}
+
+// matchRFC3339 reports whether c matches the pattern character p, where 'd'
+// stands for a digit.
+func matchRFC3339(p, c byte) bool {
+	switch p {
+	case 'd':
+		return c >= '0' && c <= '9'
+	case 'T':
+		return c == 'T' || c == 't'
+	default:
+		return c == p
+	}
+}
+
+// FormatHTTPDate formats t as an IMF-fixdate in GMT, the form HTTP requires
+// in headers such as Date, Last-Modified and Expires.
+func FormatHTTPDate(t time.Time) string {
+	return t.UTC().Format(HTTPDateLayout)
+}
+
+// ParseHTTPDate parses an HTTP date in any of the three formats RFC 7231
+// requires recipients to accept:
+//
+//	Sun, 06 Nov 1994 08:49:37 GMT    IMF-fixdate
+//	Sunday, 06-Nov-94 08:49:37 GMT   obsolete RFC 850 format
+//	Sun Nov  6 08:49:37 1994         ANSI C's asctime() format
+//
+// The result is in UTC. A two-digit RFC 850 year that would be more than 50
+// years in the future is taken to be in the past century, as the RFC
+// requires. The day of the week must match the date.
+func ParseHTTPDate(s string) (time.Time, error) {
+	const fn = "ParseHTTPDate"
+
+	for _, layout := range []string{HTTPDateLayout, rfc850Layout, asctimeLayout} {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+
+		// time.Parse puts two-digit years in 1969 to 2068; RFC 7231 puts
+		// them within 50 years of the present instead.
+		if layout == rfc850Layout {
+			now := Now().Year()
+			year := now - now%100 + t.Year()%100
+			if year > now+50 {
+				year -= 100
+			}
+			t = time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		}
+
+		// time.Parse checks neither the day of the week nor the case and
+		// width of the fields, which RFC 7231 fixes.
+		if err := checkWeekday(fn, s, t); err != nil {
+			return time.Time{}, err
+		}
+		if t.Format(layout) != s {
+			return time.Time{}, syntaxError(fn, s, "fields differ from "+strconv.Quote(t.Format(layout)))
+		}
+
+		return t.UTC(), nil
+	}
+
+	return time.Time{}, syntaxError(fn, s, "not an IMF-fixdate, RFC 850 or asctime date")
+}
+
+// ParseRFC2822 parses the date of an email Date header in the format of RFC
+// 5322, which obsoletes RFC 2822, such as "Fri, 21 Nov 1997 09:55:06 -0600".
+// It also accepts the obsolete forms the RFC requires readers to handle:
+// two- and three-digit years, the zone names UT, GMT, EST, EDT, CST, CDT,
+// MST, MDT, PST and PDT, and military single-letter zones, which are taken
+// as UTC. Seconds and the day of the week are optional, comments in
+// parentheses are ignored, and the day of the week, if present, must match
+// the date.
+func ParseRFC2822(s string) (time.Time, error) {
+	const fn = "ParseRFC2822"
+
+	text := stripComments(s)
+
+	var weekday string
+	if before, after, ok := strings.Cut(text, ","); ok {
+		weekday, text = strings.TrimSpace(before), after
+		if _, ok := parseWeekdayAbbreviation(weekday); !ok {
+			return time.Time{}, syntaxError(fn, s, "invalid day of the week "+strconv.Quote(weekday))
+		}
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) != 5 {
+		return time.Time{}, syntaxError(fn, s, "expected day, month, year, time and zone")
+	}
+
+	day, err := strconv.Atoi(fields[0])
+	if err != nil || len(fields[0]) > 2 {
+		return time.Time{}, syntaxError(fn, s, "invalid day "+strconv.Quote(fields[0]))
+	}
+
+	month, ok := parseMonthAbbreviation(fields[1])
+	if !ok {
+		return time.Time{}, syntaxError(fn, s, "invalid month "+strconv.Quote(fields[1]))
+	}
+
+	year, err := strconv.Atoi(fields[2])
+	if err != nil || !isDigits(fields[2]) || len(fields[2]) < 2 {
+		return time.Time{}, syntaxError(fn, s, "invalid year "+strconv.Quote(fields[2]))
+	}
+	switch {
+	case len(fields[2]) == 2 && year < 50:
+		year += 2000
+	case len(fields[2]) <= 3:
+		year += 1900
+	}
+
+	clock := strings.Split(fields[3], ":")
+	if len(clock) < 2 || len(clock) > 3 {
+		return time.Time{}, syntaxError(fn, s, "invalid time "+strconv.Quote(fields[3]))
+	}
+	var hms [3]int
+	for i, part := range clock {
+		if len(part) != 2 || !isDigits(part) {
+			return time.Time{}, syntaxError(fn, s, "invalid time "+strconv.Quote(fields[3]))
+		}
+		hms[i], _ = strconv.Atoi(part)
+	}
+
+	loc, ok := parseRFC2822Zone(fields[4])
+	if !ok {
+		return time.Time{}, syntaxError(fn, s, "invalid zone "+strconv.Quote(fields[4]))
+	}
+
+	t := time.Date(year, month, day, hms[0], hms[1], hms[2], 0, loc)
+	if t.Day() != day || t.Hour() != hms[0] || t.Minute() != hms[1] || t.Second() != hms[2] {
+		return time.Time{}, rangeError(fn, s, "date or time out of range")
+	}
+
+	if wd, _ := parseWeekdayAbbreviation(weekday); weekday != "" && wd != t.Weekday() {
+		return time.Time{}, rangeError(fn, s, "day of the week does not match date")
+	}
+
+	return t, nil
+}
+
+// checkWeekday returns an error for fn if the day name that s starts with,
+// which time.Parse accepts without checking it, is not that of t.
+func checkWeekday(fn, s string, t time.Time) error {
+	name := strings.TrimSuffix(strings.Fields(s)[0], ",")
+	if day := t.Weekday().String(); strings.EqualFold(name, day) || strings.EqualFold(name, day[:3]) {
+		return nil
+	}
+
+	return rangeError(fn, s, "day of the week does not match date")
+}
+
+// stripComments removes parenthesized comments, which may nest, from an
+// email header value.
+func stripComments(s string) string {
+	var b strings.Builder
+
+	depth := 0
+	for _, c := range s {
+		switch {
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(c)
+		}
+	}
+
+	return b.String()
+}
+
+// parseMonthAbbreviation parses a three-letter English month name.
+func parseMonthAbbreviation(s string) (time.Month, bool) {
+	for m := time.January; m <= time.December; m++ {
+		if strings.EqualFold(s, m.String()[:3]) {
+			return m, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseWeekdayAbbreviation parses a three-letter English day name.
+func parseWeekdayAbbreviation(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()[:3]) {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
+
+// rfc2822Zones are the obsolete zone names of RFC 5322, section 4.3.
+var rfc2822Zones = map[string]int{
+	"UT": 0, "GMT": 0,
+	"EST": -5, "EDT": -4,
+	"CST": -6, "CDT": -5,
+	"MST": -7, "MDT": -6,
+	"PST": -8, "PDT": -7,
+}
+
+// parseRFC2822Zone parses a numeric zone such as "-0600" or an obsolete
+// zone name.
+func parseRFC2822Zone(s string) (*time.Location, bool) {
+	if len(s) == 5 && (s[0] == '+' || s[0] == '-') && isDigits(s[1:]) {
+		hours, _ := strconv.Atoi(s[1:3])
+		minutes, _ := strconv.Atoi(s[3:])
+		if minutes > 59 {
+			return nil, false
+		}
+
+		offset := hours*3600 + minutes*60
+		if s[0] == '-' {
+			offset = -offset
+		}
+		if offset == 0 {
+			return time.UTC, true
+		}
+
+		return time.FixedZone("", offset), true
+	}
+
+	name := strings.ToUpper(s)
+	if hours, ok := rfc2822Zones[name]; ok {
+		if hours == 0 {
+			return time.UTC, true
+		}
+
+		return time.FixedZone(name, hours*3600), true
+	}
+
+	// Military zones were specified with the wrong sign, so RFC 5322 says
+	// to treat them as unknown, which is UTC.
+	if len(name) == 1 && name[0] >= 'A' && name[0] <= 'Z' && name[0] != 'J' {
+		return time.UTC, true
+	}
+
+	return nil, false
+}
+
+// isDigits reports whether s is non-empty and consists of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
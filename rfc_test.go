@@ -0,0 +1,145 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestParseRFC3339Strict tests timestamps that time.Parse accepts but RFC
+// 3339 does not, and the lowercase letters it allows.
+func TestParseRFC3339Strict(t *testing.T) {
+	valid := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2024-03-10T12:34:56Z", time.Date(2024, 3, 10, 12, 34, 56, 0, time.UTC)},
+		{"2024-03-10t12:34:56.5z", time.Date(2024, 3, 10, 12, 34, 56, 500000000, time.UTC)},
+		{"2024-03-10T12:34:56.123456789+05:30", time.Date(2024, 3, 10, 7, 4, 56, 123456789, time.UTC)},
+		{"2016-12-31T23:59:60Z", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2016-12-31T23:59:60.5Z", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2016-12-31T18:59:60-05:00", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range valid {
+		if actual, err := ParseRFC3339Strict(test.input); err != nil || !actual.Equal(test.expected) {
+			t.Errorf("ParseRFC3339Strict(%q) = %v, %v, expected %v", test.input, actual, err, test.expected)
+		}
+	}
+
+	invalid := []struct {
+		input    string
+		expected error
+	}{
+		{"2024-03-10 12:34:56Z", ErrSyntax},
+		{"2024-03-10T12:34:56,5Z", ErrSyntax},
+		{"2024-03-10T12:34Z", ErrSyntax},
+		{"2024-03-10T12:34:56", ErrSyntax},
+		{"2024-03-10T12:34:56.Z", ErrSyntax},
+		{"2024-03-10T12:34:56+0530", ErrSyntax},
+		{"24-03-10T12:34:56Z", ErrSyntax},
+		{"2024-3-10T12:34:56Z", ErrSyntax},
+		{"2024-03-10T12:34:56+24:00", ErrOutOfRange},
+		{"2024-02-30T12:34:56Z", ErrOutOfRange},
+		{"2024-03-10T25:34:56Z", ErrOutOfRange},
+		{"2016-12-31T12:34:60Z", ErrOutOfRange},
+		{"2016-12-31T23:59:61Z", ErrOutOfRange},
+	}
+
+	for _, test := range invalid {
+		if _, err := ParseRFC3339Strict(test.input); !errors.Is(err, test.expected) {
+			t.Errorf("ParseRFC3339Strict(%q) error = %v, expected %v", test.input, err, test.expected)
+		}
+	}
+}
+
+// TestHTTPDate tests the three formats of RFC 7231 and formatting in GMT.
+func TestHTTPDate(t *testing.T) {
+	expected := time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)
+
+	for _, input := range []string{
+		"Sun, 06 Nov 1994 08:49:37 GMT",
+		"Sunday, 06-Nov-94 08:49:37 GMT",
+		"Sun Nov  6 08:49:37 1994",
+	} {
+		if actual, err := ParseHTTPDate(input); err != nil || !actual.Equal(expected) || actual.Location() != time.UTC {
+			t.Errorf("ParseHTTPDate(%q) = %v, %v, expected %v", input, actual, err, expected)
+		}
+	}
+
+	berlin := time.FixedZone("CET", 3600)
+	if actual := FormatHTTPDate(expected.In(berlin)); actual != "Sun, 06 Nov 1994 08:49:37 GMT" {
+		t.Errorf("FormatHTTPDate() = %q, expected %q", actual, "Sun, 06 Nov 1994 08:49:37 GMT")
+	}
+
+	invalid := []struct {
+		input    string
+		expected error
+	}{
+		{"Mon, 06 Nov 1994 08:49:37 GMT", ErrOutOfRange},
+		{"Sun, 06 Nov 1994 8:49:37 GMT", ErrSyntax},
+		{"sun, 06 nov 1994 08:49:37 GMT", ErrSyntax},
+		{"Sun, 06 Nov 1994 08:49:37 +0000", ErrSyntax},
+		{"1994-11-06T08:49:37Z", ErrSyntax},
+		{"", ErrSyntax},
+	}
+
+	for _, test := range invalid {
+		if _, err := ParseHTTPDate(test.input); !errors.Is(err, test.expected) {
+			t.Errorf("ParseHTTPDate(%q) error = %v, expected %v", test.input, err, test.expected)
+		}
+	}
+}
+
+// TestHTTPDateTwoDigitYear tests that RFC 850 years are placed within 50
+// years of the present rather than by the rule of time.Parse.
+func TestHTTPDateTwoDigitYear(t *testing.T) {
+	year := time.Now().Year() + 40
+	d := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if actual, err := ParseHTTPDate(d.Format(rfc850Layout)); err != nil || actual.Year() != year {
+		t.Errorf("ParseHTTPDate(%q) = %v, %v, expected year %d", d.Format(rfc850Layout), actual, err, year)
+	}
+}
+
+// TestParseRFC2822 tests current and obsolete email Date header forms.
+func TestParseRFC2822(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"Fri, 21 Nov 1997 09:55:06 -0600", time.Date(1997, 11, 21, 15, 55, 6, 0, time.UTC)},
+		{"21 Nov 1997 09:55 +0000", time.Date(1997, 11, 21, 9, 55, 0, 0, time.UTC)},
+		{"Fri,21 Nov 97 09:55:06 GMT", time.Date(1997, 11, 21, 9, 55, 6, 0, time.UTC)},
+		{"Thu, 13 Feb 69 23:32:54 -0330", time.Date(1969, 2, 14, 3, 2, 54, 0, time.UTC)},
+		{"Mon, 1 Jan 24 10:00:00 PST (Pacific Standard Time)", time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)},
+		{"1 Jan 2024 10:00:00 Z", time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+		{"Fri, 21 Nov 1997 09:55:06 -0600 (CST (with a nested comment))", time.Date(1997, 11, 21, 15, 55, 6, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if actual, err := ParseRFC2822(test.input); err != nil || !actual.Equal(test.expected) {
+			t.Errorf("ParseRFC2822(%q) = %v, %v, expected %v", test.input, actual, err, test.expected)
+		}
+	}
+
+	invalid := []struct {
+		input    string
+		expected error
+	}{
+		{"Sat, 21 Nov 1997 09:55:06 -0600", ErrOutOfRange},
+		{"Fri, 31 Nov 1997 09:55:06 -0600", ErrOutOfRange},
+		{"Fri, 21 Nov 1997 24:00:00 -0600", ErrOutOfRange},
+		{"Xyz, 21 Nov 1997 09:55:06 -0600", ErrSyntax},
+		{"21 November 1997 09:55:06 -0600", ErrSyntax},
+		{"21 Nov 1997 9:55:06 -0600", ErrSyntax},
+		{"21 Nov 1997 09:55:06 Mars", ErrSyntax},
+		{"21 Nov 1997 09:55:06", ErrSyntax},
+	}
+
+	for _, test := range invalid {
+		if _, err := ParseRFC2822(test.input); !errors.Is(err, test.expected) {
+			t.Errorf("ParseRFC2822(%q) error = %v, expected %v", test.input, err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package temporalis
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Rollover announces that the local date of a RolloverDetector has changed.
+type Rollover struct {
+	// Previous is the date before the change. It is more than a day before
+	// Date if the detector did not run for a while, such as across a
+	// process restart.
+	Previous CivilDate
+	// Date is the new date.
+	Date CivilDate
+	// At is the start of Date in the zone of the detector. On days where
+	// clocks are turned forward at midnight it is the first instant of the
+	// day rather than 00:00.
+	At time.Time
+}
+
+// RolloverDetector reports each change of the local date in a zone exactly
+// once, for daily counters and jobs such as a daily digest. Its cursor is
+// the last date it has seen. Applications that must not miss or repeat a
+// day across restarts persist the cursor, for example Rollover.Date after
+// handling an event, and pass it back to NewRolloverDetector. Because the
+// cursor is a date rather than an instant, DST changes and clock
+// adjustments within a day never cause an extra event, and a clock that is
+// set back to an earlier date is ignored until it reaches the cursor again.
+type RolloverDetector struct {
+	// Clock provides the time. If nil, SystemClock is used.
+	Clock Clock
+
+	loc    *time.Location
+	mu     sync.Mutex
+	cursor CivilDate
+}
+
+// NewRolloverDetector returns a detector for the dates in loc, or in the
+// local time zone if loc is nil, that continues from cursor. With a zero
+// cursor the detector starts from the date at its first check, without an
+// event for it.
+func NewRolloverDetector(loc *time.Location, cursor CivilDate) *RolloverDetector {
+	if loc == nil {
+		loc = LocalLocation()
+	}
+
+	return &RolloverDetector{loc: loc, cursor: cursor}
+}
+
+// Cursor returns the last date the detector has seen.
+func (d *RolloverDetector) Cursor() CivilDate {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.cursor
+}
+
+// Check returns the rollover since the previous check, if the current date
+// in the zone is after the cursor, and advances the cursor. A change of
+// several days is reported as a single rollover.
+func (d *RolloverDetector) Check() (Rollover, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.checkLocked(clockOrSystem(d.Clock).Now())
+}
+
+// Run calls fn for every rollover, starting with one that happened while
+// the detector was not running, until ctx is cancelled, and then returns
+// the context error. It wakes at every local midnight of the zone.
+func (d *RolloverDetector) Run(ctx context.Context, fn func(Rollover)) error {
+	c := clockOrSystem(d.Clock)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		d.mu.Lock()
+		r, ok := d.checkLocked(c.Now())
+		// CivilDate.In resolves a midnight in a DST gap forward, so the
+		// start of the next day is after the cursor date even then.
+		next := d.cursor.AddDays(1).In(d.loc)
+		d.mu.Unlock()
+
+		if ok {
+			fn(r)
+		}
+
+		if err := sleepClock(ctx, c, next.Sub(c.Now())); err != nil {
+			return err
+		}
+	}
+}
+
+// checkLocked compares the date of now with the cursor and advances it. mu
+// must be held.
+func (d *RolloverDetector) checkLocked(now time.Time) (Rollover, bool) {
+	today := DateOf(now.In(d.loc))
+
+	if d.cursor.IsZero() {
+		d.cursor = today
+		return Rollover{}, false
+	}
+	if !today.After(d.cursor) {
+		return Rollover{}, false
+	}
+
+	r := Rollover{Previous: d.cursor, Date: today, At: today.In(d.loc)}
+	d.cursor = today
+
+	return r, true
+}
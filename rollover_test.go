@@ -0,0 +1,116 @@
+package temporalis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRolloverDetector tests that each date change is reported once, across
+// a DST change, a clock set back and a restart with a persisted cursor.
+func TestRolloverDetector(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	clock := NewFakeClock(time.Date(2024, time.March, 30, 22, 0, 0, 0, berlin))
+	d := NewRolloverDetector(berlin, CivilDate{})
+	d.Clock = clock
+
+	if r, ok := d.Check(); ok {
+		t.Errorf("Check() = %v, expected no rollover on the first check", r)
+	}
+
+	// Clocks go forward at 02:00 on 31 March; hourly checks see one change.
+	var events []Rollover
+	for range 20 {
+		clock.Advance(time.Hour)
+		if r, ok := d.Check(); ok {
+			events = append(events, r)
+		}
+	}
+	if len(events) != 1 || events[0].Date != NewCivilDate(2024, time.March, 31) || !events[0].At.Equal(time.Date(2024, time.March, 31, 0, 0, 0, 0, berlin)) {
+		t.Fatalf("Check() = %v, expected one rollover to 2024-03-31", events)
+	}
+
+	clock.Set(time.Date(2024, time.March, 30, 12, 0, 0, 0, berlin))
+	if r, ok := d.Check(); ok {
+		t.Errorf("Check() = %v, expected no rollover after setting the clock back", r)
+	}
+
+	// A restart three days later reports the missed days as one rollover.
+	cursor := d.Cursor()
+	clock.Set(time.Date(2024, time.April, 3, 9, 0, 0, 0, berlin))
+	d = NewRolloverDetector(berlin, cursor)
+	d.Clock = clock
+
+	r, ok := d.Check()
+	if !ok || r.Previous != NewCivilDate(2024, time.March, 31) || r.Date != NewCivilDate(2024, time.April, 3) {
+		t.Errorf("Check() = %v, %v, expected a rollover from 2024-03-31 to 2024-04-03", r, ok)
+	}
+}
+
+// TestRolloverDetectorRun tests that Run wakes at local midnight.
+func TestRolloverDetectorRun(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	clock := NewFakeClock(time.Date(2024, time.May, 1, 23, 0, 0, 0, tokyo))
+
+	d := NewRolloverDetector(tokyo, NewCivilDate(2024, time.April, 30))
+	d.Clock = clock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan Rollover, 10)
+	done := make(chan error)
+	go func() { done <- d.Run(ctx, func(r Rollover) { events <- r }) }()
+
+	if r := <-events; r.Date != NewCivilDate(2024, time.May, 1) {
+		t.Errorf("Run() reported %v, expected 2024-05-01 on start", r.Date)
+	}
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	if r := <-events; !r.At.Equal(clock.Now()) {
+		t.Errorf("Run() reported %v, expected %v", r.At, clock.Now())
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run() = %v, expected %v", err, context.Canceled)
+	}
+}
+
+// TestRolloverDetectorMidnightGap tests a day that starts at 01:00 because
+// clocks skip midnight.
+func TestRolloverDetectorMidnightGap(t *testing.T) {
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	// Clocks in Santiago jump from 00:00 to 01:00 on 8 September 2024.
+	clock := NewFakeClock(time.Date(2024, time.September, 7, 22, 0, 0, 0, santiago))
+	d := NewRolloverDetector(santiago, NewCivilDate(2024, time.September, 7))
+	d.Clock = clock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan Rollover, 10)
+	go d.Run(ctx, func(r Rollover) { events <- r })
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	clock.BlockUntil(1)
+	select {
+	case r := <-events:
+		t.Fatalf("Run() reported %v at 23:00, expected nothing before the day starts", r)
+	default:
+	}
+
+	clock.Advance(time.Hour)
+	r := <-events
+	start := time.Date(2024, time.September, 8, 1, 0, 0, 0, santiago)
+	if r.Date != NewCivilDate(2024, time.September, 8) || !r.At.Equal(start) {
+		t.Errorf("Run() reported %v, expected 2024-09-08 starting at %v", r, start)
+	}
+}
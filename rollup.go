@@ -0,0 +1,96 @@
+package temporalis
+
+import (
+	"sort"
+	"time"
+)
+
+// RollupTier describes one level of a downsampling hierarchy. Data that is at
+// least After old is stored in buckets of width Resolution. The tier with the
+// smallest After describes the resolution of the raw data itself and should
+// normally have an After of zero.
+type RollupTier struct {
+	Resolution time.Duration
+	After      time.Duration
+}
+
+// Rollup is a single compaction step: the Sources buckets, which all belong to
+// the tier before Tier, are merged into the Target bucket of Tier.
+type Rollup struct {
+	Tier    int
+	Target  Interval
+	Sources []Interval
+}
+
+// PlanRollups returns the compactions needed to bring the data in raw into the
+// shape described by tiers, evaluated as of raw.End. Tiers are sorted by their
+// After value and numbered in that order. For every tier after the first, each
+// target bucket that lies entirely within the age range of that tier is listed
+// together with the buckets of the previous tier that it absorbs. Buckets are
+// aligned to multiples of their resolution since the zero time, and source
+// buckets are clipped to raw.
+// Tiers with a non-positive resolution are ignored.
+func PlanRollups(raw Interval, tiers []RollupTier) []Rollup {
+	var sorted []RollupTier
+	for _, tier := range tiers {
+		if tier.Resolution > 0 {
+			sorted = append(sorted, tier)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].After < sorted[j].After
+	})
+
+	if raw.IsEmpty() || len(sorted) < 2 {
+		return nil
+	}
+
+	now := raw.End
+
+	var plan []Rollup
+	for i := 1; i < len(sorted); i++ {
+		tier := sorted[i]
+		source := sorted[i-1]
+
+		// The tier owns data between its own age threshold and the threshold
+		// of the next, coarser tier.
+		newest := now.Add(-tier.After).Truncate(tier.Resolution)
+		oldest := raw.Start.Truncate(tier.Resolution)
+		if i+1 < len(sorted) {
+			if next := now.Add(-sorted[i+1].After).Truncate(tier.Resolution); next.After(oldest) {
+				oldest = next
+			}
+		}
+
+		for start := oldest; start.Before(newest); start = start.Add(tier.Resolution) {
+			target := Interval{Start: start, End: start.Add(tier.Resolution)}
+
+			sources := splitInterval(target, source.Resolution, raw)
+			if len(sources) == 0 {
+				continue
+			}
+
+			plan = append(plan, Rollup{Tier: i, Target: target, Sources: sources})
+		}
+	}
+
+	return plan
+}
+
+// splitInterval cuts i into buckets of the given size aligned to the zero
+// time, keeping only the parts that overlap within.
+func splitInterval(i Interval, size time.Duration, within Interval) []Interval {
+	var buckets []Interval
+
+	for start := i.Start.Truncate(size); start.Before(i.End); start = start.Add(size) {
+		bucket := Interval{Start: start, End: start.Add(size)}
+		if clipped, ok := bucket.Intersect(within); ok {
+			if clipped, ok = clipped.Intersect(i); ok {
+				buckets = append(buckets, clipped)
+			}
+		}
+	}
+
+	return buckets
+}
@@ -0,0 +1,32 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPlanRollups checks a two-level hierarchy where minute data older than an
+// hour is compacted into hourly buckets, and verifies that only complete hours
+// inside the raw interval are planned.
+func TestPlanRollups(t *testing.T) {
+	end := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	raw := Interval{Start: end.Add(-4 * time.Hour), End: end}
+
+	plan := PlanRollups(raw, []RollupTier{
+		{Resolution: time.Minute},
+		{Resolution: time.Hour, After: time.Hour},
+	})
+
+	if len(plan) != 3 {
+		t.Fatalf("PlanRollups() returned %d rollups, expected 3", len(plan))
+	}
+
+	for _, r := range plan {
+		if r.Tier != 1 || r.Target.Duration() != time.Hour || len(r.Sources) != 60 {
+			t.Errorf("unexpected rollup %v with %d sources", r.Target, len(r.Sources))
+		}
+		if r.Target.End.After(end.Add(-time.Hour)) {
+			t.Errorf("rollup %v includes data younger than an hour", r.Target)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package temporalis
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RotationPlan returns the window in which a credential that is valid between
+// notBefore and notAfter should be renewed. The window opens leadTime before
+// notAfter and stays open for jitter, so that a fleet of clients sharing the
+// same credential lifetime spreads its renewals out. The window never opens
+// before notBefore and never extends past notAfter. A non-positive jitter
+// yields an empty window whose Start is the single renewal time.
+func RotationPlan(notBefore, notAfter time.Time, leadTime, jitter time.Duration) Interval {
+	open := notAfter.Add(-leadTime)
+	if open.Before(notBefore) {
+		open = notBefore
+	}
+
+	if jitter < 0 {
+		jitter = 0
+	}
+
+	end := open.Add(jitter)
+	if end.After(notAfter) {
+		end = notAfter
+	}
+
+	return Interval{Start: open, End: end}
+}
+
+// Rotator calls a renewal function once, at a random instant inside a
+// rotation window. After a successful renewal the caller typically computes
+// a new window from the new credential and passes it to Reset.
+type Rotator struct {
+	mu    sync.Mutex
+	timer *time.Timer
+	at    time.Time
+	renew func()
+}
+
+// NewRotator schedules renew to run in its own goroutine at a uniformly
+// random instant within window. If the window has already passed, renew is
+// called as soon as possible.
+func NewRotator(window Interval, renew func()) *Rotator {
+	r := &Rotator{renew: renew}
+	r.Reset(window)
+
+	return r
+}
+
+// At returns the instant at which the renewal function is scheduled to run.
+func (r *Rotator) At() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.at
+}
+
+// Reset cancels any pending renewal and schedules a new one inside window.
+func (r *Rotator) Reset(window Interval) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+
+	r.at = window.Start
+	if d := window.Duration(); d > 0 {
+		r.at = r.at.Add(time.Duration(rand.Int63n(int64(d))))
+	}
+
+	r.timer = time.AfterFunc(time.Until(r.at), r.renew)
+}
+
+// Stop cancels the pending renewal. It returns false if the renewal function
+// has already been started or the rotator was already stopped.
+func (r *Rotator) Stop() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.timer == nil {
+		return false
+	}
+
+	return r.timer.Stop()
+}
@@ -0,0 +1,44 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRotationPlan checks that the renewal window opens lead time before
+// expiry and is clamped to the validity period of the credential.
+func TestRotationPlan(t *testing.T) {
+	notBefore := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(90 * 24 * time.Hour)
+
+	window := RotationPlan(notBefore, notAfter, 30*24*time.Hour, 24*time.Hour)
+	if !window.Start.Equal(notAfter.Add(-30*24*time.Hour)) || window.Duration() != 24*time.Hour {
+		t.Errorf("RotationPlan() = %v, expected a one-day window 30 days before expiry", window)
+	}
+
+	window = RotationPlan(notBefore, notAfter, 365*24*time.Hour, 365*24*time.Hour)
+	if !window.Start.Equal(notBefore) || !window.End.Equal(notAfter) {
+		t.Errorf("RotationPlan() = %v, expected the whole validity period", window)
+	}
+}
+
+// TestRotator checks that the renewal function runs inside the window.
+func TestRotator(t *testing.T) {
+	done := make(chan time.Time, 1)
+	start := time.Now()
+	window := Interval{Start: start.Add(10 * time.Millisecond), End: start.Add(30 * time.Millisecond)}
+
+	r := NewRotator(window, func() { done <- time.Now() })
+	if !window.Contains(r.At()) && !r.At().Equal(window.Start) {
+		t.Errorf("At() = %v, expected an instant within %v", r.At(), window)
+	}
+
+	select {
+	case fired := <-done:
+		if fired.Before(window.Start) {
+			t.Errorf("renewal ran at %v, before the window opened", fired)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("renewal did not run")
+	}
+}
@@ -0,0 +1,605 @@
+package temporalis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ part of an iCalendar recurrence rule.
+type Frequency int
+
+const (
+	FreqSecondly Frequency = iota
+	FreqMinutely
+	FreqHourly
+	FreqDaily
+	FreqWeekly
+	FreqMonthly
+	FreqYearly
+)
+
+var frequencyNames = [...]string{
+	FreqSecondly: "SECONDLY",
+	FreqMinutely: "MINUTELY",
+	FreqHourly:   "HOURLY",
+	FreqDaily:    "DAILY",
+	FreqWeekly:   "WEEKLY",
+	FreqMonthly:  "MONTHLY",
+	FreqYearly:   "YEARLY",
+}
+
+// String returns the iCalendar name of the frequency, such as "WEEKLY".
+func (f Frequency) String() string {
+	if f < 0 || int(f) >= len(frequencyNames) {
+		return "Frequency(" + strconv.Itoa(int(f)) + ")"
+	}
+
+	return frequencyNames[f]
+}
+
+// WeekdayNum is an entry of BYDAY: a weekday with an optional ordinal, so
+// {N: -1, Weekday: time.Friday} is "the last Friday" and N of zero means
+// "every Friday".
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+var rruleDays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// String returns the iCalendar form of the entry, such as "-1FR".
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return rruleDays[w.Weekday]
+	}
+
+	return strconv.Itoa(w.N) + rruleDays[w.Weekday]
+}
+
+// RRule is an iCalendar (RFC 5545) recurrence rule anchored at a start time.
+// It implements Recurrence. The supported parts are FREQ, INTERVAL, COUNT,
+// UNTIL, BYMONTH, BYMONTHDAY, BYDAY, BYHOUR, BYMINUTE, BYSECOND, BYSETPOS
+// and WKST. BYDAY ordinals are counted within the month for MONTHLY rules
+// and for YEARLY rules with BYMONTH, and within the year otherwise.
+type RRule struct {
+	Start      time.Time
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByMonth    []int
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// maxRRulePeriods bounds the number of periods examined by Next so that a
+// rule that can never match does not loop forever.
+const maxRRulePeriods = 100000
+
+// ParseRRule parses the value of an RRULE property, with or without the
+// "RRULE:" prefix, such as "FREQ=MONTHLY;BYDAY=-1FR;COUNT=12". The start
+// time anchors the rule, as DTSTART does in iCalendar, and determines the
+// location in which the rule is evaluated.
+func ParseRRule(s string, start time.Time) (*RRule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+
+	r := &RRule{Start: start, Interval: 1, WeekStart: time.Monday, Freq: -1}
+
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, syntaxError("ParseRRule", s, "invalid part "+strconv.Quote(part))
+		}
+
+		var err error
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = -1
+			for f, name := range frequencyNames {
+				if strings.EqualFold(value, name) {
+					r.Freq = Frequency(f)
+				}
+			}
+			if r.Freq < 0 {
+				err = detailed(ErrSyntax, "unknown frequency "+strconv.Quote(value))
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = detailed(ErrOutOfRange, "interval must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			r.Until, err = parseRRuleTime(value, start.Location())
+		case "BYMONTH":
+			r.ByMonth, err = parseRRuleInts(value, 1, 12, false)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseRRuleInts(value, 1, 31, true)
+		case "BYHOUR":
+			r.ByHour, err = parseRRuleInts(value, 0, 23, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseRRuleInts(value, 0, 59, false)
+		case "BYSECOND":
+			r.BySecond, err = parseRRuleInts(value, 0, 59, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseRRuleInts(value, 1, 366, true)
+		case "BYDAY":
+			r.ByDay, err = parseRRuleDays(value)
+		case "WKST":
+			var days []WeekdayNum
+			if days, err = parseRRuleDays(value); err == nil {
+				r.WeekStart = days[0].Weekday
+			}
+		default:
+			err = detailed(ErrSyntax, "unsupported part")
+		}
+
+		if err != nil {
+			if !errors.Is(err, ErrSyntax) && !errors.Is(err, ErrOutOfRange) {
+				err = &wrappedError{category: ErrSyntax, err: err}
+			}
+			return nil, &ParseError{Func: "ParseRRule", Input: s, Err: fmt.Errorf("part %q: %w", part, err)}
+		}
+	}
+
+	if r.Freq < 0 {
+		return nil, syntaxError("ParseRRule", s, "missing FREQ")
+	}
+
+	return r, nil
+}
+
+// String returns the rule in iCalendar notation without the "RRULE:" prefix.
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	for _, list := range []struct {
+		name   string
+		values []int
+	}{
+		{"BYMONTH", r.ByMonth},
+		{"BYMONTHDAY", r.ByMonthDay},
+	} {
+		if len(list.values) > 0 {
+			parts = append(parts, list.name+"="+joinInts(list.values))
+		}
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	for _, list := range []struct {
+		name   string
+		values []int
+	}{
+		{"BYHOUR", r.ByHour},
+		{"BYMINUTE", r.ByMinute},
+		{"BYSECOND", r.BySecond},
+		{"BYSETPOS", r.BySetPos},
+	} {
+		if len(list.values) > 0 {
+			parts = append(parts, list.name+"="+joinInts(list.values))
+		}
+	}
+
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+rruleDays[r.WeekStart])
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after the given time, or the
+// zero time if the rule has ended.
+func (r *RRule) Next(after time.Time) time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	// Without COUNT, periods that end before after cannot contribute, so
+	// the search can start near after instead of at Start.
+	first := 0
+	if r.Count == 0 && after.After(r.Start) {
+		first = r.periodsBetween(r.Start, after)/interval - 1
+		if first < 0 {
+			first = 0
+		}
+	}
+
+	count := 0
+	for i := first; i < first+maxRRulePeriods; i++ {
+		periodStart := r.period(i * interval)
+
+		if !r.Until.IsZero() && periodStart.After(r.Until) {
+			return time.Time{}
+		}
+
+		for _, t := range r.expand(periodStart) {
+			if t.Before(r.Start) {
+				continue
+			}
+
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return time.Time{}
+			}
+
+			count++
+			if r.Count > 0 && count > r.Count {
+				return time.Time{}
+			}
+
+			if t.After(after) {
+				return t
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// period returns the start of the n-th frequency unit after the one that
+// contains Start.
+func (r *RRule) period(n int) time.Time {
+	s := r.Start
+	y, m, d := s.Date()
+	loc := s.Location()
+
+	switch r.Freq {
+	case FreqSecondly:
+		return s.Truncate(time.Second).Add(time.Duration(n) * time.Second)
+	case FreqMinutely:
+		return time.Date(y, m, d, s.Hour(), s.Minute(), 0, 0, loc).Add(time.Duration(n) * time.Minute)
+	case FreqHourly:
+		return time.Date(y, m, d, s.Hour(), 0, 0, 0, loc).Add(time.Duration(n) * time.Hour)
+	case FreqDaily:
+		return NewCivilDate(y, m, d+n).In(loc)
+	case FreqWeekly:
+		back := (int(s.Weekday()) - int(r.WeekStart) + 7) % 7
+		return NewCivilDate(y, m, d-back+7*n).In(loc)
+	case FreqMonthly:
+		return NewCivilDate(y, m+time.Month(n), 1).In(loc)
+	default:
+		return NewCivilDate(y+n, time.January, 1).In(loc)
+	}
+}
+
+// periodsBetween estimates the number of whole frequency units from a to b.
+// It may underestimate but never overestimates by more than one.
+func (r *RRule) periodsBetween(a, b time.Time) int {
+	switch r.Freq {
+	case FreqSecondly:
+		return int(b.Sub(a) / time.Second)
+	case FreqMinutely:
+		return int(b.Sub(a) / time.Minute)
+	case FreqHourly:
+		return int(b.Sub(a) / time.Hour)
+	case FreqDaily:
+		return DateOf(b).DaysSince(DateOf(a)) - 1
+	case FreqWeekly:
+		return (DateOf(b).DaysSince(DateOf(a)) - 1) / 7
+	case FreqMonthly:
+		return YearMonthOf(b).MonthsSince(YearMonthOf(a)) - 1
+	default:
+		return b.Year() - a.Year() - 1
+	}
+}
+
+// expand returns the sorted occurrences within the period starting at p.
+func (r *RRule) expand(p time.Time) []time.Time {
+	var days []CivilDate
+
+	switch r.Freq {
+	case FreqYearly:
+		days = r.yearDays(p.Year())
+	case FreqMonthly:
+		days = r.monthDays(YearMonthOf(p), true)
+	case FreqWeekly:
+		for i := 0; i < 7; i++ {
+			d := DateOf(p).AddDays(i)
+			if r.weekdayMatches(d) && r.monthMatches(d) {
+				days = append(days, d)
+			}
+		}
+	default:
+		d := DateOf(p)
+		if r.monthMatches(d) && r.monthDayMatches(d) && r.weekdayMatches(d) {
+			days = []CivilDate{d}
+		}
+	}
+
+	var times []time.Time
+	for _, d := range days {
+		for _, h := range r.values(r.ByHour, r.Start.Hour(), r.Freq >= FreqDaily, p.Hour()) {
+			for _, min := range r.values(r.ByMinute, r.Start.Minute(), r.Freq >= FreqHourly, p.Minute()) {
+				for _, sec := range r.values(r.BySecond, r.Start.Second(), r.Freq >= FreqMinutely, p.Second()) {
+					// RFC 5545 shifts times in a DST gap forward, as At does.
+					times = append(times, d.At(h, min, sec, p.Location()))
+				}
+			}
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	return r.applySetPos(times)
+}
+
+// values returns the values of a BYxxx time list. If the list is empty the
+// rule expands to the value of Start when the frequency is coarser than the
+// field, and otherwise keeps the value of the period itself.
+func (r *RRule) values(list []int, start int, coarser bool, period int) []int {
+	if len(list) > 0 {
+		if !coarser {
+			for _, v := range list {
+				if v == period {
+					return []int{period}
+				}
+			}
+			return nil
+		}
+		return list
+	}
+
+	if coarser {
+		return []int{start}
+	}
+
+	return []int{period}
+}
+
+// yearDays returns the days of a year selected by BYMONTH, BYMONTHDAY and
+// BYDAY, defaulting to the month and day of Start.
+func (r *RRule) yearDays(year int) []CivilDate {
+	if len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) > 0 {
+		// BYDAY on its own selects weekdays across the whole year, with
+		// ordinals counted within the year.
+		var days []CivilDate
+		for d := (CivilDate{year, time.January, 1}); d.Year == year; d = d.AddDays(1) {
+			if r.ordinalMatches(d, CivilDate{year, time.January, 1}, CivilDate{year, time.December, 31}) {
+				days = append(days, d)
+			}
+		}
+		return days
+	}
+
+	months := r.ByMonth
+	if len(months) == 0 {
+		months = []int{int(r.Start.Month())}
+	}
+
+	var days []CivilDate
+	for _, m := range months {
+		days = append(days, r.monthDays(YearMonth{year, time.Month(m)}, false)...)
+	}
+
+	return days
+}
+
+// monthDays returns the days of a month selected by BYMONTHDAY and BYDAY,
+// defaulting to the day of Start. If filterMonth is set, BYMONTH is applied.
+func (r *RRule) monthDays(ym YearMonth, filterMonth bool) []CivilDate {
+	if filterMonth && len(r.ByMonth) > 0 && !containsIntValue(r.ByMonth, int(ym.Month)) {
+		return nil
+	}
+
+	var days []CivilDate
+	for d := ym.FirstDay(); d.Month == ym.Month; d = d.AddDays(1) {
+		var ok bool
+
+		switch {
+		case len(r.ByMonthDay) > 0:
+			ok = r.monthDayMatches(d) && r.ordinalMatches(d, ym.FirstDay(), ym.LastDay())
+		case len(r.ByDay) > 0:
+			ok = r.ordinalMatches(d, ym.FirstDay(), ym.LastDay())
+		default:
+			ok = d.Day == r.Start.Day()
+		}
+
+		if ok {
+			days = append(days, d)
+		}
+	}
+
+	return days
+}
+
+// monthMatches applies BYMONTH.
+func (r *RRule) monthMatches(d CivilDate) bool {
+	return len(r.ByMonth) == 0 || containsIntValue(r.ByMonth, int(d.Month))
+}
+
+// monthDayMatches applies BYMONTHDAY, where negative values count from the
+// end of the month.
+func (r *RRule) monthDayMatches(d CivilDate) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+
+	last := daysIn(d.Year, d.Month)
+	for _, md := range r.ByMonthDay {
+		if md == d.Day || (md < 0 && last+md+1 == d.Day) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// weekdayMatches applies BYDAY without ordinals.
+func (r *RRule) weekdayMatches(d CivilDate) bool {
+	if len(r.ByDay) == 0 {
+		return r.Freq != FreqWeekly || d.Weekday() == r.Start.Weekday()
+	}
+
+	for _, wd := range r.ByDay {
+		if wd.Weekday == d.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ordinalMatches applies BYDAY with ordinals counted between first and last.
+func (r *RRule) ordinalMatches(d, first, last CivilDate) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	for _, wd := range r.ByDay {
+		if wd.Weekday != d.Weekday() {
+			continue
+		}
+
+		switch {
+		case wd.N == 0:
+			return true
+		case wd.N > 0 && d.DaysSince(first)/7+1 == wd.N:
+			return true
+		case wd.N < 0 && last.DaysSince(d)/7+1 == -wd.N:
+			return true
+		}
+	}
+
+	return false
+}
+
+// applySetPos keeps only the positions listed in BYSETPOS.
+func (r *RRule) applySetPos(times []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return times
+	}
+
+	var kept []time.Time
+	for i, t := range times {
+		for _, pos := range r.BySetPos {
+			if pos == i+1 || pos == i-len(times) {
+				kept = append(kept, t)
+				break
+			}
+		}
+	}
+
+	return kept
+}
+
+// parseRRuleInts parses a comma-separated list of integers within [min, max],
+// allowing negative values down to -max if negative is set.
+func parseRRuleInts(s string, min, max int, negative bool) ([]int, error) {
+	var values []int
+
+	for _, part := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if (v < min || v > max) && !(negative && v <= -min && v >= -max) {
+			return nil, detailed(ErrOutOfRange, strconv.Itoa(v))
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+// parseRRuleDays parses a BYDAY list such as "MO,WE,-1FR".
+func parseRRuleDays(s string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+
+	for _, part := range strings.Split(strings.ToUpper(s), ",") {
+		if len(part) < 2 {
+			return nil, detailed(ErrSyntax, "invalid weekday "+strconv.Quote(part))
+		}
+
+		name := part[len(part)-2:]
+		wd := -1
+		for i, d := range rruleDays {
+			if d == name {
+				wd = i
+			}
+		}
+		if wd < 0 {
+			return nil, detailed(ErrSyntax, "invalid weekday "+strconv.Quote(part))
+		}
+
+		n := 0
+		if prefix := part[:len(part)-2]; prefix != "" {
+			var err error
+			if n, err = strconv.Atoi(prefix); err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, detailed(ErrSyntax, "invalid weekday ordinal "+strconv.Quote(part))
+			}
+		}
+
+		days = append(days, WeekdayNum{N: n, Weekday: time.Weekday(wd)})
+	}
+
+	return days, nil
+}
+
+// parseRRuleTime parses an UNTIL value, which is a date, a floating
+// date-time in loc, or a UTC date-time.
+func parseRRuleTime(s string, loc *time.Location) (time.Time, error) {
+	switch {
+	case strings.HasSuffix(s, "Z"):
+		return time.Parse("20060102T150405Z", s)
+	case strings.Contains(s, "T"):
+		return time.ParseInLocation("20060102T150405", s, loc)
+	default:
+		d, err := time.ParseInLocation("20060102", s, loc)
+		return d.Add(day - time.Nanosecond), err
+	}
+}
+
+// joinInts formats a list of integers separated by commas.
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// containsIntValue reports whether values contains v.
+func containsIntValue(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRRuleExpansion checks the occurrences of common rules and that the
+// rules survive a round trip through String.
+func TestRRuleExpansion(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC) // Monday
+	at := func(m time.Month, d int) time.Time {
+		return time.Date(2024, m, d, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		rule     string
+		expected []time.Time
+	}{
+		{"FREQ=DAILY;INTERVAL=2;COUNT=3", []time.Time{at(1, 1), at(1, 3), at(1, 5)}},
+		{"FREQ=WEEKLY;COUNT=3;BYDAY=TU,TH", []time.Time{at(1, 2), at(1, 4), at(1, 9)}},
+		{"FREQ=MONTHLY;COUNT=2;BYDAY=-1FR", []time.Time{at(1, 26), at(2, 23)}},
+		{"FREQ=MONTHLY;COUNT=2;BYMONTHDAY=-1", []time.Time{at(1, 31), at(2, 29)}},
+		{"FREQ=MONTHLY;COUNT=2;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", []time.Time{at(1, 31), at(2, 29)}},
+		{"FREQ=YEARLY;UNTIL=20251231T000000Z;BYMONTH=3;BYDAY=2SU", []time.Time{at(3, 10), at(3, 10).AddDate(1, 0, -1)}},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseRRule("RRULE:"+test.rule, start)
+		if err != nil {
+			t.Errorf("ParseRRule(%q) returned error: %v", test.rule, err)
+			continue
+		}
+
+		if s := rule.String(); s != test.rule {
+			t.Errorf("String() = %q, expected %q", s, test.rule)
+		}
+
+		actual := Occurrences(rule, start.Add(-time.Second), 10)
+		if len(actual) != len(test.expected) {
+			t.Errorf("Occurrences(%q) = %v, expected %v", test.rule, actual, test.expected)
+			continue
+		}
+
+		for i := range actual {
+			if !actual[i].Equal(test.expected[i]) {
+				t.Errorf("Occurrences(%q)[%d] = %v, expected %v", test.rule, i, actual[i], test.expected[i])
+			}
+		}
+	}
+
+	hourly, _ := ParseRRule("FREQ=HOURLY;INTERVAL=6", start)
+	if next := hourly.Next(start.AddDate(10, 0, 0)); !next.Equal(time.Date(2034, time.January, 1, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next() far from start = %v", next)
+	}
+
+	for _, s := range []string{"INTERVAL=2", "FREQ=FORTNIGHTLY", "FREQ=DAILY;BYDAY=XX", "FREQ=DAILY;BYMONTH=13"} {
+		if _, err := ParseRRule(s, start); err == nil {
+			t.Errorf("ParseRRule(%q) expected error", s)
+		}
+	}
+}
+
+// TestRRuleDSTGap checks that an occurrence in a DST gap is shifted forward
+// by the length of the gap, as RFC 5545 requires.
+func TestRRuleDSTGap(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("America/New_York not available")
+	}
+
+	start := time.Date(2024, time.March, 9, 2, 30, 0, 0, newYork)
+	rule, err := ParseRRule("FREQ=DAILY;COUNT=3", start)
+	if err != nil {
+		t.Fatalf("ParseRRule() returned error: %v", err)
+	}
+
+	expected := []time.Time{
+		start,
+		time.Date(2024, time.March, 10, 7, 30, 0, 0, time.UTC), // 03:30 EDT
+		time.Date(2024, time.March, 11, 2, 30, 0, 0, newYork),
+	}
+
+	actual := Occurrences(rule, start.Add(-time.Second), 10)
+	if len(actual) != len(expected) {
+		t.Fatalf("Occurrences() = %v, expected %v", actual, expected)
+	}
+	for i := range actual {
+		if !actual[i].Equal(expected[i]) {
+			t.Errorf("Occurrences()[%d] = %v, expected %v", i, actual[i], expected[i])
+		}
+	}
+}
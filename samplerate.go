@@ -0,0 +1,109 @@
+package temporalis
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+// SamplesToDuration returns the playing time of the given number of samples
+// at rate samples per second, such as 44100 or 48000 for audio. Durations
+// that are not a whole number of nanoseconds, as 1/44100 s is not, are
+// rounded according to mode, by magnitude for negative counts. Results
+// beyond the range of time.Duration saturate. SamplesToDuration panics if
+// rate is not positive.
+func SamplesToDuration(samples int64, rate int, mode RoundingMode) time.Duration {
+	if rate <= 0 {
+		panic("temporalis: non-positive rate for SamplesToDuration")
+	}
+
+	return time.Duration(scaleRounded(samples, uint64(time.Second), uint64(rate), mode))
+}
+
+// DurationToSamples returns the number of samples at rate samples per second
+// that d spans, rounded according to mode. RoundDown gives the number of
+// whole samples that fit into d, and RoundUp the number of samples needed to
+// cover it. DurationToSamples panics if rate is not positive.
+func DurationToSamples(d time.Duration, rate int, mode RoundingMode) int64 {
+	if rate <= 0 {
+		panic("temporalis: non-positive rate for DurationToSamples")
+	}
+
+	return scaleRounded(int64(d), uint64(rate), uint64(time.Second), mode)
+}
+
+// TickInterval returns the time between ticks of a game server or
+// simulation that runs at rate ticks per second, rounded to the nearest
+// nanosecond, so 64 ticks per second is 15.625ms and 60 is 16.666667ms.
+// Accumulating the rounded interval drifts for rates that do not divide a
+// second; use TicksToDuration to find the time of a tick instead.
+// TickInterval panics if rate is not positive.
+func TickInterval(rate int) time.Duration {
+	if rate <= 0 {
+		panic("temporalis: non-positive rate for TickInterval")
+	}
+
+	return SamplesToDuration(1, rate, RoundNearest)
+}
+
+// TicksToDuration returns the time from tick zero to the given tick at rate
+// ticks per second, rounded according to mode. It is SamplesToDuration for
+// ticks, and computing every tick time from tick zero rather than adding up
+// intervals keeps a fixed-rate loop from drifting.
+func TicksToDuration(ticks int64, rate int, mode RoundingMode) time.Duration {
+	if rate <= 0 {
+		panic("temporalis: non-positive rate for TicksToDuration")
+	}
+
+	return SamplesToDuration(ticks, rate, mode)
+}
+
+// DurationToTicks returns the number of ticks at rate ticks per second in
+// d, rounded according to mode. With RoundDown it is the number of the tick
+// that is current d after tick zero, for deciding which simulation step a
+// timestamped client input belongs to.
+func DurationToTicks(d time.Duration, rate int, mode RoundingMode) int64 {
+	if rate <= 0 {
+		panic("temporalis: non-positive rate for DurationToTicks")
+	}
+
+	return DurationToSamples(d, rate, mode)
+}
+
+// scaleRounded returns n * num / den rounded according to mode, by
+// magnitude for negative n, saturating at the limits of int64. The product
+// is computed in 128 bits so that it cannot overflow.
+func scaleRounded(n int64, num, den uint64, mode RoundingMode) int64 {
+	magnitude := uint64(n)
+	if n < 0 {
+		magnitude = -magnitude
+	}
+
+	hi, lo := bits.Mul64(magnitude, num)
+	if hi >= den {
+		return saturate(n < 0)
+	}
+	q, rem := bits.Div64(hi, lo, den)
+
+	if rem != 0 && (mode == RoundUp || mode == RoundNearest && rem >= den-rem) {
+		q++
+	}
+
+	switch {
+	case q > math.MaxInt64:
+		return saturate(n < 0)
+	case n < 0:
+		return -int64(q)
+	default:
+		return int64(q)
+	}
+}
+
+// saturate returns the limit of int64 in the direction of the sign.
+func saturate(negative bool) int64 {
+	if negative {
+		return math.MinInt64
+	}
+
+	return math.MaxInt64
+}
@@ -0,0 +1,75 @@
+package temporalis
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSampleConversions tests rounding and saturation of sample and tick
+// conversions.
+func TestSampleConversions(t *testing.T) {
+	durations := []struct {
+		samples  int64
+		rate     int
+		mode     RoundingMode
+		expected time.Duration
+	}{
+		{48000, 48000, RoundDown, time.Second},
+		{1, 44100, RoundDown, 22675 * time.Nanosecond},
+		{1, 44100, RoundNearest, 22676 * time.Nanosecond},
+		{1, 44100, RoundUp, 22676 * time.Nanosecond},
+		{-1, 44100, RoundUp, -22676 * time.Nanosecond},
+		{441, 44100, RoundDown, 10 * time.Millisecond},
+		{math.MaxInt64, 1, RoundDown, math.MaxInt64},
+		{math.MinInt64, 48000, RoundDown, math.MinInt64},
+	}
+
+	for _, test := range durations {
+		if actual := SamplesToDuration(test.samples, test.rate, test.mode); actual != test.expected {
+			t.Errorf("SamplesToDuration(%d, %d, %v) = %v, expected %v", test.samples, test.rate, test.mode, actual, test.expected)
+		}
+	}
+
+	samples := []struct {
+		d        time.Duration
+		rate     int
+		mode     RoundingMode
+		expected int64
+	}{
+		{time.Second, 44100, RoundDown, 44100},
+		{10 * time.Millisecond, 44100, RoundDown, 441},
+		{22676 * time.Nanosecond, 44100, RoundDown, 1},
+		{22675 * time.Nanosecond, 44100, RoundDown, 0},
+		{22675 * time.Nanosecond, 44100, RoundNearest, 1},
+		{time.Nanosecond, 48000, RoundUp, 1},
+		{-time.Nanosecond, 48000, RoundUp, -1},
+		{math.MaxInt64, 192000, RoundDown, 1770887431076116},
+	}
+
+	for _, test := range samples {
+		if actual := DurationToSamples(test.d, test.rate, test.mode); actual != test.expected {
+			t.Errorf("DurationToSamples(%v, %d, %v) = %d, expected %d", test.d, test.rate, test.mode, actual, test.expected)
+		}
+	}
+}
+
+// TestTickConversions tests the tick interval and that tick times computed
+// from tick zero do not drift.
+func TestTickConversions(t *testing.T) {
+	if d := TickInterval(64); d != 15625*time.Microsecond {
+		t.Errorf("TickInterval(64) = %v, expected 15.625ms", d)
+	}
+	if d := TickInterval(60); d != 16666667*time.Nanosecond {
+		t.Errorf("TickInterval(60) = %v, expected 16.666667ms", d)
+	}
+	if d := TicksToDuration(60*3600, 60, RoundNearest); d != time.Hour {
+		t.Errorf("TicksToDuration(216000, 60) = %v, expected 1h", d)
+	}
+	if n := DurationToTicks(time.Second-time.Nanosecond, 60, RoundDown); n != 59 {
+		t.Errorf("DurationToTicks(999.999999ms, 60) = %d, expected 59", n)
+	}
+	if n := DurationToTicks(TicksToDuration(1000, 60, RoundUp), 60, RoundDown); n != 1000 {
+		t.Errorf("DurationToTicks(TicksToDuration(1000)) = %d, expected 1000", n)
+	}
+}
@@ -0,0 +1,196 @@
+package temporalis
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// OverlapPolicy decides what a recurring job does when an occurrence becomes
+// due while the previous run of the same job is still in progress.
+type OverlapPolicy int
+
+const (
+	// OverlapSkip drops the occurrence.
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue runs the occurrence as soon as the previous run returns.
+	// Occurrences missed during a long run are run back to back.
+	OverlapQueue
+	// OverlapConcurrent starts the occurrence alongside the previous run.
+	OverlapConcurrent
+)
+
+// Scheduler runs functions at absolute times or on recurrence rules such as
+// a CronRule or an RRule. Each job receives a context that is cancelled when
+// the job is cancelled, the scheduler is stopped, or the parent context
+// passed to NewScheduler is done. Panics in jobs are recovered and reported
+// to the panic handler instead of crashing the program.
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	clock  Clock
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	onPanic func(recovered any)
+	stopped bool
+}
+
+// Job is a handle to a function registered with a Scheduler.
+type Job struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Cancel stops future runs of the job and cancels the context of any run in
+// progress. It does not wait for running functions to return.
+func (j *Job) Cancel() {
+	j.cancel()
+}
+
+// Done returns a channel that is closed once the job will not be scheduled
+// again, because its recurrence ended, it was cancelled or the scheduler
+// stopped. Runs already started may still be in progress.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// NewScheduler returns a scheduler whose jobs stop when ctx is done. With
+// WithClock, the scheduler reads the time from and waits on the given clock,
+// so tests can drive it with a FakeClock.
+func NewScheduler(ctx context.Context, opts ...Option) *Scheduler {
+	o := NewOptions(opts...)
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Scheduler{ctx: ctx, cancel: cancel, clock: clockOrSystem(o.Clock)}
+}
+
+// SetPanicHandler sets the function called with the recovered value when a
+// job panics. By default panics are recovered and discarded.
+func (s *Scheduler) SetPanicHandler(handler func(recovered any)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onPanic = handler
+}
+
+// At runs fn once at t. If t is in the past fn runs immediately.
+func (s *Scheduler) At(t time.Time, fn func(ctx context.Context)) *Job {
+	return s.schedule(Once(t), t.Add(-time.Nanosecond), OverlapSkip, fn)
+}
+
+// Every runs fn at each occurrence of r after the current time, skipping
+// occurrences that become due while the previous run is still in progress.
+func (s *Scheduler) Every(r Recurrence, fn func(ctx context.Context)) *Job {
+	return s.EveryWithPolicy(r, OverlapSkip, fn)
+}
+
+// EveryWithPolicy runs fn at each occurrence of r after the current time,
+// handling overlapping runs according to policy. Registering a job on a
+// stopped scheduler returns a job that is already done.
+func (s *Scheduler) EveryWithPolicy(r Recurrence, policy OverlapPolicy, fn func(ctx context.Context)) *Job {
+	return s.schedule(r, s.clock.Now(), policy, fn)
+}
+
+// schedule registers a job for the occurrences of r after the given time.
+func (s *Scheduler) schedule(r Recurrence, after time.Time, policy OverlapPolicy, fn func(ctx context.Context)) *Job {
+	ctx, cancel := context.WithCancel(s.ctx)
+	job := &Job{cancel: cancel, done: make(chan struct{})}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		cancel()
+		close(job.done)
+		return job
+	}
+
+	s.wg.Add(1)
+	go s.loop(ctx, job, r, after, policy, fn)
+
+	return job
+}
+
+// Stop prevents further runs, cancels the contexts of running jobs and waits
+// for them to return. If ctx is done first, Stop returns its error while the
+// jobs finish in the background.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loop waits for each occurrence of r and runs fn according to policy.
+func (s *Scheduler) loop(ctx context.Context, job *Job, r Recurrence, after time.Time, policy OverlapPolicy, fn func(ctx context.Context)) {
+	defer s.wg.Done()
+	defer close(job.done)
+
+	var running sync.Mutex
+
+	for {
+		next := r.Next(after)
+		if next.IsZero() {
+			return
+		}
+
+		if err := sleepClock(ctx, s.clock, next.Sub(s.clock.Now())); err != nil {
+			return
+		}
+		after = next
+
+		switch policy {
+		case OverlapQueue:
+			s.run(ctx, fn)
+		case OverlapConcurrent:
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.run(ctx, fn)
+			}()
+		default:
+			if !running.TryLock() {
+				continue
+			}
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer running.Unlock()
+				s.run(ctx, fn)
+			}()
+		}
+	}
+}
+
+// run calls fn, recovering and reporting a panic.
+func (s *Scheduler) run(ctx context.Context, fn func(ctx context.Context)) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.mu.Lock()
+			handler := s.onPanic
+			s.mu.Unlock()
+
+			if handler != nil {
+				handler(recovered)
+			}
+		}
+	}()
+
+	fn(ctx)
+}
@@ -0,0 +1,132 @@
+package temporalis
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestScheduler checks one-off and recurring jobs, panic recovery and Stop.
+func TestScheduler(t *testing.T) {
+	s := NewScheduler(context.Background())
+
+	var panics atomic.Int32
+	s.SetPanicHandler(func(any) { panics.Add(1) })
+
+	once := make(chan struct{})
+	s.At(time.Now().Add(-time.Hour), func(context.Context) { close(once) })
+
+	select {
+	case <-once:
+	case <-time.After(time.Second):
+		t.Fatal("At() with a past time did not run")
+	}
+
+	var runs atomic.Int32
+	job := s.Every(EveryInterval(time.Now(), 5*time.Millisecond), func(context.Context) {
+		if runs.Add(1) == 1 {
+			panic("boom")
+		}
+	})
+
+	time.Sleep(60 * time.Millisecond)
+	job.Cancel()
+	<-job.Done()
+
+	if runs.Load() < 2 {
+		t.Errorf("Every() ran %d times, expected at least 2", runs.Load())
+	}
+	if panics.Load() != 1 {
+		t.Errorf("panic handler called %d times, expected 1", panics.Load())
+	}
+
+	blocked := make(chan struct{})
+	s.At(time.Now(), func(ctx context.Context) {
+		close(blocked)
+		<-ctx.Done()
+	})
+	<-blocked
+
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() = %v, expected nil", err)
+	}
+
+	late := s.At(time.Now(), func(context.Context) { t.Error("job ran after Stop()") })
+	<-late.Done()
+}
+
+// TestSchedulerOverlapQueue checks that occurrences missed during a long run
+// are run back to back, one at a time, once it returns.
+func TestSchedulerOverlapQueue(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	s := NewScheduler(context.Background(), WithClock(clock))
+	defer s.Stop(context.Background())
+
+	var active, maxActive atomic.Int32
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	s.EveryWithPolicy(EveryInterval(start, time.Minute), OverlapQueue, func(context.Context) {
+		if n := active.Add(1); n > maxActive.Load() {
+			maxActive.Store(n)
+		}
+		started <- struct{}{}
+		<-release
+		active.Add(-1)
+	})
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	<-started
+
+	// Two more occurrences become due while the first run blocks.
+	clock.Advance(2 * time.Minute)
+	for range 2 {
+		release <- struct{}{}
+		<-started
+	}
+	release <- struct{}{}
+
+	clock.BlockUntil(1)
+	select {
+	case <-started:
+		t.Error("OverlapQueue ran more occurrences than were due")
+	default:
+	}
+	if n := maxActive.Load(); n != 1 {
+		t.Errorf("OverlapQueue ran %d occurrences at once, expected 1", n)
+	}
+}
+
+// TestSchedulerOverlapConcurrent checks that an occurrence starts while the
+// previous run is still in progress.
+func TestSchedulerOverlapConcurrent(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	s := NewScheduler(context.Background(), WithClock(clock))
+
+	var active atomic.Int32
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	s.EveryWithPolicy(EveryInterval(start, time.Minute), OverlapConcurrent, func(context.Context) {
+		active.Add(1)
+		started <- struct{}{}
+		<-release
+	})
+
+	for range 2 {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		<-started
+	}
+
+	if n := active.Load(); n != 2 {
+		t.Errorf("OverlapConcurrent has %d runs in progress, expected 2", n)
+	}
+
+	close(release)
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() = %v, expected nil", err)
+	}
+}
@@ -0,0 +1,143 @@
+package temporalis
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+)
+
+// MinTimeValue and MaxTimeValue are sentinels for "unbounded in the past"
+// and "unbounded in the future", such as the start of a record that has
+// always been valid or the end of one that is valid until further notice.
+// Unlike the zero time, which is also January 1 of year 1 and easily
+// produced by accident, they sort correctly against every real time. Both
+// lie in the range that RFC 3339 can represent. Use IsMin and IsMax rather
+// than == to test for them, since == also compares locations.
+var (
+	MinTimeValue = time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)
+	MaxTimeValue = time.Date(9999, time.December, 31, 23, 59, 59, 999999999, time.UTC)
+)
+
+// IsMin reports whether t is MinTimeValue or earlier.
+func IsMin(t time.Time) bool {
+	return !t.After(MinTimeValue)
+}
+
+// IsMax reports whether t is MaxTimeValue or later.
+func IsMax(t time.Time) bool {
+	return !t.Before(MaxTimeValue)
+}
+
+// ClampTime returns t limited to the range [MinTimeValue, MaxTimeValue].
+func ClampTime(t time.Time) time.Time {
+	switch {
+	case IsMin(t):
+		return MinTimeValue
+	case IsMax(t):
+		return MaxTimeValue
+	default:
+		return t
+	}
+}
+
+// SafeAdd returns t+d, except that the sentinels stay where they are and
+// results beyond them saturate to them, so that MaxTimeValue plus an hour is
+// still MaxTimeValue rather than a time in year 10000.
+func SafeAdd(t time.Time, d time.Duration) time.Time {
+	if IsMin(t) || IsMax(t) {
+		return ClampTime(t)
+	}
+
+	return ClampTime(t.Add(d))
+}
+
+// LowerBound is a time that encodes MinTimeValue as JSON null, for fields
+// such as valid_from where a missing value means "since always". Other
+// values are encoded as RFC 3339 strings. IsZero reports true for the
+// sentinel, so the field is left out with the omitzero tag option. An unset
+// LowerBound, holding the zero time, also means MinTimeValue.
+type LowerBound struct {
+	time.Time
+}
+
+// Value returns the bound, or MinTimeValue if it is unset.
+func (b LowerBound) Value() time.Time {
+	if b.Time.IsZero() || IsMin(b.Time) {
+		return MinTimeValue
+	}
+
+	return b.Time
+}
+
+// IsZero reports whether the bound is unbounded.
+func (b LowerBound) IsZero() bool {
+	return IsMin(b.Value())
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b LowerBound) MarshalJSON() ([]byte, error) {
+	return marshalBound(b.Value(), b.IsZero())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *LowerBound) UnmarshalJSON(data []byte) error {
+	return unmarshalBound(data, &b.Time, MinTimeValue)
+}
+
+// UpperBound is like LowerBound for MaxTimeValue, for fields such as
+// valid_until where a missing value means "until further notice".
+type UpperBound struct {
+	time.Time
+}
+
+// Value returns the bound, or MaxTimeValue if it is unset.
+func (b UpperBound) Value() time.Time {
+	if b.Time.IsZero() || IsMax(b.Time) {
+		return MaxTimeValue
+	}
+
+	return b.Time
+}
+
+// IsZero reports whether the bound is unbounded.
+func (b UpperBound) IsZero() bool {
+	return IsMax(b.Value())
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b UpperBound) MarshalJSON() ([]byte, error) {
+	return marshalBound(b.Value(), b.IsZero())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *UpperBound) UnmarshalJSON(data []byte) error {
+	return unmarshalBound(data, &b.Time, MaxTimeValue)
+}
+
+// marshalBound encodes t as an RFC 3339 string, or null if unbounded.
+func marshalBound(t time.Time, unbounded bool) ([]byte, error) {
+	if unbounded {
+		return jsonNull, nil
+	}
+
+	return strconv.AppendQuote(nil, t.Format(RFC3339Nano)), nil
+}
+
+// unmarshalBound decodes null as the sentinel and strings as RFC 3339 times.
+func unmarshalBound(data []byte, t *time.Time, sentinel time.Time) error {
+	if bytes.Equal(data, jsonNull) {
+		*t = sentinel
+		return nil
+	}
+
+	return unmarshalJSONText(data, t, func(text []byte) error {
+		parsed, err := parseRFC3339("UnmarshalJSON", string(text))
+		if err != nil {
+			return err
+		}
+
+		*t = parsed
+
+		return nil
+	})
+}
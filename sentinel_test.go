@@ -0,0 +1,58 @@
+package temporalis
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestSentinels checks the sentinel predicates and saturating arithmetic.
+func TestSentinels(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if IsMin(time.Time{}) || IsMax(now) || !IsMin(MinTimeValue) || !IsMax(MaxTimeValue.In(time.Local)) {
+		t.Errorf("IsMin/IsMax misclassified a time")
+	}
+
+	if !MinTimeValue.Before(time.Time{}) {
+		t.Errorf("MinTimeValue is not before the zero time")
+	}
+
+	if got := SafeAdd(MaxTimeValue, time.Hour); !got.Equal(MaxTimeValue) {
+		t.Errorf("SafeAdd(MaxTimeValue, 1h) = %v, expected MaxTimeValue", got)
+	}
+
+	if got := SafeAdd(now, time.Hour); !got.Equal(now.Add(time.Hour)) {
+		t.Errorf("SafeAdd(now, 1h) = %v, expected %v", got, now.Add(time.Hour))
+	}
+}
+
+// TestBoundJSON checks that unbounded values encode as null and are left out
+// with omitzero, and that null decodes to the sentinels.
+func TestBoundJSON(t *testing.T) {
+	type validity struct {
+		From  LowerBound `json:"from"`
+		Until UpperBound `json:"until,omitzero"`
+	}
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	data, err := json.Marshal(validity{From: LowerBound{now}})
+	if err != nil || string(data) != `{"from":"2024-01-01T00:00:00Z"}` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+
+	data, _ = json.Marshal(validity{Until: UpperBound{now}})
+	if string(data) != `{"from":null,"until":"2024-01-01T00:00:00Z"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var v validity
+	if err := json.Unmarshal([]byte(`{"from":null,"until":null}`), &v); err != nil {
+		t.Fatalf("json.Unmarshal() returned error: %v", err)
+	}
+
+	if !IsMin(v.From.Time) || !IsMax(v.Until.Time) {
+		t.Errorf("json.Unmarshal(null) = %v, %v, expected the sentinels", v.From.Time, v.Until.Time)
+	}
+}
@@ -0,0 +1,70 @@
+// Command temporalis-server serves the temporalis HTTP API described in
+// package server.
+//
+// Usage:
+//
+//	temporalis-server [-addr address] [-zone zone] [-holidays list]
+//
+// The -zone flag sets the zone used when a request names none, by default
+// the local zone, and -holidays a comma-separated list of dates such as
+// 2024-12-25 that the business calendar treats as closed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goify/temporalis"
+	"github.com/goify/temporalis/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	zone := flag.String("zone", "", "default zone")
+	holidays := flag.String("holidays", "", "comma-separated holiday dates")
+	flag.Parse()
+
+	s, err := newServer(*zone, *holidays)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "temporalis-server:", err)
+		os.Exit(1)
+	}
+
+	srv := &http.Server{Addr: *addr, Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	log.Printf("listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
+
+// newServer configures a server from the flags.
+func newServer(zone, holidays string) (*server.Server, error) {
+	var opts []temporalis.Option
+
+	if zone != "" {
+		loc, err := temporalis.LoadLocation(zone)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, temporalis.WithLocation(loc))
+	}
+
+	cal := temporalis.NewBusinessCalendar()
+	for _, h := range strings.Split(holidays, ",") {
+		if h = strings.TrimSpace(h); h == "" {
+			continue
+		}
+
+		d, err := temporalis.ParseCivilDate(h)
+		if err != nil {
+			return nil, err
+		}
+		cal.AddHoliday(d, "")
+	}
+	opts = append(opts, temporalis.WithCalendar(cal))
+
+	return server.New(opts...), nil
+}
@@ -0,0 +1,7 @@
+module github.com/goify/temporalis/server
+
+go 1.24
+
+require github.com/goify/temporalis v0.0.0
+
+replace github.com/goify/temporalis => ../
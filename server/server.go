@@ -0,0 +1,268 @@
+// Package server exposes the core operations of temporalis over HTTP with
+// JSON responses, so that services written in other languages can use the
+// same parsing, zone and business-day logic as the Go services of a company
+// instead of reimplementing it. It lives in its own module so that the
+// temporalis package itself keeps no server code.
+//
+// The endpoints take their arguments as query parameters:
+//
+//	GET /v1/parse?value=EOD&zone=Europe/Berlin
+//	GET /v1/parse?value=2024-05-01+09:00&layout=2006-01-02+15:04
+//	GET /v1/convert?time=2024-05-01T09:00:00Z&to=Asia/Tokyo
+//	GET /v1/business-days?from=2024-04-29&to=2024-05-10&holidays=2024-05-01
+//	GET /v1/schedule?expr=30+9+*+*+1-5&after=2024-05-01T00:00:00Z&n=3
+//
+// Parameters must be URL-encoded; in particular the semicolons of an RRULE
+// are written as %3B. Times in responses are formatted as RFC 3339.
+// Failures are reported with status 400 and a body of the form
+// {"error": "..."}.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// maxScheduleRuns bounds the number of runs returned by /v1/schedule.
+const maxScheduleRuns = 1000
+
+// Server serves the endpoints. Its defaults, such as the zone used when a
+// request names none and the business calendar, come from TP.
+type Server struct {
+	TP  *temporalis.Temporalis
+	mux *http.ServeMux
+}
+
+// New returns a server whose defaults are taken from opts, as for
+// temporalis.New.
+func New(opts ...temporalis.Option) *Server {
+	s := &Server{TP: temporalis.New(opts...), mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /v1/parse", s.handle(s.parse))
+	s.mux.HandleFunc("GET /v1/convert", s.handle(s.convert))
+	s.mux.HandleFunc("GET /v1/business-days", s.handle(s.businessDays))
+	s.mux.HandleFunc("GET /v1/schedule", s.handle(s.schedule))
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle adapts an endpoint that returns a value to be encoded as JSON. All
+// errors are caused by the arguments of the request.
+func (s *Server) handle(endpoint func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		v, err := endpoint(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			v = map[string]string{"error": err.Error()}
+		}
+
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+// parse parses value with layout, or as a deadline phrase such as "EOD" or
+// "noon friday" if no layout is given.
+func (s *Server) parse(r *http.Request) (any, error) {
+	q := r.URL.Query()
+
+	value, err := required(q.Get("value"), "value")
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := s.zone(q.Get("zone"))
+	if err != nil {
+		return nil, err
+	}
+
+	var t time.Time
+	if layout := q.Get("layout"); layout != "" {
+		if loc != nil {
+			t, err = temporalis.ParseInLocation(layout, value, loc)
+		} else {
+			t, err = s.TP.Parse(layout, value)
+		}
+	} else {
+		var opts []temporalis.Option
+		if loc != nil {
+			opts = append(opts, temporalis.WithLocation(loc))
+		}
+		t, err = s.TP.ParseDeadline(value, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return struct {
+		Time time.Time `json:"time"`
+	}{t}, nil
+}
+
+// convert expresses an RFC 3339 time in another zone.
+func (s *Server) convert(r *http.Request) (any, error) {
+	q := r.URL.Query()
+
+	t, err := parseTime(q.Get("time"), "time")
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.zone(q.Get("to"))
+	if err != nil {
+		return nil, err
+	}
+	if to == nil {
+		return nil, errors.New("missing parameter to")
+	}
+
+	t = t.In(to)
+	name, offset := t.Zone()
+
+	return struct {
+		Time         time.Time `json:"time"`
+		Abbreviation string    `json:"abbreviation"`
+		Offset       int       `json:"offset"`
+	}{t, name, offset}, nil
+}
+
+// businessDays counts the business days from one date to another, both
+// inclusive, with the calendar of the server and any extra holidays.
+func (s *Server) businessDays(r *http.Request) (any, error) {
+	q := r.URL.Query()
+
+	from, err := parseDate(q.Get("from"), "from")
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := parseDate(q.Get("to"), "to")
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []temporalis.CivilDate
+	for _, h := range strings.Split(q.Get("holidays"), ",") {
+		if h == "" {
+			continue
+		}
+
+		d, err := parseDate(h, "holidays")
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, d)
+	}
+
+	return struct {
+		BusinessDays int `json:"businessDays"`
+	}{temporalis.BusinessDaysBetween(from, to, holidays, s.TP.Options()...)}, nil
+}
+
+// schedule returns the next runs of a cron expression or RRULE.
+func (s *Server) schedule(r *http.Request) (any, error) {
+	q := r.URL.Query()
+
+	expr, err := required(q.Get("expr"), "expr")
+	if err != nil {
+		return nil, err
+	}
+
+	after := s.TP.Now()
+	if q.Has("after") {
+		if after, err = parseTime(q.Get("after"), "after"); err != nil {
+			return nil, err
+		}
+	}
+
+	loc, err := s.zone(q.Get("zone"))
+	if err != nil {
+		return nil, err
+	}
+	if loc != nil {
+		after = after.In(loc)
+	}
+
+	n := 5
+	if q.Has("n") {
+		if n, err = strconv.Atoi(q.Get("n")); err != nil || n < 1 || n > maxScheduleRuns {
+			return nil, fmt.Errorf("n must be from 1 to %d", maxScheduleRuns)
+		}
+	}
+
+	// An RRULE occurs at its start, which Next would skip.
+	t := after
+	var rule temporalis.Recurrence
+	if strings.Contains(strings.ToUpper(expr), "FREQ=") {
+		rule, err = temporalis.ParseRRule(expr, after)
+		t = after.Add(-time.Nanosecond)
+	} else {
+		var cron *temporalis.CronRule
+		cron, err = temporalis.ParseCron(expr)
+		rule = cron
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []time.Time{}
+	for len(runs) < n {
+		if t = rule.Next(t); t.IsZero() {
+			break
+		}
+		runs = append(runs, t)
+	}
+
+	return struct {
+		Runs []time.Time `json:"runs"`
+	}{runs}, nil
+}
+
+// zone loads the named zone, returning nil for an empty name.
+func (s *Server) zone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	return temporalis.LoadLocation(name)
+}
+
+// required returns value, or an error naming the parameter if it is empty.
+func required(value, param string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("missing parameter %s", param)
+	}
+
+	return value, nil
+}
+
+// parseTime parses an RFC 3339 parameter.
+func parseTime(value, param string) (time.Time, error) {
+	if _, err := required(value, param); err != nil {
+		return time.Time{}, err
+	}
+
+	return temporalis.Parse(time.RFC3339Nano, value)
+}
+
+// parseDate parses a date parameter written as 2006-01-02.
+func parseDate(value, param string) (temporalis.CivilDate, error) {
+	if _, err := required(value, param); err != nil {
+		return temporalis.CivilDate{}, err
+	}
+
+	return temporalis.ParseCivilDate(value)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goify/temporalis"
+)
+
+// get performs a request against s and decodes the JSON response.
+func get(t *testing.T, s *Server, url string) (int, map[string]any) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("GET %s returned invalid JSON %q: %v", url, rec.Body.String(), err)
+	}
+
+	return rec.Code, body
+}
+
+// TestEndpoints tests each endpoint on fixed inputs.
+func TestEndpoints(t *testing.T) {
+	clock := temporalis.NewFakeClock(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	s := New(temporalis.WithClock(clock), temporalis.WithLocation(time.UTC))
+
+	tests := []struct {
+		url      string
+		key      string
+		expected any
+	}{
+		{"/v1/parse?value=EOD", "time", "2024-05-01T17:00:00Z"},
+		{"/v1/parse?value=2024-05-01+09:00&layout=2006-01-02+15:04&zone=Asia/Tokyo", "time", "2024-05-01T09:00:00+09:00"},
+		{"/v1/convert?time=2024-05-01T09:00:00Z&to=Asia/Tokyo", "time", "2024-05-01T18:00:00+09:00"},
+		{"/v1/convert?time=2024-05-01T09:00:00Z&to=Asia/Tokyo", "offset", float64(9 * 3600)},
+		{"/v1/business-days?from=2024-04-29&to=2024-05-10&holidays=2024-05-01", "businessDays", float64(9)},
+		{"/v1/schedule?expr=30+9+*+*+1-5&n=2", "runs", []any{"2024-05-02T09:30:00Z", "2024-05-03T09:30:00Z"}},
+		{"/v1/schedule?expr=FREQ%3DWEEKLY%3BBYDAY%3DMO&after=2024-05-06T00:00:00Z&n=1", "runs", []any{"2024-05-06T00:00:00Z"}},
+	}
+
+	for _, test := range tests {
+		code, body := get(t, s, test.url)
+		if code != http.StatusOK {
+			t.Errorf("GET %s = %d %v, expected 200", test.url, code, body)
+			continue
+		}
+
+		if actual, _ := json.Marshal(body[test.key]); string(actual) != mustMarshal(test.expected) {
+			t.Errorf("GET %s %s = %s, expected %s", test.url, test.key, actual, mustMarshal(test.expected))
+		}
+	}
+}
+
+// TestErrors tests that bad arguments are reported as 400 with a message.
+func TestErrors(t *testing.T) {
+	s := New()
+
+	for _, url := range []string{
+		"/v1/parse",
+		"/v1/parse?value=whenever",
+		"/v1/convert?time=2024-05-01T09:00:00Z",
+		"/v1/convert?time=2024-05-01T09:00:00Z&to=Nowhere/Special",
+		"/v1/business-days?from=2024-04-29&to=2024-02-30",
+		"/v1/schedule?expr=61+*+*+*+*",
+		"/v1/schedule?expr=@daily&n=0",
+	} {
+		code, body := get(t, s, url)
+		if code != http.StatusBadRequest || body["error"] == "" {
+			t.Errorf("GET %s = %d %v, expected 400 with an error", url, code, body)
+		}
+	}
+}
+
+// mustMarshal encodes v as JSON.
+func mustMarshal(v any) string {
+	b, _ := json.Marshal(v)
+
+	return string(b)
+}
@@ -0,0 +1,72 @@
+package temporalis
+
+import (
+	"slices"
+	"time"
+)
+
+// NightHours splits a worked interval into the time within the daily night
+// window, which typically earns a premium, and the rest. The window is
+// applied to the wall clock in loc, or in the location of i.Start if loc is
+// nil, and may wrap around midnight, as 22:00 to 06:00 does. Time is
+// measured as elapsed, so a night shift across a DST change is an hour
+// shorter or longer than its wall-clock times suggest, as it is for the
+// worker.
+func NightHours(i Interval, window ClockRange, loc *time.Location) (premium, regular time.Duration) {
+	return splitHours(i, loc, func(d CivilDate, loc *time.Location) []Interval {
+		return window.intervalsOn(d, loc)
+	})
+}
+
+// WeekendHours splits a worked interval into the time on weekend days in loc,
+// or in the location of i.Start if loc is nil, and the rest. If weekend is
+// empty, Saturday and Sunday are the weekend.
+func WeekendHours(i Interval, weekend []time.Weekday, loc *time.Location) (premium, regular time.Duration) {
+	if len(weekend) == 0 {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+
+	return splitHours(i, loc, func(d CivilDate, loc *time.Location) []Interval {
+		if !slices.Contains(weekend, d.Weekday()) {
+			return nil
+		}
+
+		return []Interval{{Start: d.In(loc), End: d.AddDays(1).In(loc)}}
+	})
+}
+
+// splitHours returns how much of i is covered by the intervals that premium
+// returns for each date in loc that i touches, and how much is not.
+func splitHours(i Interval, loc *time.Location, premium func(d CivilDate, loc *time.Location) []Interval) (covered, rest time.Duration) {
+	if i.IsEmpty() {
+		return 0, 0
+	}
+	if loc == nil {
+		loc = i.Start.Location()
+	}
+
+	last := DateOf(i.End.In(loc))
+	for d := DateOf(i.Start.In(loc)); !d.After(last); d = d.AddDays(1) {
+		for _, p := range premium(d, loc) {
+			if overlap, ok := p.Intersect(i); ok {
+				covered += overlap.Duration()
+			}
+		}
+	}
+
+	return covered, i.Duration() - covered
+}
+
+// intervalsOn returns the parts of the date in loc that fall within the
+// range: one interval, or two if the range wraps around midnight.
+func (r ClockRange) intervalsOn(d CivilDate, loc *time.Location) []Interval {
+	start, end := r.Start.OnDate(d, loc), r.End.OnDate(d, loc)
+	if r.Start.Before(r.End) {
+		return []Interval{{Start: start, End: end}}
+	}
+
+	return []Interval{
+		{Start: d.In(loc), End: end},
+		{Start: start, End: d.AddDays(1).In(loc)},
+	}
+}
@@ -0,0 +1,88 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNightHours tests night premiums for shifts across midnight and DST
+// changes.
+func TestNightHours(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	night := ClockRange{Start: NewTimeOfDay(22, 0, 0, 0), End: NewTimeOfDay(6, 0, 0, 0)}
+
+	tests := []struct {
+		name             string
+		start, end       time.Time
+		premium, regular time.Duration
+	}{
+		{"day shift", time.Date(2024, 3, 5, 8, 0, 0, 0, berlin), time.Date(2024, 3, 5, 16, 0, 0, 0, berlin), 0, 8 * time.Hour},
+		{"late shift", time.Date(2024, 3, 5, 18, 0, 0, 0, berlin), time.Date(2024, 3, 6, 2, 0, 0, 0, berlin), 4 * time.Hour, 4 * time.Hour},
+		{"night shift", time.Date(2024, 3, 5, 20, 0, 0, 0, berlin), time.Date(2024, 3, 6, 8, 0, 0, 0, berlin), 8 * time.Hour, 4 * time.Hour},
+		// Clocks go forward at 02:00 on 31 March, so the night is an hour
+		// shorter.
+		{"spring forward", time.Date(2024, 3, 30, 22, 0, 0, 0, berlin), time.Date(2024, 3, 31, 6, 0, 0, 0, berlin), 7 * time.Hour, 0},
+		// And back at 03:00 on 27 October, an hour longer.
+		{"fall back", time.Date(2024, 10, 26, 22, 0, 0, 0, berlin), time.Date(2024, 10, 27, 6, 0, 0, 0, berlin), 9 * time.Hour, 0},
+		{"two nights", time.Date(2024, 3, 5, 0, 0, 0, 0, berlin), time.Date(2024, 3, 7, 0, 0, 0, 0, berlin), 16 * time.Hour, 32 * time.Hour},
+	}
+
+	for _, test := range tests {
+		premium, regular := NightHours(Interval{Start: test.start, End: test.end}, night, nil)
+		if premium != test.premium || regular != test.regular {
+			t.Errorf("NightHours(%s) = %v, %v, expected %v, %v", test.name, premium, regular, test.premium, test.regular)
+		}
+	}
+
+	// The window applies in the given location, not that of the times.
+	utc := Interval{Start: time.Date(2024, 3, 5, 20, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 5, 22, 0, 0, 0, time.UTC)}
+	if premium, regular := NightHours(utc, night, berlin); premium != time.Hour || regular != time.Hour {
+		t.Errorf("NightHours() in Berlin = %v, %v, expected 1h, 1h", premium, regular)
+	}
+}
+
+// TestWeekendHours tests weekend premiums with the default and a custom
+// weekend.
+func TestWeekendHours(t *testing.T) {
+	// Friday 20:00 to Saturday 04:00.
+	shift := Interval{Start: time.Date(2024, 3, 8, 20, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 9, 4, 0, 0, 0, time.UTC)}
+
+	if premium, regular := WeekendHours(shift, nil, nil); premium != 4*time.Hour || regular != 4*time.Hour {
+		t.Errorf("WeekendHours() = %v, %v, expected 4h, 4h", premium, regular)
+	}
+
+	friSat := []time.Weekday{time.Friday, time.Saturday}
+	if premium, regular := WeekendHours(shift, friSat, nil); premium != 8*time.Hour || regular != 0 {
+		t.Errorf("WeekendHours(Friday and Saturday) = %v, %v, expected 8h, 0s", premium, regular)
+	}
+
+	if premium, regular := WeekendHours(Interval{}, nil, nil); premium != 0 || regular != 0 {
+		t.Errorf("WeekendHours(empty) = %v, %v, expected 0s, 0s", premium, regular)
+	}
+}
+
+// TestShiftHoursMidnightGap tests premiums in a zone whose clocks skip
+// midnight, where the day starts at 01:00.
+func TestShiftHoursMidnightGap(t *testing.T) {
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+
+	// Clocks in Santiago jump from 00:00 to 01:00 on Sunday, 8 September
+	// 2024, so the weekend lasts 47 hours.
+	week := Interval{Start: time.Date(2024, 9, 6, 12, 0, 0, 0, santiago), End: time.Date(2024, 9, 9, 12, 0, 0, 0, santiago)}
+	if premium, regular := WeekendHours(week, nil, santiago); premium != 47*time.Hour || regular != 24*time.Hour {
+		t.Errorf("WeekendHours() = %v, %v, expected 47h, 24h", premium, regular)
+	}
+
+	night := ClockRange{Start: NewTimeOfDay(22, 0, 0, 0), End: NewTimeOfDay(6, 0, 0, 0)}
+	shift := Interval{Start: time.Date(2024, 9, 7, 20, 0, 0, 0, santiago), End: time.Date(2024, 9, 8, 10, 0, 0, 0, santiago)}
+	if premium, regular := NightHours(shift, night, santiago); premium != 7*time.Hour || regular != 6*time.Hour {
+		t.Errorf("NightHours() = %v, %v, expected 7h, 6h", premium, regular)
+	}
+}
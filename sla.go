@@ -0,0 +1,28 @@
+package temporalis
+
+import "time"
+
+// SLADeadline returns the instant by which sla of business time has passed
+// after start, counting only the business hours of the business days of
+// cal on the wall clock of start's location. A four-hour SLA on a ticket
+// opened at 16:00 on a Friday, with hours from 09:00 to 17:00, is due at
+// 12:00 on Monday, or on Tuesday if Monday is a holiday. A deadline that
+// falls exactly at the close of business is reported at the close rather
+// than at the next opening. A non-positive sla yields start. If cal has no
+// business days, the zero time is returned.
+func SLADeadline(start time.Time, sla time.Duration, cal *BusinessCalendar) time.Time {
+	if sla <= 0 {
+		return start
+	}
+
+	return AddBusinessHours(start, sla, cal)
+}
+
+// SLARemaining returns the business time left from now until deadline,
+// counted like SLADeadline on the wall clock of deadline's location. If the
+// deadline has passed, the result is negative and its magnitude is the
+// business time by which it was missed, so a breach on Friday evening does
+// not grow over the weekend.
+func SLARemaining(now, deadline time.Time, cal *BusinessCalendar) time.Duration {
+	return BusinessTimeBetween(now.In(deadline.Location()), deadline, cal)
+}
@@ -0,0 +1,84 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSLADeadline tests deadlines across closing time, weekends, holidays
+// and overnight hours.
+func TestSLADeadline(t *testing.T) {
+	// Friday 15 March 2024; Monday 18 March is a holiday.
+	cal := NewBusinessCalendar(NewCivilDate(2024, 3, 18))
+	cal.SetBusinessHours(ClockRange{Start: NewTimeOfDay(9, 0, 0, 0), End: NewTimeOfDay(17, 0, 0, 0)})
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		sla      time.Duration
+		cal      *BusinessCalendar
+		expected time.Time
+	}{
+		{"same day", at(15, 10, 0), 2 * time.Hour, cal, at(15, 12, 0)},
+		{"at close", at(15, 13, 0), 4 * time.Hour, cal, at(15, 17, 0)},
+		{"over holiday weekend", at(15, 16, 0), 4 * time.Hour, cal, at(19, 12, 0)},
+		{"before opening", at(14, 6, 0), 30 * time.Minute, cal, at(14, 9, 30)},
+		{"from weekend", at(16, 12, 0), 8 * time.Hour, cal, at(19, 17, 0)},
+		{"zero", at(16, 12, 0), 0, cal, at(16, 12, 0)},
+		{"whole days", at(15, 20, 0), 24 * time.Hour, nil, at(18, 20, 0)},
+	}
+
+	for _, test := range tests {
+		if actual := SLADeadline(test.start, test.sla, test.cal); !actual.Equal(test.expected) {
+			t.Errorf("SLADeadline(%s) = %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+
+	// A night shift that starts on weekdays and runs into the next day.
+	night := NewBusinessCalendar()
+	night.SetBusinessHours(ClockRange{Start: NewTimeOfDay(22, 0, 0, 0), End: NewTimeOfDay(6, 0, 0, 0)})
+	if actual, expected := SLADeadline(at(16, 2, 0), 6*time.Hour, night), at(18, 24, 0); !actual.Equal(expected) {
+		t.Errorf("SLADeadline(night shift) = %v, expected %v", actual, expected)
+	}
+
+	closed := NewBusinessCalendar()
+	closed.SetWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	if actual := SLADeadline(at(15, 10, 0), time.Hour, closed); !actual.IsZero() {
+		t.Errorf("SLADeadline() without business days = %v, expected the zero time", actual)
+	}
+}
+
+// TestSLARemaining tests business time left and overdue, and that a DST
+// change does not count as business time.
+func TestSLARemaining(t *testing.T) {
+	cal := NewBusinessCalendar()
+	cal.SetBusinessHours(ClockRange{Start: NewTimeOfDay(9, 0, 0, 0), End: NewTimeOfDay(17, 0, 0, 0)})
+
+	deadline := time.Date(2024, 3, 18, 11, 0, 0, 0, time.UTC)
+	friday := time.Date(2024, 3, 15, 15, 0, 0, 0, time.UTC)
+	if actual := SLARemaining(friday, deadline, cal); actual != 4*time.Hour {
+		t.Errorf("SLARemaining() = %v, expected 4h", actual)
+	}
+	if actual := SLARemaining(deadline.AddDate(0, 0, 1), deadline, cal); actual != -8*time.Hour {
+		t.Errorf("SLARemaining() a day late = %v, expected -8h", actual)
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	// The clocks go forward early on Sunday 31 March 2024.
+	start := time.Date(2024, 3, 29, 16, 0, 0, 0, berlin)
+	due := SLADeadline(start, 2*time.Hour, cal)
+	if expected := time.Date(2024, 4, 1, 10, 0, 0, 0, berlin); !due.Equal(expected) {
+		t.Errorf("SLADeadline() across DST = %v, expected %v", due, expected)
+	}
+	if actual := SLARemaining(start, due, cal); actual != 2*time.Hour {
+		t.Errorf("SLARemaining() across DST = %v, expected 2h", actual)
+	}
+}
@@ -0,0 +1,206 @@
+package temporalis
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// SlidingWindow counts events over a trailing window of time, for questions
+// such as "how many errors in the last five minutes". Events older than the
+// window are pruned automatically. By default every timestamp is kept, so
+// memory grows with the number of events in the window; a window created
+// with NewBucketedSlidingWindow uses a fixed ring of counters instead. It is
+// safe for concurrent use.
+type SlidingWindow struct {
+	// Clock provides the time. If nil, SystemClock is used. It must be set
+	// before the first event.
+	Clock Clock
+
+	window time.Duration
+	mu     sync.Mutex
+	events []time.Time // in order; nil in bucketed mode
+
+	// Bucketed mode: slot i counts the events of bucket number epochs[i],
+	// where bucket number n covers [n*bucket, (n+1)*bucket) in Unix time.
+	bucket time.Duration
+	counts []int
+	epochs []int64
+}
+
+// NewSlidingWindow returns a counter that keeps the exact timestamps of the
+// events in the last window. It panics if window is not positive.
+func NewSlidingWindow(window time.Duration) *SlidingWindow {
+	if window <= 0 {
+		panic("temporalis: non-positive window for NewSlidingWindow")
+	}
+
+	return &SlidingWindow{window: window}
+}
+
+// NewBucketedSlidingWindow returns a counter that uses constant memory by
+// dividing the window into n buckets and counting events per bucket. Counts
+// are then only as precise as a bucket: a query includes every bucket that
+// overlaps it. It panics if window or n is not positive, or if the window
+// is shorter than n nanoseconds.
+func NewBucketedSlidingWindow(window time.Duration, n int) *SlidingWindow {
+	if window <= 0 || n <= 0 || window < time.Duration(n) {
+		panic("temporalis: invalid window or bucket count for NewBucketedSlidingWindow")
+	}
+
+	epochs := make([]int64, n)
+	for i := range epochs {
+		epochs[i] = -1 << 63
+	}
+
+	return &SlidingWindow{window: window, bucket: window / time.Duration(n), counts: make([]int, n), epochs: epochs}
+}
+
+// Window returns the length of the window.
+func (w *SlidingWindow) Window() time.Duration {
+	return w.window
+}
+
+// Record records an event at the current time.
+func (w *SlidingWindow) Record() {
+	w.RecordAt(clockOrSystem(w.Clock).Now())
+}
+
+// RecordAt records an event at t, which may be in the past, for example when
+// replaying a log. Events that are already outside the window are ignored.
+// A bucketed window counts an event in the future in the current bucket.
+func (w *SlidingWindow) RecordAt(t time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := clockOrSystem(w.Clock).Now()
+	if !t.After(now.Add(-w.window)) {
+		return
+	}
+
+	if w.counts != nil {
+		n := w.bucketOf(minTime(t, now))
+		slot := w.slotOf(n)
+		if w.epochs[slot] != n {
+			w.epochs[slot], w.counts[slot] = n, 0
+		}
+		w.counts[slot]++
+
+		return
+	}
+
+	w.prune(now)
+
+	i, _ := slices.BinarySearchFunc(w.events, t, time.Time.Compare)
+	w.events = slices.Insert(w.events, i, t)
+}
+
+// Count returns the number of events in the window.
+func (w *SlidingWindow) Count() int {
+	return w.CountSince(w.window)
+}
+
+// CountSince returns the number of events in the last d, which is limited to
+// the window.
+func (w *SlidingWindow) CountSince(d time.Duration) int {
+	now := clockOrSystem(w.Clock).Now()
+
+	return w.count(now.Add(-min(d, w.window)), now, true)
+}
+
+// Rate returns the average number of events per the given duration over the
+// window, such as events per second for time.Second.
+func (w *SlidingWindow) Rate(per time.Duration) float64 {
+	return float64(w.Count()) * float64(per) / float64(w.window)
+}
+
+// EventsBetween returns the number of events in the interval. Only events
+// still in the window are counted.
+func (w *SlidingWindow) EventsBetween(i Interval) int {
+	return w.count(i.Start, i.End, false)
+}
+
+// Reset discards all events.
+func (w *SlidingWindow) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.events = nil
+	for i := range w.counts {
+		w.counts[i], w.epochs[i] = 0, -1<<63
+	}
+}
+
+// count returns the number of events after from and before to, or at to if
+// inclusive is set, and at from if it is not.
+func (w *SlidingWindow) count(from, to time.Time, inclusive bool) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := clockOrSystem(w.Clock).Now()
+
+	if w.counts != nil {
+		// No bucket after the current one holds events, so the loop stays
+		// within the ring however far away the bounds are.
+		current := w.bucketOf(now)
+		first, last := max(w.bucketOf(from), current-int64(len(w.counts))+1), w.bucketOf(to)
+		if !inclusive {
+			last = w.bucketOf(to.Add(-1))
+		}
+		last = min(last, current)
+		if first > last {
+			return 0
+		}
+
+		total := 0
+		for n := first; n <= last; n++ {
+			if slot := w.slotOf(n); w.epochs[slot] == n {
+				total += w.counts[slot]
+			}
+		}
+
+		return total
+	}
+
+	w.prune(now)
+
+	total := 0
+	for _, t := range w.events {
+		switch {
+		case inclusive && t.After(from) && !t.After(to):
+			total++
+		case !inclusive && !t.Before(from) && t.Before(to):
+			total++
+		}
+	}
+
+	return total
+}
+
+// prune drops the events that have left the window. w.mu must be held.
+func (w *SlidingWindow) prune(now time.Time) {
+	cutoff := now.Add(-w.window)
+
+	expired := 0
+	for expired < len(w.events) && !w.events[expired].After(cutoff) {
+		expired++
+	}
+	w.events = w.events[expired:]
+}
+
+// bucketOf returns the number of the bucket containing t.
+func (w *SlidingWindow) bucketOf(t time.Time) int64 {
+	ns, size := t.UnixNano(), int64(w.bucket)
+
+	n := ns / size
+	if ns%size < 0 {
+		n--
+	}
+
+	return n
+}
+
+// slotOf returns the ring slot of bucket number n.
+func (w *SlidingWindow) slotOf(n int64) int {
+	return int(uint64(n) % uint64(len(w.counts)))
+}
@@ -0,0 +1,97 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSlidingWindow checks counting, pruning, rates and interval queries on
+// the exact counter.
+func TestSlidingWindow(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	w := NewSlidingWindow(time.Minute)
+	w.Clock = clock
+
+	for i := 0; i < 6; i++ {
+		w.Record()
+		clock.Advance(10 * time.Second)
+	}
+
+	// Events at 0s, 10s, ..., 50s; now is 60s, so the event at 0s has left.
+	if n := w.Count(); n != 5 {
+		t.Errorf("Count() = %d, expected 5", n)
+	}
+	if n := w.CountSince(25 * time.Second); n != 2 {
+		t.Errorf("CountSince(25s) = %d, expected 2", n)
+	}
+	if n := w.CountSince(time.Hour); n != 5 {
+		t.Errorf("CountSince(1h) = %d, expected 5", n)
+	}
+	if r := w.Rate(time.Minute); r != 5 {
+		t.Errorf("Rate(1m) = %v, expected 5", r)
+	}
+
+	between := Interval{Start: start.Add(10 * time.Second), End: start.Add(30 * time.Second)}
+	if n := w.EventsBetween(between); n != 2 {
+		t.Errorf("EventsBetween() = %d, expected 2", n)
+	}
+
+	w.RecordAt(start.Add(15 * time.Second))
+	w.RecordAt(start)
+	if n := w.EventsBetween(between); n != 3 {
+		t.Errorf("EventsBetween() after RecordAt() = %d, expected 3", n)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if n := w.Count(); n != 0 {
+		t.Errorf("Count() after the window passed = %d, expected 0", n)
+	}
+}
+
+// TestBucketedSlidingWindow checks that the bucketed counter agrees with the
+// exact one at bucket granularity and forgets old buckets.
+func TestBucketedSlidingWindow(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	w := NewBucketedSlidingWindow(time.Minute, 6)
+	w.Clock = clock
+
+	for i := 0; i < 12; i++ {
+		w.Record()
+		w.Record()
+		clock.Advance(5 * time.Second)
+	}
+
+	// Two events every 5s for a minute; now is 60s. The buckets cover
+	// [10s, 70s), which hold the events from 10s to 55s.
+	if n := w.Count(); n != 20 {
+		t.Errorf("Count() = %d, expected 20", n)
+	}
+	if n := w.CountSince(10 * time.Second); n != 4 {
+		t.Errorf("CountSince(10s) = %d, expected 4", n)
+	}
+
+	clock.Advance(45 * time.Second)
+	if n := w.Count(); n != 4 {
+		t.Errorf("Count() after 45s = %d, expected 4", n)
+	}
+
+	w.Reset()
+	if n := w.Count(); n != 0 {
+		t.Errorf("Count() after Reset() = %d, expected 0", n)
+	}
+
+	// Bounds far from now only cover the buckets in the ring, and an event
+	// in the future counts in the current bucket.
+	now := clock.Now()
+	w.RecordAt(now.Add(time.Hour))
+	far := Interval{Start: now.Add(-time.Hour), End: now.AddDate(50, 0, 0)}
+	if n := w.EventsBetween(far); n != 1 {
+		t.Errorf("EventsBetween(%v) = %d, expected 1", far, n)
+	}
+	if n := w.EventsBetween(Interval{Start: now.Add(time.Hour), End: now.AddDate(50, 0, 0)}); n != 0 {
+		t.Errorf("EventsBetween() after now = %d, expected 0", n)
+	}
+}
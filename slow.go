@@ -0,0 +1,184 @@
+package temporalis
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SlowOperation describes an operation that took longer than its threshold.
+type SlowOperation struct {
+	// Label names the operation.
+	Label string
+	// Stack holds the labels of the operations tracked by a Tracker that
+	// enclose it, outermost first, followed by Label.
+	Stack []string
+	// Start is when the operation started.
+	Start time.Time
+	// Duration is how long the operation took.
+	Duration time.Duration
+	// Threshold is the duration it was expected to stay within.
+	Threshold time.Duration
+}
+
+// String returns the stack of labels and the duration, such as
+// "handle > db query took 1.2s (threshold 500ms)".
+func (op SlowOperation) String() string {
+	return strings.Join(op.Stack, " > ") + " took " + op.Duration.String() + " (threshold " + op.Threshold.String() + ")"
+}
+
+// operationLabelsKey is the context key of the labels of the enclosing
+// tracked operations.
+type operationLabelsKey struct{}
+
+// operationLabels returns the labels of the operations tracked in ctx
+// followed by label.
+func operationLabels(ctx context.Context, label string) []string {
+	labels, _ := ctx.Value(operationLabelsKey{}).([]string)
+
+	return append(slices.Clip(labels), label)
+}
+
+// WarnIfSlow starts timing an operation and returns a function to call when
+// it is done, which calls callback if the operation took longer than
+// threshold and returns how long it took:
+//
+//	defer temporalis.WarnIfSlow(ctx, 500*time.Millisecond, "db query", func(op temporalis.SlowOperation) {
+//		log.Printf("slow: %v", op)
+//	})()
+//
+// The stack of the reported operation includes the operations that ctx was
+// derived from by Tracker.Track.
+func WarnIfSlow(ctx context.Context, threshold time.Duration, label string, callback func(SlowOperation)) func() time.Duration {
+	return timeOperation(SystemClock, operationLabels(ctx, label), threshold, callback)
+}
+
+// timeOperation times the operation with the given stack of labels on c
+// and returns the function that ends it.
+func timeOperation(c Clock, stack []string, threshold time.Duration, callback func(SlowOperation)) func() time.Duration {
+	start := c.Now()
+	sw := &Stopwatch{Clock: c}
+	sw.Start()
+
+	return func() time.Duration {
+		d := sw.Stop()
+		if d > threshold && callback != nil {
+			callback(SlowOperation{
+				Label:     stack[len(stack)-1],
+				Stack:     stack,
+				Start:     start,
+				Duration:  d,
+				Threshold: threshold,
+			})
+		}
+
+		return d
+	}
+}
+
+// Tracker records the most recent operations that exceeded their
+// thresholds, for exposing on a debug page or in metrics. Thresholds can be
+// set per label, with a default for the others. A Tracker is safe for
+// concurrent use.
+type Tracker struct {
+	// OnSlow, if set, is also called for each slow operation. It must be
+	// set before the first operation is tracked.
+	OnSlow func(SlowOperation)
+
+	clock     Clock
+	threshold time.Duration
+	limit     int
+
+	mu         sync.Mutex
+	thresholds map[string]time.Duration
+	slow       []SlowOperation
+	count      int
+}
+
+// NewTracker returns a tracker that considers operations slow once they
+// take longer than threshold and keeps the last limit of them. The
+// WithClock option selects the clock that times them. It panics if limit
+// is not positive.
+func NewTracker(threshold time.Duration, limit int, opts ...Option) *Tracker {
+	if limit <= 0 {
+		panic("temporalis: non-positive limit for NewTracker")
+	}
+
+	o := NewOptions(opts...)
+
+	return &Tracker{
+		clock:      clockOrSystem(o.Clock),
+		threshold:  threshold,
+		limit:      limit,
+		thresholds: make(map[string]time.Duration),
+	}
+}
+
+// SetThreshold sets the threshold for operations with the given label,
+// replacing the default threshold of the tracker for them.
+func (t *Tracker) SetThreshold(label string, threshold time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.thresholds[label] = threshold
+}
+
+// Track starts timing an operation and returns a context for the work it
+// does, which adds label to the stack of operations tracked within it, and
+// a function to call when it is done. That function records the operation
+// if it was slow and returns how long it took.
+func (t *Tracker) Track(ctx context.Context, label string) (context.Context, func() time.Duration) {
+	t.mu.Lock()
+	threshold, ok := t.thresholds[label]
+	if !ok {
+		threshold = t.threshold
+	}
+	t.mu.Unlock()
+
+	stack := operationLabels(ctx, label)
+
+	return context.WithValue(ctx, operationLabelsKey{}, stack), timeOperation(t.clock, stack, threshold, t.record)
+}
+
+// Slow returns the recorded slow operations, oldest first.
+func (t *Tracker) Slow() []SlowOperation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.slow)
+}
+
+// Count returns the number of slow operations recorded since the tracker
+// was created or reset, including those no longer kept.
+func (t *Tracker) Count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.count
+}
+
+// Reset discards the recorded slow operations.
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.slow, t.count = nil, 0
+}
+
+// record keeps op, dropping the oldest operation if the limit is reached,
+// and passes it to OnSlow.
+func (t *Tracker) record(op SlowOperation) {
+	t.mu.Lock()
+	if len(t.slow) == t.limit {
+		t.slow = slices.Delete(t.slow, 0, 1)
+	}
+	t.slow = append(t.slow, op)
+	t.count++
+	t.mu.Unlock()
+
+	if t.OnSlow != nil {
+		t.OnSlow(op)
+	}
+}
@@ -0,0 +1,83 @@
+package temporalis
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestWarnIfSlow tests that the callback is only called for operations that
+// exceed the threshold.
+func TestWarnIfSlow(t *testing.T) {
+	var reported []SlowOperation
+	callback := func(op SlowOperation) { reported = append(reported, op) }
+
+	WarnIfSlow(context.Background(), time.Hour, "fast", callback)()
+	if len(reported) != 0 {
+		t.Errorf("WarnIfSlow() reported %v, expected nothing", reported)
+	}
+
+	done := WarnIfSlow(context.Background(), 0, "slow", callback)
+	time.Sleep(time.Millisecond)
+	if d := done(); len(reported) != 1 || reported[0].Label != "slow" || reported[0].Duration != d {
+		t.Errorf("WarnIfSlow() reported %v, expected slow taking %v", reported, d)
+	}
+}
+
+// TestTracker tests thresholds per label, the stack of nested operations
+// and the limit on recorded operations.
+func TestTracker(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	tr := NewTracker(time.Second, 2, WithClock(clock))
+	tr.SetThreshold("cache", 10*time.Millisecond)
+
+	var callbacks int
+	tr.OnSlow = func(SlowOperation) { callbacks++ }
+
+	ctx, doneRequest := tr.Track(context.Background(), "request")
+
+	_, doneCache := tr.Track(ctx, "cache")
+	clock.Advance(20 * time.Millisecond)
+	doneCache()
+
+	_, doneQuery := tr.Track(ctx, "query")
+	clock.Advance(500 * time.Millisecond)
+	doneQuery()
+
+	var warned SlowOperation
+	done := WarnIfSlow(ctx, 0, "inline", func(op SlowOperation) { warned = op })
+	time.Sleep(time.Millisecond)
+	done()
+	if expected := []string{"request", "inline"}; !slices.Equal(warned.Stack, expected) {
+		t.Errorf("WarnIfSlow() stack = %v, expected %v", warned.Stack, expected)
+	}
+
+	clock.Advance(time.Second)
+	if d := doneRequest(); d != 1520*time.Millisecond {
+		t.Errorf("Track() duration = %v, expected 1.52s", d)
+	}
+
+	slow := tr.Slow()
+	if len(slow) != 2 || tr.Count() != 2 || callbacks != 2 {
+		t.Fatalf("Slow() = %v with count %d and %d callbacks, expected 2 of each", slow, tr.Count(), callbacks)
+	}
+	if expected := "request > cache took 20ms (threshold 10ms)"; slow[0].String() != expected {
+		t.Errorf("Slow()[0] = %q, expected %q", slow[0], expected)
+	}
+	if slow[1].Label != "request" || !slow[1].Start.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Slow()[1] = %+v, expected the request", slow[1])
+	}
+
+	_, done = tr.Track(context.Background(), "other")
+	clock.Advance(2 * time.Second)
+	done()
+	if slow := tr.Slow(); len(slow) != 2 || slow[0].Label != "request" || tr.Count() != 3 {
+		t.Errorf("Slow() after the limit = %v with count %d, expected the last two of 3", slow, tr.Count())
+	}
+
+	tr.Reset()
+	if len(tr.Slow()) != 0 || tr.Count() != 0 {
+		t.Errorf("Slow() after Reset() = %v, expected nothing", tr.Slow())
+	}
+}
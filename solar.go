@@ -0,0 +1,184 @@
+package temporalis
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SolarEvent is a daily event defined by the position of the sun.
+type SolarEvent int
+
+const (
+	// Sunrise is when the upper edge of the sun appears on the horizon.
+	Sunrise SolarEvent = iota
+	// Sunset is when the upper edge of the sun disappears below the
+	// horizon.
+	Sunset
+	// SolarNoon is when the sun is highest in the sky.
+	SolarNoon
+	// CivilDawn is when the centre of the sun is six degrees below the
+	// horizon in the morning, which is about when it becomes light enough
+	// to work outdoors.
+	CivilDawn
+	// CivilDusk is the evening counterpart of CivilDawn, commonly used to
+	// switch on street lighting.
+	CivilDusk
+)
+
+var solarEventNames = [...]string{"sunrise", "sunset", "solar noon", "civil dawn", "civil dusk"}
+
+// String returns the name of the event, such as "sunset".
+func (e SolarEvent) String() string {
+	if e < 0 || int(e) >= len(solarEventNames) {
+		return "SolarEvent(" + strconv.Itoa(int(e)) + ")"
+	}
+
+	return solarEventNames[e]
+}
+
+// On returns the instant of the event on the given date at the given
+// latitude and longitude, in degrees north and east, in UTC. The date is the
+// local date at that longitude. The boolean result is false when the event
+// does not happen that day, which is the case for sunrise and sunset during
+// polar day and night. The result is accurate to about a minute outside the
+// polar regions; refraction and elevation above the horizon are modelled
+// with the standard values only.
+func (e SolarEvent) On(d CivilDate, lat, lon float64) (time.Time, bool) {
+	transit, sinDecl := solarDay(d, lon)
+	if e == SolarNoon {
+		return fromJ2000Days(transit), true
+	}
+
+	altitude := -0.833
+	if e == CivilDawn || e == CivilDusk {
+		altitude = -6
+	}
+
+	hour, ok := solarHourAngle(lat, sinDecl, altitude)
+	if !ok {
+		return time.Time{}, false
+	}
+	if e == Sunrise || e == CivilDawn {
+		hour = -hour
+	}
+
+	return fromJ2000Days(transit + hour), true
+}
+
+// solarDay returns the solar transit on the given date at the given
+// longitude, in days since 2000-01-01 12:00 UTC, and the sine of the
+// declination of the sun on that day.
+func solarDay(d CivilDate, lon float64) (transit, sinDecl float64) {
+	const rad = math.Pi / 180
+
+	// Days since 2000-01-01 12:00 UTC at the local mean noon of the date.
+	n := float64(daysFromCivil(d.Year, d.Month, d.Day)-daysFromCivil(2000, time.January, 1)) + 0.0008 - lon/360
+
+	m := math.Mod(357.5291+0.98560028*n, 360)
+	c := 1.9148*math.Sin(m*rad) + 0.02*math.Sin(2*m*rad) + 0.0003*math.Sin(3*m*rad)
+	lambda := math.Mod(m+c+180+102.9372, 360)
+
+	transit = n + 0.0053*math.Sin(m*rad) - 0.0069*math.Sin(2*lambda*rad)
+	sinDecl = math.Sin(lambda*rad) * math.Sin(23.4397*rad)
+
+	return transit, sinDecl
+}
+
+// solarHourAngle returns how long, as a fraction of a day, before or after
+// the transit the centre of the sun is at the given altitude in degrees, and
+// false if it does not reach that altitude that day.
+func solarHourAngle(lat, sinDecl, altitude float64) (float64, bool) {
+	const rad = math.Pi / 180
+
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	cosHour := (math.Sin(altitude*rad) - math.Sin(lat*rad)*sinDecl) / (math.Cos(lat*rad) * cosDecl)
+	if cosHour < -1 || cosHour > 1 || math.IsNaN(cosHour) {
+		return 0, false
+	}
+
+	return math.Acos(cosHour) / rad / 360, true
+}
+
+// fromJ2000Days returns the UTC instant that is the given number of days
+// after 2000-01-01 12:00 UTC, to the second.
+func fromJ2000Days(days float64) time.Time {
+	return time.Unix(unixJ2000, 0).UTC().Add(time.Duration(math.Round(days*secondsPerDay)) * time.Second)
+}
+
+// SolarRecurrence returns a recurrence that fires at the event every day at
+// the given latitude and longitude, shifted by offset, so that
+// SolarRecurrence(Sunset, lat, lon, -30*time.Minute) fires half an hour
+// before sunset. Because the time is computed afresh for every day, it can
+// be passed to Scheduler.Every to drive lighting or irrigation that follows
+// the seasons. Days on which the event does not happen are skipped. The
+// occurrences are in the location of the time passed to Next.
+func SolarRecurrence(event SolarEvent, lat, lon float64, offset time.Duration) Recurrence {
+	return RecurrenceFunc(func(after time.Time) time.Time {
+		// Start a day early, since the local date at lon may lag the UTC
+		// date and the offset may move an event across midnight.
+		d := DateOf(after.UTC()).AddDays(-1 - int(offset.Abs()/(24*time.Hour)))
+
+		// Polar night can suppress sunrise for months; give up after a
+		// year, which contains every event that can happen at all.
+		for range 370 + int(offset.Abs()/(24*time.Hour)) {
+			if t, ok := event.On(d, lat, lon); ok {
+				if t = t.Add(offset); t.After(after) {
+					return t.In(after.Location())
+				}
+			}
+			d = d.AddDays(1)
+		}
+
+		return time.Time{}
+	})
+}
+
+// ParseSolarRecurrence parses a rule such as "sunset", "30 minutes before
+// sunset" or "1h after sunrise" and returns the matching SolarRecurrence at
+// the given latitude and longitude. The events are named "sunrise",
+// "sunset", "solar noon" (or "noon"), "civil dawn" (or "dawn") and "civil
+// dusk" (or "dusk"), and durations are accepted as by ParseHumanDuration.
+// Errors are returned as *ParseError wrapping ErrSyntax.
+func ParseSolarRecurrence(rule string, lat, lon float64) (Recurrence, error) {
+	const fn = "ParseSolarRecurrence"
+
+	text := strings.Join(strings.Fields(strings.ToLower(rule)), " ")
+
+	var offset time.Duration
+	for _, word := range []string{" before ", " after "} {
+		amount, rest, ok := strings.Cut(text, word)
+		if !ok {
+			continue
+		}
+
+		d, err := ParseHumanDuration(amount)
+		if err != nil {
+			return nil, syntaxError(fn, rule, "invalid offset "+strconv.Quote(amount))
+		}
+		if word == " before " {
+			d = -d
+		}
+		offset, text = d, rest
+		break
+	}
+
+	var event SolarEvent
+	switch text {
+	case "sunrise":
+		event = Sunrise
+	case "sunset":
+		event = Sunset
+	case "solar noon", "noon":
+		event = SolarNoon
+	case "civil dawn", "dawn":
+		event = CivilDawn
+	case "civil dusk", "dusk":
+		event = CivilDusk
+	default:
+		return nil, syntaxError(fn, rule, "unknown solar event "+strconv.Quote(text))
+	}
+
+	return SolarRecurrence(event, lat, lon, offset), nil
+}
@@ -0,0 +1,141 @@
+package temporalis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestSolarEventOn tests solar events against published times.
+func TestSolarEventOn(t *testing.T) {
+	tests := []struct {
+		event    SolarEvent
+		date     CivilDate
+		lat, lon float64
+		expected time.Time
+	}{
+		// London at the June solstice.
+		{Sunrise, CivilDate{2024, time.June, 21}, 51.5074, -0.1278, time.Date(2024, 6, 21, 3, 43, 0, 0, time.UTC)},
+		{Sunset, CivilDate{2024, time.June, 21}, 51.5074, -0.1278, time.Date(2024, 6, 21, 20, 21, 0, 0, time.UTC)},
+		{SolarNoon, CivilDate{2024, time.June, 21}, 51.5074, -0.1278, time.Date(2024, 6, 21, 12, 2, 0, 0, time.UTC)},
+		// New York at the December solstice, when sunset is before midnight
+		// UTC.
+		{Sunrise, CivilDate{2024, time.December, 21}, 40.7128, -74.0060, time.Date(2024, 12, 21, 12, 16, 0, 0, time.UTC)},
+		{Sunset, CivilDate{2024, time.December, 21}, 40.7128, -74.0060, time.Date(2024, 12, 21, 21, 32, 0, 0, time.UTC)},
+		// Sydney, with sunset after midnight UTC.
+		{Sunset, CivilDate{2024, time.January, 1}, -33.8688, 151.2093, time.Date(2024, 1, 1, 9, 10, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		actual, ok := test.event.On(test.date, test.lat, test.lon)
+		if !ok || actual.Sub(test.expected).Abs() > 2*time.Minute {
+			t.Errorf("%v.On(%v) = %v, %v, expected %v", test.event, test.date, actual, ok, test.expected)
+		}
+	}
+
+	// Tromsø has midnight sun in June and polar night in December.
+	for _, date := range []CivilDate{{2024, time.June, 21}, {2024, time.December, 21}} {
+		if actual, ok := Sunrise.On(date, 69.6492, 18.9553); ok {
+			t.Errorf("Sunrise.On(%v) in Tromsø = %v, expected no sunrise", date, actual)
+		}
+	}
+	if _, ok := SolarNoon.On(CivilDate{2024, time.December, 21}, 69.6492, 18.9553); !ok {
+		t.Error("SolarNoon.On() in Tromsø reported no solar noon")
+	}
+}
+
+// TestSolarRecurrence tests that occurrences follow the changing sunset.
+func TestSolarRecurrence(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	r := SolarRecurrence(Sunset, 51.5074, -0.1278, -30*time.Minute)
+
+	after := time.Date(2024, 6, 21, 12, 0, 0, 0, london)
+	first := r.Next(after)
+	expected := time.Date(2024, 6, 21, 20, 51, 0, 0, london)
+	if first.Sub(expected).Abs() > 2*time.Minute || first.Location() != london {
+		t.Errorf("Next(%v) = %v, expected %v", after, first, expected)
+	}
+
+	second := r.Next(first)
+	if second.YearDay() != 174 {
+		t.Errorf("Next(%v) = %v, expected the next evening", first, second)
+	}
+
+	winter := r.Next(time.Date(2024, 12, 21, 12, 0, 0, 0, london))
+	expected = time.Date(2024, 12, 21, 15, 24, 0, 0, london)
+	if winter.Sub(expected).Abs() > 2*time.Minute {
+		t.Errorf("Next() in December = %v, expected %v", winter, expected)
+	}
+
+	// Sunrise in Tromsø returns with the sun in mid-January.
+	polar := SolarRecurrence(Sunrise, 69.6492, 18.9553, 0).Next(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC))
+	if polar.Month() != time.January || polar.Year() != 2025 {
+		t.Errorf("Next() during polar night = %v, expected January 2025", polar)
+	}
+}
+
+// TestParseSolarRecurrence tests the rule syntax and its errors.
+func TestParseSolarRecurrence(t *testing.T) {
+	after := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule   string
+		event  SolarEvent
+		offset time.Duration
+	}{
+		{"sunset", Sunset, 0},
+		{"30 minutes before sunset", Sunset, -30 * time.Minute},
+		{"1h after  Sunrise", Sunrise, time.Hour},
+		{"dusk", CivilDusk, 0},
+		{"15m before solar noon", SolarNoon, -15 * time.Minute},
+	}
+
+	for _, test := range tests {
+		r, err := ParseSolarRecurrence(test.rule, 51.5074, -0.1278)
+		if err != nil {
+			t.Errorf("ParseSolarRecurrence(%q) returned error: %v", test.rule, err)
+			continue
+		}
+
+		expected := SolarRecurrence(test.event, 51.5074, -0.1278, test.offset).Next(after)
+		if actual := r.Next(after); !actual.Equal(expected) {
+			t.Errorf("ParseSolarRecurrence(%q).Next() = %v, expected %v", test.rule, actual, expected)
+		}
+	}
+
+	for _, rule := range []string{"", "moonrise", "soon before sunset", "30m before"} {
+		if _, err := ParseSolarRecurrence(rule, 0, 0); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ParseSolarRecurrence(%q) error = %v, expected ErrSyntax", rule, err)
+		}
+	}
+}
+
+// TestSchedulerSolarRecurrence tests a sunset job driven by a fake clock.
+func TestSchedulerSolarRecurrence(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC))
+	s := NewScheduler(context.Background(), WithClock(clock))
+	defer s.Stop(context.Background())
+
+	ran := make(chan struct{}, 1)
+	s.Every(SolarRecurrence(Sunset, 51.5074, -0.1278, -30*time.Minute), func(context.Context) { ran <- struct{}{} })
+
+	clock.BlockUntil(1)
+	clock.Set(time.Date(2024, 6, 21, 19, 40, 0, 0, time.UTC))
+	select {
+	case <-ran:
+		t.Fatal("job ran before half an hour before sunset")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Set(time.Date(2024, 6, 21, 19, 55, 0, 0, time.UTC))
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("job did not run half an hour before sunset")
+	}
+}
@@ -0,0 +1,74 @@
+package temporalis
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// Splay returns a host-stable offset in [0, base*fraction). The offset is
+// derived from a hash of the host name, so the same machine always waits the
+// same amount of time while a fleet of machines spreads out evenly. If the
+// host name cannot be determined a random offset is returned instead.
+// A non-positive base or fraction yields zero; fraction is capped at 1.
+func Splay(base time.Duration, fraction float64) time.Duration {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return RandomSplay(base, fraction)
+	}
+
+	return SplayFor(host, base, fraction)
+}
+
+// SplayFor is like Splay but derives the offset from key instead of the host
+// name, for agents that have a more meaningful identity such as a node ID.
+func SplayFor(key string, base time.Duration, fraction float64) time.Duration {
+	limit := splayLimit(base, fraction)
+	if limit <= 0 {
+		return 0
+	}
+
+	return time.Duration(stableHash(key) % uint64(limit))
+}
+
+// RandomSplay returns a uniformly random offset in [0, base*fraction), for
+// callers that want a different offset on every start.
+func RandomSplay(base time.Duration, fraction float64) time.Duration {
+	limit := splayLimit(base, fraction)
+	if limit <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(limit)))
+}
+
+// WaitSplayed blocks for the host-stable splay of base and fraction, as
+// computed by Splay, and returns early with the context error if ctx is
+// cancelled first.
+func WaitSplayed(ctx context.Context, base time.Duration, fraction float64) error {
+	return SleepContext(ctx, Splay(base, fraction))
+}
+
+// splayLimit returns the exclusive upper bound of a splay offset.
+func splayLimit(base time.Duration, fraction float64) time.Duration {
+	if base <= 0 || fraction <= 0 {
+		return 0
+	}
+
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	return time.Duration(float64(base) * fraction)
+}
+
+// stableHash returns a 64-bit FNV-1a hash of key that is stable across
+// processes and releases.
+func stableHash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+
+	return h.Sum64()
+}
@@ -0,0 +1,29 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSplayFor checks that the splay for a key is stable, stays within the
+// requested bound, and differs between keys.
+func TestSplayFor(t *testing.T) {
+	base := 10 * time.Minute
+
+	a := SplayFor("node-a", base, 0.5)
+	if a != SplayFor("node-a", base, 0.5) {
+		t.Errorf("SplayFor() is not stable for the same key")
+	}
+
+	if a < 0 || a >= 5*time.Minute {
+		t.Errorf("SplayFor() = %v, expected a value in [0, 5m)", a)
+	}
+
+	if a == SplayFor("node-b", base, 0.5) {
+		t.Errorf("SplayFor() returned the same offset for different keys")
+	}
+
+	if got := SplayFor("node-a", base, 0); got != 0 {
+		t.Errorf("SplayFor() with zero fraction = %v, expected 0", got)
+	}
+}
@@ -0,0 +1,168 @@
+package temporalis
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// The civil types store themselves in the database as their canonical text
+// form, which every SQL driver supports. Each type has a Null variant for
+// nullable columns, following the sql.NullTime convention.
+
+// Value implements driver.Valuer, storing the period in ISO 8601 notation.
+// PostgreSQL accepts this notation for interval columns.
+func (p Period) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan implements sql.Scanner for ISO 8601 text values.
+func (p *Period) Scan(src any) error {
+	s, err := scanText(src, "Period")
+	if err != nil {
+		return err
+	}
+
+	return p.UnmarshalText([]byte(s))
+}
+
+// NullPeriod is a Period that may be NULL.
+type NullPeriod struct {
+	Period Period
+	Valid  bool
+}
+
+// Value implements driver.Valuer.
+func (n NullPeriod) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Period.Value()
+}
+
+// Scan implements sql.Scanner.
+func (n *NullPeriod) Scan(src any) error {
+	if src == nil {
+		n.Period, n.Valid = Period{}, false
+		return nil
+	}
+
+	n.Valid = true
+
+	return n.Period.Scan(src)
+}
+
+// Value implements driver.Valuer, storing the date as "YYYY-MM-DD", which
+// SQL DATE columns accept.
+func (d CivilDate) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts "YYYY-MM-DD" text as well as the
+// time.Time values that most drivers return for DATE columns.
+func (d *CivilDate) Scan(src any) error {
+	if t, ok := src.(time.Time); ok {
+		*d = DateOf(t)
+		return nil
+	}
+
+	s, err := scanText(src, "CivilDate")
+	if err != nil {
+		return err
+	}
+
+	if len(s) > len(time.DateOnly) {
+		s = s[:len(time.DateOnly)]
+	}
+
+	return d.UnmarshalText([]byte(s))
+}
+
+// NullCivilDate is a CivilDate that may be NULL.
+type NullCivilDate struct {
+	Date  CivilDate
+	Valid bool
+}
+
+// Value implements driver.Valuer.
+func (n NullCivilDate) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Date.Value()
+}
+
+// Scan implements sql.Scanner.
+func (n *NullCivilDate) Scan(src any) error {
+	if src == nil {
+		n.Date, n.Valid = CivilDate{}, false
+		return nil
+	}
+
+	n.Valid = true
+
+	return n.Date.Scan(src)
+}
+
+// Value implements driver.Valuer, storing the time as "15:04:05" with an
+// optional fraction, which SQL TIME columns accept.
+func (t TimeOfDay) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts text values as well as time.Time
+// values, from which only the clock reading is kept.
+func (t *TimeOfDay) Scan(src any) error {
+	if v, ok := src.(time.Time); ok {
+		*t = TimeOfDayOf(v)
+		return nil
+	}
+
+	s, err := scanText(src, "TimeOfDay")
+	if err != nil {
+		return err
+	}
+
+	return t.UnmarshalText([]byte(s))
+}
+
+// NullTimeOfDay is a TimeOfDay that may be NULL.
+type NullTimeOfDay struct {
+	TimeOfDay TimeOfDay
+	Valid     bool
+}
+
+// Value implements driver.Valuer.
+func (n NullTimeOfDay) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.TimeOfDay.Value()
+}
+
+// Scan implements sql.Scanner.
+func (n *NullTimeOfDay) Scan(src any) error {
+	if src == nil {
+		n.TimeOfDay, n.Valid = TimeOfDay{}, false
+		return nil
+	}
+
+	n.Valid = true
+
+	return n.TimeOfDay.Scan(src)
+}
+
+// scanText converts a text column value into a string.
+func scanText(src any, typ string) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("cannot scan %T into %s", src, typ)
+	}
+}
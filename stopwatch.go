@@ -0,0 +1,170 @@
+package temporalis
+
+import (
+	"sync"
+	"time"
+)
+
+// Stopwatch measures elapsed time with laps. It reads the monotonic clock, so
+// measurements are not disturbed by changes to the wall clock. The zero value
+// is a stopped stopwatch at zero. It is safe for concurrent use.
+type Stopwatch struct {
+	// Clock provides the time. If nil, SystemClock is used.
+	Clock Clock
+
+	mu      sync.Mutex
+	running bool
+	started time.Time     // start of the current running stretch
+	elapsed time.Duration // time accumulated before the current stretch
+	lapMark time.Duration // elapsed time at the end of the previous lap
+	laps    []time.Duration
+}
+
+// StartStopwatch returns a running stopwatch.
+func StartStopwatch() *Stopwatch {
+	s := &Stopwatch{}
+	s.Start()
+
+	return s
+}
+
+// Start resets the stopwatch to zero, discarding the laps, and starts it.
+func (s *Stopwatch) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.running = true
+	s.started = clockOrSystem(s.Clock).Now()
+	s.elapsed, s.lapMark, s.laps = 0, 0, nil
+}
+
+// Stop stops the stopwatch and returns the total elapsed time. The time is
+// kept until the next Start; Resume continues from it.
+func (s *Stopwatch) Stop() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pause()
+
+	return s.elapsed
+}
+
+// Pause stops the stopwatch temporarily without resetting it.
+func (s *Stopwatch) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pause()
+}
+
+// Resume restarts a paused or stopped stopwatch, continuing from the time
+// already elapsed. It does nothing if the stopwatch is running.
+func (s *Stopwatch) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		s.running = true
+		s.started = clockOrSystem(s.Clock).Now()
+	}
+}
+
+// Lap records and returns the time elapsed since the previous lap, or since
+// the start for the first lap.
+func (s *Stopwatch) Lap() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := s.total()
+	lap := total - s.lapMark
+	s.lapMark = total
+	s.laps = append(s.laps, lap)
+
+	return lap
+}
+
+// Laps returns the recorded lap times in order.
+func (s *Stopwatch) Laps() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]time.Duration(nil), s.laps...)
+}
+
+// Elapsed returns the total time the stopwatch has been running since the
+// last Start, excluding pauses.
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.total()
+}
+
+// IsRunning reports whether the stopwatch is running.
+func (s *Stopwatch) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.running
+}
+
+// pause folds the current stretch into the elapsed time. s.mu must be held.
+func (s *Stopwatch) pause() {
+	if s.running {
+		s.elapsed = s.total()
+		s.running = false
+	}
+}
+
+// total returns the elapsed time including the current stretch. s.mu must
+// be held.
+func (s *Stopwatch) total() time.Duration {
+	if !s.running {
+		return s.elapsed
+	}
+
+	return s.elapsed + clockOrSystem(s.Clock).Now().Sub(s.started)
+}
+
+// Measure calls fn and returns how long it took.
+func Measure(fn func()) time.Duration {
+	start := time.Now()
+	fn()
+
+	return time.Since(start)
+}
+
+// Measurement summarizes repeated timings of a function.
+type Measurement struct {
+	N     int
+	Total time.Duration
+	Mean  time.Duration
+	Min   time.Duration
+	Max   time.Duration
+}
+
+// MeasureN calls fn n times and summarizes the durations of the calls. It
+// returns a zero Measurement if n is not positive.
+func MeasureN(n int, fn func()) Measurement {
+	var m Measurement
+
+	for i := 0; i < n; i++ {
+		d := Measure(fn)
+
+		if m.N == 0 || d < m.Min {
+			m.Min = d
+		}
+		if d > m.Max {
+			m.Max = d
+		}
+
+		m.N++
+		m.Total += d
+	}
+
+	if m.N > 0 {
+		m.Mean = m.Total / time.Duration(m.N)
+	}
+
+	return m
+}
@@ -0,0 +1,48 @@
+package temporalis
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestStopwatch checks laps and that pauses are excluded from the elapsed
+// time.
+func TestStopwatch(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	s := &Stopwatch{Clock: clock}
+	s.Start()
+
+	clock.Advance(3 * time.Second)
+	s.Lap()
+	clock.Advance(2 * time.Second)
+	s.Pause()
+	clock.Advance(time.Minute)
+	s.Resume()
+	clock.Advance(time.Second)
+	s.Lap()
+
+	if laps, expected := s.Laps(), []time.Duration{3 * time.Second, 3 * time.Second}; !slices.Equal(laps, expected) {
+		t.Errorf("Laps() = %v, expected %v", laps, expected)
+	}
+
+	clock.Advance(time.Second)
+	if elapsed := s.Stop(); elapsed != 7*time.Second {
+		t.Errorf("Stop() = %v, expected 7s", elapsed)
+	}
+
+	clock.Advance(time.Hour)
+	if elapsed := s.Elapsed(); elapsed != 7*time.Second || s.IsRunning() {
+		t.Errorf("Elapsed() after Stop() = %v, expected 7s", elapsed)
+	}
+}
+
+// TestMeasureN checks the summary of repeated measurements.
+func TestMeasureN(t *testing.T) {
+	calls := 0
+	m := MeasureN(3, func() { calls++ })
+
+	if calls != 3 || m.N != 3 || m.Min > m.Mean || m.Mean > m.Max || m.Total < m.Max {
+		t.Errorf("MeasureN() = %+v after %d calls", m, calls)
+	}
+}
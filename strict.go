@@ -0,0 +1,43 @@
+package temporalis
+
+import (
+	"fmt"
+	"time"
+)
+
+// RangeMode selects how functions that take a start and an end time, such as
+// DateDiff and WorkingDays, treat edge cases. Passing no mode keeps the
+// default behavior documented on each function.
+type RangeMode int
+
+const (
+	// Strict rejects zero times with ErrZeroTime and inverted ranges with
+	// ErrInvertedRange.
+	Strict RangeMode = iota + 1
+	// Lenient swaps the start and end of an inverted range instead of
+	// failing. Zero times are used as they are.
+	Lenient
+)
+
+// checkRange validates start and end according to the last of modes and
+// returns them in the order to use.
+func checkRange(start, end time.Time, modes []RangeMode) (time.Time, time.Time, error) {
+	var mode RangeMode
+	if len(modes) > 0 {
+		mode = modes[len(modes)-1]
+	}
+
+	if mode == Strict && (start.IsZero() || end.IsZero()) {
+		return start, end, fmt.Errorf("range from %v to %v: %w", start, end, ErrZeroTime)
+	}
+
+	if end.Before(start) {
+		if mode == Lenient {
+			return end, start, nil
+		}
+
+		return start, end, fmt.Errorf("end date %v is before start date %v: %w", end, start, ErrInvertedRange)
+	}
+
+	return start, end, nil
+}
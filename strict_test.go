@@ -0,0 +1,38 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRangeModes checks the default, Strict and Lenient handling of zero
+// times and inverted ranges by DateDiff and WorkingDays.
+func TestRangeModes(t *testing.T) {
+	monday := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	friday := monday.AddDate(0, 0, 4)
+
+	if _, err := DateDiff(friday, monday); !errors.Is(err, ErrInvertedRange) {
+		t.Errorf("DateDiff(inverted) error = %v, expected ErrInvertedRange", err)
+	}
+
+	if days, err := DateDiff(friday, monday, Lenient); err != nil || days != 4 {
+		t.Errorf("DateDiff(inverted, Lenient) = %d, %v, expected 4", days, err)
+	}
+
+	if _, err := DateDiff(time.Time{}, monday, Strict); !errors.Is(err, ErrZeroTime) {
+		t.Errorf("DateDiff(zero, Strict) error = %v, expected ErrZeroTime", err)
+	}
+
+	if _, err := WorkingDays(time.Time{}, friday, nil, Strict); !errors.Is(err, ErrZeroTime) {
+		t.Errorf("WorkingDays(zero, Strict) error = %v, expected ErrZeroTime", err)
+	}
+
+	if days, err := WorkingDays(friday, monday, nil, Lenient); err != nil || days != 5 {
+		t.Errorf("WorkingDays(inverted, Lenient) = %d, %v, expected 5", days, err)
+	}
+
+	if _, err := WorkingDays(friday, monday, nil, Strict); !errors.Is(err, ErrInvertedRange) {
+		t.Errorf("WorkingDays(inverted, Strict) error = %v, expected ErrInvertedRange", err)
+	}
+}
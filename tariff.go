@@ -0,0 +1,170 @@
+package temporalis
+
+import (
+	"slices"
+	"time"
+)
+
+// TariffBand is a price band of a time-of-use tariff, such as "peak" or
+// "off-peak". Rate is the price multiplier of the band, relative to
+// whatever base price the caller bills.
+type TariffBand struct {
+	Name string
+	Rate float64
+}
+
+// TariffRule assigns a band to the times that match all of its conditions.
+// Conditions left at their zero value match everything.
+type TariffRule struct {
+	Band TariffBand
+	// Hours is the daily window of the rule. The zero value is the whole
+	// day.
+	Hours ClockRange
+	// Weekdays limits the rule to the given days of the week.
+	Weekdays []time.Weekday
+	// Months limits the rule to a season, such as June to September.
+	Months []time.Month
+	// Holidays makes the rule apply only on holidays of the calendar of the
+	// schedule. Rules without it do not apply on holidays at all, so that
+	// holidays get the default band unless a rule says otherwise.
+	Holidays bool
+}
+
+// TariffSchedule is a time-of-use tariff as used for energy and telecom
+// billing: the first rule that matches a time decides its band, and times
+// that match no rule get the default band. A typical schedule is
+//
+//	schedule := &temporalis.TariffSchedule{
+//		Default: temporalis.TariffBand{Name: "off-peak", Rate: 0.6},
+//		Rules: []temporalis.TariffRule{{
+//			Band:     temporalis.TariffBand{Name: "peak", Rate: 1.5},
+//			Hours:    temporalis.ClockRange{Start: temporalis.NewTimeOfDay(17, 0, 0, 0), End: temporalis.NewTimeOfDay(21, 0, 0, 0)},
+//			Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+//		}},
+//	}
+//
+// A TariffSchedule must not be modified while it is in use.
+type TariffSchedule struct {
+	Rules   []TariffRule
+	Default TariffBand
+	// Calendar provides the holidays. If nil, there are none.
+	Calendar *BusinessCalendar
+	// Location is the zone of the wall-clock windows. If nil, every time is
+	// taken in its own location.
+	Location *time.Location
+}
+
+// TariffPeriod is a span of time billed in one band.
+type TariffPeriod struct {
+	Interval Interval
+	Band     TariffBand
+}
+
+// BandAt returns the band in effect at t.
+func (s *TariffSchedule) BandAt(t time.Time) TariffBand {
+	t = s.in(t)
+
+	_, holiday := s.Calendar.IsHoliday(DateOf(t))
+	month, weekday, tod := t.Month(), t.Weekday(), TimeOfDayOf(t)
+
+	for _, r := range s.Rules {
+		switch {
+		case r.Holidays != holiday:
+		case len(r.Weekdays) > 0 && !slices.Contains(r.Weekdays, weekday):
+		case len(r.Months) > 0 && !slices.Contains(r.Months, month):
+		case !r.Hours.Contains(tod):
+		default:
+			return r.Band
+		}
+	}
+
+	return s.Default
+}
+
+// Split divides the interval into the spans billed in each band, in order.
+// Adjacent spans always have different bands.
+func (s *TariffSchedule) Split(i Interval) []TariffPeriod {
+	var periods []TariffPeriod
+
+	for t := i.Start; t.Before(i.End); {
+		band := s.BandAt(t)
+
+		end := t
+		for end.Before(i.End) && s.BandAt(end) == band {
+			end = s.nextBoundary(end)
+		}
+		end = minTime(end, i.End)
+
+		periods = append(periods, TariffPeriod{Interval: Interval{Start: t, End: end}, Band: band})
+		t = end
+	}
+
+	return periods
+}
+
+// CostWeightedDuration returns the length of the interval with every span
+// weighted by the rate of its band, so an hour at rate 1.5 counts as 90
+// minutes. Multiplying the result in hours by an hourly base price gives the
+// price of the interval.
+func (s *TariffSchedule) CostWeightedDuration(i Interval) time.Duration {
+	var total float64
+	for _, p := range s.Split(i) {
+		total += float64(p.Interval.Duration()) * p.Band.Rate
+	}
+
+	return time.Duration(total)
+}
+
+// Durations returns how much of the interval falls into each band, by band
+// name.
+func (s *TariffSchedule) Durations(i Interval) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for _, p := range s.Split(i) {
+		durations[p.Band.Name] += p.Interval.Duration()
+	}
+
+	return durations
+}
+
+// nextBoundary returns the first instant after t at which the band may
+// change: the next local midnight or the next start or end of a rule
+// window.
+func (s *TariffSchedule) nextBoundary(t time.Time) time.Time {
+	local := s.in(t)
+	today := DateOf(local)
+
+	// The next midnight is after t even on days that start in a DST gap,
+	// but step on rather than loop forever should a zone say otherwise.
+	next := today.AddDays(1).In(local.Location())
+	for d := today.AddDays(2); !next.After(t); d = d.AddDays(1) {
+		next = d.In(local.Location())
+	}
+
+	for _, r := range s.Rules {
+		for _, tod := range []TimeOfDay{r.Hours.Start, r.Hours.End} {
+			if b := tod.OnDate(today, local.Location()); b.After(t) && b.Before(next) {
+				next = b
+			}
+		}
+	}
+
+	return next
+}
+
+// in converts t to the location of the schedule.
+func (s *TariffSchedule) in(t time.Time) time.Time {
+	if s.Location == nil {
+		return t
+	}
+
+	return t.In(s.Location)
+}
+
+// minTime returns the earlier of a and b.
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+
+	return a
+}
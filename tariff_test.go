@@ -0,0 +1,129 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// testTariff returns a schedule with weekday peak hours, a cheaper summer
+// peak, a night band and a holiday band.
+func testTariff() *TariffSchedule {
+	workdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	peak := ClockRange{Start: NewTimeOfDay(17, 0, 0, 0), End: NewTimeOfDay(21, 0, 0, 0)}
+
+	return &TariffSchedule{
+		Default:  TariffBand{Name: "standard", Rate: 1},
+		Calendar: NewBusinessCalendar(NewCivilDate(2024, 12, 25)),
+		Rules: []TariffRule{
+			{Band: TariffBand{Name: "holiday", Rate: 0.5}, Holidays: true},
+			{Band: TariffBand{Name: "summer-peak", Rate: 1.2}, Hours: peak, Weekdays: workdays, Months: []time.Month{time.June, time.July, time.August}},
+			{Band: TariffBand{Name: "peak", Rate: 1.5}, Hours: peak, Weekdays: workdays},
+			{Band: TariffBand{Name: "night", Rate: 0.6}, Hours: ClockRange{Start: NewTimeOfDay(23, 0, 0, 0), End: NewTimeOfDay(6, 0, 0, 0)}},
+		},
+	}
+}
+
+// TestTariffBandAt tests that the first matching rule decides the band.
+func TestTariffBandAt(t *testing.T) {
+	s := testTariff()
+
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{time.Date(2024, 3, 13, 18, 0, 0, 0, time.UTC), "peak"},
+		{time.Date(2024, 3, 13, 21, 0, 0, 0, time.UTC), "standard"},
+		{time.Date(2024, 3, 16, 18, 0, 0, 0, time.UTC), "standard"},
+		{time.Date(2024, 7, 10, 17, 0, 0, 0, time.UTC), "summer-peak"},
+		{time.Date(2024, 3, 13, 2, 0, 0, 0, time.UTC), "night"},
+		{time.Date(2024, 12, 25, 18, 0, 0, 0, time.UTC), "holiday"},
+	}
+
+	for _, test := range tests {
+		if actual := s.BandAt(test.t); actual.Name != test.expected {
+			t.Errorf("BandAt(%v) = %v, expected %s", test.t, actual, test.expected)
+		}
+	}
+}
+
+// TestTariffSplit tests splitting a day into bands and weighting it.
+func TestTariffSplit(t *testing.T) {
+	s := testTariff()
+
+	day := Interval{Start: time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)}
+
+	periods := s.Split(day)
+	expected := []string{"night", "standard", "peak", "standard", "night"}
+	if len(periods) != len(expected) {
+		t.Fatalf("Split() = %v, expected bands %v", periods, expected)
+	}
+	for i, p := range periods {
+		if p.Band.Name != expected[i] {
+			t.Errorf("Split()[%d] = %v, expected %s", i, p, expected[i])
+		}
+	}
+
+	durations := s.Durations(day)
+	if durations["night"] != 7*time.Hour || durations["peak"] != 4*time.Hour || durations["standard"] != 13*time.Hour {
+		t.Errorf("Durations() = %v, expected 7h night, 4h peak and 13h standard", durations)
+	}
+
+	// 7h*0.6 + 13h*1 + 4h*1.5 = 23h12m.
+	if actual, expected := s.CostWeightedDuration(day), 23*time.Hour+12*time.Minute; actual != expected {
+		t.Errorf("CostWeightedDuration() = %v, expected %v", actual, expected)
+	}
+
+	// A partial interval is clipped to its own bounds.
+	evening := Interval{Start: time.Date(2024, 3, 13, 20, 30, 0, 0, time.UTC), End: time.Date(2024, 3, 13, 21, 30, 0, 0, time.UTC)}
+	if actual, expected := s.CostWeightedDuration(evening), 75*time.Minute; actual != expected {
+		t.Errorf("CostWeightedDuration(%v) = %v, expected %v", evening, actual, expected)
+	}
+}
+
+// TestTariffLocation tests that windows are taken in the location of the
+// schedule, across a DST change.
+func TestTariffLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+
+	s := testTariff()
+	s.Location = berlin
+
+	// 2024-03-31 is a Sunday with only 23 hours in Berlin.
+	day := Interval{Start: time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC)}
+	durations := s.Durations(day)
+	if durations["night"] != 6*time.Hour || durations["standard"] != 17*time.Hour {
+		t.Errorf("Durations(%v) = %v, expected 6h night and 17h standard", day, durations)
+	}
+}
+
+// TestTariffMidnightGap tests a zone whose clocks skip midnight, where the
+// day starts at 01:00.
+func TestTariffMidnightGap(t *testing.T) {
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+
+	s := testTariff()
+	s.Location = santiago
+
+	// Clocks in Santiago jump from 00:00 to 01:00 on 8 September 2024.
+	i := Interval{Start: time.Date(2024, 9, 7, 22, 0, 0, 0, santiago), End: time.Date(2024, 9, 8, 10, 0, 0, 0, santiago)}
+	periods := s.Split(i)
+
+	expected := []string{"standard", "night", "standard"}
+	if len(periods) != len(expected) {
+		t.Fatalf("Split(%v) = %v, expected %v", i, periods, expected)
+	}
+	for n, p := range periods {
+		if p.Band.Name != expected[n] {
+			t.Errorf("Split(%v)[%d] = %v, expected %s", i, n, p, expected[n])
+		}
+	}
+	if d := periods[1].Interval.Duration(); d != 6*time.Hour {
+		t.Errorf("night period = %v, expected 6h", d)
+	}
+}
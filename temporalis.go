@@ -1,7 +1,9 @@
 package temporalis
 
 import (
-	"fmt"
+	"context"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -75,6 +77,59 @@ func Sleep(d time.Duration) {
 	time.Sleep(d)
 }
 
+// SleepContext pauses the current goroutine for at least the duration d, or
+// until ctx is cancelled, whichever happens first. It returns nil after a full
+// sleep and the context error if the sleep was cut short, so long pauses can
+// be aborted during shutdown. A negative or zero duration returns immediately
+// unless the context is already done.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// AfterContext is like After but gives up when ctx is cancelled. The returned
+// channel receives the current time once d has elapsed; if the context is
+// cancelled first, the channel is closed without a value being sent.
+func AfterContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	c := make(chan time.Time, 1)
+
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			close(c)
+		case t := <-timer.C:
+			c <- t
+		}
+	}()
+
+	return c
+}
+
+// WaitUntil blocks until the wall clock reaches t or ctx is cancelled. It
+// returns nil if t was reached, including when t is already in the past, and
+// the context error otherwise.
+func WaitUntil(ctx context.Context, t time.Time) error {
+	return SleepContext(ctx, time.Until(t))
+}
+
 // Tick returns a new ticker that sends the current time on the returned
 // channel at a regular interval defined by the duration argument. The ticker
 // will start immediately and continue indefinitely, until stopped explicitly
@@ -125,48 +180,70 @@ func Format(t time.Time, layout string) string {
 // Mon Jan 2 15:04:05 -0700 MST 2006
 // would be represented if it were the value being parsed.
 // The same interpretation as in Format is used to determine the meaning of each
-// input character. Parse returns an error if the input string and layout string
-// do not match.
+// input character. Parse returns a *ParseError wrapping ErrSyntax or
+// ErrOutOfRange, and the underlying *time.ParseError, if the input string and
+// layout string do not match.
 func Parse(layout, value string) (time.Time, error) {
-	return time.Parse(layout, value)
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, timeParseError("Parse", value, err)
+	}
+
+	return t, nil
 }
 
 // ParseInLocation is like Parse but allows the caller to specify the location.
 // The given location must be a valid time zone name such as "UTC" or "America/New_York",
 // or a fixed offset in seconds east of UTC such as -18000 for Eastern Standard Time.
 func ParseInLocation(layout, value string, loc *time.Location) (time.Time, error) {
-	return time.ParseInLocation(layout, value, loc)
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, timeParseError("ParseInLocation", value, err)
+	}
+
+	return t, nil
 }
 
 // ParseTime parses a formatted string and returns the time value it represents.
 // The layout string specifies the format by showing how the reference time,
 // defined to be Mon Jan 2 15:04:05 -0700 MST 2006, would be formatted if it
 // were the value. ParseTime returns an error if the input string and layout
-// string do not match.
+// string do not match; the error is as for Parse.
 func ParseTime(str, format string) (time.Time, error) {
-	return time.Parse(format, str)
+	t, err := time.Parse(format, str)
+	if err != nil {
+		return time.Time{}, timeParseError("ParseTime", str, err)
+	}
+
+	return t, nil
 }
 
-// ConvertTimezone takes a time.Time object and a target timezone location string,
-// and returns the time.Time object converted to the target timezone.
-// The `location` parameter should be a string representing the target timezone's location,
-// such as "America/New_York" or "Asia/Tokyo".
-// The returned time.Time object will have the same UTC time as the input time.Time object,
-// but its location will be set to the target timezone.
+// ConvertTimezone interprets the wall-clock reading of t (its year, month,
+// day, hour, minute, second and nanosecond fields) in the `from` time zone and
+// returns the same instant expressed in the `to` time zone. The location of t
+// itself is ignored, so ConvertTimezone(Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+// "America/New_York", "Europe/London") yields 14:00 in London.
+// Both zones accept anything LoadLocation understands: IANA names such as
+// "Asia/Tokyo", fixed offsets such as "+05:30", and abbreviations such as "PST".
+// If the wall-clock time does not exist or occurs twice in `from` because of a
+// DST transition, the result follows the rules of time.Date. To keep the
+// wall-clock reading and change the instant instead, use SameWallClockIn.
 func ConvertTimezone(t time.Time, from, to string) (time.Time, error) {
-	locFrom, err := time.LoadLocation(from)
+	locFrom, err := LoadLocation(from)
 
 	if err != nil {
 		return time.Time{}, err
 	}
 
-	locTo, err := time.LoadLocation(to)
+	locTo, err := LoadLocation(to)
 
 	if err != nil {
 		return time.Time{}, err
 	}
 
-	return t.In(locFrom).In(locTo), nil
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), locFrom)
+
+	return wall.In(locTo), nil
 }
 
 // DateRange returns a slice of time.Time values representing all the days
@@ -174,26 +251,30 @@ func ConvertTimezone(t time.Time, from, to string) (time.Time, error) {
 // end dates should be specified as a string in the format "UTC±hh:mm", where
 // "UTC" is the literal string "UTC" and "±hh:mm" is the time offset from UTC.
 // If the start date is after the end date, an empty slice is returned.
+// Dates are compared without their time of day, so a range starting at 18:00
+// still includes an end date whose time is 09:00. Use CivilDateRange to work
+// with dates only.
 func DateRange(start, end time.Time) []time.Time {
 	var dates []time.Time
 
-	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+	for d := start; !DateOf(d).After(DateOf(end)); d = d.AddDate(0, 0, 1) {
 		dates = append(dates, d)
 	}
 
 	return dates
 }
 
-// DateDiff calculates the difference between two dates and returns the result
-// as a Duration. The first argument represents the start date, and the second
-// argument represents the end date. If the start date is later than the end
-// date, the function returns a negative duration. The returned duration will
-// include any time that occurs between the start and end dates, including leap
-// seconds and leap years. If either of the arguments are zero values, the
-// function will panic.
-func DateDiff(start, end time.Time) (int, error) {
-	if end.Before(start) {
-		return 0, fmt.Errorf("end date %v is before start date %v", end, start)
+// DateDiff calculates the number of whole days between two dates. The first
+// argument represents the start date, and the second argument represents the
+// end date. The result counts 24-hour periods, so it includes any time that
+// occurs between the start and end dates. If the end date is before the start
+// date, an error wrapping ErrInvertedRange is returned. An optional RangeMode
+// makes the handling of edge cases explicit: Strict also rejects zero times
+// with ErrZeroTime, and Lenient swaps an inverted range instead of failing.
+func DateDiff(start, end time.Time, mode ...RangeMode) (int, error) {
+	start, end, err := checkRange(start, end, mode)
+	if err != nil {
+		return 0, err
 	}
 
 	diff := end.Sub(start)
@@ -202,20 +283,21 @@ func DateDiff(start, end time.Time) (int, error) {
 }
 
 // WorkingDays returns the number of working days between two dates (inclusive).
-// It takes start and end dates in the format "YYYY-MM-DD", and a list of holidays
-// in the same format. The function assumes a 5-day workweek from Monday to Friday,
-// and does not account for weekends. Holidays are considered as non-working days
-// and are subtracted from the total number of days. If start date is after end date,
-// the function returns 0. If the list of holidays is empty or nil, all days between
-// the start and end dates are considered as working days.
-func WorkingDays(start, end time.Time, holidays []time.Time) (int, error) {
-	if end.Before(start) {
-		return 0, fmt.Errorf("end date %v is before start date %v", end, start)
+// The function assumes a 5-day workweek from Monday to Friday, so weekends are
+// not counted. Holidays are considered as non-working days and are subtracted
+// from the total number of days. If the list of holidays is empty or nil, all
+// weekdays between the start and end dates are considered as working days. If
+// the end date is before the start date, an error wrapping ErrInvertedRange is
+// returned. The optional RangeMode works as for DateDiff.
+func WorkingDays(start, end time.Time, holidays []time.Time, mode ...RangeMode) (int, error) {
+	start, end, err := checkRange(start, end, mode)
+	if err != nil {
+		return 0, err
 	}
 
 	weekdays := 0
 
-	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+	for d := start; !DateOf(d).After(DateOf(end)); d = d.AddDate(0, 0, 1) {
 		if !isWeekend(d) && !isHoliday(d, holidays) {
 			weekdays++
 		}
@@ -226,66 +308,49 @@ func WorkingDays(start, end time.Time, holidays []time.Time) (int, error) {
 
 // FormatDuration formats a time.Duration value into a human-readable string.
 // The string will list each unit of time in descending order of magnitude,
-// and will use the singular or plural form of the unit name as appropriate.
-func FormatDuration(duration time.Duration) string {
-	seconds := int64(duration.Seconds())
-
-	days := seconds / 86400
-	seconds -= days * 86400
-
-	hours := seconds / 3600
-	seconds -= hours * 3600
-
-	minutes := seconds / 60
-	seconds -= minutes * 60
-
-	var parts []string
-	if days > 0 {
-		parts = append(parts, pluralize(days, "day"))
-	}
-	if hours > 0 {
-		parts = append(parts, pluralize(hours, "hour"))
-	}
-	if minutes > 0 {
-		parts = append(parts, pluralize(minutes, "minute"))
-	}
-	if seconds > 0 {
-		parts = append(parts, pluralize(seconds, "second"))
-	}
+// and will use the singular or plural form of the unit name as appropriate,
+// such as "2 days, 3 hours and 4 minutes". By default the duration is
+// truncated to whole seconds, except that durations shorter than a second
+// are shown in milliseconds, microseconds or nanoseconds. The options
+// WithMaxUnits, WithCompact, WithPrecision and WithRounding change the
+// output, and WithLocale the language of the unit names. Negative durations
+// are prefixed with a minus sign, or described as being in the past with
+// WithRelative. WithWeeks adds weeks as a unit, and WithApproximate shows
+// only the largest unit, such as "about 2 months".
+func FormatDuration(duration time.Duration, opts ...Option) string {
+	return formatDuration(duration, NewOptions(opts...))
+}
 
-	switch len(parts) {
-	case 0:
-		return "0 seconds"
-	case 1:
-		return parts[0]
-	case 2:
-		return fmt.Sprintf("%s and %s", parts[0], parts[1])
-	default:
-		last := parts[len(parts)-1]
-		parts = parts[:len(parts)-1]
+// WithWorkingHours limits BusinessHours to a daily working window, such as
+// 09:00 to 17:00. A window that wraps around midnight starts on the business
+// day and runs into the next one.
+func WithWorkingHours(r ClockRange) Option {
+	return func(o *Options) { o.workingHours = r }
+}
 
-		return fmt.Sprintf("%s and %s", strings.Join(parts, ", "), last)
+// BusinessHours returns the business time between from and to: the parts of
+// the span that fall on weekdays other than Saturday and Sunday that are not
+// among holidays, and within the daily window given with WithWorkingHours,
+// or anywhere in the day without it. The span is measured exactly, so half
+// an hour inside working hours counts as 30 minutes. Days and the window are
+// taken on the wall clock of the location given with WithLocation, or of
+// from's location. The result is zero if to is not after from. For other
+// weekends and named holidays, use BusinessTimeBetween with a
+// BusinessCalendar.
+func BusinessHours(from, to time.Time, holidays []time.Time, opts ...Option) time.Duration {
+	if !to.After(from) {
+		return 0
 	}
-}
 
-// BusinessHours returns the number of business hours between two dates, excluding weekends and non-working hours.
-// It takes start and end times, as well as the start and end hour of business for each weekday, and returns the
-// duration of business hours between the two dates. The start and end hours of business for each weekday are
-// specified as a map with keys representing the weekdays (time.Weekday) and values as structs with fields Start
-// and End that represent the start and end hours of business for the given weekday. The timezone of the input
-// dates and the business hours are assumed to be the same.
-// The function returns a duration rounded up to the nearest minute.
-func BusinessHours(from, to time.Time, holidays []time.Time) time.Duration {
-	var total time.Duration
+	o := NewOptions(opts...)
 
-	for from.Before(to) {
-		if from.Weekday() != time.Saturday && from.Weekday() != time.Sunday && !isHoliday(from, holidays) {
-			total += time.Hour
-		}
-		from = from.Add(time.Hour)
+	cal := NewBusinessCalendar()
+	for _, h := range holidays {
+		cal.AddHoliday(DateOf(h), "")
 	}
+	cal.SetBusinessHours(o.workingHours)
 
-	return total
+	return BusinessTimeBetween(from.In(o.locationOr(from.Location())), to, cal)
 }
 
 // BusinessDays calculates the number of business days between two dates,
@@ -293,10 +358,12 @@ func BusinessHours(from, to time.Time, holidays []time.Time) time.Duration {
 // It returns the number of business days and the list of holidays that fall
 // within the date range (inclusive).
 // If the end date is before the start date, the function returns 0 business days.
+// Only the dates of from and to are considered, not their time of day; see
+// BusinessDaysBetween for a variant that takes CivilDate values.
 func BusinessDays(from, to time.Time, holidays []time.Time) int {
 	var total int
 
-	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+	for d := from; !DateOf(d).After(DateOf(to)); d = d.AddDate(0, 0, 1) {
 		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday && !isHoliday(d, holidays) {
 			total++
 		}
@@ -350,7 +417,7 @@ func FromUnixTimestamp(timestamp int64) time.Time {
 // The function takes a time zone abbreviation (e.g. "PST", "UTC") and a time.Time object as input.
 // If the time zone abbreviation is not recognized by the time package, the function returns an error.
 func TimezoneOffset(tz string, t time.Time) (int, error) {
-	loc, err := time.LoadLocation(tz)
+	loc, err := LoadLocation(tz)
 
 	if err != nil {
 		return 0, err
@@ -365,7 +432,7 @@ func TimezoneOffset(tz string, t time.Time) (int, error) {
 // of the timezone (e.g. "PST" for Pacific Standard Time). The name returned is based
 // on the current offset of the timezone from UTC.
 func TimezoneAbbreviation(tz string) (string, error) {
-	loc, err := time.LoadLocation(tz)
+	loc, err := LoadLocation(tz)
 
 	if err != nil {
 		return "", err
@@ -375,3 +442,108 @@ func TimezoneAbbreviation(tz string) (string, error) {
 
 	return now.Format("MST"), nil
 }
+
+// UnixMilli returns t as a Unix time, the number of milliseconds elapsed
+// since January 1, 1970 UTC.
+func UnixMilli(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+// UnixMicro returns t as a Unix time, the number of microseconds elapsed
+// since January 1, 1970 UTC.
+func UnixMicro(t time.Time) int64 {
+	return t.UnixMicro()
+}
+
+// UnixNano returns t as a Unix time, the number of nanoseconds elapsed
+// since January 1, 1970 UTC. The result is undefined for dates outside the
+// years 1678 to 2262.
+func UnixNano(t time.Time) int64 {
+	return t.UnixNano()
+}
+
+// UnixFloat returns t as fractional Unix seconds, the representation used by
+// many JSON APIs (for example 1715000000.123). A float64 holds about 16
+// significant digits, so the result is exact to roughly a microsecond for
+// present-day times.
+func UnixFloat(t time.Time) float64 {
+	return float64(t.Unix()) + float64(t.Nanosecond())/1e9
+}
+
+// FromUnixMilli returns the local time corresponding to the given Unix time
+// in milliseconds.
+func FromUnixMilli(ms int64) time.Time {
+	return time.UnixMilli(ms)
+}
+
+// FromUnixMicro returns the local time corresponding to the given Unix time
+// in microseconds.
+func FromUnixMicro(us int64) time.Time {
+	return time.UnixMicro(us)
+}
+
+// FromUnixNano returns the local time corresponding to the given Unix time
+// in nanoseconds.
+func FromUnixNano(ns int64) time.Time {
+	return time.Unix(0, ns)
+}
+
+// FromUnixFloat returns the local time corresponding to fractional Unix
+// seconds. The fraction is rounded to the nearest microsecond to hide the
+// binary representation error of float64, so 1715000000.123 yields exactly
+// 123ms past the second.
+func FromUnixFloat(seconds float64) time.Time {
+	sec, frac := math.Modf(seconds)
+	nsec := math.Round(frac*1e6) * 1e3
+
+	return time.Unix(int64(sec), int64(nsec))
+}
+
+// FromUnixAuto converts a Unix timestamp whose unit is not known, guessing
+// seconds, milliseconds, microseconds or nanoseconds from its magnitude.
+// Values below 1e11 are taken as seconds, below 1e14 as milliseconds, below
+// 1e17 as microseconds and anything larger as nanoseconds. The guess is
+// correct for every unit for times between 1973 and 5138.
+func FromUnixAuto(ts int64) time.Time {
+	abs := ts
+	if abs < 0 {
+		abs = -abs
+	}
+
+	switch {
+	case abs < 1e11:
+		return time.Unix(ts, 0)
+	case abs < 1e14:
+		return time.UnixMilli(ts)
+	case abs < 1e17:
+		return time.UnixMicro(ts)
+	default:
+		return time.Unix(0, ts)
+	}
+}
+
+// ParseUnix parses a decimal Unix timestamp in seconds such as "1715000000" or
+// "1715000000.123456789" without going through float64, so all nine
+// fractional digits are preserved. A leading minus sign is accepted.
+func ParseUnix(s string) (time.Time, error) {
+	intPart, fracPart, hasFrac := strings.Cut(strings.TrimSpace(s), ".")
+
+	sec, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return time.Time{}, numberError("ParseUnix", s, err)
+	}
+	if hasFrac && (!isDigits(fracPart) || len(fracPart) > 9) {
+		return time.Time{}, syntaxError("ParseUnix", s, "fraction must have 1 to 9 digits")
+	}
+
+	var nsec int64
+	if hasFrac {
+		nsec, _ = strconv.ParseInt(fracPart+strings.Repeat("0", 9-len(fracPart)), 10, 64)
+	}
+
+	if strings.HasPrefix(intPart, "-") {
+		nsec = -nsec
+	}
+
+	return time.Unix(sec, nsec), nil
+}
@@ -1,6 +1,8 @@
 package temporalis
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -155,3 +157,160 @@ func TestFormatDuration(t *testing.T) {
 		}
 	}
 }
+
+// TestConvertTimezone tests that ConvertTimezone treats the input as a wall-clock
+// reading in the source zone, for IANA names, fixed offsets and abbreviations alike.
+// It also checks that an unknown zone name is reported as an error.
+func TestConvertTimezone(t *testing.T) {
+	wall := Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		from, to string
+		expected string
+	}{
+		{"America/New_York", "Europe/London", "2024-05-01 14:00"},
+		{"+05:30", "UTC", "2024-05-01 03:30"},
+		{"PST", "UTC+01:00", "2024-05-01 18:00"},
+		{"UTC", "Asia/Tokyo", "2024-05-01 18:00"},
+	}
+
+	for _, test := range tests {
+		converted, err := ConvertTimezone(wall, test.from, test.to)
+		if err != nil {
+			t.Errorf("ConvertTimezone(%q, %q) returned error: %v", test.from, test.to, err)
+			continue
+		}
+
+		if actual := converted.Format("2006-01-02 15:04"); actual != test.expected {
+			t.Errorf("ConvertTimezone(%q, %q) = %q, expected %q", test.from, test.to, actual, test.expected)
+		}
+	}
+
+	for _, from := range []string{"Nowhere/Special", "+-5", "UTC+-05:00", "+0:530", "+05:-3"} {
+		if _, err := ConvertTimezone(wall, from, "UTC"); err == nil {
+			t.Errorf("ConvertTimezone(%q, %q) returned no error", from, "UTC")
+		}
+	}
+}
+
+// TestLoadLocationZoneNames tests that names which are both IANA zones and
+// abbreviations in the built-in table keep the DST of the zone.
+func TestLoadLocationZoneNames(t *testing.T) {
+	july := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, name := range []string{"CET", "EET", "WET"} {
+		zone, err := time.LoadLocation(name)
+		if err != nil {
+			t.Skip("zone data not available")
+		}
+		_, expected := july.In(zone).Zone()
+
+		actual, err := TimezoneOffset(name, july)
+		if err != nil || actual != expected {
+			t.Errorf("TimezoneOffset(%q, %v) = %d, %v, expected %d", name, july, actual, err, expected)
+		}
+	}
+}
+
+// TestUnixConversions tests the sub-second Unix timestamp helpers, including unit
+// detection by magnitude and float and decimal string parsing. Every conversion
+// of the same instant must agree exactly.
+func TestUnixConversions(t *testing.T) {
+	expected := time.Date(2024, time.May, 6, 12, 53, 20, 123000000, time.UTC)
+
+	conversions := map[string]time.Time{
+		"FromUnixMilli": FromUnixMilli(UnixMilli(expected)),
+		"FromUnixMicro": FromUnixMicro(UnixMicro(expected)),
+		"FromUnixNano":  FromUnixNano(UnixNano(expected)),
+		"FromUnixFloat": FromUnixFloat(1715000000.123),
+		"auto millis":   FromUnixAuto(1715000000123),
+		"auto micros":   FromUnixAuto(1715000000123000),
+		"auto nanos":    FromUnixAuto(1715000000123000000),
+	}
+
+	for name, actual := range conversions {
+		if !actual.Equal(expected) {
+			t.Errorf("%s = %v, expected %v", name, actual.UTC(), expected)
+		}
+	}
+
+	if actual := FromUnixAuto(1715000000); !actual.Equal(expected.Truncate(time.Second)) {
+		t.Errorf("FromUnixAuto(seconds) = %v, expected %v", actual.UTC(), expected.Truncate(time.Second))
+	}
+
+	parsed, err := ParseUnix("1715000000.123000001")
+	if err != nil || parsed.Sub(expected) != time.Nanosecond {
+		t.Errorf("ParseUnix() = %v, %v, expected one nanosecond after %v", parsed.UTC(), err, expected)
+	}
+
+	for _, input := range []string{"17150.00.1", "1715000000.+5", "1715000000.-5", "1715000000. 5"} {
+		if _, err := ParseUnix(input); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ParseUnix(%q) error = %v, expected %v", input, err, ErrSyntax)
+		}
+	}
+}
+
+// TestSleepContext tests that SleepContext completes a short sleep and that a
+// cancelled context aborts a long one with the context error. The long sleep
+// would make the test time out if cancellation were ignored.
+func TestSleepContext(t *testing.T) {
+	if err := SleepContext(context.Background(), 10*time.Millisecond); err != nil {
+		t.Errorf("SleepContext() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := Now()
+	if err := SleepContext(ctx, time.Hour); err != context.DeadlineExceeded {
+		t.Errorf("SleepContext() = %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SleepContext() took %v after cancellation", elapsed)
+	}
+
+	if _, ok := <-AfterContext(ctx, time.Hour); ok {
+		t.Errorf("AfterContext() delivered a value after cancellation")
+	}
+
+	if err := WaitUntil(context.Background(), Now().Add(-time.Second)); err != nil {
+		t.Errorf("WaitUntil() in the past returned error: %v", err)
+	}
+}
+
+// TestBusinessHours tests partial hours, working windows, weekends and
+// holidays.
+func TestBusinessHours(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+	nineToFive := WithWorkingHours(ClockRange{Start: NewTimeOfDay(9, 0, 0, 0), End: NewTimeOfDay(17, 0, 0, 0)})
+	holidays := []time.Time{at(18, 0, 0)}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		opts     []Option
+		expected time.Duration
+	}{
+		{"half hour", at(15, 10, 0), at(15, 10, 30), nil, 30 * time.Minute},
+		{"half hour in window", at(15, 10, 0), at(15, 10, 30), []Option{nineToFive}, 30 * time.Minute},
+		{"across close", at(15, 16, 15), at(15, 18, 0), []Option{nineToFive}, 45 * time.Minute},
+		{"over weekend and holiday", at(15, 16, 0), at(19, 9, 30), []Option{nineToFive}, 90 * time.Minute},
+		{"whole days", at(15, 12, 0), at(19, 12, 0), nil, 24 * time.Hour},
+		{"inverted", at(15, 12, 0), at(15, 11, 0), nil, 0},
+	}
+
+	for _, test := range tests {
+		if actual := BusinessHours(test.from, test.to, holidays, test.opts...); actual != test.expected {
+			t.Errorf("BusinessHours(%s) = %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+
+	// 08:00 in Berlin is 07:00 UTC, before the window opens there.
+	berlin := time.FixedZone("CET", 3600)
+	if actual := BusinessHours(at(15, 7, 0), at(15, 9, 0), nil, nineToFive, WithLocation(berlin)); actual != time.Hour {
+		t.Errorf("BusinessHours() in Berlin = %v, expected 1h", actual)
+	}
+}
@@ -0,0 +1,202 @@
+package temporalis
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Ticker delivers ticks at instants computed from absolute targets rather
+// than by sleeping a fixed interval after each tick, so the delays of the
+// goroutine and the receiver do not accumulate. Like time.Ticker, ticks are
+// dropped if the receiver falls behind.
+type Ticker struct {
+	C <-chan time.Time
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewJitteredTicker returns a ticker whose intervals are drawn uniformly from
+// d ± jitterFraction·d, which keeps many processes started together from
+// polling in lockstep. The fraction is clamped to [0, 1]. It panics if d is
+// not positive.
+func NewJitteredTicker(d time.Duration, jitterFraction float64) *Ticker {
+	if d <= 0 {
+		panic("temporalis: non-positive interval for NewJitteredTicker")
+	}
+
+	jitterFraction = max(0, min(jitterFraction, 1))
+
+	return startTicker(func(after time.Time) time.Time {
+		f := 1 + jitterFraction*(2*rand.Float64()-1)
+		return after.Add(time.Duration(f * float64(d)))
+	})
+}
+
+// NewAlignedTicker returns a ticker that ticks on the wall-clock boundaries
+// of d in the local time zone, so a one-minute ticker ticks at :00 of every
+// minute and a one-hour ticker on the hour, even in zones with half-hour
+// offsets. It panics if d is not positive.
+func NewAlignedTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("temporalis: non-positive interval for NewAlignedTicker")
+	}
+
+	return startTicker(func(after time.Time) time.Time {
+		return nextAligned(after, d, LocalLocation())
+	})
+}
+
+// nextAligned returns the first instant strictly after now at which the wall
+// clock in loc is a multiple of d.
+func nextAligned(now time.Time, d time.Duration, loc *time.Location) time.Time {
+	_, offset := now.In(loc).Zone()
+	phase := -time.Duration(offset) * time.Second % d
+	if phase < 0 {
+		phase += d
+	}
+
+	return nextPhase(now, d, phase)
+}
+
+// NewDriftFreeTicker returns a ticker that ticks at now+d, now+2d and so on.
+// Unlike time.Ticker after a slow receiver or a suspended process, it never
+// shifts its schedule: late ticks are delivered as soon as possible and the
+// following ones keep to the original targets, skipping those that were
+// missed entirely. It panics if d is not positive.
+func NewDriftFreeTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("temporalis: non-positive interval for NewDriftFreeTicker")
+	}
+
+	start := time.Now()
+
+	return startTicker(func(after time.Time) time.Time {
+		return nextDriftFree(start, after, d)
+	})
+}
+
+// nextDriftFree returns the first instant of the form start+n·d strictly
+// after now.
+func nextDriftFree(start, now time.Time, d time.Duration) time.Time {
+	n := now.Sub(start)/d + 1
+	return start.Add(n * d)
+}
+
+// Stop turns off the ticker. No more ticks are sent after Stop returns, but
+// C is not closed.
+func (t *Ticker) Stop() {
+	t.once.Do(func() { close(t.stop) })
+}
+
+// startTicker starts a ticker whose targets are computed by next.
+func startTicker(next func(after time.Time) time.Time) *Ticker {
+	c := make(chan time.Time, 1)
+	t := &Ticker{C: c, stop: make(chan struct{})}
+
+	go tickLoop(c, t.stop, next)
+
+	return t
+}
+
+// tickLoop delivers a tick on c at each instant returned by next until stop
+// is closed. The next target is computed from the later of the current time
+// and the previous target, so a timer that fires early by the wall clock
+// never produces the same tick twice.
+func tickLoop(c chan<- time.Time, stop <-chan struct{}, next func(after time.Time) time.Time) {
+	var target time.Time
+
+	for {
+		now := time.Now()
+		if now.Before(target) {
+			now = target
+		}
+
+		target = next(now)
+		timer := time.NewTimer(time.Until(target))
+
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case tick := <-timer.C:
+			select {
+			case c <- tick:
+			default:
+			}
+		}
+	}
+}
+
+// PhasedTicker delivers ticks every period at a fixed phase within that
+// period. The phase is derived from a key, so every process that uses the
+// same key ticks at the same moment while different keys are spread evenly
+// across the period without any coordination.
+type PhasedTicker struct {
+	C <-chan time.Time
+
+	c      chan time.Time
+	period time.Duration
+	phase  time.Duration
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// NewPhasedTicker returns a ticker that ticks every d, at the instants whose
+// offset from the Unix epoch modulo d equals hash(key) mod d. The first tick
+// is the first such instant after now, so it arrives within one period. Like
+// time.Ticker, ticks are dropped if the receiver falls behind. NewPhasedTicker
+// panics if d is not positive.
+func NewPhasedTicker(d time.Duration, key string) *PhasedTicker {
+	if d <= 0 {
+		panic("temporalis: non-positive interval for NewPhasedTicker")
+	}
+
+	c := make(chan time.Time, 1)
+	t := &PhasedTicker{
+		C:      c,
+		c:      c,
+		period: d,
+		phase:  time.Duration(stableHash(key) % uint64(d)),
+		stop:   make(chan struct{}),
+	}
+
+	go t.run()
+
+	return t
+}
+
+// Phase returns the offset of the ticks within each period.
+func (t *PhasedTicker) Phase() time.Duration {
+	return t.phase
+}
+
+// Stop turns off the ticker. No more ticks are sent after Stop returns, but
+// C is not closed.
+func (t *PhasedTicker) Stop() {
+	t.once.Do(func() { close(t.stop) })
+}
+
+// run delivers the ticks at the phase within each period.
+func (t *PhasedTicker) run() {
+	tickLoop(t.c, t.stop, func(after time.Time) time.Time {
+		return nextPhase(after, t.period, t.phase)
+	})
+}
+
+// nextPhase returns the first instant strictly after now whose offset from
+// the Unix epoch modulo period equals phase.
+func nextPhase(now time.Time, period, phase time.Duration) time.Time {
+	since := time.Duration(now.UnixNano() % int64(period))
+	if since < 0 {
+		since += period
+	}
+
+	wait := phase - since
+	if wait <= 0 {
+		wait += period
+	}
+
+	return now.Add(wait)
+}
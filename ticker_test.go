@@ -0,0 +1,99 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNextPhase checks that phased ticks land on the requested offset within
+// each period and always in the future.
+func TestNextPhase(t *testing.T) {
+	now := time.Unix(1200, 0).Add(20 * time.Second)
+
+	if got := nextPhase(now, time.Minute, 30*time.Second); !got.Equal(now.Add(10 * time.Second)) {
+		t.Errorf("nextPhase() = %v, expected 10s later", got.Sub(now))
+	}
+
+	if got := nextPhase(now, time.Minute, 10*time.Second); !got.Equal(now.Add(50 * time.Second)) {
+		t.Errorf("nextPhase() = %v, expected 50s later", got.Sub(now))
+	}
+}
+
+// TestPhasedTicker checks that the ticker delivers a tick within a period.
+func TestPhasedTicker(t *testing.T) {
+	ticker := NewPhasedTicker(20*time.Millisecond, "job")
+	defer ticker.Stop()
+
+	select {
+	case <-ticker.C:
+	case <-time.After(time.Second):
+		t.Fatal("no tick received")
+	}
+}
+
+// TestNextAligned checks that aligned ticks land on wall-clock multiples of
+// the interval, including in a zone with a half-hour offset.
+func TestNextAligned(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+
+	tests := []struct {
+		now      time.Time
+		d        time.Duration
+		loc      *time.Location
+		expected time.Time
+	}{
+		{time.Date(2024, 5, 1, 12, 0, 20, 0, time.UTC), time.Minute, time.UTC, time.Date(2024, 5, 1, 12, 1, 0, 0, time.UTC)},
+		{time.Date(2024, 5, 1, 12, 1, 0, 0, time.UTC), time.Minute, time.UTC, time.Date(2024, 5, 1, 12, 2, 0, 0, time.UTC)},
+		{time.Date(2024, 5, 1, 12, 10, 0, 0, kolkata), time.Hour, kolkata, time.Date(2024, 5, 1, 13, 0, 0, 0, kolkata)},
+		{time.Date(2024, 5, 1, 12, 10, 0, 0, time.UTC), time.Hour, kolkata, time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if actual := nextAligned(test.now, test.d, test.loc); !actual.Equal(test.expected) {
+			t.Errorf("nextAligned(%v, %v, %v) = %v, expected %v", test.now, test.d, test.loc, actual, test.expected)
+		}
+	}
+}
+
+// TestNextDriftFree checks that drift-free ticks keep to the original
+// schedule after a late tick and skip targets that were missed entirely.
+func TestNextDriftFree(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	d := 20 * time.Millisecond
+
+	tests := []struct {
+		now      time.Time
+		expected time.Time
+	}{
+		{start, start.Add(d)},
+		{start.Add(d), start.Add(2 * d)},
+		{start.Add(d + 15*time.Millisecond), start.Add(2 * d)},
+		{start.Add(5*d + time.Millisecond), start.Add(6 * d)},
+	}
+
+	for _, test := range tests {
+		if actual := nextDriftFree(start, test.now, d); !actual.Equal(test.expected) {
+			t.Errorf("nextDriftFree(%v) = %v, expected %v", test.now.Sub(start), actual.Sub(start), test.expected.Sub(start))
+		}
+	}
+}
+
+// TestTickers checks that each kind of ticker keeps delivering ticks.
+func TestTickers(t *testing.T) {
+	tickers := map[string]*Ticker{
+		"aligned":    NewAlignedTicker(10 * time.Millisecond),
+		"drift-free": NewDriftFreeTicker(10 * time.Millisecond),
+		"jittered":   NewJitteredTicker(10*time.Millisecond, 0.5),
+	}
+
+	for name, ticker := range tickers {
+		for i := 0; i < 3; i++ {
+			select {
+			case <-ticker.C:
+			case <-time.After(time.Second):
+				t.Fatalf("%s ticker did not tick", name)
+			}
+		}
+		ticker.Stop()
+	}
+}
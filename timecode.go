@@ -0,0 +1,250 @@
+package temporalis
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FrameRate is a video frame rate for SMPTE timecodes.
+type FrameRate int
+
+const (
+	// FrameRate24 is 24 frames per second, as used for film.
+	FrameRate24 FrameRate = iota
+	// FrameRate25 is 25 frames per second, as used for PAL video.
+	FrameRate25
+	// FrameRate30 is 30 frames per second.
+	FrameRate30
+	// FrameRate2997DropFrame is the 30000/1001 frames per second of NTSC
+	// video with drop-frame timecode: the labels of frames 0 and 1 are
+	// skipped at the start of every minute except every tenth, so that the
+	// timecode stays within a few frames of the wall clock.
+	FrameRate2997DropFrame
+)
+
+// String returns the rate as written in video tools, such as "25" or
+// "29.97 DF".
+func (r FrameRate) String() string {
+	switch r {
+	case FrameRate24:
+		return "24"
+	case FrameRate25:
+		return "25"
+	case FrameRate30:
+		return "30"
+	case FrameRate2997DropFrame:
+		return "29.97 DF"
+	default:
+		return "FrameRate(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// DropFrame reports whether timecodes at the rate skip frame labels.
+func (r FrameRate) DropFrame() bool {
+	return r == FrameRate2997DropFrame
+}
+
+// rational returns the rate as the fraction num/den frames per second, and
+// the number of frame labels per second.
+func (r FrameRate) rational() (num, den uint64, labels int64) {
+	switch r {
+	case FrameRate24:
+		return 24, 1, 24
+	case FrameRate25:
+		return 25, 1, 25
+	case FrameRate30:
+		return 30, 1, 30
+	case FrameRate2997DropFrame:
+		return 30000, 1001, 30
+	default:
+		panic("temporalis: unknown frame rate " + r.String())
+	}
+}
+
+// framesPerDay returns the number of frames from 00:00:00:00 to 24:00:00:00.
+func (r FrameRate) framesPerDay() int64 {
+	_, _, labels := r.rational()
+	frames := labels * 86400
+	if r.DropFrame() {
+		// Two labels are dropped in 1296 of the 1440 minutes of a day.
+		frames -= 2 * (1440 - 144)
+	}
+
+	return frames
+}
+
+// Timecode is an SMPTE timecode, the HH:MM:SS:FF label of a video frame.
+// It is stored as the number of frames since 00:00:00:00 and wraps around
+// after 24 hours, as timecodes do, so adding a frame to 23:59:59:23 at 24
+// frames per second gives 00:00:00:00. The zero value is 00:00:00:00 at 24
+// frames per second. Timecodes are comparable with ==.
+type Timecode struct {
+	rate   FrameRate
+	frames int64
+}
+
+// NewTimecode returns the timecode with the given label. An error wrapping
+// ErrOutOfRange is returned if a field is out of range or if the label is
+// skipped by drop-frame timecode, such as 00:01:00;00. It panics if the
+// rate is unknown.
+func NewTimecode(hours, minutes, seconds, frames int, rate FrameRate) (Timecode, error) {
+	_, _, labels := rate.rational()
+
+	switch {
+	case hours < 0 || hours > 23 || minutes < 0 || minutes > 59 || seconds < 0 || seconds > 59 || frames < 0 || int64(frames) >= labels:
+		return Timecode{}, fmt.Errorf("timecode %02d:%02d:%02d:%02d at %v frames per second: %w", hours, minutes, seconds, frames, rate, ErrOutOfRange)
+	case rate.DropFrame() && seconds == 0 && frames < 2 && minutes%10 != 0:
+		return Timecode{}, fmt.Errorf("timecode %02d:%02d:%02d;%02d is dropped: %w", hours, minutes, seconds, frames, ErrOutOfRange)
+	}
+
+	n := (int64(hours)*3600+int64(minutes)*60+int64(seconds))*labels + int64(frames)
+	if rate.DropFrame() {
+		total := int64(hours)*60 + int64(minutes)
+		n -= 2 * (total - total/10)
+	}
+
+	return Timecode{rate: rate, frames: n}, nil
+}
+
+// ParseTimecode parses a timecode in the form "HH:MM:SS:FF". Drop-frame
+// timecodes are usually written with a semicolon or a period before the
+// frames, as in "01:02:03;04", which is accepted only for a drop-frame rate;
+// colons are accepted for every rate. It panics if the rate is unknown.
+func ParseTimecode(s string, rate FrameRate) (Timecode, error) {
+	if len(s) != 11 || s[2] != ':' || s[5] != ':' || !strings.ContainsRune(":;.", rune(s[8])) {
+		return Timecode{}, syntaxError("ParseTimecode", s, "expected HH:MM:SS:FF")
+	}
+	if s[8] != ':' && !rate.DropFrame() {
+		return Timecode{}, syntaxError("ParseTimecode", s, "drop-frame separator for a rate of "+rate.String())
+	}
+
+	var fields [4]int
+	for i := range fields {
+		digits := s[3*i : 3*i+2]
+		if !isDigit(digits[0]) || !isDigit(digits[1]) {
+			return Timecode{}, syntaxError("ParseTimecode", s, "expected HH:MM:SS:FF")
+		}
+		fields[i], _ = strconv.Atoi(digits)
+	}
+
+	tc, err := NewTimecode(fields[0], fields[1], fields[2], fields[3], rate)
+	if err != nil {
+		return Timecode{}, rangeError("ParseTimecode", s, "")
+	}
+
+	return tc, nil
+}
+
+// TimecodeFromFrames returns the timecode of the frame with the given
+// number, counted from 00:00:00:00 and wrapped around at 24 hours. It
+// panics if the rate is unknown.
+func TimecodeFromFrames(frames int64, rate FrameRate) Timecode {
+	return Timecode{rate: rate}.Add(frames)
+}
+
+// TimecodeFromDuration returns the timecode of the frame that is showing d
+// after 00:00:00:00, wrapped around at 24 hours. It panics if the rate is
+// unknown.
+func TimecodeFromDuration(d time.Duration, rate FrameRate) Timecode {
+	num, den, _ := rate.rational()
+
+	// frames = floor(d * num / (den * 1e9)), computed without overflow.
+	hi, lo := bits.Mul64(uint64(abs(d)), num)
+	q, rem := bits.Div64(hi, lo, den*uint64(time.Second))
+
+	frames := int64(q)
+	if d < 0 {
+		frames = -frames
+		if rem != 0 {
+			frames--
+		}
+	}
+
+	return TimecodeFromFrames(frames, rate)
+}
+
+// Rate returns the frame rate of the timecode.
+func (t Timecode) Rate() FrameRate {
+	return t.rate
+}
+
+// Frames returns the number of frames since 00:00:00:00.
+func (t Timecode) Frames() int64 {
+	return t.frames
+}
+
+// Duration returns the time from 00:00:00:00 to the start of the frame,
+// rounded down to the nanosecond. At drop-frame rates it is close to, but
+// not exactly, what the label suggests.
+func (t Timecode) Duration() time.Duration {
+	num, den, _ := t.rate.rational()
+
+	return time.Duration(uint64(t.frames) * den * uint64(time.Second) / num)
+}
+
+// Fields returns the hours, minutes, seconds and frames of the label.
+func (t Timecode) Fields() (hours, minutes, seconds, frames int) {
+	_, _, labels := t.rate.rational()
+
+	n := t.frames
+	if t.rate.DropFrame() {
+		// Add back the labels skipped before the frame: 18 in every ten
+		// minutes of 17982 frames, and 2 in every later minute of 1798.
+		tens, rem := n/17982, n%17982
+		n += 18 * tens
+		if rem >= 2 {
+			n += 2 * ((rem - 2) / 1798)
+		}
+	}
+
+	frames = int(n % labels)
+	n /= labels
+
+	return int(n / 3600), int(n / 60 % 60), int(n % 60), frames
+}
+
+// String returns the timecode in the form "HH:MM:SS:FF", with a semicolon
+// before the frames at drop-frame rates.
+func (t Timecode) String() string {
+	h, m, s, f := t.Fields()
+
+	sep := ':'
+	if t.rate.DropFrame() {
+		sep = ';'
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d%c%02d", h, m, s, sep, f)
+}
+
+// Add returns the timecode n frames later, or earlier if n is negative,
+// wrapping around at 24 hours.
+func (t Timecode) Add(n int64) Timecode {
+	perDay := t.rate.framesPerDay()
+
+	frames := (t.frames + n%perDay) % perDay
+	if frames < 0 {
+		frames += perDay
+	}
+
+	return Timecode{rate: t.rate, frames: frames}
+}
+
+// AddDuration returns the timecode of the frame that is showing d after the
+// start of t, wrapping around at 24 hours.
+func (t Timecode) AddDuration(d time.Duration) Timecode {
+	return t.Add(TimecodeFromDuration(d, t.rate).frames)
+}
+
+// Sub returns the number of frames from u to t, which is negative if u is
+// later. It does not account for wrapping, so it suits timecodes within
+// one day. Sub panics if the timecodes have different rates.
+func (t Timecode) Sub(u Timecode) int64 {
+	if t.rate != u.rate {
+		panic("temporalis: Sub of timecodes with different frame rates")
+	}
+
+	return t.frames - u.frames
+}
@@ -0,0 +1,106 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestParseTimecode tests parsing, printing and the frame numbers of
+// drop-frame and non-drop-frame timecodes.
+func TestParseTimecode(t *testing.T) {
+	tests := []struct {
+		input    string
+		rate     FrameRate
+		frames   int64
+		expected string
+	}{
+		{"01:02:03:04", FrameRate24, (3600+120+3)*24 + 4, "01:02:03:04"},
+		{"00:00:01:24", FrameRate25, 49, "00:00:01:24"},
+		{"00:01:00:00", FrameRate30, 1800, "00:01:00:00"},
+		{"00:00:59;29", FrameRate2997DropFrame, 1799, "00:00:59;29"},
+		{"00:01:00;02", FrameRate2997DropFrame, 1800, "00:01:00;02"},
+		{"00:10:00;00", FrameRate2997DropFrame, 17982, "00:10:00;00"},
+		{"00:10:00.01", FrameRate2997DropFrame, 17983, "00:10:00;01"},
+		{"01:00:00:00", FrameRate2997DropFrame, 107892, "01:00:00;00"},
+	}
+
+	for _, test := range tests {
+		tc, err := ParseTimecode(test.input, test.rate)
+		if err != nil || tc.Frames() != test.frames || tc.String() != test.expected {
+			t.Errorf("ParseTimecode(%q, %v) = %v (frame %d), %v, expected %s (frame %d)", test.input, test.rate, tc, tc.Frames(), err, test.expected, test.frames)
+		}
+	}
+}
+
+// TestParseTimecodeErrors tests that malformed and nonexistent timecodes
+// are rejected.
+func TestParseTimecodeErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		rate     FrameRate
+		expected error
+	}{
+		{"1:02:03:04", FrameRate25, ErrSyntax},
+		{"01:02:03-04", FrameRate25, ErrSyntax},
+		{"01:02:03;04", FrameRate25, ErrSyntax},
+		{"01:0x:03:04", FrameRate25, ErrSyntax},
+		{"01:02:03:25", FrameRate25, ErrOutOfRange},
+		{"24:00:00:00", FrameRate24, ErrOutOfRange},
+		{"00:01:00;00", FrameRate2997DropFrame, ErrOutOfRange},
+		{"00:01:00;01", FrameRate2997DropFrame, ErrOutOfRange},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseTimecode(test.input, test.rate); !errors.Is(err, test.expected) {
+			t.Errorf("ParseTimecode(%q, %v) error = %v, expected %v", test.input, test.rate, err, test.expected)
+		}
+	}
+}
+
+// TestTimecodeRoundTrip tests that every frame of a drop-frame day prints
+// as a label that parses back to it.
+func TestTimecodeRoundTrip(t *testing.T) {
+	rate := FrameRate2997DropFrame
+	for n := int64(0); n < rate.framesPerDay(); n += 7 {
+		tc := TimecodeFromFrames(n, rate)
+		h, m, s, f := tc.Fields()
+		back, err := NewTimecode(h, m, s, f, rate)
+		if err != nil || back != tc {
+			t.Fatalf("NewTimecode(%v) = %v, %v, expected frame %d", tc, back, err, n)
+		}
+	}
+
+	last := TimecodeFromFrames(-1, rate)
+	if last.String() != "23:59:59;29" {
+		t.Errorf("TimecodeFromFrames(-1) = %v, expected 23:59:59;29", last)
+	}
+}
+
+// TestTimecodeArithmetic tests conversion to and from durations and
+// wrapping around at 24 hours.
+func TestTimecodeArithmetic(t *testing.T) {
+	if tc := TimecodeFromDuration(time.Hour+40*time.Millisecond, FrameRate25); tc.String() != "01:00:00:01" || tc.Duration() != time.Hour+40*time.Millisecond {
+		t.Errorf("TimecodeFromDuration(1h40ms) = %v (%v), expected 01:00:00:01", tc, tc.Duration())
+	}
+	if tc := TimecodeFromDuration(time.Hour, FrameRate2997DropFrame); tc.String() != "01:00:00;00" {
+		t.Errorf("TimecodeFromDuration(1h) = %v, expected 01:00:00;00", tc)
+	}
+	if tc := TimecodeFromDuration(-time.Millisecond, FrameRate24); tc.String() != "23:59:59:23" {
+		t.Errorf("TimecodeFromDuration(-1ms) = %v, expected 23:59:59:23", tc)
+	}
+
+	tc, _ := ParseTimecode("23:59:59:23", FrameRate24)
+	if next := tc.Add(1); next != (Timecode{}) {
+		t.Errorf("Add(1) = %v, expected 00:00:00:00", next)
+	}
+	if next := tc.AddDuration(2 * time.Second); next.String() != "00:00:01:23" {
+		t.Errorf("AddDuration(2s) = %v, expected 00:00:01:23", next)
+	}
+
+	start, _ := ParseTimecode("00:00:59;29", FrameRate2997DropFrame)
+	end, _ := ParseTimecode("00:01:00;02", FrameRate2997DropFrame)
+	if n := end.Sub(start); n != 1 {
+		t.Errorf("Sub() = %d, expected 1", n)
+	}
+}
@@ -0,0 +1,260 @@
+package temporalis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a wall-clock reading without a date or time zone, such as the
+// opening time of a shop. The zero value is midnight. TimeOfDay values can be
+// compared with == and used as map keys.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// day is the length of a calendar day without DST transitions.
+const day = 24 * time.Hour
+
+// Midnight and Noon are the two named times of day.
+var (
+	Midnight = TimeOfDay{}
+	Noon     = TimeOfDay{Hour: 12}
+)
+
+// NewTimeOfDay returns the time of day with the given fields, wrapping values
+// that are out of range around midnight, so 25:00 becomes 01:00.
+func NewTimeOfDay(hour, minute, second, nanosecond int) TimeOfDay {
+	d := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(nanosecond)
+
+	return TimeOfDayFromDuration(d)
+}
+
+// TimeOfDayFromDuration returns the time of day that is d after midnight,
+// wrapping around so that the result is always within a day.
+func TimeOfDayFromDuration(d time.Duration) TimeOfDay {
+	d %= day
+	if d < 0 {
+		d += day
+	}
+
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	d -= seconds * time.Second
+
+	return TimeOfDay{Hour: int(hours), Minute: int(minutes), Second: int(seconds), Nanosecond: int(d)}
+}
+
+// TimeOfDayOf returns the wall-clock reading of t in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay parses a time of day in 24-hour notation ("09:30",
+// "9:30:15", "21:00:00.5") or 12-hour notation ("9pm", "9:30 PM",
+// "12:15am"), as well as "noon" and "midnight". A bare number such as "9" is
+// rejected because it is ambiguous.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	str := strings.ToLower(strings.Join(strings.Fields(s), ""))
+
+	switch str {
+	case "noon", "midday":
+		return Noon, nil
+	case "midnight":
+		return Midnight, nil
+	}
+
+	suffix := ""
+	if strings.HasSuffix(str, "am") || strings.HasSuffix(str, "pm") {
+		suffix, str = str[len(str)-2:], str[:len(str)-2]
+	}
+
+	parts := strings.Split(str, ":")
+	if len(parts) > 3 || (len(parts) == 1 && suffix == "") {
+		return TimeOfDay{}, syntaxError("ParseTimeOfDay", s, "")
+	}
+
+	var t TimeOfDay
+	var err error
+
+	if t.Hour, err = strconv.Atoi(parts[0]); err != nil || len(parts[0]) > 2 {
+		return TimeOfDay{}, syntaxError("ParseTimeOfDay", s, "")
+	}
+
+	if len(parts) > 1 {
+		if t.Minute, err = strconv.Atoi(parts[1]); err != nil || len(parts[1]) != 2 {
+			return TimeOfDay{}, syntaxError("ParseTimeOfDay", s, "")
+		}
+	}
+
+	if len(parts) > 2 {
+		sec, frac, _ := strings.Cut(parts[2], ".")
+		if t.Second, err = strconv.Atoi(sec); err != nil || len(sec) != 2 {
+			return TimeOfDay{}, syntaxError("ParseTimeOfDay", s, "")
+		}
+
+		if frac != "" {
+			if len(frac) > 9 {
+				return TimeOfDay{}, syntaxError("ParseTimeOfDay", s, "")
+			}
+			if t.Nanosecond, err = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac))); err != nil {
+				return TimeOfDay{}, syntaxError("ParseTimeOfDay", s, "")
+			}
+		}
+	}
+
+	if suffix != "" {
+		if t.Hour < 1 || t.Hour > 12 {
+			return TimeOfDay{}, rangeError("ParseTimeOfDay", s, "hour must be 1 to 12 with am or pm")
+		}
+		t.Hour %= 12
+		if suffix == "pm" {
+			t.Hour += 12
+		}
+	}
+
+	if !t.IsValid() {
+		return TimeOfDay{}, rangeError("ParseTimeOfDay", s, "")
+	}
+
+	return t, nil
+}
+
+// IsValid reports whether every field is within its range.
+func (t TimeOfDay) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 && t.Nanosecond >= 0 && t.Nanosecond < 1e9
+}
+
+// String returns the time in "15:04:05" format, followed by a fraction of a
+// second if the nanosecond field is not zero.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+
+	return s
+}
+
+// SinceMidnight returns the time elapsed between midnight and t on a day
+// without DST transitions.
+func (t TimeOfDay) SinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
+
+// Add returns the time of day d after t, wrapping around midnight.
+func (t TimeOfDay) Add(d time.Duration) TimeOfDay {
+	return TimeOfDayFromDuration(t.SinceMidnight() + d%day)
+}
+
+// Sub returns the time from u to t going forward on the clock, in [0, 24h),
+// so 01:00.Sub(23:00) is two hours.
+func (t TimeOfDay) Sub(u TimeOfDay) time.Duration {
+	d := t.SinceMidnight() - u.SinceMidnight()
+	if d < 0 {
+		d += day
+	}
+
+	return d
+}
+
+// Compare returns -1 if t is earlier in the day than u, +1 if it is later,
+// and 0 if they are equal.
+func (t TimeOfDay) Compare(u TimeOfDay) int {
+	a, b := t.SinceMidnight(), u.SinceMidnight()
+
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Before reports whether t is earlier in the day than u.
+func (t TimeOfDay) Before(u TimeOfDay) bool {
+	return t.Compare(u) < 0
+}
+
+// After reports whether t is later in the day than u.
+func (t TimeOfDay) After(u TimeOfDay) bool {
+	return t.Compare(u) > 0
+}
+
+// OnDate returns the instant at which the wall clock in loc shows t on date
+// d. If that reading does not exist because of a DST gap, it is shifted
+// forward by the length of the gap; if it occurs twice, the earlier instant
+// is returned.
+func (t TimeOfDay) OnDate(d CivilDate, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	wall := time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, time.UTC)
+	resolved, _ := ResolveAmbiguous(wall, loc, PreferEarlier)
+
+	return resolved
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting every format
+// understood by ParseTimeOfDay.
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	parsed, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+
+	return nil
+}
+
+// ClockRange is a daily span of wall-clock time from Start up to, but not
+// including, End, such as opening hours or an off-peak window. If End is not
+// after Start, the range wraps around midnight, so 22:00 to 06:00 covers the
+// night; the zero value, from midnight to midnight, covers the whole day.
+type ClockRange struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// Contains reports whether t falls within the range.
+func (r ClockRange) Contains(t TimeOfDay) bool {
+	if r.Start.Before(r.End) {
+		return !t.Before(r.Start) && t.Before(r.End)
+	}
+
+	return !t.Before(r.Start) || t.Before(r.End)
+}
+
+// Duration returns the length of the range on a day without DST
+// transitions, which is 24 hours if Start equals End.
+func (r ClockRange) Duration() time.Duration {
+	if r.Start == r.End {
+		return day
+	}
+
+	return r.End.Sub(r.Start)
+}
+
+// String returns the range in "08:00:00-18:00:00" format.
+func (r ClockRange) String() string {
+	return r.Start.String() + "-" + r.End.String()
+}
@@ -0,0 +1,96 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseTimeOfDay checks 24-hour, 12-hour and named inputs as well as
+// rejection of ambiguous or out-of-range values.
+func TestParseTimeOfDay(t *testing.T) {
+	tests := map[string]TimeOfDay{
+		"09:30":       {Hour: 9, Minute: 30},
+		"21:00:05.25": {Hour: 21, Second: 5, Nanosecond: 250000000},
+		"9:30pm":      {Hour: 21, Minute: 30},
+		"12:15 AM":    {Minute: 15},
+		"12pm":        Noon,
+		"midnight":    Midnight,
+	}
+
+	for input, expected := range tests {
+		actual, err := ParseTimeOfDay(input)
+		if err != nil || actual != expected {
+			t.Errorf("ParseTimeOfDay(%q) = %v, %v, expected %v", input, actual, err, expected)
+		}
+	}
+
+	for _, input := range []string{"9", "24:00", "13pm", "9:5", "09:30:61", "noonish"} {
+		if _, err := ParseTimeOfDay(input); err == nil {
+			t.Errorf("ParseTimeOfDay(%q) returned no error", input)
+		}
+	}
+}
+
+// TestTimeOfDayArithmetic checks wrapping around midnight in both directions.
+func TestTimeOfDayArithmetic(t *testing.T) {
+	late := TimeOfDay{Hour: 23}
+
+	if got := late.Add(2 * time.Hour); got != (TimeOfDay{Hour: 1}) {
+		t.Errorf("Add(2h) = %v, expected 01:00:00", got)
+	}
+
+	if got := (TimeOfDay{Hour: 1}).Add(-2 * time.Hour); got != late {
+		t.Errorf("Add(-2h) = %v, expected 23:00:00", got)
+	}
+
+	if got := (TimeOfDay{Hour: 1}).Sub(late); got != 2*time.Hour {
+		t.Errorf("Sub() = %v, expected 2h", got)
+	}
+
+	if !Noon.After(Midnight) || late.Before(Noon) {
+		t.Errorf("comparison of times of day is wrong")
+	}
+}
+
+// TestTimeOfDayOnDate checks that a time in a DST gap is moved forward.
+func TestTimeOfDayOnDate(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("Europe/Berlin not available")
+	}
+
+	got := TimeOfDay{Hour: 2, Minute: 30}.OnDate(CivilDate{2024, time.March, 31}, loc)
+	if got.Hour() != 3 || got.Minute() != 30 {
+		t.Errorf("OnDate() = %v, expected 03:30", got)
+	}
+}
+
+// TestClockRange tests ranges within a day, across midnight and the whole
+// day.
+func TestClockRange(t *testing.T) {
+	night := ClockRange{Start: NewTimeOfDay(22, 0, 0, 0), End: NewTimeOfDay(6, 0, 0, 0)}
+	office := ClockRange{Start: NewTimeOfDay(9, 0, 0, 0), End: NewTimeOfDay(17, 0, 0, 0)}
+
+	tests := []struct {
+		r        ClockRange
+		t        TimeOfDay
+		expected bool
+	}{
+		{office, NewTimeOfDay(9, 0, 0, 0), true},
+		{office, NewTimeOfDay(17, 0, 0, 0), false},
+		{night, NewTimeOfDay(23, 0, 0, 0), true},
+		{night, NewTimeOfDay(5, 59, 0, 0), true},
+		{night, Noon, false},
+		{ClockRange{}, Noon, true},
+	}
+
+	for _, test := range tests {
+		if actual := test.r.Contains(test.t); actual != test.expected {
+			t.Errorf("%v.Contains(%v) = %v, expected %v", test.r, test.t, actual, test.expected)
+		}
+	}
+
+	if night.Duration() != 8*time.Hour || office.Duration() != 8*time.Hour || (ClockRange{}).Duration() != 24*time.Hour {
+		t.Errorf("Duration() = %v, %v, %v, expected 8h, 8h, 24h", night.Duration(), office.Duration(), ClockRange{}.Duration())
+	}
+}
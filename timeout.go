@@ -0,0 +1,72 @@
+package temporalis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutError reports that a function did not finish before its deadline.
+// It matches both ErrTimeout and context.DeadlineExceeded with errors.Is.
+// Err is the error the function returned after the deadline, if it returned
+// before the caller gave up on it.
+type TimeoutError struct {
+	Deadline time.Time
+	Err      error
+}
+
+// Error implements error.
+func (e *TimeoutError) Error() string {
+	msg := fmt.Sprintf("timed out at %s", e.Deadline.Format(RFC3339Nano))
+	if e.Err != nil && !errors.Is(e.Err, context.DeadlineExceeded) {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+// Timeout reports true, so that code checking for net.Error timeouts also
+// recognizes a TimeoutError.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// Unwrap returns ErrTimeout, context.DeadlineExceeded and Err.
+func (e *TimeoutError) Unwrap() []error {
+	errs := []error{ErrTimeout, context.DeadlineExceeded}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+
+	return errs
+}
+
+// WithTimeout runs fn with a context that expires after d and returns the
+// error of fn, or a *TimeoutError if the context expired first. It returns as
+// soon as the deadline passes even if fn ignores its context, in which case
+// fn keeps running in the background until it returns.
+func WithTimeout(d time.Duration, fn func(ctx context.Context) error) error {
+	return RunUntil(time.Now().Add(d), fn)
+}
+
+// RunUntil is like WithTimeout with an absolute deadline.
+func RunUntil(deadline time.Time, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && ctx.Err() == context.DeadlineExceeded {
+			return &TimeoutError{Deadline: deadline, Err: err}
+		}
+		return err
+	case <-ctx.Done():
+		return &TimeoutError{Deadline: deadline}
+	}
+}
@@ -0,0 +1,40 @@
+package temporalis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestWithTimeout checks that function errors and timeouts are told apart.
+func TestWithTimeout(t *testing.T) {
+	failure := errors.New("failure")
+
+	if err := WithTimeout(time.Second, func(context.Context) error { return failure }); err != failure {
+		t.Errorf("WithTimeout() = %v, expected %v", err, failure)
+	}
+
+	if err := WithTimeout(time.Second, func(context.Context) error { return nil }); err != nil {
+		t.Errorf("WithTimeout() = %v, expected nil", err)
+	}
+
+	err := WithTimeout(10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WithTimeout() = %v, expected ErrTimeout", err)
+	}
+
+	block := make(chan struct{})
+	defer close(block)
+
+	err = RunUntil(time.Now().Add(10*time.Millisecond), func(context.Context) error {
+		<-block
+		return nil
+	})
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("RunUntil() with a function ignoring its context = %v, expected ErrTimeout", err)
+	}
+}
@@ -0,0 +1,173 @@
+package temporalis
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"time"
+)
+
+// Point is a value observed at a time.
+type Point[T any] struct {
+	Time  time.Time
+	Value T
+}
+
+// TimeSeries is a sequence of points ordered by time. Points with equal
+// times are kept in insertion order. The zero value is an empty series. A
+// TimeSeries is not safe for concurrent use.
+type TimeSeries[T any] struct {
+	points []Point[T]
+}
+
+// NewTimeSeries returns a series holding the given points, which need not
+// be in order.
+func NewTimeSeries[T any](points ...Point[T]) *TimeSeries[T] {
+	s := &TimeSeries[T]{points: slices.Clone(points)}
+	slices.SortStableFunc(s.points, comparePoints)
+
+	return s
+}
+
+// Insert adds a point, keeping the series sorted.
+func (s *TimeSeries[T]) Insert(t time.Time, v T) {
+	// Insert after any points with the same time.
+	i := s.search(t.Add(1))
+	s.points = slices.Insert(s.points, i, Point[T]{Time: t, Value: v})
+}
+
+// Len returns the number of points.
+func (s *TimeSeries[T]) Len() int {
+	return len(s.points)
+}
+
+// Points returns a copy of the points in order.
+func (s *TimeSeries[T]) Points() []Point[T] {
+	return slices.Clone(s.points)
+}
+
+// All yields the points in order.
+func (s *TimeSeries[T]) All() iter.Seq2[time.Time, T] {
+	return func(yield func(time.Time, T) bool) {
+		for _, p := range s.points {
+			if !yield(p.Time, p.Value) {
+				return
+			}
+		}
+	}
+}
+
+// At returns the value of the first point at exactly t.
+func (s *TimeSeries[T]) At(t time.Time) (T, bool) {
+	if i := s.search(t); i < len(s.points) && s.points[i].Time.Equal(t) {
+		return s.points[i].Value, true
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// Before returns the last point strictly before t.
+func (s *TimeSeries[T]) Before(t time.Time) (Point[T], bool) {
+	if i := s.search(t); i > 0 {
+		return s.points[i-1], true
+	}
+
+	return Point[T]{}, false
+}
+
+// After returns the first point strictly after t.
+func (s *TimeSeries[T]) After(t time.Time) (Point[T], bool) {
+	i := s.search(t)
+	for i < len(s.points) && s.points[i].Time.Equal(t) {
+		i++
+	}
+
+	if i < len(s.points) {
+		return s.points[i], true
+	}
+
+	return Point[T]{}, false
+}
+
+// Nearest returns the point closest to t. Of two points equally far away
+// the earlier one is returned. It reports false if the series is empty.
+func (s *TimeSeries[T]) Nearest(t time.Time) (Point[T], bool) {
+	i := s.search(t)
+
+	switch {
+	case len(s.points) == 0:
+		return Point[T]{}, false
+	case i == 0:
+		return s.points[0], true
+	case i == len(s.points):
+		return s.points[i-1], true
+	}
+
+	before, after := s.points[i-1], s.points[i]
+	if after.Time.Sub(t) < t.Sub(before.Time) {
+		return after, true
+	}
+
+	return before, true
+}
+
+// Between returns the points in the interval, in order.
+func (s *TimeSeries[T]) Between(i Interval) []Point[T] {
+	lo := s.search(i.Start)
+	hi := max(lo, s.search(i.End))
+
+	return slices.Clone(s.points[lo:hi])
+}
+
+// search returns the index of the first point not before t.
+func (s *TimeSeries[T]) search(t time.Time) int {
+	i, _ := slices.BinarySearchFunc(s.points, t, func(p Point[T], t time.Time) int {
+		return p.Time.Compare(t)
+	})
+
+	return i
+}
+
+// comparePoints orders points by time.
+func comparePoints[T any](a, b Point[T]) int {
+	return a.Time.Compare(b.Time)
+}
+
+// Number is the set of numeric types that Interpolate supports.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Interpolate returns the value of the series at t, interpolated linearly
+// between the last point at or before t and the first point after it. For
+// integer types the result is rounded to the nearest integer. It reports
+// false if t lies outside the series.
+func Interpolate[T Number](s *TimeSeries[T], t time.Time) (T, bool) {
+	if v, ok := s.At(t); ok {
+		return v, true
+	}
+
+	before, ok := s.Before(t)
+	if !ok {
+		return 0, false
+	}
+
+	after, ok := s.After(t)
+	if !ok {
+		return 0, false
+	}
+
+	frac := float64(t.Sub(before.Time)) / float64(after.Time.Sub(before.Time))
+	v := float64(before.Value) + frac*(float64(after.Value)-float64(before.Value))
+
+	// Converting one half reveals whether T is an integer type.
+	if half := 0.5; T(half) == 0 {
+		v = math.Round(v)
+	}
+
+	return T(v), true
+}
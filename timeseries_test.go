@@ -0,0 +1,94 @@
+package temporalis
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestTimeSeries checks sorted insertion and the point queries.
+func TestTimeSeries(t *testing.T) {
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+	s := NewTimeSeries(Point[string]{at(20), "c"}, Point[string]{at(0), "a"})
+	s.Insert(at(10), "b")
+	s.Insert(at(10), "b2")
+
+	if s.Len() != 4 {
+		t.Fatalf("Len() = %d, expected 4", s.Len())
+	}
+
+	var values []string
+	for _, v := range s.All() {
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []string{"a", "b", "b2", "c"}) {
+		t.Errorf("All() = %v, expected [a b b2 c]", values)
+	}
+
+	if v, ok := s.At(at(10)); !ok || v != "b" {
+		t.Errorf("At(10) = %q, %v, expected b, true", v, ok)
+	}
+	if _, ok := s.At(at(5)); ok {
+		t.Errorf("At(5) = _, true, expected false")
+	}
+
+	if p, ok := s.Before(at(10)); !ok || p.Value != "a" {
+		t.Errorf("Before(10) = %v, %v, expected a", p, ok)
+	}
+	if p, ok := s.After(at(10)); !ok || p.Value != "c" {
+		t.Errorf("After(10) = %v, %v, expected c", p, ok)
+	}
+	if _, ok := s.Before(at(0)); ok {
+		t.Errorf("Before(0) = _, true, expected false")
+	}
+	if _, ok := s.After(at(20)); ok {
+		t.Errorf("After(20) = _, true, expected false")
+	}
+
+	nearest := []struct {
+		minutes  int
+		expected string
+	}{
+		{-5, "a"}, {4, "a"}, {5, "a"}, {6, "b"}, {16, "c"}, {99, "c"},
+	}
+	for _, test := range nearest {
+		if p, ok := s.Nearest(at(test.minutes)); !ok || p.Value != test.expected {
+			t.Errorf("Nearest(%d) = %v, %v, expected %s", test.minutes, p, ok, test.expected)
+		}
+	}
+
+	if between := s.Between(Interval{Start: at(10), End: at(20)}); len(between) != 2 || between[1].Value != "b2" {
+		t.Errorf("Between([10, 20)) = %v, expected the two points at 10", between)
+	}
+	if between := s.Between(Interval{Start: at(20), End: at(10)}); len(between) != 0 {
+		t.Errorf("Between() of an empty interval = %v, expected none", between)
+	}
+
+	var empty TimeSeries[int]
+	if _, ok := empty.Nearest(base); ok {
+		t.Errorf("Nearest() on an empty series = _, true, expected false")
+	}
+}
+
+// TestInterpolate checks linear interpolation for float and integer series.
+func TestInterpolate(t *testing.T) {
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	floats := NewTimeSeries(Point[float64]{base, 10}, Point[float64]{base.Add(time.Hour), 20})
+	if v, ok := Interpolate(floats, base.Add(15*time.Minute)); !ok || v != 12.5 {
+		t.Errorf("Interpolate() = %v, %v, expected 12.5", v, ok)
+	}
+	if _, ok := Interpolate(floats, base.Add(2*time.Hour)); ok {
+		t.Errorf("Interpolate() after the series = _, true, expected false")
+	}
+
+	ints := NewTimeSeries(Point[uint8]{base, 200}, Point[uint8]{base.Add(time.Hour), 100})
+	if v, ok := Interpolate(ints, base.Add(40*time.Minute)); !ok || v != 133 {
+		t.Errorf("Interpolate() = %v, %v, expected 133", v, ok)
+	}
+	if v, ok := Interpolate(ints, base); !ok || v != 200 {
+		t.Errorf("Interpolate() at a point = %v, %v, expected 200", v, ok)
+	}
+}
@@ -1,7 +1,5 @@
 package temporalis
 
-type Duration int64
-
 type Time struct {
 	// wall and ext encode the wall time seconds, wall time nanoseconds,
 	// and optional monotonic clock reading in nanoseconds.
@@ -0,0 +1,380 @@
+package temporalis
+
+import (
+	"archive/zip"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The embedded snapshot is a copy of $GOROOT/lib/time/zoneinfo.zip, and
+// tzdata/VERSION holds the IANA release it was built from. WebAssembly
+// builds embed tzdata/zoneinfo_trimmed.zip instead, which holds only the
+// zones listed in tzdata/zone.tab plus UTC and the Etc zones; see
+// tzdata_wasm.go. All of these files are replaced together when the
+// snapshot is updated.
+
+//go:embed tzdata/VERSION
+var embeddedVersion string
+
+// SystemTZData is the version name that refers to the zone data installed on
+// the host, as used by time.LoadLocation.
+const SystemTZData = "system"
+
+// tzDataSet is a zoneinfo.zip archive indexed by zone name.
+type tzDataSet struct {
+	version string
+	zones   map[string]*zip.File
+	cache   map[string]*time.Location
+	mu      sync.Mutex
+}
+
+var (
+	tzMu       sync.RWMutex
+	tzSets     = map[string]*tzDataSet{}
+	tzDefault  = SystemTZData
+	tzEmbedded string
+)
+
+func init() {
+	version := strings.TrimSpace(embeddedVersion)
+	if err := RegisterTZData(version, embeddedZoneinfo); err == nil {
+		tzEmbedded = version
+		if preferEmbeddedTZData {
+			tzDefault = version
+		}
+	}
+}
+
+// RegisterTZData makes the zones in a zoneinfo.zip archive available under the
+// given version name, for use with LoadLocationVersion. The archive must have
+// the layout of $GOROOT/lib/time/zoneinfo.zip, with one TZif file per zone.
+// Registering a version that already exists replaces it.
+func RegisterTZData(version string, zoneinfoZip []byte) error {
+	if version == "" || version == SystemTZData {
+		return fmt.Errorf("tzdata version %q: %w", version, ErrSyntax)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(zoneinfoZip), int64(len(zoneinfoZip)))
+	if err != nil {
+		return fmt.Errorf("reading tzdata %s: %w", version, &wrappedError{category: ErrSyntax, err: err})
+	}
+
+	set := &tzDataSet{
+		version: version,
+		zones:   make(map[string]*zip.File, len(r.File)),
+		cache:   map[string]*time.Location{},
+	}
+
+	for _, f := range r.File {
+		if !strings.HasSuffix(f.Name, "/") {
+			set.zones[f.Name] = f
+		}
+	}
+
+	tzMu.Lock()
+	defer tzMu.Unlock()
+
+	tzSets[version] = set
+
+	return nil
+}
+
+// UseEmbeddedTZData makes LoadLocation and every function built on it read
+// zone data from the snapshot embedded in this package instead of the host.
+// Binaries that run in minimal containers without /usr/share/zoneinfo should
+// call it once during start-up.
+func UseEmbeddedTZData() {
+	tzMu.Lock()
+	defer tzMu.Unlock()
+
+	tzDefault = tzEmbedded
+}
+
+// UseTZData makes LoadLocation and every function built on it read zone data
+// from the given version, which is SystemTZData, the release of the embedded
+// snapshot or a version registered with RegisterTZData. It is typically
+// called by code generated with the tztrim command, after registering an
+// allowlisted set of zones.
+func UseTZData(version string) error {
+	tzMu.Lock()
+	defer tzMu.Unlock()
+
+	if _, ok := tzSets[version]; !ok && version != SystemTZData {
+		return fmt.Errorf("tzdata version %q: %w", version, ErrInvalidZone)
+	}
+
+	tzDefault = version
+
+	return nil
+}
+
+// UseSystemTZData restores the default behaviour of reading zone data from the
+// host, falling back to the embedded snapshot for zones the host lacks.
+func UseSystemTZData() {
+	tzMu.Lock()
+	defer tzMu.Unlock()
+
+	tzDefault = SystemTZData
+}
+
+// TZDataVersion returns the IANA release of the zone data currently used by
+// LoadLocation, such as "2024a". For the host data it is read from the
+// tzdata.zi file that most distributions install, and is empty if the
+// release cannot be determined.
+func TZDataVersion() string {
+	tzMu.RLock()
+	version := tzDefault
+	tzMu.RUnlock()
+
+	if version == SystemTZData {
+		return systemTZDataVersion()
+	}
+
+	return version
+}
+
+// EmbeddedTZDataVersion returns the IANA release of the embedded snapshot.
+func EmbeddedTZDataVersion() string {
+	return tzEmbedded
+}
+
+// LoadLocationVersion loads a zone from a specific version of the zone data,
+// regardless of the default chosen with UseEmbeddedTZData. The version is
+// either SystemTZData, the release of the embedded snapshot, or a version
+// registered with RegisterTZData.
+func LoadLocationVersion(name, version string) (*time.Location, error) {
+	if version == SystemTZData {
+		return time.LoadLocation(name)
+	}
+
+	tzMu.RLock()
+	set, ok := tzSets[version]
+	tzMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q: tzdata version %q is not available", ErrInvalidZone, name, version)
+	}
+
+	return set.load(name)
+}
+
+// AvailableTimezones returns the sorted names of all zones in the zone data
+// currently used by LoadLocation. For the host data the zoneinfo directory is
+// scanned; if it cannot be found the embedded snapshot is listed instead.
+func AvailableTimezones() []string {
+	tzMu.RLock()
+	version := tzDefault
+	set := tzSets[tzEmbedded]
+	if version != SystemTZData {
+		set = tzSets[version]
+	}
+	tzMu.RUnlock()
+
+	if version == SystemTZData {
+		if names := systemTimezones(); len(names) > 0 {
+			return names
+		}
+	}
+
+	if set == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(set.zones))
+	for name := range set.zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// TrimTZData returns a zoneinfo.zip archive, for use with RegisterTZData,
+// that holds only the given zones from a registered version of the zone
+// data, such as the embedded snapshot. Bundling such an archive instead of
+// the full snapshot keeps binaries small while guaranteeing that the listed
+// zones load on hosts without zone data. Zones missing from the version are
+// reported as an error wrapping ErrInvalidZone. The tztrim command wraps
+// this function for use with go generate.
+func TrimTZData(version string, zones []string) ([]byte, error) {
+	tzMu.RLock()
+	set, ok := tzSets[version]
+	tzMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("tzdata version %q: %w", version, ErrInvalidZone)
+	}
+
+	names := append([]string(nil), zones...)
+	sort.Strings(names)
+	names = slices.Compact(names)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	for _, name := range names {
+		f, ok := set.zones[name]
+		if !ok {
+			return nil, fmt.Errorf("%w %q: not found in tzdata %s", ErrInvalidZone, name, version)
+		}
+
+		if err := w.Copy(f); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// loadZone loads an IANA zone from the default zone data. When reading from
+// the host fails, the embedded snapshot is tried before giving up.
+func loadZone(name string) (*time.Location, error) {
+	tzMu.RLock()
+	version := tzDefault
+	tzMu.RUnlock()
+
+	if version != SystemTZData {
+		return LoadLocationVersion(name, version)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err == nil || tzEmbedded == "" {
+		return loc, err
+	}
+
+	if fallback, ferr := LoadLocationVersion(name, tzEmbedded); ferr == nil {
+		return fallback, nil
+	}
+
+	return nil, err
+}
+
+// load returns the named zone from the archive, caching parsed locations.
+func (s *tzDataSet) load(name string) (*time.Location, error) {
+	if name == "" || name == "UTC" {
+		return time.UTC, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if loc, ok := s.cache[name]; ok {
+		return loc, nil
+	}
+
+	f, ok := s.zones[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q: not found in tzdata %s", ErrInvalidZone, name, s.version)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidZone, name, err)
+	}
+
+	s.cache[name] = loc
+
+	return loc, nil
+}
+
+// zoneinfoDirs lists the directories searched for host zone data, mirroring
+// the search order of the time package on Unix systems.
+func zoneinfoDirs() []string {
+	dirs := []string{"/usr/share/zoneinfo", "/usr/share/lib/zoneinfo", "/usr/lib/locale/TZ"}
+	if dir := os.Getenv("ZONEINFO"); dir != "" {
+		dirs = append([]string{dir}, dirs...)
+	}
+
+	return dirs
+}
+
+// systemTZDataVersion reads the release from the header of tzdata.zi.
+func systemTZDataVersion() string {
+	for _, dir := range zoneinfoDirs() {
+		data, err := os.ReadFile(filepath.Join(dir, "tzdata.zi"))
+		if err != nil {
+			continue
+		}
+
+		line, _, _ := strings.Cut(string(data), "\n")
+		if version, ok := strings.CutPrefix(line, "# version "); ok {
+			return strings.TrimSpace(version)
+		}
+	}
+
+	return ""
+}
+
+// systemTimezones walks the first host zoneinfo directory that exists and
+// returns the names of all files in TZif format.
+func systemTimezones() []string {
+	for _, dir := range zoneinfoDirs() {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+
+		var names []string
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+
+			rel, _ := filepath.Rel(dir, path)
+			if strings.HasPrefix(rel, "posix/") || strings.HasPrefix(rel, "right/") || !isTZif(path) {
+				return nil
+			}
+
+			names = append(names, filepath.ToSlash(rel))
+
+			return nil
+		})
+
+		sort.Strings(names)
+
+		return names
+	}
+
+	return nil
+}
+
+// isTZif reports whether the file at path starts with the TZif magic.
+func isTZif(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+
+	return string(magic) == "TZif"
+}
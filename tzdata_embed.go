@@ -0,0 +1,12 @@
+//go:build !js && !wasip1 && !temporalis_notzdata
+
+package temporalis
+
+import _ "embed"
+
+//go:embed tzdata/zoneinfo.zip
+var embeddedZoneinfo []byte
+
+// preferEmbeddedTZData reports whether LoadLocation reads the embedded
+// snapshot by default. Hosts with a file system use their own zone data.
+const preferEmbeddedTZData = false
@@ -0,0 +1,12 @@
+//go:build temporalis_notzdata
+
+package temporalis
+
+// Building with the temporalis_notzdata tag leaves the zone data snapshot
+// out of the binary. Zones then come from the host, or from data registered
+// with RegisterTZData, such as an allowlist generated by the tztrim command.
+var embeddedZoneinfo []byte
+
+// preferEmbeddedTZData reports whether LoadLocation reads the embedded
+// snapshot by default. There is no snapshot to read.
+const preferEmbeddedTZData = false
@@ -0,0 +1,82 @@
+package temporalis
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestEmbeddedTZData checks that zones can be loaded from the embedded snapshot
+// and behave like the ones from the host, and that the snapshot can be listed.
+func TestEmbeddedTZData(t *testing.T) {
+	version := EmbeddedTZDataVersion()
+	if version == "" {
+		t.Skip("built without the embedded snapshot")
+	}
+
+	loc, err := LoadLocationVersion("Europe/Berlin", version)
+	if err != nil {
+		t.Fatalf("LoadLocationVersion() returned error: %v", err)
+	}
+
+	summer := time.Date(2024, time.July, 1, 12, 0, 0, 0, loc)
+	if name, offset := summer.Zone(); name != "CEST" || offset != 2*3600 {
+		t.Errorf("Zone() = %s %d, expected CEST 7200", name, offset)
+	}
+
+	UseEmbeddedTZData()
+	defer UseSystemTZData()
+
+	if TZDataVersion() != version {
+		t.Errorf("TZDataVersion() = %q, expected %q", TZDataVersion(), version)
+	}
+
+	zones := AvailableTimezones()
+	if len(zones) < 300 {
+		t.Errorf("AvailableTimezones() returned only %d zones", len(zones))
+	}
+
+	if _, err := LoadLocationVersion("Europe/Berlin", "1999z"); err == nil {
+		t.Errorf("LoadLocationVersion() with an unknown version returned no error")
+	}
+}
+
+// TestTrimTZData checks that a trimmed archive holds exactly the allowlisted
+// zones and can be made the default with UseTZData.
+func TestTrimTZData(t *testing.T) {
+	if EmbeddedTZDataVersion() == "" {
+		t.Skip("built without the embedded snapshot")
+	}
+
+	data, err := TrimTZData(EmbeddedTZDataVersion(), []string{"Europe/Berlin", "Asia/Tokyo", "Europe/Berlin"})
+	if err != nil {
+		t.Fatalf("TrimTZData() returned error: %v", err)
+	}
+
+	if err := RegisterTZData("allowlist", data); err != nil {
+		t.Fatalf("RegisterTZData() returned error: %v", err)
+	}
+
+	if err := UseTZData("allowlist"); err != nil {
+		t.Fatalf("UseTZData() returned error: %v", err)
+	}
+	defer UseSystemTZData()
+
+	if zones := AvailableTimezones(); len(zones) != 2 || zones[0] != "Asia/Tokyo" || zones[1] != "Europe/Berlin" {
+		t.Errorf("AvailableTimezones() = %v, expected [Asia/Tokyo Europe/Berlin]", zones)
+	}
+
+	if _, err := LoadLocation("Asia/Tokyo"); err != nil {
+		t.Errorf("LoadLocation() returned error: %v", err)
+	}
+	if _, err := LoadLocation("America/New_York"); !errors.Is(err, ErrInvalidZone) {
+		t.Errorf("LoadLocation() of a zone outside the allowlist = %v, expected ErrInvalidZone", err)
+	}
+
+	if _, err := TrimTZData(EmbeddedTZDataVersion(), []string{"Nowhere/Atlantis"}); !errors.Is(err, ErrInvalidZone) {
+		t.Errorf("TrimTZData() with an unknown zone = %v, expected ErrInvalidZone", err)
+	}
+	if err := UseTZData("1999z"); !errors.Is(err, ErrInvalidZone) {
+		t.Errorf("UseTZData() with an unknown version = %v, expected ErrInvalidZone", err)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build (js || wasip1) && !temporalis_notzdata
+
+package temporalis
+
+import _ "embed"
+
+// WebAssembly binaries are downloaded by browsers and rarely have zone data
+// on a file system, so they embed a trimmed snapshot without the backward
+// compatibility links, such as "US/Eastern", and use it by default.
+
+//go:embed tzdata/zoneinfo_trimmed.zip
+var embeddedZoneinfo []byte
+
+// preferEmbeddedTZData reports whether LoadLocation reads the embedded
+// snapshot by default.
+const preferEmbeddedTZData = true
@@ -1,18 +1,9 @@
 package temporalis
 
 import (
-	"fmt"
 	"time"
 )
 
-// pluralize returns the plural form of the given word if the count is not 1.
-func pluralize(count int64, word string) string {
-	if count == 1 {
-		return fmt.Sprintf("%d %s", count, word)
-	}
-	return fmt.Sprintf("%d %ss", count, word)
-}
-
 // isWeekend returns true if the given time is on a weekend (Saturday or Sunday), and false otherwise.
 // It takes a single argument, t, which is the time to check.
 func isWeekend(t time.Time) bool {
@@ -33,3 +24,47 @@ func isHoliday(t time.Time, holidays []time.Time) bool {
 
 	return false
 }
+
+// daysIn returns the number of days in the given month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// sign returns -1, 0 or +1 according to the sign of n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// daysFromCivil returns the number of days between 1970-01-01 and the given
+// proleptic Gregorian date, without the range limits of time.Duration.
+func daysFromCivil(year int, month time.Month, day int) int {
+	y := year
+	if month <= time.February {
+		y--
+	}
+
+	era := y / 400
+	if y < 0 && y%400 != 0 {
+		era--
+	}
+
+	yoe := y - era*400
+	m := int(month)
+	if m > 2 {
+		m -= 3
+	} else {
+		m += 9
+	}
+
+	doy := (153*m+2)/5 + day - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+
+	return era*146097 + doe - 719468
+}
@@ -0,0 +1,78 @@
+package temporalis
+
+import "time"
+
+// NthWeekdayOfMonth returns the nth occurrence of weekday in the month, such
+// as the third Monday of January for n = 3. Negative values count from the
+// end of the month, so n = -1 is the last occurrence. The boolean result is
+// false if the month has fewer than |n| such weekdays, as with the fifth
+// Friday of most months, or if n is zero.
+func NthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int) (CivilDate, bool) {
+	days := daysIn(year, month)
+
+	var day int
+	switch {
+	case n > 0:
+		first := CivilDate{Year: year, Month: month, Day: 1}.Weekday()
+		day = 1 + int(weekday-first+7)%7 + 7*(n-1)
+	case n < 0:
+		last := CivilDate{Year: year, Month: month, Day: days}.Weekday()
+		day = days - int(last-weekday+7)%7 + 7*(n+1)
+	default:
+		return CivilDate{}, false
+	}
+
+	if day < 1 || day > days {
+		return CivilDate{}, false
+	}
+
+	return CivilDate{Year: year, Month: month, Day: day}, true
+}
+
+// NextWeekday returns the first time after t that falls on weekday, at the
+// same time of day, so the next Monday after a Monday is a week later.
+func NextWeekday(t time.Time, weekday time.Weekday) time.Time {
+	days := int(weekday-t.Weekday()+7) % 7
+	if days == 0 {
+		days = 7
+	}
+
+	return t.AddDate(0, 0, days)
+}
+
+// PreviousWeekday returns the last time before t that falls on weekday, at
+// the same time of day, so the previous Monday before a Monday is a week
+// earlier.
+func PreviousWeekday(t time.Time, weekday time.Weekday) time.Time {
+	days := int(t.Weekday()-weekday+7) % 7
+	if days == 0 {
+		days = 7
+	}
+
+	return t.AddDate(0, 0, -days)
+}
+
+// ClosestBusinessDay returns t if it falls on a business day of cal, which
+// may be nil for a plain Monday to Friday week, and otherwise the same time
+// of day on the nearest business day. When the business days before and
+// after are equally near, the later one is chosen. With a Saturday and
+// Sunday weekend this moves Saturday to Friday and Sunday to Monday, which is
+// how many countries observe holidays that fall on a weekend. If cal has no
+// business days within a year, t is returned.
+func ClosestBusinessDay(t time.Time, cal *BusinessCalendar) time.Time {
+	d := DateOf(t)
+	if cal.IsBusinessDay(d) {
+		return t
+	}
+
+	for i := 1; i <= 366; i++ {
+		if cal.IsBusinessDay(d.AddDays(i)) {
+			return t.AddDate(0, 0, i)
+		}
+		if cal.IsBusinessDay(d.AddDays(-i)) {
+			return t.AddDate(0, 0, -i)
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,94 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNthWeekdayOfMonth tests counting weekdays from the start and the end
+// of months.
+func TestNthWeekdayOfMonth(t *testing.T) {
+	tests := []struct {
+		year     int
+		month    time.Month
+		weekday  time.Weekday
+		n        int
+		expected CivilDate
+		ok       bool
+	}{
+		// Martin Luther King Jr. Day, the third Monday of January.
+		{2024, time.January, time.Monday, 3, CivilDate{2024, time.January, 15}, true},
+		// Thanksgiving, the fourth Thursday of November.
+		{2024, time.November, time.Thursday, 4, CivilDate{2024, time.November, 28}, true},
+		// Memorial Day, the last Monday of May.
+		{2024, time.May, time.Monday, -1, CivilDate{2024, time.May, 27}, true},
+		{2024, time.February, time.Thursday, 5, CivilDate{2024, time.February, 29}, true},
+		{2024, time.February, time.Thursday, -5, CivilDate{2024, time.February, 1}, true},
+		{2024, time.February, time.Friday, 1, CivilDate{2024, time.February, 2}, true},
+		{2024, time.March, time.Sunday, -2, CivilDate{2024, time.March, 24}, true},
+		{2024, time.February, time.Friday, 5, CivilDate{}, false},
+		{2024, time.February, time.Friday, -5, CivilDate{}, false},
+		{2024, time.February, time.Friday, 0, CivilDate{}, false},
+	}
+
+	for _, test := range tests {
+		actual, ok := NthWeekdayOfMonth(test.year, test.month, test.weekday, test.n)
+		if actual != test.expected || ok != test.ok {
+			t.Errorf("NthWeekdayOfMonth(%d, %v, %v, %d) = %v, %v, expected %v, %v", test.year, test.month, test.weekday, test.n, actual, ok, test.expected, test.ok)
+		}
+	}
+}
+
+// TestNextPreviousWeekday tests that the result is strictly after or before
+// the argument and keeps its time of day.
+func TestNextPreviousWeekday(t *testing.T) {
+	monday := time.Date(2024, time.March, 4, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		f        func(time.Time, time.Weekday) time.Time
+		name     string
+		weekday  time.Weekday
+		expected time.Time
+	}{
+		{NextWeekday, "NextWeekday", time.Friday, time.Date(2024, time.March, 8, 9, 30, 0, 0, time.UTC)},
+		{NextWeekday, "NextWeekday", time.Monday, time.Date(2024, time.March, 11, 9, 30, 0, 0, time.UTC)},
+		{NextWeekday, "NextWeekday", time.Sunday, time.Date(2024, time.March, 10, 9, 30, 0, 0, time.UTC)},
+		{PreviousWeekday, "PreviousWeekday", time.Friday, time.Date(2024, time.March, 1, 9, 30, 0, 0, time.UTC)},
+		{PreviousWeekday, "PreviousWeekday", time.Monday, time.Date(2024, time.February, 26, 9, 30, 0, 0, time.UTC)},
+		{PreviousWeekday, "PreviousWeekday", time.Sunday, time.Date(2024, time.March, 3, 9, 30, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if actual := test.f(monday, test.weekday); !actual.Equal(test.expected) {
+			t.Errorf("%s(%v, %v) = %v, expected %v", test.name, monday, test.weekday, actual, test.expected)
+		}
+	}
+}
+
+// TestClosestBusinessDay tests the observance of weekend holidays and ties.
+func TestClosestBusinessDay(t *testing.T) {
+	cal := NewBusinessCalendar(CivilDate{2024, time.July, 4})
+
+	tests := []struct {
+		t        time.Time
+		expected time.Time
+	}{
+		{time.Date(2024, time.July, 3, 12, 0, 0, 0, time.UTC), time.Date(2024, time.July, 3, 12, 0, 0, 0, time.UTC)},
+		// Saturday and Sunday.
+		{time.Date(2024, time.July, 13, 12, 0, 0, 0, time.UTC), time.Date(2024, time.July, 12, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2024, time.July, 14, 12, 0, 0, 0, time.UTC), time.Date(2024, time.July, 15, 12, 0, 0, 0, time.UTC)},
+		// A Thursday holiday is as near to Wednesday as to Friday.
+		{time.Date(2024, time.July, 4, 12, 0, 0, 0, time.UTC), time.Date(2024, time.July, 5, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if actual := ClosestBusinessDay(test.t, cal); !actual.Equal(test.expected) {
+			t.Errorf("ClosestBusinessDay(%v) = %v, expected %v", test.t, actual, test.expected)
+		}
+	}
+
+	saturday := time.Date(2024, time.July, 6, 8, 0, 0, 0, time.UTC)
+	if actual, expected := ClosestBusinessDay(saturday, nil), saturday.AddDate(0, 0, -1); !actual.Equal(expected) {
+		t.Errorf("ClosestBusinessDay(%v, nil) = %v, expected %v", saturday, actual, expected)
+	}
+}
@@ -0,0 +1,124 @@
+package temporalis
+
+import (
+	"iter"
+	"time"
+)
+
+// Bucket returns the start of the bucket of the given size that contains t.
+// Sizes that are a whole number of days are aligned to midnight in t's
+// location, counting from 1970-01-01, so daily buckets follow the local
+// calendar across DST changes. Other sizes are aligned to the Unix epoch in
+// absolute time, like time.Truncate, so hourly buckets start on the hour in
+// UTC. The result is in t's location. Bucket panics if size is not positive.
+func Bucket(t time.Time, size time.Duration) time.Time {
+	if size <= 0 {
+		panic("temporalis: non-positive size for Bucket")
+	}
+
+	if days, ok := wholeDays(size); ok {
+		y, m, d := t.Date()
+		n := floorDiv(daysFromCivil(y, m, d), days)
+
+		return CivilDate{1970, time.January, 1}.AddDays(n * days).In(t.Location())
+	}
+
+	return t.Truncate(size)
+}
+
+// WithHop makes Windows yield hopping windows that start every hop instead
+// of tumbling windows that start every size. With a hop shorter than the
+// size the windows overlap, and every time belongs to several of them.
+func WithHop(hop time.Duration) Option {
+	return func(o *Options) { o.hop = hop }
+}
+
+// Windows yields the aligned windows of the given size that overlap
+// [start, end), in order. By default the windows tumble: they are the
+// consecutive buckets returned by Bucket. With WithHop they start at every
+// multiple of the hop instead. The first and last windows may extend beyond
+// the range; they are not clipped. Windows are computed in the location
+// given with WithLocation, or in start's location. Windows panics if size or
+// the hop is not positive.
+func Windows(start, end time.Time, size time.Duration, opts ...Option) iter.Seq[Interval] {
+	o := NewOptions(opts...)
+
+	hop := size
+	if o.hop != 0 {
+		hop = o.hop
+	}
+	if size <= 0 || hop <= 0 {
+		panic("temporalis: non-positive size or hop for Windows")
+	}
+
+	return func(yield func(Interval) bool) {
+		if !end.After(start) {
+			return
+		}
+
+		start := start.In(o.locationOr(start.Location()))
+
+		// Step back to the first window that still overlaps start.
+		from := Bucket(start, hop)
+		for {
+			prev := addSpan(from, -hop)
+			if !addSpan(prev, size).After(start) {
+				break
+			}
+			from = prev
+		}
+
+		for ; from.Before(end); from = addSpan(from, hop) {
+			window := Interval{Start: from, End: addSpan(from, size)}
+
+			// With a hop longer than the size there are gaps between
+			// windows, and the first window may end before start.
+			if !window.End.After(start) {
+				continue
+			}
+
+			if !yield(window) {
+				return
+			}
+		}
+	}
+}
+
+// wholeDays returns the number of days in d if it is a whole number of
+// days.
+func wholeDays(d time.Duration) (int, bool) {
+	if d%day != 0 {
+		return 0, false
+	}
+
+	return int(d / day), true
+}
+
+// addSpan adds d to t, in calendar days if d is a whole number of days so
+// that day-sized steps keep the wall-clock time across DST changes. The
+// start of a day stays the start of a day, even where a day begins after a
+// DST gap at midnight.
+func addSpan(t time.Time, d time.Duration) time.Time {
+	days, ok := wholeDays(d)
+	if !ok {
+		return t.Add(d)
+	}
+
+	loc := t.Location()
+	date := DateOf(t)
+	if t.Equal(date.In(loc)) {
+		return date.AddDays(days).In(loc)
+	}
+
+	return TimeOfDayOf(t).OnDate(date.AddDays(days), loc)
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+
+	return q
+}
@@ -0,0 +1,141 @@
+package temporalis
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestBucket tests sub-day buckets aligned to the epoch and day-sized
+// buckets aligned to local midnight.
+func TestBucket(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+
+	tests := []struct {
+		t        time.Time
+		size     time.Duration
+		expected time.Time
+	}{
+		{time.Date(2024, 3, 13, 15, 47, 12, 0, time.UTC), 5 * time.Minute, time.Date(2024, 3, 13, 15, 45, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 13, 15, 47, 12, 0, time.UTC), time.Hour, time.Date(2024, 3, 13, 15, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 13, 0, 30, 0, 0, berlin), 24 * time.Hour, time.Date(2024, 3, 13, 0, 0, 0, 0, berlin)},
+		{time.Date(2024, 3, 31, 23, 0, 0, 0, berlin), 24 * time.Hour, time.Date(2024, 3, 31, 0, 0, 0, 0, berlin)},
+		{time.Date(1970, 1, 3, 12, 0, 0, 0, time.UTC), 2 * 24 * time.Hour, time.Date(1970, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{time.Date(1969, 12, 31, 12, 0, 0, 0, time.UTC), 2 * 24 * time.Hour, time.Date(1969, 12, 30, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		actual := Bucket(test.t, test.size)
+		if !actual.Equal(test.expected) || actual.Location() != test.expected.Location() {
+			t.Errorf("Bucket(%v, %v) = %v, expected %v", test.t, test.size, actual, test.expected)
+		}
+	}
+}
+
+// TestWindowsTumbling tests that tumbling windows cover the range without
+// gaps or overlaps.
+func TestWindowsTumbling(t *testing.T) {
+	start := time.Date(2024, 3, 13, 10, 7, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 13, 10, 31, 0, 0, time.UTC)
+
+	var starts []int
+	for w := range Windows(start, end, 10*time.Minute) {
+		starts = append(starts, w.Start.Minute())
+		if w.Duration() != 10*time.Minute {
+			t.Errorf("window %v has duration %v, expected 10m", w, w.Duration())
+		}
+	}
+
+	if expected := []int{0, 10, 20, 30}; !slices.Equal(starts, expected) {
+		t.Errorf("Windows() starts = %v, expected %v", starts, expected)
+	}
+}
+
+// TestWindowsHopping tests overlapping windows and windows with gaps.
+func TestWindowsHopping(t *testing.T) {
+	start := time.Date(2024, 3, 13, 10, 7, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 13, 10, 20, 0, 0, time.UTC)
+
+	var starts []int
+	for w := range Windows(start, end, 10*time.Minute, WithHop(5*time.Minute)) {
+		starts = append(starts, w.Start.Minute())
+	}
+
+	if expected := []int{0, 5, 10, 15}; !slices.Equal(starts, expected) {
+		t.Errorf("Windows() hopping starts = %v, expected %v", starts, expected)
+	}
+
+	starts = nil
+	for w := range Windows(start, end, time.Minute, WithHop(5*time.Minute)) {
+		starts = append(starts, w.Start.Minute())
+	}
+
+	if expected := []int{10, 15}; !slices.Equal(starts, expected) {
+		t.Errorf("Windows() sparse starts = %v, expected %v", starts, expected)
+	}
+}
+
+// TestWindowsDaily tests that daily windows follow local midnight across a
+// DST change.
+func TestWindowsDaily(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+
+	start := time.Date(2024, 3, 30, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	var windows []Interval
+	for w := range Windows(start, end, 24*time.Hour, WithLocation(berlin)) {
+		windows = append(windows, w)
+	}
+
+	if len(windows) != 3 {
+		t.Fatalf("Windows() = %v, expected 3 windows", windows)
+	}
+	if d := windows[1].Duration(); d != 23*time.Hour {
+		t.Errorf("window on the DST change lasts %v, expected 23h", d)
+	}
+	if h := windows[2].Start.Hour(); h != 0 {
+		t.Errorf("window after the DST change starts at hour %d, expected 0", h)
+	}
+}
+
+// TestWindowsMidnightGap tests daily buckets and windows in a zone whose
+// clocks skip midnight, where the day starts at 01:00.
+func TestWindowsMidnightGap(t *testing.T) {
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skip("zone data not available")
+	}
+
+	// Clocks in Santiago jump from 00:00 to 01:00 on 8 September 2024.
+	dayStart := time.Date(2024, 9, 8, 1, 0, 0, 0, santiago)
+	if b := Bucket(time.Date(2024, 9, 8, 12, 0, 0, 0, santiago), 24*time.Hour); !b.Equal(dayStart) {
+		t.Errorf("Bucket() = %v, expected %v", b, dayStart)
+	}
+
+	start := time.Date(2024, 9, 6, 12, 0, 0, 0, santiago)
+	end := time.Date(2024, 9, 11, 0, 0, 0, 0, santiago)
+
+	var windows []Interval
+	for w := range Windows(start, end, 24*time.Hour) {
+		windows = append(windows, w)
+	}
+
+	if len(windows) != 5 {
+		t.Fatalf("Windows() = %v, expected 5 windows", windows)
+	}
+	if !windows[2].Start.Equal(dayStart) || windows[2].Duration() != 23*time.Hour {
+		t.Errorf("Windows() = %v, expected a 23h window starting at %v", windows, dayStart)
+	}
+	for _, w := range windows[3:] {
+		if w.Start.Hour() != 0 {
+			t.Errorf("window %v starts at hour %d, expected 0", w, w.Start.Hour())
+		}
+	}
+}
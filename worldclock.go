@@ -0,0 +1,110 @@
+package temporalis
+
+import "time"
+
+// WorldClock shows one instant in a fixed set of time zones, as on the wall
+// of a newsroom. Zones are given by any name LoadLocation accepts.
+type WorldClock struct {
+	names []string
+	locs  []*time.Location
+}
+
+// ZoneTime is the reading of a WorldClock in one zone.
+type ZoneTime struct {
+	Zone  string
+	Local time.Time
+	// IsDST reports whether the zone observes daylight saving time at Local.
+	IsDST bool
+	// DSTChange reports whether the UTC offset differs from the previous
+	// reading in a ConversionTable column, marking the row after a transition.
+	DSTChange bool
+	// DayOffset is the difference between the local date and the reference
+	// date of a ConversionTable, such as -1 for "yesterday".
+	DayOffset int
+}
+
+// NewWorldClock loads the given zones. It returns an error naming the first
+// zone that cannot be loaded.
+func NewWorldClock(zones ...string) (*WorldClock, error) {
+	w := &WorldClock{}
+
+	for _, name := range zones {
+		loc, err := LoadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+
+		w.names = append(w.names, name)
+		w.locs = append(w.locs, loc)
+	}
+
+	return w, nil
+}
+
+// Zones returns the zone names of the clock, in the order they were given.
+func (w *WorldClock) Zones() []string {
+	return append([]string(nil), w.names...)
+}
+
+// At returns t in every zone of the clock.
+func (w *WorldClock) At(t time.Time) []ZoneTime {
+	readings := make([]ZoneTime, len(w.locs))
+
+	for i, loc := range w.locs {
+		local := t.In(loc)
+		readings[i] = ZoneTime{Zone: w.names[i], Local: local, IsDST: local.IsDST()}
+	}
+
+	return readings
+}
+
+// Now returns the current time in every zone of the clock.
+func (w *WorldClock) Now() []ZoneTime {
+	return w.At(time.Now())
+}
+
+// ConversionTable is a grid of local times, one row per hour and one column
+// per zone, as used by meeting planners. The first zone is the reference
+// zone whose date and hours define the rows.
+type ConversionTable struct {
+	Zones []string
+	Rows  [][]ZoneTime
+}
+
+// BuildConversionTable returns a table of hours consecutive rows, starting at
+// midnight of date in the first zone and advancing one real hour per row.
+// Each cell records whether the zone is on daylight saving time, whether its
+// offset changed since the previous row, and how many days its local date is
+// ahead of or behind date. Because rows advance in real time, the reference
+// column itself shows the skipped or repeated hour on DST transition days.
+func BuildConversionTable(zones []string, date CivilDate, hours int) (ConversionTable, error) {
+	clock, err := NewWorldClock(zones...)
+	if err != nil {
+		return ConversionTable{}, err
+	}
+
+	table := ConversionTable{Zones: clock.Zones()}
+	if len(zones) == 0 || hours <= 0 {
+		return table, nil
+	}
+
+	start := date.In(clock.locs[0])
+
+	for h := 0; h < hours; h++ {
+		row := clock.At(start.Add(time.Duration(h) * time.Hour))
+
+		for i := range row {
+			row[i].DayOffset = DateOf(row[i].Local).DaysSince(date)
+
+			if h > 0 {
+				_, prev := table.Rows[h-1][i].Local.Zone()
+				_, cur := row[i].Local.Zone()
+				row[i].DSTChange = prev != cur
+			}
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table, nil
+}
@@ -0,0 +1,32 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBuildConversionTable checks a New York / Tokyo table on the day the
+// United States starts daylight saving time.
+func TestBuildConversionTable(t *testing.T) {
+	table, err := BuildConversionTable([]string{"America/New_York", "Asia/Tokyo"}, CivilDate{2024, time.March, 10}, 24)
+	if err != nil {
+		t.Fatalf("BuildConversionTable() returned error: %v", err)
+	}
+
+	if len(table.Rows) != 24 || len(table.Rows[0]) != 2 {
+		t.Fatalf("table has %d rows of %d cells, expected 24 rows of 2", len(table.Rows), len(table.Rows[0]))
+	}
+
+	if tokyo := table.Rows[0][1]; tokyo.Local.Hour() != 14 || tokyo.DayOffset != 0 {
+		t.Errorf("Tokyo at New York midnight = %v (day %+d), expected 14:00 on the same day", tokyo.Local, tokyo.DayOffset)
+	}
+
+	// The third row is the first one after clocks jump from 02:00 to 03:00.
+	if ny := table.Rows[2][0]; !ny.DSTChange || !ny.IsDST || ny.Local.Hour() != 3 {
+		t.Errorf("New York row 2 = %+v, expected 03:00 EDT flagged as a change", ny)
+	}
+
+	if _, err := BuildConversionTable([]string{"Mars/Olympus"}, CivilDate{2024, time.March, 10}, 1); err == nil {
+		t.Errorf("BuildConversionTable() with an unknown zone returned no error")
+	}
+}
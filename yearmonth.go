@@ -0,0 +1,131 @@
+package temporalis
+
+import (
+	"fmt"
+	"time"
+)
+
+// YearMonth is a month of a specific year, such as a billing period or a
+// credit card expiry. Its arithmetic never overflows into a neighbouring
+// month because it carries no day. YearMonth values can be compared with ==.
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// NewYearMonth returns the given month, normalising out-of-range months so
+// that month 13 of 2024 is January 2025 and month 0 is December 2023.
+func NewYearMonth(year int, month time.Month) YearMonth {
+	m := int(month) - 1
+	year += m / 12
+	m %= 12
+	if m < 0 {
+		m += 12
+		year--
+	}
+
+	return YearMonth{Year: year, Month: time.Month(m + 1)}
+}
+
+// YearMonthOf returns the month containing t in t's location.
+func YearMonthOf(t time.Time) YearMonth {
+	return YearMonth{Year: t.Year(), Month: t.Month()}
+}
+
+// ParseYearMonth parses a month in "YYYY-MM" format.
+func ParseYearMonth(s string) (YearMonth, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return YearMonth{}, timeParseError("ParseYearMonth", s, err)
+	}
+
+	return YearMonthOf(t), nil
+}
+
+// String returns the month in "YYYY-MM" format.
+func (ym YearMonth) String() string {
+	return fmt.Sprintf("%04d-%02d", ym.Year, ym.Month)
+}
+
+// AddMonths returns the month n months after ym.
+func (ym YearMonth) AddMonths(n int) YearMonth {
+	return NewYearMonth(ym.Year, ym.Month+time.Month(n))
+}
+
+// MonthsSince returns the number of months from other to ym, which is
+// negative if ym is earlier.
+func (ym YearMonth) MonthsSince(other YearMonth) int {
+	return (ym.Year-other.Year)*12 + int(ym.Month-other.Month)
+}
+
+// DaysInMonth returns the number of days in the month.
+func (ym YearMonth) DaysInMonth() int {
+	return daysIn(ym.Year, ym.Month)
+}
+
+// FirstDay returns the first day of the month.
+func (ym YearMonth) FirstDay() CivilDate {
+	return CivilDate{Year: ym.Year, Month: ym.Month, Day: 1}
+}
+
+// LastDay returns the last day of the month.
+func (ym YearMonth) LastDay() CivilDate {
+	return CivilDate{Year: ym.Year, Month: ym.Month, Day: ym.DaysInMonth()}
+}
+
+// Day returns the given day of the month, clamped to the last day, so day 31
+// of February is February 28 or 29.
+func (ym YearMonth) Day(day int) CivilDate {
+	if last := ym.DaysInMonth(); day > last {
+		day = last
+	}
+	if day < 1 {
+		day = 1
+	}
+
+	return CivilDate{Year: ym.Year, Month: ym.Month, Day: day}
+}
+
+// Compare returns -1 if ym is before other, +1 if it is after, and 0 if they
+// are the same month.
+func (ym YearMonth) Compare(other YearMonth) int {
+	return sign(ym.MonthsSince(other))
+}
+
+// Before reports whether ym is before other.
+func (ym YearMonth) Before(other YearMonth) bool {
+	return ym.Compare(other) < 0
+}
+
+// After reports whether ym is after other.
+func (ym YearMonth) After(other YearMonth) bool {
+	return ym.Compare(other) > 0
+}
+
+// MarshalText implements encoding.TextMarshaler using "YYYY-MM".
+func (ym YearMonth) MarshalText() ([]byte, error) {
+	return []byte(ym.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using "YYYY-MM".
+func (ym *YearMonth) UnmarshalText(data []byte) error {
+	parsed, err := ParseYearMonth(string(data))
+	if err != nil {
+		return err
+	}
+
+	*ym = parsed
+
+	return nil
+}
+
+// AddMonthsClamped returns t moved n months forward (or backward if n is
+// negative), keeping the time of day. Unlike time.Time.AddDate, which turns
+// January 31 plus one month into March 2 or 3, the day is clamped to the
+// last day of the target month, giving February 28 or 29. DST changes are
+// handled as by AddDateSafe.
+func AddMonthsClamped(t time.Time, n int) time.Time {
+	target := YearMonthOf(t).AddMonths(n).Day(t.Day())
+
+	return AddDateSafe(t, 0, 0, target.DaysSince(DateOf(t)))
+}
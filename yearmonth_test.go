@@ -0,0 +1,51 @@
+package temporalis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestYearMonth checks month arithmetic across year boundaries and the first
+// and last days of February in a leap year.
+func TestYearMonth(t *testing.T) {
+	ym := YearMonth{2024, time.November}
+
+	if got := ym.AddMonths(3); got != (YearMonth{2025, time.February}) {
+		t.Errorf("AddMonths(3) = %v, expected 2025-02", got)
+	}
+
+	if got := ym.AddMonths(-11); got != (YearMonth{2023, time.December}) {
+		t.Errorf("AddMonths(-11) = %v, expected 2023-12", got)
+	}
+
+	feb := YearMonth{2024, time.February}
+	if feb.DaysInMonth() != 29 || feb.LastDay() != (CivilDate{2024, time.February, 29}) || feb.FirstDay().Day != 1 {
+		t.Errorf("February 2024 has %d days, last day %v", feb.DaysInMonth(), feb.LastDay())
+	}
+
+	if got := (YearMonth{2025, time.March}).MonthsSince(ym); got != 4 {
+		t.Errorf("MonthsSince() = %d, expected 4", got)
+	}
+}
+
+// TestAddMonthsClamped checks that the end of a month maps to the end of a
+// shorter month instead of overflowing.
+func TestAddMonthsClamped(t *testing.T) {
+	jan31 := time.Date(2023, time.January, 31, 10, 0, 0, 0, time.UTC)
+
+	if got := AddMonthsClamped(jan31, 1); !got.Equal(time.Date(2023, time.February, 28, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("AddMonthsClamped(+1) = %v, expected 2023-02-28 10:00", got)
+	}
+
+	if got := AddMonthsClamped(jan31, -2); !got.Equal(time.Date(2022, time.November, 30, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("AddMonthsClamped(-2) = %v, expected 2022-11-30 10:00", got)
+	}
+
+	if newYork, err := time.LoadLocation("America/New_York"); err == nil {
+		// 02:30 does not exist on 10 March 2024 and becomes 03:30 EDT.
+		feb := time.Date(2024, time.February, 10, 2, 30, 0, 0, newYork)
+		if got := AddMonthsClamped(feb, 1); !got.Equal(time.Date(2024, time.March, 10, 7, 30, 0, 0, time.UTC)) {
+			t.Errorf("AddMonthsClamped() into a DST gap = %v, expected 2024-03-10 03:30 EDT", got)
+		}
+	}
+}
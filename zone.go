@@ -0,0 +1,139 @@
+package temporalis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// abbreviationsMu guards abbreviations against RegisterAbbreviation.
+var abbreviationsMu sync.RWMutex
+
+// RegisterAbbreviation makes LoadLocation, ResolveAbbreviation and
+// LocationsForAbbreviation resolve abbr to the given zone. The zone may be an
+// IANA name such as "America/Chicago" or a fixed offset such as "-06:00".
+// Abbreviations are matched case-insensitively. Registering an abbreviation
+// that already exists, including one of the built-in table, replaces all of
+// its zones.
+func RegisterAbbreviation(abbr, zone string) {
+	c := abbreviationZone{zone: zone}
+	if loc, ok := parseFixedOffset(zone); ok {
+		c = abbreviationZone{offset: offsetSeconds(loc)}
+	}
+
+	abbreviationsMu.Lock()
+	defer abbreviationsMu.Unlock()
+
+	abbreviations[strings.ToUpper(strings.TrimSpace(abbr))] = []abbreviationZone{c}
+}
+
+// LoadLocation returns the location with the given name. In addition to the
+// names understood by time.LoadLocation ("UTC", "Local" and IANA names such as
+// "Asia/Tokyo"), it accepts fixed offsets in the forms "+05:30", "-0800",
+// "+05" and "UTC+05:30", and time zone abbreviations such as "PST" or "CEST".
+// Abbreviations registered with RegisterAbbreviation resolve to their zone.
+// The built-in table only applies to names that are not zones themselves,
+// so "CET", "EET" and "WET" keep their DST, and resolves to the usual
+// offset of the zone most people mean. IANA names are read from the zone
+// data selected with UseEmbeddedTZData or UseSystemTZData.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "UTC" || name == "Local" {
+		return time.LoadLocation(name)
+	}
+
+	if loc, ok := parseFixedOffset(name); ok {
+		return loc, nil
+	}
+
+	candidates := abbreviationCandidates(name)
+	switch {
+	case len(candidates) > 0 && candidates[0].named():
+		name = candidates[0].zone
+	case len(candidates) > 0 && candidates[0].registered():
+		return time.FixedZone(strings.ToUpper(name), candidates[0].offset), nil
+	}
+
+	loc, err := loadZone(name)
+	if err == nil {
+		return loc, nil
+	}
+	if len(candidates) > 0 && !candidates[0].registered() {
+		return time.FixedZone(strings.ToUpper(name), candidates[0].offset), nil
+	}
+
+	return nil, fmt.Errorf("%w %q: %w", ErrInvalidZone, name, err)
+}
+
+// parseFixedOffset parses offsets such as "+05:30", "-0800", "+05" and
+// "UTC+05:30" into a fixed location named after the normalised offset.
+func parseFixedOffset(s string) (*time.Location, bool) {
+	s = strings.TrimSpace(s)
+	for _, prefix := range []string{"UTC", "GMT"} {
+		if len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+			s = s[len(prefix):]
+			break
+		}
+	}
+
+	if len(s) < 2 || (s[0] != '+' && s[0] != '-') {
+		return nil, false
+	}
+
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+
+	// A colon may only separate two-digit hours from the minutes, and
+	// strconv.Atoi would accept a second sign, so the rest must be digits.
+	digits := s[1:]
+	if len(digits) == 5 && digits[2] == ':' {
+		digits = digits[:2] + digits[3:]
+	}
+	if !isDigits(digits) {
+		return nil, false
+	}
+
+	var hours, minutes int
+	var err error
+
+	switch len(digits) {
+	case 1, 2:
+		hours, err = strconv.Atoi(digits)
+	case 4:
+		hours, err = strconv.Atoi(digits[:2])
+		if err == nil {
+			minutes, err = strconv.Atoi(digits[2:])
+		}
+	default:
+		return nil, false
+	}
+
+	if err != nil || hours > 14 || minutes > 59 {
+		return nil, false
+	}
+
+	offset := sign * (hours*3600 + minutes*60)
+
+	return time.FixedZone(formatOffset(offset), offset), true
+}
+
+// formatOffset renders an offset in seconds east of UTC as "+hh:mm".
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// offsetSeconds returns the current offset of a fixed location.
+func offsetSeconds(loc *time.Location) int {
+	_, offset := time.Time{}.In(loc).Zone()
+
+	return offset
+}
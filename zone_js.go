@@ -0,0 +1,25 @@
+//go:build js && wasm
+
+package temporalis
+
+import "syscall/js"
+
+func init() {
+	localZoneResolver = browserZone
+}
+
+// browserZone returns the zone the browser resolves for the user, or an
+// empty string if the Intl API is not available.
+func browserZone() string {
+	intl := js.Global().Get("Intl")
+	if intl.Type() != js.TypeObject {
+		return ""
+	}
+
+	zone := intl.Call("DateTimeFormat").Call("resolvedOptions").Get("timeZone")
+	if zone.Type() != js.TypeString {
+		return ""
+	}
+
+	return zone.String()
+}
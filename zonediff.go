@@ -0,0 +1,120 @@
+package temporalis
+
+import (
+	"fmt"
+	"time"
+)
+
+// zoneDiffHorizon is how far ahead DiffZoneRules compares two versions.
+const zoneDiffHorizon = 10 * 366 * 24 * time.Hour
+
+// ZoneRuleChange is a span of time in which two versions of the zone data
+// disagree about a zone: the wall clock there reads differently, or has a
+// different abbreviation, depending on the version. Offsets are in seconds
+// east of UTC, as returned by time.Time.Zone.
+type ZoneRuleChange struct {
+	Zone      string
+	Interval  Interval
+	OldName   string
+	OldOffset int
+	NewName   string
+	NewOffset int
+}
+
+// Affects reports whether an event scheduled at t falls into the change, in
+// which case the versions disagree about its offset or zone abbreviation.
+func (c ZoneRuleChange) Affects(t time.Time) bool {
+	return c.Interval.Contains(t)
+}
+
+// String describes the change, such as "Europe/Example [2025-03-30T01:00:00Z,
+// 2025-10-26T01:00:00Z): CEST (+02:00) -> CET (+01:00)".
+func (c ZoneRuleChange) String() string {
+	return fmt.Sprintf("%s %s: %s (%s) -> %s (%s)", c.Zone, c.Interval,
+		c.OldName, formatOffset(c.OldOffset), c.NewName, formatOffset(c.NewOffset))
+}
+
+// DiffZoneRules compares a zone in two versions of the zone data, as
+// accepted by LoadLocationVersion, over the ten years from now, and returns
+// the spans in which they disagree, in order. Operators can run it when a
+// new release is deployed to find scheduled events whose wall-clock time
+// moves, for example because a government abolished daylight saving time.
+// The current time comes from WithClock. Transitions less than a day apart
+// may be reported as one change.
+func DiffZoneRules(zone, versionA, versionB string, opts ...Option) ([]ZoneRuleChange, error) {
+	o := NewOptions(opts...)
+
+	a, err := LoadLocationVersion(zone, versionA)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := LoadLocationVersion(zone, versionB)
+	if err != nil {
+		return nil, err
+	}
+
+	start := o.now().UTC().Truncate(time.Second)
+	end := start.Add(zoneDiffHorizon)
+
+	signature := func(t time.Time) zonePair {
+		oldName, oldOffset := t.In(a).Zone()
+		newName, newOffset := t.In(b).Zone()
+
+		return zonePair{oldName, oldOffset, newName, newOffset}
+	}
+
+	var changes []ZoneRuleChange
+	emit := func(from, to time.Time, p zonePair) {
+		if p.oldName != p.newName || p.oldOffset != p.newOffset {
+			changes = append(changes, ZoneRuleChange{
+				Zone:      zone,
+				Interval:  Interval{Start: from, End: to},
+				OldName:   p.oldName,
+				OldOffset: p.oldOffset,
+				NewName:   p.newName,
+				NewOffset: p.newOffset,
+			})
+		}
+	}
+
+	from, current := start, signature(start)
+	for t := start; t.Before(end); {
+		next := t.Add(24 * time.Hour)
+		if next.After(end) {
+			next = end
+		}
+
+		if signature(next) == current {
+			t = next
+			continue
+		}
+
+		// Narrow (t, next] down to the first second with a new signature.
+		lo, hi := t, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+			if signature(mid) == current {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+
+		emit(from, hi, current)
+		from, current, t = hi, signature(hi), hi
+	}
+
+	emit(from, end, current)
+
+	return changes, nil
+}
+
+// zonePair is the zone abbreviation and offset of an instant in two
+// versions of the zone data.
+type zonePair struct {
+	oldName   string
+	oldOffset int
+	newName   string
+	newOffset int
+}
@@ -0,0 +1,88 @@
+package temporalis
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+// registerTestZone registers a version of the zone data in which the zone
+// "Test/Zone" has the rules of the given zone from the embedded snapshot.
+func registerTestZone(t *testing.T, version, source string) {
+	t.Helper()
+
+	f, ok := tzSets[EmbeddedTZDataVersion()].zones[source]
+	if !ok {
+		t.Fatalf("zone %s not in the embedded snapshot", source)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	out, _ := w.Create("Test/Zone")
+	out.Write(data)
+	w.Close()
+
+	if err := RegisterTZData(version, buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDiffZoneRules checks that abolishing DST shows up as the summer spans
+// in which the old and new rules disagree.
+func TestDiffZoneRules(t *testing.T) {
+	if EmbeddedTZDataVersion() == "" {
+		t.Skip("built without the embedded snapshot")
+	}
+
+	registerTestZone(t, "diff-old", "Europe/Berlin")
+	registerTestZone(t, "diff-new", "Etc/GMT-1")
+
+	clock := NewFakeClock(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	changes, err := DiffZoneRules("Test/Zone", "diff-old", "diff-new", WithClock(clock))
+	if err != nil {
+		t.Fatalf("DiffZoneRules() returned error: %v", err)
+	}
+
+	// Winter time differs only in the abbreviation, CET against +01, so the
+	// whole horizon is covered by alternating summer and winter changes.
+	if len(changes) < 19 {
+		t.Fatalf("DiffZoneRules() returned %d changes, expected at least 19", len(changes))
+	}
+
+	summer := changes[1]
+	expected := Interval{
+		Start: time.Date(2030, time.March, 31, 1, 0, 0, 0, time.UTC),
+		End:   time.Date(2030, time.October, 27, 1, 0, 0, 0, time.UTC),
+	}
+	if !summer.Interval.Start.Equal(expected.Start) || !summer.Interval.End.Equal(expected.End) {
+		t.Errorf("second change = %v, expected %v", summer.Interval, expected)
+	}
+	if summer.OldOffset != 7200 || summer.NewOffset != 3600 {
+		t.Errorf("offsets = %d -> %d, expected 7200 -> 3600", summer.OldOffset, summer.NewOffset)
+	}
+	if !summer.Affects(time.Date(2030, time.July, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Affects() = false for a summer event, expected true")
+	}
+
+	same, err := DiffZoneRules("Test/Zone", "diff-old", "diff-old", WithClock(clock))
+	if err != nil || len(same) != 0 {
+		t.Errorf("DiffZoneRules() of a version with itself = %v, %v, expected no changes", same, err)
+	}
+
+	if _, err := DiffZoneRules("Test/Zone", "diff-old", "1999z"); err == nil {
+		t.Errorf("DiffZoneRules() with an unknown version returned no error")
+	}
+}
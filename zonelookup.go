@@ -0,0 +1,186 @@
+package temporalis
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// tzdata/zone.tab is the country and coordinate table distributed with the
+// IANA time zone database. Each zone is represented by the coordinates of its
+// principal city.
+
+//go:embed tzdata/zone.tab
+var zoneTab string
+
+// zoneEntry is one line of zone.tab.
+type zoneEntry struct {
+	country string
+	zone    string
+	lat     float64
+	lon     float64
+}
+
+// maxZoneDistance is the distance in kilometres beyond which a coordinate is
+// considered to be at sea and is mapped to a nautical "Etc/GMT" zone.
+const maxZoneDistance = 1500
+
+var (
+	zoneEntries     []zoneEntry
+	zoneEntriesOnce sync.Once
+)
+
+// TimezonesForCountry returns the IANA zones used in the country with the given
+// ISO 3166-1 alpha-2 code, such as "US" or "de", in the order listed by the
+// time zone database (most populous region first). It returns nil for unknown
+// codes.
+func TimezonesForCountry(iso2 string) []string {
+	iso2 = strings.ToUpper(strings.TrimSpace(iso2))
+
+	var zones []string
+	for _, e := range loadZoneEntries() {
+		if e.country == iso2 {
+			zones = append(zones, e.zone)
+		}
+	}
+
+	return zones
+}
+
+// TimezoneOfNearestCity returns the IANA zone of the reference city
+// closest to a position given in decimal degrees, as a cheap approximation
+// of the zone that applies there. The reference cities are the principal
+// cities of zone.tab, one per zone, and zone boundaries are not consulted,
+// so positions nearer to a city across a border get that city's zone: Vigo
+// in Spain maps to Europe/Lisbon, Seattle to America/Vancouver and El Paso
+// to America/Ciudad_Juarez. Use it for defaults that the user can correct,
+// not where the legal time of a place matters. Positions more than 1500 km
+// from any city, such as the open ocean, map to the nautical zone
+// "Etc/GMT±N" for their longitude. An error is returned if the coordinates
+// are out of range.
+func TimezoneOfNearestCity(lat, lon float64) (string, error) {
+	if math.IsNaN(lat) || math.IsNaN(lon) || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return "", fmt.Errorf("coordinates (%v, %v): %w", lat, lon, ErrOutOfRange)
+	}
+
+	best := ""
+	bestDistance := math.Inf(1)
+
+	for _, e := range loadZoneEntries() {
+		if d := greatCircleKm(lat, lon, e.lat, e.lon); d < bestDistance {
+			best, bestDistance = e.zone, d
+		}
+	}
+
+	if best == "" || bestDistance > maxZoneDistance {
+		return nauticalZone(lon), nil
+	}
+
+	return best, nil
+}
+
+// nauticalZone returns the Etc zone whose 15 degree band contains lon. The
+// sign of Etc zone names is inverted, so Etc/GMT+5 is five hours behind UTC.
+func nauticalZone(lon float64) string {
+	hours := int(math.Round(lon / 15))
+
+	switch {
+	case hours == 0:
+		return "Etc/GMT"
+	case hours > 0:
+		return fmt.Sprintf("Etc/GMT-%d", hours)
+	default:
+		return fmt.Sprintf("Etc/GMT+%d", -hours)
+	}
+}
+
+// loadZoneEntries parses the embedded zone.tab on first use.
+func loadZoneEntries() []zoneEntry {
+	zoneEntriesOnce.Do(func() {
+		for _, line := range strings.Split(zoneTab, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			fields := strings.Split(line, "\t")
+			if len(fields) < 3 {
+				continue
+			}
+
+			lat, lon, ok := parseISO6709(fields[1])
+			if !ok {
+				continue
+			}
+
+			zoneEntries = append(zoneEntries, zoneEntry{country: fields[0], zone: fields[2], lat: lat, lon: lon})
+		}
+	})
+
+	return zoneEntries
+}
+
+// parseISO6709 parses the coordinate notation of zone.tab, ±DDMM±DDDMM or
+// ±DDMMSS±DDDMMSS, into decimal degrees.
+func parseISO6709(s string) (lat, lon float64, ok bool) {
+	split := strings.IndexAny(s[1:], "+-") + 1
+	if split <= 0 {
+		return 0, 0, false
+	}
+
+	lat, ok = parseSexagesimal(s[:split], 2)
+	if !ok {
+		return 0, 0, false
+	}
+
+	lon, ok = parseSexagesimal(s[split:], 3)
+
+	return lat, lon, ok
+}
+
+// parseSexagesimal parses a signed degrees-minutes[-seconds] value whose
+// degree part has the given number of digits.
+func parseSexagesimal(s string, degreeDigits int) (float64, bool) {
+	if len(s) < 1+degreeDigits+2 {
+		return 0, false
+	}
+
+	sign := 1.0
+	if s[0] == '-' {
+		sign = -1
+	}
+
+	digits := s[1:]
+	parts := []string{digits[:degreeDigits], digits[degreeDigits : degreeDigits+2]}
+	if len(digits) >= degreeDigits+4 {
+		parts = append(parts, digits[degreeDigits+2:degreeDigits+4])
+	}
+
+	value := 0.0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, false
+		}
+		value += float64(n) / math.Pow(60, float64(i))
+	}
+
+	return sign * value, true
+}
+
+// greatCircleKm returns the haversine distance between two positions in
+// kilometres.
+func greatCircleKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
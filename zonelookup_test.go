@@ -0,0 +1,49 @@
+package temporalis
+
+import "testing"
+
+// TestTimezonesForCountry checks country lookups for single- and multi-zone
+// countries and for an unknown code.
+func TestTimezonesForCountry(t *testing.T) {
+	if zones := TimezonesForCountry("jp"); len(zones) != 1 || zones[0] != "Asia/Tokyo" {
+		t.Errorf("TimezonesForCountry(jp) = %v, expected [Asia/Tokyo]", zones)
+	}
+
+	if zones := TimezonesForCountry("US"); len(zones) < 10 || zones[0] != "America/New_York" {
+		t.Errorf("TimezonesForCountry(US) = %v, expected America/New_York first", zones)
+	}
+
+	if zones := TimezonesForCountry("XX"); zones != nil {
+		t.Errorf("TimezonesForCountry(XX) = %v, expected nil", zones)
+	}
+}
+
+// TestTimezoneOfNearestCity checks a few well-known cities, positions whose
+// nearest city is across a zone border, an ocean position and out-of-range
+// input.
+func TestTimezoneOfNearestCity(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		expected string
+	}{
+		{48.85, 2.35, "Europe/Paris"},
+		{35.68, 139.69, "Asia/Tokyo"},
+		{34.05, -118.24, "America/Los_Angeles"},
+		{-40, -140, "Etc/GMT+9"},
+		// Nearest cities across a border, where the zone in effect differs.
+		{42.24, -8.72, "Europe/Lisbon"},
+		{47.61, -122.33, "America/Vancouver"},
+		{31.76, -106.49, "America/Ciudad_Juarez"},
+	}
+
+	for _, test := range tests {
+		zone, err := TimezoneOfNearestCity(test.lat, test.lon)
+		if err != nil || zone != test.expected {
+			t.Errorf("TimezoneOfNearestCity(%v, %v) = %q, %v, expected %q", test.lat, test.lon, zone, err, test.expected)
+		}
+	}
+
+	if _, err := TimezoneOfNearestCity(91, 0); err == nil {
+		t.Errorf("TimezoneOfNearestCity(91, 0) returned no error")
+	}
+}